@@ -64,7 +64,7 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, "")
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -179,7 +179,7 @@ func generateToolsDoc(tsg *toolsets.ToolsetGroup) string {
 
 		var toolDocs []string
 		for _, serverTool := range tools {
-			toolDoc := generateToolDoc(serverTool.Tool)
+			toolDoc := generateToolDoc(toolsetName, serverTool.Tool)
 			toolDocs = append(toolDocs, toolDoc)
 		}
 
@@ -217,12 +217,17 @@ func formatToolsetName(name string) string {
 	}
 }
 
-func generateToolDoc(tool mcp.Tool) string {
+func generateToolDoc(toolset string, tool mcp.Tool) string {
 	var lines []string
 
 	// Tool name only (using annotation name instead of verbose description)
 	lines = append(lines, fmt.Sprintf("- **%s** - %s", tool.Name, tool.Annotations.Title))
 
+	// Fine-grained permissions the tool needs
+	if permissions := github.RequiredPermissions(toolset, tool); len(permissions) > 0 {
+		lines = append(lines, fmt.Sprintf("  - Required permissions: `%s`", strings.Join(permissions, "`, `")))
+	}
+
 	// Parameters
 	schema := tool.InputSchema
 	if len(schema.Properties) > 0 {
@@ -302,7 +307,7 @@ func generateRemoteToolsetsDoc() string {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, "")
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
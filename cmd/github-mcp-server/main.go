@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -32,7 +34,16 @@ var (
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			token := viper.GetString("personal_access_token")
-			if token == "" {
+
+			var accountNames []string
+			if err := viper.UnmarshalKey("accounts", &accountNames); err != nil {
+				return fmt.Errorf("failed to unmarshal accounts: %w", err)
+			}
+			accounts, err := loadAccounts(token, accountNames)
+			if err != nil {
+				return err
+			}
+			if token == "" && len(accounts) == 0 {
 				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
 			}
 
@@ -45,10 +56,17 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var redactFilters []string
+			if err := viper.UnmarshalKey("redact", &redactFilters); err != nil {
+				return fmt.Errorf("failed to unmarshal redact: %w", err)
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
 				Version:              version,
 				Host:                 viper.GetString("host"),
 				Token:                token,
+				Accounts:             accounts,
+				ActiveAccount:        viper.GetString("account"),
 				EnabledToolsets:      enabledToolsets,
 				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
 				ReadOnly:             viper.GetBool("read-only"),
@@ -56,6 +74,37 @@ var (
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
 				ContentWindowSize:    viper.GetInt("content-window-size"),
+				RateLimit: ratelimit.Config{
+					MaxConcurrent:     viper.GetInt("max-concurrent-requests"),
+					RequestsPerMinute: viper.GetInt("requests-per-minute"),
+					MaxRetries:        viper.GetInt("rate-limit-retries"),
+				},
+				GraphQLBudget: ratelimit.GraphQLBudgetConfig{
+					MaxQueryCost: viper.GetInt("graphql-max-query-cost"),
+					MaxCallCost:  viper.GetInt("graphql-max-call-cost"),
+				},
+				HTTPCacheSize:       viper.GetInt("http-cache-size"),
+				CheckTokenScopes:    viper.GetBool("check-token-scopes"),
+				HideUnusableTools:   viper.GetBool("hide-unusable-tools"),
+				MetricsAddr:         viper.GetString("metrics-addr"),
+				RedactFilters:       redactFilters,
+				RedactPatterns:      viper.GetStringSlice("redact-patterns"),
+				RedactAllow:         viper.GetStringSlice("redact-allow"),
+				PermissionCeiling:   viper.GetStringSlice("permission-ceiling"),
+				RequireConfirmation: viper.GetBool("require-confirmation"),
+				ConfirmTools:        viper.GetStringSlice("confirm-tools"),
+				ConfirmExemptTools:  viper.GetStringSlice("confirm-exempt-tools"),
+				DefaultOwner:        viper.GetString("default-owner"),
+				DefaultRepo:         viper.GetString("default-repo"),
+				ArchiveDir:          viper.GetString("archive-dir"),
+				ToolTimeout:         viper.GetDuration("tool-timeout"),
+				ToolTimeouts:        viper.GetStringSlice("tool-timeouts"),
+			}
+			if stdioServerConfig.DefaultRepo != "" && stdioServerConfig.DefaultOwner == "" {
+				return errors.New("--default-repo requires --default-owner")
+			}
+			if !stdioServerConfig.RequireConfirmation && (len(stdioServerConfig.ConfirmTools) > 0 || len(stdioServerConfig.ConfirmExemptTools) > 0) {
+				return errors.New("--confirm-tools and --confirm-exempt-tools require --require-confirmation")
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -77,6 +126,29 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().Int("max-concurrent-requests", 0, "Maximum number of concurrent GitHub API calls (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("requests-per-minute", 0, "Maximum number of GitHub API calls per minute (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("rate-limit-retries", 3, "Number of times to retry a GitHub API call that hit a secondary rate limit or a 502/503 response (0 to disable)")
+	rootCmd.PersistentFlags().Int("graphql-max-query-cost", 0, "Refuse GraphQL queries predicted to cost more rate limit points than this (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("graphql-max-call-cost", 0, "Maximum number of GraphQL rate limit points a single tool call may spend (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("http-cache-size", 500, "Maximum number of GitHub API responses to cache for conditional requests (0 to disable)")
+	rootCmd.PersistentFlags().Bool("check-token-scopes", true, "Check the token's scopes on startup and log which enabled tools it lacks the scopes for")
+	rootCmd.PersistentFlags().Bool("hide-unusable-tools", false, "Hide the tools the token lacks the scopes for, as determined by the startup scope check")
+	rootCmd.PersistentFlags().String("metrics-addr", "", "Address to serve Prometheus metrics on at /metrics, for example localhost:9090 (disabled by default)")
+	rootCmd.PersistentFlags().StringSlice("redact", nil, "An optional comma separated list of built-in filters to redact from tool results: emails, tokens")
+	rootCmd.PersistentFlags().StringArray("redact-patterns", nil, "Regular expression whose matches are redacted from tool results, can be repeated")
+	rootCmd.PersistentFlags().StringArray("redact-allow", nil, "Regular expression for content that is never redacted, such as noreply email addresses, can be repeated")
+	rootCmd.PersistentFlags().StringSlice("permission-ceiling", nil, "An optional comma separated list of the highest fine-grained permissions tools may need, such as contents:read,issues:write or *:read. Tools that need more are not registered")
+	rootCmd.PersistentFlags().Bool("require-confirmation", false, "Require destructive tools to be confirmed with a token returned by a first call before they run")
+	rootCmd.PersistentFlags().StringSlice("confirm-tools", nil, "An optional comma separated list of tools that must be confirmed in addition to the destructive ones, used with --require-confirmation")
+	rootCmd.PersistentFlags().StringSlice("confirm-exempt-tools", nil, "An optional comma separated list of destructive tools that run without confirmation, used with --require-confirmation")
+	rootCmd.PersistentFlags().StringSlice("accounts", nil, "An optional comma separated list of named token profiles, each read from GITHUB_PERSONAL_ACCESS_TOKEN_<NAME>")
+	rootCmd.PersistentFlags().String("account", "", "Name of the account to act as on startup, defaults to the first configured account")
+	rootCmd.PersistentFlags().String("default-owner", "", "Owner to use when a tool call omits the owner parameter, which makes it optional")
+	rootCmd.PersistentFlags().String("default-repo", "", "Repository to use when a tool call for the default owner omits the repo parameter, which makes it optional")
+	rootCmd.PersistentFlags().Duration("tool-timeout", 30*time.Second, "How long a tool call may run before it is stopped (0 for no limit)")
+	rootCmd.PersistentFlags().StringSlice("tool-timeouts", nil, "An optional comma separated list of per-tool timeouts overriding --tool-timeout, such as download_repo_archive=5m")
+	rootCmd.PersistentFlags().String("archive-dir", "", "Directory download_repo_archive and export_project_items store files in. When not set, they return download URLs and exports inline instead")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -87,6 +159,29 @@ func init() {
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("max-concurrent-requests", rootCmd.PersistentFlags().Lookup("max-concurrent-requests"))
+	_ = viper.BindPFlag("requests-per-minute", rootCmd.PersistentFlags().Lookup("requests-per-minute"))
+	_ = viper.BindPFlag("rate-limit-retries", rootCmd.PersistentFlags().Lookup("rate-limit-retries"))
+	_ = viper.BindPFlag("graphql-max-query-cost", rootCmd.PersistentFlags().Lookup("graphql-max-query-cost"))
+	_ = viper.BindPFlag("graphql-max-call-cost", rootCmd.PersistentFlags().Lookup("graphql-max-call-cost"))
+	_ = viper.BindPFlag("http-cache-size", rootCmd.PersistentFlags().Lookup("http-cache-size"))
+	_ = viper.BindPFlag("check-token-scopes", rootCmd.PersistentFlags().Lookup("check-token-scopes"))
+	_ = viper.BindPFlag("hide-unusable-tools", rootCmd.PersistentFlags().Lookup("hide-unusable-tools"))
+	_ = viper.BindPFlag("metrics-addr", rootCmd.PersistentFlags().Lookup("metrics-addr"))
+	_ = viper.BindPFlag("redact", rootCmd.PersistentFlags().Lookup("redact"))
+	_ = viper.BindPFlag("redact-patterns", rootCmd.PersistentFlags().Lookup("redact-patterns"))
+	_ = viper.BindPFlag("redact-allow", rootCmd.PersistentFlags().Lookup("redact-allow"))
+	_ = viper.BindPFlag("permission-ceiling", rootCmd.PersistentFlags().Lookup("permission-ceiling"))
+	_ = viper.BindPFlag("require-confirmation", rootCmd.PersistentFlags().Lookup("require-confirmation"))
+	_ = viper.BindPFlag("confirm-tools", rootCmd.PersistentFlags().Lookup("confirm-tools"))
+	_ = viper.BindPFlag("confirm-exempt-tools", rootCmd.PersistentFlags().Lookup("confirm-exempt-tools"))
+	_ = viper.BindPFlag("accounts", rootCmd.PersistentFlags().Lookup("accounts"))
+	_ = viper.BindPFlag("account", rootCmd.PersistentFlags().Lookup("account"))
+	_ = viper.BindPFlag("default-owner", rootCmd.PersistentFlags().Lookup("default-owner"))
+	_ = viper.BindPFlag("default-repo", rootCmd.PersistentFlags().Lookup("default-repo"))
+	_ = viper.BindPFlag("archive-dir", rootCmd.PersistentFlags().Lookup("archive-dir"))
+	_ = viper.BindPFlag("tool-timeout", rootCmd.PersistentFlags().Lookup("tool-timeout"))
+	_ = viper.BindPFlag("tool-timeouts", rootCmd.PersistentFlags().Lookup("tool-timeouts"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -95,10 +190,41 @@ func init() {
 func initConfig() {
 	// Initialize Viper configuration
 	viper.SetEnvPrefix("github")
+	// Flags such as rate-limit-retries are read from GITHUB_RATE_LIMIT_RETRIES
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
 }
 
+// loadAccounts builds the named token profiles from GITHUB_PERSONAL_ACCESS_TOKEN_<NAME> environment variables.
+// The plain personal access token, if set, comes first as the default account.
+func loadAccounts(token string, names []string) ([]ghmcp.Account, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var accounts []ghmcp.Account
+	if token != "" {
+		accounts = append(accounts, ghmcp.Account{Name: ghmcp.DefaultAccountName, Token: token})
+	}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == ghmcp.DefaultAccountName {
+			return nil, fmt.Errorf("account name %q is reserved for GITHUB_PERSONAL_ACCESS_TOKEN", name)
+		}
+		key := "personal_access_token_" + strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+		accountToken := viper.GetString(key)
+		if accountToken == "" {
+			return nil, fmt.Errorf("%s not set for account %q", strings.ToUpper("github_"+key), name)
+		}
+		accounts = append(accounts, ghmcp.Account{Name: name, Token: accountToken})
+	}
+	return accounts, nil
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -6,17 +6,25 @@ import (
 	"io"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/httpcache"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/metrics"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/redact"
+	"github.com/github/github-mcp-server/pkg/timeout"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -34,6 +42,13 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// Accounts are named token profiles the server can switch between at runtime.
+	// When empty, Token is used as the only account.
+	Accounts []Account
+
+	// ActiveAccount is the account used until another one is selected, defaults to the first account
+	ActiveAccount string
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -50,32 +65,142 @@ type MCPServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// RateLimit bounds the concurrency and rate of GitHub API calls made by the server
+	RateLimit ratelimit.Config
+
+	// GraphQLBudget bounds the rate limit points single GraphQL queries and tool calls may cost
+	GraphQLBudget ratelimit.GraphQLBudgetConfig
+
+	// HTTPCacheSize is the maximum number of REST responses kept for conditional requests, 0 disables caching
+	HTTPCacheSize int
+
+	// CheckTokenScopes inspects the token on startup and logs which enabled tools it lacks the scopes for
+	CheckTokenScopes bool
+
+	// HideUnusableTools removes the tools the token lacks the scopes for, it implies CheckTokenScopes
+	HideUnusableTools bool
+
+	// Logger receives the results of the token scope check and the schema probe, if nil they are discarded
+	Logger *slog.Logger
+
+	// Metrics collects tool call and GitHub API metrics, if nil no metrics are collected
+	Metrics *metrics.Metrics
+
+	// Redactor removes sensitive content from tool results, if nil results are returned unchanged
+	Redactor *redact.Redactor
+
+	// PermissionCeiling lists the highest fine-grained permission, as "resource:level", tools may need
+	// to be registered. Tools that need more are left out. "*:level" applies to unlisted resources.
+	// When empty, all tools are registered.
+	PermissionCeiling []string
+
+	// RequireConfirmation makes destructive tools return a summary and a confirmation token
+	// on the first call, and only perform the operation when called again with that token
+	RequireConfirmation bool
+
+	// ConfirmTools and ConfirmExemptTools adjust which tools RequireConfirmation applies to:
+	// the listed tools are added to, or removed from, the tools annotated as destructive
+	ConfirmTools       []string
+	ConfirmExemptTools []string
+
+	// DefaultOwner and DefaultRepo are filled in for tools whose owner or repo parameter is omitted,
+	// which makes those parameters optional
+	DefaultOwner string
+	DefaultRepo  string
+
+	// ArchiveDir is the directory download_repo_archive and export_project_items store files in, if empty they
+	// return download URLs and the export itself instead
+	ArchiveDir string
+
+	// ToolTimeout is how long a tool call may run before it is stopped, 0 lets calls run until they finish
+	ToolTimeout time.Duration
+
+	// ToolTimeouts overrides ToolTimeout for individual tools, as "tool=duration"
+	ToolTimeouts []string
+}
+
+// Account is a named GitHub token the server can act as.
+type Account struct {
+	Name  string
+	Token string
 }
 
+// DefaultAccountName is the name of the account backed by GITHUB_PERSONAL_ACCESS_TOKEN.
+const DefaultAccountName = "default"
+
 const stdioServerLogPrefix = "stdioserver"
 
+// accountClients are the API clients authenticated as a single account.
+type accountClients struct {
+	rest          *gogithub.Client
+	gqlHTTPClient *http.Client
+	gql           *githubv4.Client
+	gqlBudget     *ratelimit.GraphQLBudget
+}
+
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
-	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
-	restClient.BaseURL = apiHost.baseRESTURL
-	restClient.UploadURL = apiHost.uploadURL
-
-	// Construct our GraphQL client
-	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
-	// did the necessary API host parsing so that github.com will return the correct URL anyway.
-	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
-	} // We're going to wrap the Transport later in beforeInit
-	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+	accounts := cfg.Accounts
+	if len(accounts) == 0 {
+		accounts = []Account{{Name: DefaultAccountName, Token: cfg.Token}}
+	}
+	accountNames := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountNames = append(accountNames, account.Name)
+	}
+	activeAccount, err := github.NewAccounts(accountNames, cfg.ActiveAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure accounts: %w", err)
+	}
+
+	baseTransport := http.DefaultTransport
+	if cfg.Metrics != nil {
+		// Observe every attempt that reaches GitHub, including retries
+		baseTransport = cfg.Metrics.Transport(baseTransport)
+	}
+
+	// A single limiter is shared by the REST and GraphQL clients so the limits apply to the server as a whole
+	limitedTransport := ratelimit.NewTransport(baseTransport, cfg.RateLimit)
+
+	// REST responses are replayed from memory via conditional requests. The cache is keyed by token
+	// as well as URL, so accounts can share it without seeing each other's responses.
+	cachedTransport := httpcache.NewTransport(limitedTransport, cfg.HTTPCacheSize)
+
+	clients := make(map[string]*accountClients, len(accounts))
+	for _, account := range accounts {
+		// Construct our REST client
+		restClient := gogithub.NewClient(&http.Client{
+			Transport: cachedTransport,
+		}).WithAuthToken(account.Token)
+		restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
+		restClient.BaseURL = apiHost.baseRESTURL
+		restClient.UploadURL = apiHost.uploadURL
+
+		// Construct our GraphQL client
+		// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
+		// did the necessary API host parsing so that github.com will return the correct URL anyway.
+		// Every account has a budget of its own, as GitHub counts GraphQL points per token.
+		gqlBudget := ratelimit.NewGraphQLBudget(cfg.GraphQLBudget)
+		gqlHTTPClient := &http.Client{
+			Transport: &bearerAuthTransport{
+				transport: gqlBudget.Transport(limitedTransport),
+				token:     account.Token,
+			},
+		} // We're going to wrap the Transport later in beforeInit
+		gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+
+		clients[account.Name] = &accountClients{
+			rest:          restClient,
+			gqlHTTPClient: gqlHTTPClient,
+			gql:           gqlClient,
+			gqlBudget:     gqlBudget,
+		}
+	}
 
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
@@ -86,11 +211,13 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 			message.Params.ClientInfo.Version,
 		)
 
-		restClient.UserAgent = userAgent
+		for _, c := range clients {
+			c.rest.UserAgent = userAgent
 
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
+			c.gqlHTTPClient.Transport = &userAgentTransport{
+				transport: c.gqlHTTPClient.Transport,
+				agent:     userAgent,
+			}
 		}
 	}
 
@@ -105,7 +232,47 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	// Record the retries of every tool call so that a call which still fails can report them,
+	// and the GraphQL points it spends so that they can be bounded per call
+	recordRetries := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, _ = ratelimit.ContextWithRetries(ctx)
+			ctx = ratelimit.ContextWithGraphQLCall(ctx)
+			return next(ctx, request)
+		}
+	}
+
+	toolTimeouts, err := timeout.ParseToolTimeouts(cfg.ToolTimeouts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tool timeouts: %w", err)
+	}
+	// Tools that wait for something bound their waiting themselves, unless configured otherwise
+	for tool, d := range github.LongRunningToolTimeouts {
+		if _, ok := toolTimeouts[tool]; !ok {
+			toolTimeouts[tool] = d
+		}
+	}
+	timeouts := timeout.New(cfg.ToolTimeout, toolTimeouts)
+	hooks.AddBeforeCallTool(timeouts.OnBeforeCallTool)
+	hooks.AddOnError(timeouts.OnError)
+
+	serverOpts := []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(recordRetries),
+		// Tool calls keep the account they started with when switch_account runs concurrently
+		server.WithToolHandlerMiddleware(activeAccount.ToolHandlerMiddleware),
+	}
+	if cfg.Metrics != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.Metrics.ToolHandlerMiddleware))
+	}
+	if cfg.Redactor != nil && cfg.Redactor.Enabled() {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.Redactor.ToolHandlerMiddleware))
+	}
+	// Added last to run innermost, so that metrics record timed out and cancelled calls as failed
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(timeouts.ToolHandlerMiddleware))
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
+	ghServer.AddNotificationHandler(timeout.MethodNotificationCancelled, timeouts.HandleCancelled)
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -118,12 +285,12 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
+	getClient := func(ctx context.Context) (*gogithub.Client, error) {
+		return clients[activeAccount.Current(ctx)].rest, nil // closing over clients
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
-		return gqlClient, nil // closing over client
+	getGQLClient := func(ctx context.Context) (*githubv4.Client, error) {
+		return clients[activeAccount.Current(ctx)].gql, nil // closing over clients
 	}
 
 	getRawClient := func(ctx context.Context) (*raw.Client, error) {
@@ -134,8 +301,60 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
+	getGraphQLBudget := func(ctx context.Context) (*ratelimit.GraphQLBudget, error) {
+		return clients[activeAccount.Current(ctx)].gqlBudget, nil // closing over clients
+	}
+
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize, cfg.ArchiveDir)
+	contextTools, err := tsg.GetToolset("context")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context toolset: %w", err)
+	}
+	contextTools.AddReadTools(toolsets.NewServerTool(github.GetGraphQLBudget(getGraphQLBudget, cfg.Translator)))
+	if len(accounts) > 1 {
+		contextTools.AddReadTools(
+			toolsets.NewServerTool(github.ListAccounts(activeAccount, cfg.Translator)),
+			toolsets.NewServerTool(github.SwitchAccount(activeAccount, getClient, cfg.Translator)),
+		)
+	}
+
+	if apiHost.enterpriseServer {
+		client, _ := getGQLClient(context.Background())
+		gateUnsupportedTools(cfg, client, tsg)
+	}
+
+	if len(cfg.PermissionCeiling) > 0 {
+		ceiling, err := github.ParsePermissionCeiling(cfg.PermissionCeiling)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse permission ceiling: %w", err)
+		}
+		gatePermissionCeiling(cfg, ceiling, tsg)
+	}
+
+	if cfg.RequireConfirmation {
+		confirmations := github.NewConfirmations(github.DefaultConfirmationTTL, github.ConfirmationPolicy{
+			Require: cfg.ConfirmTools,
+			Exempt:  cfg.ConfirmExemptTools,
+		})
+		for _, toolset := range tsg.Toolsets {
+			toolset.WrapTools(confirmations.RequireConfirmation)
+		}
+	}
+
+	if cfg.DefaultOwner != "" {
+		// Applied last so that confirmations see the filled in arguments
+		defaults := github.RepoDefaults{Owner: cfg.DefaultOwner, Repo: cfg.DefaultRepo}
+		for _, toolset := range tsg.Toolsets {
+			toolset.WrapTools(defaults.Apply)
+		}
+	}
+
+	// Applied after the defaults so that they only fill in what a URL did not provide
+	for _, toolset := range tsg.Toolsets {
+		toolset.WrapTools(github.AcceptURLs)
+	}
+
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
@@ -145,6 +364,15 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
 
+	if cfg.CheckTokenScopes || cfg.HideUnusableTools {
+		// Every account is checked, as switch_account changes the token tools run with
+		accountRESTClients := make(map[string]*gogithub.Client, len(clients))
+		for name, c := range clients {
+			accountRESTClients[name] = c.rest
+		}
+		checkTokenScopes(cfg, accountNames, accountRESTClients, tsg, ghServer)
+	}
+
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
 		dynamic.RegisterTools(ghServer)
@@ -153,6 +381,98 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	return ghServer, nil
 }
 
+// tokenExpiryWarning is how long before its expiry a token is reported as about to expire.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+// checkTokenScopes logs which of the registered tools will fail because the token of an account lacks
+// a required scope, and removes the tools no account can use from the server when cfg.HideUnusableTools
+// is set.
+func checkTokenScopes(cfg MCPServerConfig, accountNames []string, clients map[string]*gogithub.Client, tsg *toolsets.ToolsetGroup, ghServer *server.MCPServer) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// unusableBy counts the accounts each tool is unusable for, a tool is hidden only if that is all of them
+	unusableBy := map[string]int{}
+	for _, account := range accountNames {
+		info, _, err := github.GetTokenInfo(ctx, clients[account])
+		if err != nil {
+			logger.Warn("failed to check token scopes", "account", account, "error", err)
+			return
+		}
+		if info.ExpiresAt != nil && time.Until(*info.ExpiresAt) < tokenExpiryWarning {
+			logger.Warn("token expires soon", "account", account, "login", info.Login, "expiresAt", info.ExpiresAt)
+		}
+		if !info.ScopesKnown() {
+			// Any tool may work with this account, so none can be hidden
+			logger.Info("token scopes cannot be inspected, skipping scope check", "account", account, "login", info.Login)
+			return
+		}
+
+		for _, tool := range github.UnusableTools(tsg, info) {
+			logger.Warn("tool will fail, token lacks a required scope", "account", account, "tool", tool.Tool, "toolset", tool.Toolset, "requiredScopes", tool.RequiredScopes)
+			unusableBy[tool.Tool]++
+		}
+	}
+
+	names := make([]string, 0, len(unusableBy))
+	for name, n := range unusableBy {
+		if n == len(accountNames) {
+			names = append(names, name)
+		}
+	}
+	if cfg.HideUnusableTools && len(names) > 0 {
+		ghServer.DeleteTools(names...)
+		logger.Info("removed tools no account has the scopes for", "count", len(names))
+	}
+}
+
+// gateUnsupportedTools probes the GraphQL schema of a GitHub Enterprise Server and removes the tools
+// and parameters that rely on parts of the schema its release does not have yet. If the schema cannot
+// be probed, all tools are kept.
+func gateUnsupportedTools(cfg MCPServerConfig, client *githubv4.Client, tsg *toolsets.ToolsetGroup) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	capabilities, err := github.ProbeSchemaCapabilities(ctx, client)
+	if err != nil {
+		logger.Warn("failed to probe the GraphQL schema, keeping all tools", "error", err)
+		return
+	}
+	for _, unsupported := range github.GateUnsupportedTools(tsg, capabilities) {
+		if unsupported.Param != "" {
+			logger.Info("removed tool parameter unsupported by this server", "tool", unsupported.Tool, "param", unsupported.Param, "missing", unsupported.Missing)
+			continue
+		}
+		logger.Info("removed tool unsupported by this server", "tool", unsupported.Tool, "toolset", unsupported.Toolset, "missing", unsupported.Missing)
+	}
+}
+
+// gatePermissionCeiling removes the tools that need a permission above the configured ceiling.
+func gatePermissionCeiling(cfg MCPServerConfig, ceiling *github.PermissionCeiling, tsg *toolsets.ToolsetGroup) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	exceeding := github.GatePermissionCeiling(tsg, ceiling)
+	for _, tool := range exceeding {
+		logger.Info("removed tool above the permission ceiling", "tool", tool.Tool, "toolset", tool.Toolset, "exceeding", tool.Exceeding)
+	}
+	if len(exceeding) > 0 {
+		logger.Info("removed tools above the permission ceiling", "count", len(exceeding))
+	}
+}
+
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
@@ -163,6 +483,13 @@ type StdioServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// Accounts are named token profiles the server can switch between at runtime.
+	// When empty, Token is used as the only account.
+	Accounts []Account
+
+	// ActiveAccount is the account used until another one is selected, defaults to the first account
+	ActiveAccount string
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -186,6 +513,62 @@ type StdioServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// RateLimit bounds the concurrency and rate of GitHub API calls made by the server
+	RateLimit ratelimit.Config
+
+	// GraphQLBudget bounds the rate limit points single GraphQL queries and tool calls may cost
+	GraphQLBudget ratelimit.GraphQLBudgetConfig
+
+	// HTTPCacheSize is the maximum number of REST responses kept for conditional requests, 0 disables caching
+	HTTPCacheSize int
+
+	// CheckTokenScopes inspects the token on startup and logs which enabled tools it lacks the scopes for
+	CheckTokenScopes bool
+
+	// HideUnusableTools removes the tools the token lacks the scopes for, it implies CheckTokenScopes
+	HideUnusableTools bool
+
+	// MetricsAddr is the address to serve Prometheus metrics on at /metrics, empty disables metrics
+	MetricsAddr string
+
+	// RedactFilters are the names of built-in filters applied to tool results, such as "emails" or "tokens"
+	RedactFilters []string
+
+	// RedactPatterns are regular expressions whose matches are removed from tool results
+	RedactPatterns []string
+
+	// RedactAllow are regular expressions for content that is never redacted
+	RedactAllow []string
+
+	// PermissionCeiling lists the highest fine-grained permission, as "resource:level", tools may need
+	// to be registered. Tools that need more are left out. "*:level" applies to unlisted resources.
+	// When empty, all tools are registered.
+	PermissionCeiling []string
+
+	// RequireConfirmation makes destructive tools return a summary and a confirmation token
+	// on the first call, and only perform the operation when called again with that token
+	RequireConfirmation bool
+
+	// ConfirmTools and ConfirmExemptTools adjust which tools RequireConfirmation applies to:
+	// the listed tools are added to, or removed from, the tools annotated as destructive
+	ConfirmTools       []string
+	ConfirmExemptTools []string
+
+	// DefaultOwner and DefaultRepo are filled in for tools whose owner or repo parameter is omitted,
+	// which makes those parameters optional
+	DefaultOwner string
+	DefaultRepo  string
+
+	// ArchiveDir is the directory download_repo_archive and export_project_items store files in, if empty they
+	// return download URLs and the export itself instead
+	ArchiveDir string
+
+	// ToolTimeout is how long a tool call may run before it is stopped, 0 lets calls run until they finish
+	ToolTimeout time.Duration
+
+	// ToolTimeouts overrides ToolTimeout for individual tools, as "tool=duration"
+	ToolTimeouts []string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -196,22 +579,6 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
-	}
-
-	stdioServer := server.NewStdioServer(ghServer)
-
 	var slogHandler slog.Handler
 	var logOutput io.Writer
 	if cfg.LogFilePath != "" {
@@ -226,6 +593,58 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelInfo})
 	}
 	logger := slog.New(slogHandler)
+
+	var serverMetrics *metrics.Metrics
+	if cfg.MetricsAddr != "" {
+		serverMetrics = metrics.New()
+		metricsServer, err := serveMetrics(ctx, cfg.MetricsAddr, serverMetrics)
+		if err != nil {
+			return fmt.Errorf("failed to serve metrics: %w", err)
+		}
+		defer func() { _ = metricsServer.Close() }()
+		logger.Info("serving metrics", "addr", cfg.MetricsAddr)
+	}
+
+	redactor, err := redact.New(cfg.RedactFilters, cfg.RedactPatterns, cfg.RedactAllow)
+	if err != nil {
+		return err
+	}
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:             cfg.Version,
+		Host:                cfg.Host,
+		Token:               cfg.Token,
+		Accounts:            cfg.Accounts,
+		ActiveAccount:       cfg.ActiveAccount,
+		EnabledToolsets:     cfg.EnabledToolsets,
+		DynamicToolsets:     cfg.DynamicToolsets,
+		ReadOnly:            cfg.ReadOnly,
+		Translator:          t,
+		ContentWindowSize:   cfg.ContentWindowSize,
+		RateLimit:           cfg.RateLimit,
+		GraphQLBudget:       cfg.GraphQLBudget,
+		HTTPCacheSize:       cfg.HTTPCacheSize,
+		CheckTokenScopes:    cfg.CheckTokenScopes,
+		HideUnusableTools:   cfg.HideUnusableTools,
+		Logger:              logger,
+		Metrics:             serverMetrics,
+		Redactor:            redactor,
+		PermissionCeiling:   cfg.PermissionCeiling,
+		RequireConfirmation: cfg.RequireConfirmation,
+		ConfirmTools:        cfg.ConfirmTools,
+		ConfirmExemptTools:  cfg.ConfirmExemptTools,
+		DefaultOwner:        cfg.DefaultOwner,
+		DefaultRepo:         cfg.DefaultRepo,
+		ArchiveDir:          cfg.ArchiveDir,
+		ToolTimeout:         cfg.ToolTimeout,
+		ToolTimeouts:        cfg.ToolTimeouts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
+
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
@@ -266,11 +685,31 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
+// serveMetrics serves m at /metrics on addr until ctx is done.
+func serveMetrics(ctx context.Context, addr string, m *metrics.Metrics) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	metricsServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() { _ = metricsServer.Serve(listener) }()
+	return metricsServer, nil
+}
+
 type apiHost struct {
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	// enterpriseServer is set for GitHub Enterprise Server, whose API may lag behind github.com
+	enterpriseServer bool
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -367,10 +806,11 @@ func newGHESHost(hostname string) (apiHost, error) {
 	}
 
 	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-		rawURL:      rawURL,
+		baseRESTURL:      restURL,
+		graphqlURL:       gqlURL,
+		uploadURL:        uploadURL,
+		rawURL:           rawURL,
+		enterpriseServer: true,
 	}, nil
 }
 
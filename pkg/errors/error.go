@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -106,8 +107,21 @@ func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
 
+// withRetries appends the retries performed for the failed call to message, so that the caller
+// knows the failure persisted rather than being a one-off.
+func withRetries(ctx context.Context, message string) string {
+	if ctx == nil {
+		return message
+	}
+	if retries := ratelimit.RetriesFromContext(ctx); retries != nil && retries.Count() > 0 {
+		return fmt.Sprintf("%s (%s)", message, retries)
+	}
+	return message
+}
+
 // NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
 func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	message = withRetries(ctx, message)
 	apiErr := newGitHubAPIError(message, resp, err)
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
@@ -117,6 +131,7 @@ func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github
 
 // NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
 func NewGitHubGraphQLErrorResponse(ctx context.Context, message string, err error) *mcp.CallToolResult {
+	message = withRetries(ctx, message)
 	graphQLErr := newGitHubGraphQLError(message, err)
 	if ctx != nil {
 		_, _ = addGitHubGraphQLErrorToContext(ctx, graphQLErr) // Explicitly ignore error for graceful handling
@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Accounts holds the names of the token profiles the server is configured with and
+// which of them is currently used to make GitHub API calls. It is safe for concurrent use.
+type Accounts struct {
+	mu     sync.RWMutex
+	names  []string
+	active string
+}
+
+// NewAccounts creates the account selection for the given profile names, starting with active.
+// When active is empty, the first profile is used.
+func NewAccounts(names []string, active string) (*Accounts, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("at least one account must be configured")
+	}
+	if active == "" {
+		active = names[0]
+	}
+	a := &Accounts{names: names}
+	if !a.has(active) {
+		return nil, fmt.Errorf("unknown account %q, configured accounts are %v", active, names)
+	}
+	a.active = active
+	return a, nil
+}
+
+func (a *Accounts) has(name string) bool {
+	for _, n := range a.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the configured profile names in configuration order.
+func (a *Accounts) Names() []string {
+	return append([]string(nil), a.names...)
+}
+
+// Active returns the name of the profile currently in use.
+func (a *Accounts) Active() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.active
+}
+
+// accountContextKey is the context key of the profile a tool call acts as.
+type accountContextKey struct{}
+
+// Current returns the profile the tool call of ctx acts as: the profile that was active when the
+// call started, or the active profile outside of ToolHandlerMiddleware.
+func (a *Accounts) Current(ctx context.Context) string {
+	if name, ok := ctx.Value(accountContextKey{}).(string); ok {
+		return name
+	}
+	return a.Active()
+}
+
+// ToolHandlerMiddleware pins every tool call to the profile active when it starts, so that a
+// switch made while the call runs does not change the account its later requests are made as.
+func (a *Accounts) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(context.WithValue(ctx, accountContextKey{}, a.Active()), request)
+	}
+}
+
+// Switch makes name the active profile.
+func (a *Accounts) Switch(name string) error {
+	if !a.has(name) {
+		return fmt.Errorf("unknown account %q, configured accounts are %v", name, a.names)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active = name
+	return nil
+}
+
+// ListAccounts creates a tool to list the token profiles the server can act as.
+func ListAccounts(accounts *Accounts, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_accounts",
+			mcp.WithDescription(t("TOOL_LIST_ACCOUNTS_DESCRIPTION", "List the named accounts (token profiles) this server is configured with and which one is currently used for GitHub API calls. Use switch_account to act as a different account.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ACCOUNTS_USER_TITLE", "List accounts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return MarshalledTextResult(map[string]any{
+				"accounts": accounts.Names(),
+				"active":   accounts.Active(),
+			}), nil
+		}
+}
+
+// SwitchAccount creates a tool to change the token profile used by all subsequent tool calls.
+// getClient must resolve the client of the account returned by Accounts.Current, so that the
+// account can be verified before switching to it. Calls already running keep their account.
+func SwitchAccount(accounts *Accounts, getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("switch_account",
+			mcp.WithDescription(t("TOOL_SWITCH_ACCOUNT_DESCRIPTION", "Switch the account (token profile) used for all subsequent GitHub API calls in this session, for example from a work to a personal identity. Returns the login of the user now being acted as. Only switch when the user asks to act as a different identity.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_SWITCH_ACCOUNT_USER_TITLE", "Switch account"),
+				// Switching only changes which configured token is used, it does not modify anything on GitHub
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("account",
+				mcp.Required(),
+				mcp.Description("Name of the account to switch to"),
+				mcp.Enum(accounts.Names()...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			account, err := RequiredParam[string](request, "account")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !accounts.has(account) {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown account %q, configured accounts are %v", account, accounts.names)), nil
+			}
+			previous := accounts.Active()
+
+			// Make sure the token works before committing to it, so a bad profile doesn't break every later call
+			accountCtx := context.WithValue(ctx, accountContextKey{}, account)
+			client, err := getClient(accountCtx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			user, resp, err := client.Users.Get(accountCtx, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to authenticate as account %q, still using %q", account, previous),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if err := accounts.Switch(account); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"account":  account,
+				"login":    user.GetLogin(),
+				"previous": previous,
+			}), nil
+		}
+}
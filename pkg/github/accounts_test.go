@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewAccounts(t *testing.T) {
+	accounts, err := NewAccounts([]string{"work", "personal"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "work", accounts.Active())
+	assert.Equal(t, []string{"work", "personal"}, accounts.Names())
+
+	accounts, err = NewAccounts([]string{"work", "personal"}, "personal")
+	require.NoError(t, err)
+	assert.Equal(t, "personal", accounts.Active())
+
+	_, err = NewAccounts([]string{"work"}, "bot")
+	require.Error(t, err)
+
+	_, err = NewAccounts(nil, "")
+	require.Error(t, err)
+
+	accounts, err = NewAccounts([]string{"work", "personal"}, "")
+	require.NoError(t, err)
+	require.NoError(t, accounts.Switch("personal"))
+	assert.Equal(t, "personal", accounts.Active())
+	require.Error(t, accounts.Switch("bot"))
+	assert.Equal(t, "personal", accounts.Active())
+}
+
+func Test_ListAccounts(t *testing.T) {
+	accounts, err := NewAccounts([]string{"work", "personal"}, "personal")
+	require.NoError(t, err)
+
+	tool, handler := ListAccounts(accounts, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_accounts", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, []any{"work", "personal"}, response["accounts"])
+	assert.Equal(t, "personal", response["active"])
+}
+
+func Test_SwitchAccount(t *testing.T) {
+	workClient := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUser,
+			&github.User{Login: github.Ptr("octocat-work")},
+		),
+	))
+	brokenClient := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+			}),
+		),
+	))
+
+	newAccounts := func(t *testing.T) (*Accounts, GetClientFn) {
+		accounts, err := NewAccounts([]string{"personal", "work", "bot"}, "")
+		require.NoError(t, err)
+		clients := map[string]*github.Client{
+			"personal": github.NewClient(nil),
+			"work":     workClient,
+			"bot":      brokenClient,
+		}
+		return accounts, func(ctx context.Context) (*github.Client, error) {
+			return clients[accounts.Current(ctx)], nil
+		}
+	}
+
+	accounts, getClient := newAccounts(t)
+	tool, _ := SwitchAccount(accounts, getClient, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "switch_account", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "account")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"account"})
+
+	tests := []struct {
+		name           string
+		account        string
+		expectError    bool
+		expectedErrMsg string
+		expectedActive string
+		expectedLogin  string
+	}{
+		{
+			name:           "switches to a working account",
+			account:        "work",
+			expectedActive: "work",
+			expectedLogin:  "octocat-work",
+		},
+		{
+			name:           "keeps the previous account when the token is rejected",
+			account:        "bot",
+			expectError:    true,
+			expectedErrMsg: `failed to authenticate as account "bot", still using "personal"`,
+			expectedActive: "personal",
+		},
+		{
+			name:           "unknown account",
+			account:        "nobody",
+			expectError:    true,
+			expectedErrMsg: `unknown account "nobody"`,
+			expectedActive: "personal",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			accounts, getClient := newAccounts(t)
+			_, handler := SwitchAccount(accounts, getClient, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"account": tc.account,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, accounts.Active())
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.account, response["account"])
+			assert.Equal(t, tc.expectedLogin, response["login"])
+			assert.Equal(t, "personal", response["previous"])
+		})
+	}
+}
+
+func Test_Accounts_ToolHandlerMiddleware(t *testing.T) {
+	accounts, err := NewAccounts([]string{"work", "personal"}, "")
+	require.NoError(t, err)
+	clients := map[string]*github.Client{
+		"work": github.NewClient(nil),
+		"personal": github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetUser,
+				&github.User{Login: github.Ptr("octocat")},
+			),
+		)),
+	}
+	getClient := func(ctx context.Context) (*github.Client, error) {
+		return clients[accounts.Current(ctx)], nil
+	}
+	_, switchHandler := SwitchAccount(accounts, getClient, translations.NullTranslationHelper)
+	switchAccount := accounts.ToolHandlerMiddleware(switchHandler)
+
+	// A call that resolves its client before and after another call switches the account
+	started := make(chan struct{})
+	switched := make(chan struct{})
+	var before, after *github.Client
+	call := accounts.ToolHandlerMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		before, _ = getClient(ctx)
+		close(started)
+		<-switched
+		after, _ = getClient(ctx)
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = call(context.Background(), createMCPRequest(map[string]any{}))
+	}()
+
+	<-started
+	result, err := switchAccount(context.Background(), createMCPRequest(map[string]any{"account": "personal"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	close(switched)
+	wg.Wait()
+
+	assert.Equal(t, "personal", accounts.Active())
+	assert.Same(t, clients["work"], before)
+	assert.Same(t, clients["work"], after)
+
+	// Calls started after the switch act as the new account
+	var current string
+	_, err = accounts.ToolHandlerMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		current = accounts.Current(ctx)
+		return mcp.NewToolResultText("done"), nil
+	})(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, "personal", current)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/profiler"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
@@ -150,6 +152,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
 			WithPagination(),
+			WithSummary(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -183,6 +186,11 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			summary, err := OptionalParam[bool](request, SummaryParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// Get optional pagination parameters
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
@@ -206,6 +214,10 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			if summary {
+				return summarizeWorkflowRuns(ctx, client, owner, repo, workflowID, opts)
+			}
+
 			workflowRuns, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
@@ -221,6 +233,56 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// summarizeWorkflowRuns reads the workflow runs matching opts across all pages and counts them by
+// status, conclusion, event and branch. The most recent run of each outcome is kept as a sample.
+func summarizeWorkflowRuns(ctx context.Context, client *github.Client, owner, repo, workflowID string, opts *github.ListWorkflowRunsOptions) (*mcp.CallToolResult, error) {
+	summary := newListSummary("status", "conclusion", "event", "branch")
+	opts.ListOptions = github.ListOptions{PerPage: 100, Page: 1}
+	for {
+		runs, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		summary.TotalCount = runs.GetTotalCount()
+		for _, run := range runs.WorkflowRuns {
+			if summary.Analyzed >= maxSummaryItems {
+				break
+			}
+			summary.Analyzed++
+			summary.count("status", run.GetStatus())
+			summary.count("conclusion", run.GetConclusion())
+			summary.count("event", run.GetEvent())
+			summary.count("branch", run.GetHeadBranch())
+
+			outcome := run.GetConclusion()
+			if outcome == "" {
+				outcome = run.GetStatus()
+			}
+			summary.sample(outcome, map[string]any{
+				"id":          run.GetID(),
+				"run_number":  run.GetRunNumber(),
+				"status":      run.GetStatus(),
+				"conclusion":  run.GetConclusion(),
+				"event":       run.GetEvent(),
+				"head_branch": run.GetHeadBranch(),
+				"head_sha":    run.GetHeadSHA(),
+				"created_at":  run.GetCreatedAt(),
+				"html_url":    run.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 || summary.Analyzed >= maxSummaryItems {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	summary.Truncated = summary.Analyzed < summary.TotalCount
+
+	return MarshalledTextResult(summary), nil
+}
+
 // RunWorkflow creates a tool to run an Actions workflow
 func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("run_workflow",
@@ -778,6 +840,90 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 	return finalResult, totalLines, httpResp, nil
 }
 
+// CreateRepositoryDispatchEvent creates a tool to trigger a repository_dispatch event
+func CreateRepositoryDispatchEvent(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_dispatch_event",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DISPATCH_EVENT_DESCRIPTION", "Trigger a repository_dispatch event, which runs any workflow or webhook listening for the given event type. Use run_workflow instead to run a single workflow with workflow_dispatch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_DISPATCH_EVENT_USER_TITLE", "Create repository dispatch event"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("event_type",
+				mcp.Required(),
+				mcp.Description("A custom event name that workflows filter on with 'on: repository_dispatch: types: [...]' (max 100 characters)"),
+			),
+			mcp.WithObject("client_payload",
+				mcp.Description("JSON payload with extra information for the workflow, available as github.event.client_payload (max 10 top-level properties)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			eventType, err := RequiredParam[string](request, "event_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			clientPayload, err := OptionalParam[map[string]any](request, "client_payload")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(clientPayload) > 10 {
+				return mcp.NewToolResultError(fmt.Sprintf("client_payload can have at most 10 top-level properties, got %d", len(clientPayload))), nil
+			}
+
+			event := github.DispatchRequestOptions{
+				EventType: eventType,
+			}
+			if clientPayload != nil {
+				payload, err := json.Marshal(clientPayload)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal client_payload: %w", err)
+				}
+				event.ClientPayload = (*json.RawMessage)(&payload)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Repositories.Dispatch(ctx, owner, repo, event)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create repository dispatch event", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":        "Repository dispatch event has been created",
+				"event_type":     eventType,
+				"client_payload": clientPayload,
+				"status":         resp.Status,
+				"status_code":    resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // RerunWorkflowRun creates a tool to re-run an entire workflow run
 func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("rerun_workflow_run",
@@ -1222,3 +1368,410 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// PendingDeployment is an environment a workflow run waits on for approval.
+type PendingDeployment struct {
+	EnvironmentID         int64      `json:"environment_id"`
+	Environment           string     `json:"environment"`
+	URL                   string     `json:"url,omitempty"`
+	WaitTimerMinutes      int64      `json:"wait_timer_minutes,omitempty"`
+	WaitTimerStartedAt    *time.Time `json:"wait_timer_started_at,omitempty"`
+	CurrentUserCanApprove bool       `json:"current_user_can_approve"`
+	Reviewers             []string   `json:"reviewers"`
+}
+
+func convertToPendingDeployment(deployment *github.PendingDeployment) PendingDeployment {
+	pending := PendingDeployment{
+		EnvironmentID:         deployment.GetEnvironment().GetID(),
+		Environment:           deployment.GetEnvironment().GetName(),
+		URL:                   deployment.GetEnvironment().GetHTMLURL(),
+		WaitTimerMinutes:      deployment.GetWaitTimer(),
+		CurrentUserCanApprove: deployment.GetCurrentUserCanApprove(),
+		Reviewers:             []string{},
+	}
+	if deployment.WaitTimerStartedAt != nil {
+		pending.WaitTimerStartedAt = &deployment.WaitTimerStartedAt.Time
+	}
+	for _, reviewer := range deployment.Reviewers {
+		switch r := reviewer.Reviewer.(type) {
+		case *github.User:
+			pending.Reviewers = append(pending.Reviewers, r.GetLogin())
+		case *github.Team:
+			pending.Reviewers = append(pending.Reviewers, "team:"+r.GetSlug())
+		}
+	}
+	return pending
+}
+
+// ListPendingDeployments creates a tool to list the environments a workflow run is waiting on for approval
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the environments a workflow run is waiting on because their protection rules require a review, with the required reviewers and whether the current user can approve")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_DEPLOYMENTS_USER_TITLE", "List pending deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			pending := make([]PendingDeployment, 0, len(deployments))
+			for _, deployment := range deployments {
+				pending = append(pending, convertToPendingDeployment(deployment))
+			}
+
+			return MarshalledTextResult(pending), nil
+		}
+}
+
+// ReviewPendingDeployment creates a tool to approve or reject the pending deployments of a workflow run
+func ReviewPendingDeployment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployment",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENT_DESCRIPTION", "Approve or reject the deployments of a workflow run that are waiting for an environment review. Only do this when explicitly instructed to. Use list_pending_deployments to see which environments are waiting.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENT_USER_TITLE", "Review pending deployment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("Comment explaining the review"),
+			),
+			mcp.WithArray("environments",
+				mcp.Description("Names of the environments to review. Defaults to all pending environments the current user can approve"),
+				mcp.Items(map[string]interface{}{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environments, err := OptionalStringArrayParam(request, "environments")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			byName := make(map[string]*github.PendingDeployment, len(pending))
+			for _, deployment := range pending {
+				byName[deployment.GetEnvironment().GetName()] = deployment
+			}
+			var environmentIDs []int64
+			if len(environments) == 0 {
+				for _, deployment := range pending {
+					if deployment.GetCurrentUserCanApprove() {
+						environmentIDs = append(environmentIDs, deployment.GetEnvironment().GetID())
+					}
+				}
+				if len(environmentIDs) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow run %d has no pending deployments the current user can review", runID)), nil
+				}
+			}
+			for _, name := range environments {
+				deployment, ok := byName[name]
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow run %d is not waiting on environment %s", runID, name)), nil
+				}
+				if !deployment.GetCurrentUserCanApprove() {
+					return mcp.NewToolResultError(fmt.Sprintf("the current user cannot review deployments to environment %s", name)), nil
+				}
+				environmentIDs = append(environmentIDs, deployment.GetEnvironment().GetID())
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, &github.PendingDeploymentsRequest{
+				EnvironmentIDs: environmentIDs,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			reviewed := make([]map[string]any, 0, len(deployments))
+			for _, deployment := range deployments {
+				reviewed = append(reviewed, map[string]any{
+					"id":          deployment.GetID(),
+					"environment": deployment.GetEnvironment(),
+					"ref":         deployment.GetRef(),
+					"sha":         deployment.GetSHA(),
+				})
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"run_id":      runID,
+				"state":       state,
+				"deployments": reviewed,
+			}), nil
+		}
+}
+
+// annotationLevels orders check run annotation levels from the most to the least severe.
+var annotationLevels = map[string]int{"failure": 0, "warning": 1, "notice": 2}
+
+// CheckAnnotation is an annotation a check run reported on a file position.
+type CheckAnnotation struct {
+	CheckRun    string `json:"check_run"`
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	StartColumn int    `json:"start_column,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty"`
+	Level       string `json:"level"`
+	Title       string `json:"title,omitempty"`
+	Message     string `json:"message"`
+}
+
+// GetCheckAnnotations creates a tool to collect the annotations of the check runs of a workflow run or commit
+func GetCheckAnnotations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_check_annotations",
+			mcp.WithDescription(t("TOOL_GET_CHECK_ANNOTATIONS_DESCRIPTION", "Collect the annotations (file, line, level and message) reported by the check runs of a workflow run or a commit, most severe first. Linters, compilers and test reporters surface their failures as annotations, so this points at the failing file positions without downloading logs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CHECK_ANNOTATIONS_USER_TITLE", "Get check run annotations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("The unique identifier of the workflow run whose check runs to collect the annotations of. Either run_id or ref is required"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Commit SHA, branch or tag whose check runs, of all apps, to collect the annotations of. Either run_id or ref is required"),
+			),
+			mcp.WithString("level",
+				mcp.Description("Only return annotations of this level or more severe (default notice, i.e. all)"),
+				mcp.Enum("failure", "warning", "notice"),
+			),
+			mcp.WithNumber("max_annotations",
+				mcp.Description("Maximum number of annotations to return (default 200, max 1000)"),
+				mcp.Min(1),
+				mcp.Max(1000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := OptionalIntParam(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (runID == 0) == (ref == "") {
+				return mcp.NewToolResultError("exactly one of run_id or ref must be provided"), nil
+			}
+			level, err := OptionalParam[string](request, "level")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if level == "" {
+				level = "notice"
+			}
+			maxLevel, ok := annotationLevels[level]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid level %q, must be failure, warning or notice", level)), nil
+			}
+			maxAnnotations, err := OptionalIntParamWithDefault(request, "max_annotations", 200)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var checkRuns []*github.CheckRun
+			var resp *github.Response
+			if runID != 0 {
+				run, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, int64(runID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get workflow run",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+				for {
+					runs, resp, err := client.Checks.ListCheckRunsCheckSuite(ctx, owner, repo, run.GetCheckSuiteID(), opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to list check runs",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					checkRuns = append(checkRuns, runs.CheckRuns...)
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			} else {
+				checkRuns, resp, err = listCheckRuns(ctx, client, owner, repo, ref)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list check runs",
+						resp,
+						err,
+					), nil
+				}
+			}
+
+			annotations := []CheckAnnotation{}
+			counts := map[string]int{}
+			for _, checkRun := range checkRuns {
+				if checkRun.GetOutput().GetAnnotationsCount() == 0 {
+					continue
+				}
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					page, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRun.GetID(), opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to list annotations of check run %s", checkRun.GetName()),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, a := range page {
+						if severity, ok := annotationLevels[a.GetAnnotationLevel()]; ok && severity > maxLevel {
+							continue
+						}
+						counts[a.GetAnnotationLevel()]++
+						annotations = append(annotations, CheckAnnotation{
+							CheckRun:    checkRun.GetName(),
+							Path:        a.GetPath(),
+							StartLine:   a.GetStartLine(),
+							EndLine:     a.GetEndLine(),
+							StartColumn: a.GetStartColumn(),
+							EndColumn:   a.GetEndColumn(),
+							Level:       a.GetAnnotationLevel(),
+							Title:       a.GetTitle(),
+							Message:     a.GetMessage(),
+						})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			sort.SliceStable(annotations, func(i, j int) bool {
+				return annotationLevels[annotations[i].Level] < annotationLevels[annotations[j].Level]
+			})
+			totalCount := len(annotations)
+			if totalCount > maxAnnotations {
+				annotations = annotations[:maxAnnotations]
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"check_runs":   len(checkRuns),
+				"total_count":  totalCount,
+				"truncated":    totalCount > maxAnnotations,
+				"level_counts": counts,
+				"annotations":  annotations,
+			}), nil
+		}
+}
@@ -0,0 +1,420 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AllowedActionsPolicy lists the actions a repository or organization may use when its allowed
+// actions are set to selected.
+type AllowedActionsPolicy struct {
+	GitHubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+	PatternsAllowed    []string `json:"patterns_allowed,omitempty"`
+}
+
+// ActionsPolicy is the GitHub Actions policy of a repository or organization.
+type ActionsPolicy struct {
+	// Target is the organization login, or owner/repo for a repository
+	Target string `json:"target"`
+	// Enabled is only set for repositories
+	Enabled *bool `json:"enabled,omitempty"`
+	// EnabledRepositories is only set for organizations: all, none or selected
+	EnabledRepositories          string                `json:"enabled_repositories,omitempty"`
+	AllowedActions               string                `json:"allowed_actions,omitempty"`
+	SelectedActions              *AllowedActionsPolicy `json:"selected_actions,omitempty"`
+	DefaultWorkflowPermissions   string                `json:"default_workflow_permissions,omitempty"`
+	CanApprovePullRequestReviews bool                  `json:"can_approve_pull_request_reviews"`
+	ForkPRApprovalPolicy         string                `json:"fork_pr_approval_policy,omitempty"`
+	// Unavailable explains why settings are missing, by setting
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// forkPRApproval is the setting deciding which outside contributors need approval before workflows
+// run on their pull requests. go-github does not support its endpoints yet.
+type forkPRApproval struct {
+	ApprovalPolicy string `json:"approval_policy"`
+}
+
+// actionsPath returns the path of an Actions permissions endpoint of a repository, or of an
+// organization when repo is empty.
+func actionsPath(owner, repo, endpoint string) string {
+	if repo == "" {
+		return fmt.Sprintf("orgs/%s/actions/permissions/%s", owner, endpoint)
+	}
+	return fmt.Sprintf("repos/%s/%s/actions/permissions/%s", owner, repo, endpoint)
+}
+
+// recordActionsSetting handles the outcome of reading an optional Actions setting. A setting the API
+// refuses to return is recorded as unavailable. Any other error is returned as the result of the tool
+// call, in which case done is true.
+func recordActionsSetting(ctx context.Context, policy *ActionsPolicy, name string, resp *github.Response, err error, message string) (result *mcp.CallToolResult, done bool) {
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err == nil {
+		return nil, false
+	}
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			policy.Unavailable[name] = "forbidden, requires admin access"
+			return nil, false
+		case http.StatusNotFound, http.StatusConflict:
+			policy.Unavailable[name] = "not available, GitHub Actions may be disabled"
+			return nil, false
+		}
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err), true
+}
+
+// getActionsPolicy reads the Actions policy of a repository, or of an organization when repo is
+// empty. When an API call fails, the policy is nil and result holds the error to return.
+func getActionsPolicy(ctx context.Context, client *github.Client, owner, repo string) (policy *ActionsPolicy, result *mcp.CallToolResult, err error) {
+	policy = &ActionsPolicy{Target: owner, Unavailable: map[string]string{}}
+	var (
+		allowedActions string
+		resp           *github.Response
+	)
+
+	if repo == "" {
+		var permissions *github.ActionsPermissions
+		permissions, resp, err = client.Actions.GetActionsPermissions(ctx, owner)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get Actions permissions of organization %s", owner), resp, err), nil
+		}
+		_ = resp.Body.Close()
+		policy.EnabledRepositories = permissions.GetEnabledRepositories()
+		allowedActions = permissions.GetAllowedActions()
+	} else {
+		policy.Target = fmt.Sprintf("%s/%s", owner, repo)
+		var permissions *github.ActionsPermissionsRepository
+		permissions, resp, err = client.Repositories.GetActionsPermissions(ctx, owner, repo)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get Actions permissions of repository %s/%s", owner, repo), resp, err), nil
+		}
+		_ = resp.Body.Close()
+		policy.Enabled = github.Ptr(permissions.GetEnabled())
+		allowedActions = permissions.GetAllowedActions()
+	}
+	policy.AllowedActions = allowedActions
+
+	if allowedActions == "selected" {
+		var allowed *github.ActionsAllowed
+		if repo == "" {
+			allowed, resp, err = client.Actions.GetActionsAllowed(ctx, owner)
+		} else {
+			allowed, resp, err = client.Repositories.GetActionsAllowed(ctx, owner, repo)
+		}
+		if result, done := recordActionsSetting(ctx, policy, "selected_actions", resp, err, "failed to get allowed actions"); done {
+			return nil, result, nil
+		}
+		if err == nil {
+			policy.SelectedActions = &AllowedActionsPolicy{
+				GitHubOwnedAllowed: allowed.GetGithubOwnedAllowed(),
+				VerifiedAllowed:    allowed.GetVerifiedAllowed(),
+				PatternsAllowed:    allowed.PatternsAllowed,
+			}
+		}
+	}
+
+	var (
+		defaultPermissions string
+		canApprove         bool
+	)
+	if repo == "" {
+		var workflow *github.DefaultWorkflowPermissionOrganization
+		workflow, resp, err = client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, owner)
+		defaultPermissions, canApprove = workflow.GetDefaultWorkflowPermissions(), workflow.GetCanApprovePullRequestReviews()
+	} else {
+		var workflow *github.DefaultWorkflowPermissionRepository
+		workflow, resp, err = client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+		defaultPermissions, canApprove = workflow.GetDefaultWorkflowPermissions(), workflow.GetCanApprovePullRequestReviews()
+	}
+	if result, done := recordActionsSetting(ctx, policy, "default_workflow_permissions", resp, err, "failed to get default workflow permissions"); done {
+		return nil, result, nil
+	}
+	policy.DefaultWorkflowPermissions = defaultPermissions
+	policy.CanApprovePullRequestReviews = canApprove
+
+	req, err := client.NewRequest(http.MethodGet, actionsPath(owner, repo, "fork-pr-contributor-approval"), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var approval forkPRApproval
+	resp, err = client.Do(ctx, req, &approval)
+	if result, done := recordActionsSetting(ctx, policy, "fork_pr_approval_policy", resp, err, "failed to get fork pull request approval policy"); done {
+		return nil, result, nil
+	}
+	policy.ForkPRApprovalPolicy = approval.ApprovalPolicy
+
+	return policy, nil, nil
+}
+
+// withActionsTarget adds the parameters identifying the repository or organization whose Actions
+// policy a tool works on.
+func withActionsTarget() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner, or the organization login when repo is omitted"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description("Repository name. Omit to work on the policy of the organization"),
+		)(tool)
+	}
+}
+
+// GetActionsPermissions creates a tool to get the GitHub Actions policy of a repository or organization.
+func GetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_actions_permissions",
+			mcp.WithDescription(t("TOOL_GET_ACTIONS_PERMISSIONS_DESCRIPTION", "Get the GitHub Actions policy of a repository, or of an organization when repo is omitted: whether Actions are enabled, which actions are allowed, the default permissions of the GITHUB_TOKEN, whether workflows can approve pull requests, and which outside contributors need approval before workflows run on their pull requests. Use this for CI governance audits. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ACTIONS_PERMISSIONS_USER_TITLE", "Get Actions permissions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withActionsTarget(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			policy, result, err := getActionsPolicy(ctx, client, owner, repo)
+			if err != nil || result != nil {
+				return result, err
+			}
+			return MarshalledTextResult(policy), nil
+		}
+}
+
+// SetActionsPermissions creates a tool to change the GitHub Actions policy of a repository or organization.
+func SetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_actions_permissions",
+			mcp.WithDescription(t("TOOL_SET_ACTIONS_PERMISSIONS_DESCRIPTION", "Change the GitHub Actions policy of a repository, or of an organization when repo is omitted. Only the given settings are changed, and the resulting policy is returned. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ACTIONS_PERMISSIONS_USER_TITLE", "Set Actions permissions"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withActionsTarget(),
+			mcp.WithBoolean("enabled",
+				mcp.Description("Whether GitHub Actions are enabled for the repository. Repositories only"),
+			),
+			mcp.WithString("enabled_repositories",
+				mcp.Description("Which repositories of the organization may use GitHub Actions. Organizations only"),
+				mcp.Enum("all", "none", "selected"),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("Which actions and reusable workflows may be used"),
+				mcp.Enum("all", "local_only", "selected"),
+			),
+			mcp.WithBoolean("github_owned_allowed",
+				mcp.Description("Whether actions created by GitHub are allowed, when allowed_actions is selected"),
+			),
+			mcp.WithBoolean("verified_allowed",
+				mcp.Description("Whether actions by verified creators are allowed, when allowed_actions is selected"),
+			),
+			mcp.WithArray("patterns_allowed",
+				mcp.Description("Patterns of the other actions that are allowed, such as monalisa/octocat@* or docker/*, when allowed_actions is selected. Replaces the current patterns"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("default_workflow_permissions",
+				mcp.Description("Default permissions granted to the GITHUB_TOKEN of workflow runs"),
+				mcp.Enum("read", "write"),
+			),
+			mcp.WithBoolean("can_approve_pull_request_reviews",
+				mcp.Description("Whether workflows may approve pull requests"),
+			),
+			mcp.WithString("fork_pr_approval_policy",
+				mcp.Description("Which outside contributors need approval before workflows run on their pull requests"),
+				mcp.Enum("first_time_contributors_new_to_github", "first_time_contributors", "all_external_contributors"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabled, hasEnabled, err := OptionalParamOK[bool](request, "enabled")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabledRepositories, err := OptionalParam[string](request, "enabled_repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowedActions, err := OptionalParam[string](request, "allowed_actions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			githubOwnedAllowed, hasGitHubOwnedAllowed, err := OptionalParamOK[bool](request, "github_owned_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			verifiedAllowed, hasVerifiedAllowed, err := OptionalParamOK[bool](request, "verified_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasPatternsAllowed := request.GetArguments()["patterns_allowed"]
+			patternsAllowed, err := OptionalStringArrayParam(request, "patterns_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultWorkflowPermissions, err := OptionalParam[string](request, "default_workflow_permissions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			canApprove, hasCanApprove, err := OptionalParamOK[bool](request, "can_approve_pull_request_reviews")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			forkPRApprovalPolicy, err := OptionalParam[string](request, "fork_pr_approval_policy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			switch {
+			case repo == "" && hasEnabled:
+				return mcp.NewToolResultError("enabled only applies to repositories, use enabled_repositories for organizations"), nil
+			case repo != "" && enabledRepositories != "":
+				return mcp.NewToolResultError("enabled_repositories only applies to organizations, use enabled for repositories"), nil
+			}
+			setPermissions := hasEnabled || enabledRepositories != "" || allowedActions != ""
+			setAllowed := hasGitHubOwnedAllowed || hasVerifiedAllowed || hasPatternsAllowed
+			setWorkflow := defaultWorkflowPermissions != "" || hasCanApprove
+			if !setPermissions && !setAllowed && !setWorkflow && forkPRApprovalPolicy == "" {
+				return mcp.NewToolResultError("at least one setting to change must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if setPermissions {
+				// Both endpoints require the enabled setting, so the current one is kept when it is not changed
+				if repo == "" {
+					permissions := github.ActionsPermissions{AllowedActions: github.Ptr(allowedActions)}
+					if allowedActions == "" {
+						permissions.AllowedActions = nil
+					}
+					if enabledRepositories == "" {
+						current, resp, err := client.Actions.GetActionsPermissions(ctx, owner)
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Actions permissions", resp, err), nil
+						}
+						_ = resp.Body.Close()
+						enabledRepositories = current.GetEnabledRepositories()
+					}
+					permissions.EnabledRepositories = github.Ptr(enabledRepositories)
+					_, resp, err = client.Actions.EditActionsPermissions(ctx, owner, permissions)
+				} else {
+					permissions := github.ActionsPermissionsRepository{AllowedActions: github.Ptr(allowedActions)}
+					if allowedActions == "" {
+						permissions.AllowedActions = nil
+					}
+					if !hasEnabled {
+						current, resp, err := client.Repositories.GetActionsPermissions(ctx, owner, repo)
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Actions permissions", resp, err), nil
+						}
+						_ = resp.Body.Close()
+						enabled = current.GetEnabled()
+					}
+					permissions.Enabled = github.Ptr(enabled)
+					_, resp, err = client.Repositories.EditActionsPermissions(ctx, owner, repo, permissions)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set Actions permissions", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if setAllowed {
+				allowed := github.ActionsAllowed{PatternsAllowed: patternsAllowed}
+				if hasGitHubOwnedAllowed {
+					allowed.GithubOwnedAllowed = github.Ptr(githubOwnedAllowed)
+				}
+				if hasVerifiedAllowed {
+					allowed.VerifiedAllowed = github.Ptr(verifiedAllowed)
+				}
+				if repo == "" {
+					_, resp, err = client.Actions.EditActionsAllowed(ctx, owner, allowed)
+				} else {
+					_, resp, err = client.Repositories.EditActionsAllowed(ctx, owner, repo, allowed)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set allowed actions", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if setWorkflow {
+				var permissions *string
+				if defaultWorkflowPermissions != "" {
+					permissions = github.Ptr(defaultWorkflowPermissions)
+				}
+				var approve *bool
+				if hasCanApprove {
+					approve = github.Ptr(canApprove)
+				}
+				if repo == "" {
+					_, resp, err = client.Actions.EditDefaultWorkflowPermissionsInOrganization(ctx, owner, github.DefaultWorkflowPermissionOrganization{
+						DefaultWorkflowPermissions:   permissions,
+						CanApprovePullRequestReviews: approve,
+					})
+				} else {
+					_, resp, err = client.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repo, github.DefaultWorkflowPermissionRepository{
+						DefaultWorkflowPermissions:   permissions,
+						CanApprovePullRequestReviews: approve,
+					})
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set default workflow permissions", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if forkPRApprovalPolicy != "" {
+				req, err := client.NewRequest(http.MethodPut, actionsPath(owner, repo, "fork-pr-contributor-approval"), forkPRApproval{ApprovalPolicy: forkPRApprovalPolicy})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				resp, err = client.Do(ctx, req, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set fork pull request approval policy", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			policy, result, err := getActionsPolicy(ctx, client, owner, repo)
+			if err != nil || result != nil {
+				return result, err
+			}
+			return MarshalledTextResult(policy), nil
+		}
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	getReposForkPRApprovalByOwnerByRepo = mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/actions/permissions/fork-pr-contributor-approval",
+		Method:  "GET",
+	}
+	putReposForkPRApprovalByOwnerByRepo = mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/actions/permissions/fork-pr-contributor-approval",
+		Method:  "PUT",
+	}
+	getOrgsForkPRApprovalByOrg = mock.EndpointPattern{
+		Pattern: "/orgs/{org}/actions/permissions/fork-pr-contributor-approval",
+		Method:  "GET",
+	}
+)
+
+func Test_GetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedPolicy ActionsPolicy
+	}{
+		{
+			name: "repository with selected actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsPermissionsByOwnerByRepo, github.ActionsPermissionsRepository{
+					Enabled:        github.Ptr(true),
+					AllowedActions: github.Ptr("selected"),
+				}),
+				mock.WithRequestMatch(mock.GetReposActionsPermissionsSelectedActionsByOwnerByRepo, github.ActionsAllowed{
+					GithubOwnedAllowed: github.Ptr(true),
+					PatternsAllowed:    []string{"docker/*"},
+				}),
+				mock.WithRequestMatch(mock.GetReposActionsPermissionsWorkflowByOwnerByRepo, github.DefaultWorkflowPermissionRepository{
+					DefaultWorkflowPermissions:   github.Ptr("read"),
+					CanApprovePullRequestReviews: github.Ptr(false),
+				}),
+				mock.WithRequestMatch(getReposForkPRApprovalByOwnerByRepo, forkPRApproval{ApprovalPolicy: "first_time_contributors"}),
+			),
+			requestArgs: map[string]any{"owner": "octo-org", "repo": "octo-repo"},
+			expectedPolicy: ActionsPolicy{
+				Target:         "octo-org/octo-repo",
+				Enabled:        github.Ptr(true),
+				AllowedActions: "selected",
+				SelectedActions: &AllowedActionsPolicy{
+					GitHubOwnedAllowed: true,
+					PatternsAllowed:    []string{"docker/*"},
+				},
+				DefaultWorkflowPermissions: "read",
+				ForkPRApprovalPolicy:       "first_time_contributors",
+			},
+		},
+		{
+			name: "organization without fork approval setting",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsActionsPermissionsByOrg, github.ActionsPermissions{
+					EnabledRepositories: github.Ptr("all"),
+					AllowedActions:      github.Ptr("all"),
+				}),
+				mock.WithRequestMatch(mock.GetOrgsActionsPermissionsWorkflowByOrg, github.DefaultWorkflowPermissionOrganization{
+					DefaultWorkflowPermissions:   github.Ptr("write"),
+					CanApprovePullRequestReviews: github.Ptr(true),
+				}),
+				mock.WithRequestMatchHandler(
+					getOrgsForkPRApprovalByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{"owner": "octo-org"},
+			expectedPolicy: ActionsPolicy{
+				Target:                       "octo-org",
+				EnabledRepositories:          "all",
+				AllowedActions:               "all",
+				DefaultWorkflowPermissions:   "write",
+				CanApprovePullRequestReviews: true,
+				Unavailable: map[string]string{
+					"fork_pr_approval_policy": "forbidden, requires admin access",
+				},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org", "repo": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to get Actions permissions of repository octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var policy ActionsPolicy
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &policy))
+			assert.Equal(t, tc.expectedPolicy, policy)
+		})
+	}
+}
+
+func Test_SetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_actions_permissions", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	t.Run("keeps the current enabled setting", func(t *testing.T) {
+		bodies := map[string]map[string]any{}
+		recordBody := func(name string) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var decoded map[string]any
+				require.NoError(t, json.Unmarshal(body, &decoded))
+				bodies[name] = decoded
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposActionsPermissionsByOwnerByRepo,
+				github.ActionsPermissionsRepository{Enabled: github.Ptr(true), AllowedActions: github.Ptr("all")},
+				github.ActionsPermissionsRepository{Enabled: github.Ptr(true), AllowedActions: github.Ptr("local_only")},
+			),
+			mock.WithRequestMatchHandler(mock.PutReposActionsPermissionsByOwnerByRepo, recordBody("permissions")),
+			mock.WithRequestMatchHandler(mock.PutReposActionsPermissionsWorkflowByOwnerByRepo, recordBody("workflow")),
+			mock.WithRequestMatchHandler(putReposForkPRApprovalByOwnerByRepo, recordBody("fork_pr_approval")),
+			mock.WithRequestMatch(mock.GetReposActionsPermissionsWorkflowByOwnerByRepo, github.DefaultWorkflowPermissionRepository{
+				DefaultWorkflowPermissions: github.Ptr("read"),
+			}),
+			mock.WithRequestMatch(getReposForkPRApprovalByOwnerByRepo, forkPRApproval{ApprovalPolicy: "all_external_contributors"}),
+		)
+		_, handler := SetActionsPermissions(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":                        "octo-org",
+			"repo":                         "octo-repo",
+			"allowed_actions":              "local_only",
+			"default_workflow_permissions": "read",
+			"fork_pr_approval_policy":      "all_external_contributors",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		assert.Equal(t, map[string]any{"enabled": true, "allowed_actions": "local_only"}, bodies["permissions"])
+		assert.Equal(t, map[string]any{"default_workflow_permissions": "read"}, bodies["workflow"])
+		assert.Equal(t, map[string]any{"approval_policy": "all_external_contributors"}, bodies["fork_pr_approval"])
+
+		var policy ActionsPolicy
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &policy))
+		assert.Equal(t, "local_only", policy.AllowedActions)
+		assert.Equal(t, "all_external_contributors", policy.ForkPRApprovalPolicy)
+	})
+
+	t.Run("rejects settings of the wrong scope", func(t *testing.T) {
+		_, handler := SetActionsPermissions(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "enabled": false}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "enabled only applies to repositories")
+	})
+
+	t.Run("requires a setting", func(t *testing.T) {
+		_, handler := SetActionsPermissions(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "octo-repo"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "at least one setting to change must be provided")
+	})
+}
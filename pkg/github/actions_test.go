@@ -308,6 +308,133 @@ func Test_RunWorkflow_WithFilename(t *testing.T) {
 	}
 }
 
+func Test_CreateRepositoryDispatchEvent(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryDispatchEvent(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_repository_dispatch_event", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "event_type")
+	assert.Contains(t, tool.InputSchema.Properties, "client_payload")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "event_type"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful dispatch with payload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDispatchesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"event_type": "deploy",
+						"client_payload": map[string]any{
+							"environment": "staging",
+							"sha":         "abc123",
+						},
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "deploy",
+				"client_payload": map[string]any{
+					"environment": "staging",
+					"sha":         "abc123",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "successful dispatch without payload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDispatchesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"event_type": "docs",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "docs",
+			},
+			expectError: false,
+		},
+		{
+			name:         "too many payload properties",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "deploy",
+				"client_payload": map[string]any{
+					"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9, "j": 10, "k": 11,
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "client_payload can have at most 10 top-level properties, got 11",
+		},
+		{
+			name:         "missing required parameter event_type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: event_type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryDispatchEvent(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "Repository dispatch event has been created", response["message"])
+			assert.Equal(t, tc.requestArgs["event_type"], response["event_type"])
+		})
+	}
+}
+
 func Test_CancelWorkflowRun(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1319,3 +1446,421 @@ func Test_MemoryUsage_SlidingWindow_vs_NoWindow(t *testing.T) {
 	t.Logf("Sliding window: %s", profile1.String())
 	t.Logf("No window: %s", profile2.String())
 }
+
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+			[]*github.PendingDeployment{
+				{
+					Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(1)), Name: github.Ptr("production")},
+					WaitTimer:             github.Ptr(int64(30)),
+					CurrentUserCanApprove: github.Ptr(true),
+					Reviewers: []*github.RequiredReviewer{
+						{Type: github.Ptr("User"), Reviewer: &github.User{Login: github.Ptr("octocat")}},
+						{Type: github.Ptr("Team"), Reviewer: &github.Team{Slug: github.Ptr("release-managers")}},
+					},
+				},
+			},
+		),
+	))
+	_, handler := ListPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(12345),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var pending []PendingDeployment
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pending))
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(1), pending[0].EnvironmentID)
+	assert.Equal(t, "production", pending[0].Environment)
+	assert.Equal(t, int64(30), pending[0].WaitTimerMinutes)
+	assert.True(t, pending[0].CurrentUserCanApprove)
+	assert.Equal(t, []string{"octocat", "team:release-managers"}, pending[0].Reviewers)
+}
+
+func Test_ReviewPendingDeployment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "state", "comment"})
+
+	pending := []*github.PendingDeployment{
+		{
+			Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(1)), Name: github.Ptr("staging")},
+			CurrentUserCanApprove: github.Ptr(true),
+		},
+		{
+			Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(2)), Name: github.Ptr("production")},
+			CurrentUserCanApprove: github.Ptr(false),
+		},
+	}
+	deployments := []*github.Deployment{
+		{ID: github.Ptr(int64(99)), Environment: github.Ptr("staging"), Ref: github.Ptr("main"), SHA: github.Ptr("abc123")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approve all approvable environments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pending),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(1)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, deployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"state":   "approved",
+				"comment": "Ship it",
+			},
+		},
+		{
+			name: "reject named environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pending),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(1)},
+						"state":           "rejected",
+						"comment":         "Tests are flaky",
+					}).andThen(
+						mockResponse(t, http.StatusOK, deployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"run_id":       float64(12345),
+				"state":        "rejected",
+				"comment":      "Tests are flaky",
+				"environments": []any{"staging"},
+			},
+		},
+		{
+			name: "environment the user cannot review",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pending),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"run_id":       float64(12345),
+				"state":        "approved",
+				"comment":      "Ship it",
+				"environments": []any{"production"},
+			},
+			expectError:    true,
+			expectedErrMsg: "the current user cannot review deployments to environment production",
+		},
+		{
+			name: "environment not pending",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pending),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"run_id":       float64(12345),
+				"state":        "approved",
+				"comment":      "Ship it",
+				"environments": []any{"qa"},
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow run 12345 is not waiting on environment qa",
+		},
+		{
+			name: "nothing to review",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, []*github.PendingDeployment{}),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"state":   "approved",
+				"comment": "Ship it",
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow run 12345 has no pending deployments the current user can review",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			var returned struct {
+				RunID       int64            `json:"run_id"`
+				State       string           `json:"state"`
+				Deployments []map[string]any `json:"deployments"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(12345), returned.RunID)
+			assert.Equal(t, tc.requestArgs["state"], returned.State)
+			require.Len(t, returned.Deployments, 1)
+			assert.Equal(t, "staging", returned.Deployments[0]["environment"])
+		})
+	}
+}
+
+func Test_ListWorkflowRuns_Summary(t *testing.T) {
+	tool, _ := ListWorkflowRuns(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Contains(t, tool.InputSchema.Properties, "summary")
+
+	run := func(id int64, status, conclusion, branch string) *github.WorkflowRun {
+		return &github.WorkflowRun{
+			ID:         github.Ptr(id),
+			Status:     github.Ptr(status),
+			Conclusion: github.Ptr(conclusion),
+			Event:      github.Ptr("push"),
+			HeadBranch: github.Ptr(branch),
+		}
+	}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchPages(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			&github.WorkflowRuns{TotalCount: github.Ptr(4), WorkflowRuns: []*github.WorkflowRun{
+				run(4, "in_progress", "", "main"),
+				run(3, "completed", "failure", "main"),
+			}},
+			&github.WorkflowRuns{TotalCount: github.Ptr(4), WorkflowRuns: []*github.WorkflowRun{
+				run(2, "completed", "success", "feature"),
+				run(1, "completed", "failure", "main"),
+			}},
+		),
+	)
+	_, handler := ListWorkflowRuns(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"workflow_id": "ci.yml",
+		"summary":     true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var summary ListSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+	assert.Equal(t, 4, summary.TotalCount)
+	assert.Equal(t, 4, summary.Analyzed)
+	assert.False(t, summary.Truncated)
+	assert.Equal(t, map[string]int{"completed": 3, "in_progress": 1}, summary.Counts["status"])
+	assert.Equal(t, map[string]int{"failure": 2, "success": 1, "(none)": 1}, summary.Counts["conclusion"])
+	assert.Equal(t, map[string]int{"main": 3, "feature": 1}, summary.Counts["branch"])
+
+	// The most recent run of each outcome
+	require.Len(t, summary.Samples, 3)
+	var ids []float64
+	for _, sample := range summary.Samples {
+		ids = append(ids, sample.(map[string]any)["id"].(float64))
+	}
+	assert.Equal(t, []float64{4, 3, 2}, ids)
+}
+
+func Test_GetCheckAnnotations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCheckAnnotations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_check_annotations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	checkRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Output: &github.CheckRunOutput{AnnotationsCount: github.Ptr(2)}},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Output: &github.CheckRunOutput{AnnotationsCount: github.Ptr(1)}},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("build"), Output: &github.CheckRunOutput{AnnotationsCount: github.Ptr(0)}},
+		},
+	}
+	annotationsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/check-runs/1/annotations":
+			mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+				{Path: github.Ptr("main.go"), StartLine: github.Ptr(3), EndLine: github.Ptr(3), AnnotationLevel: github.Ptr("warning"), Message: github.Ptr("exported function should have comment")},
+				{Path: github.Ptr("util.go"), StartLine: github.Ptr(10), EndLine: github.Ptr(12), AnnotationLevel: github.Ptr("notice"), Message: github.Ptr("consider simplifying")},
+			})(w, r)
+		case "/repos/owner/repo/check-runs/2/annotations":
+			mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+				{Path: github.Ptr("main_test.go"), StartLine: github.Ptr(42), EndLine: github.Ptr(42), AnnotationLevel: github.Ptr("failure"), Title: github.Ptr("TestMain"), Message: github.Ptr("expected 1, got 2")},
+			})(w, r)
+		default:
+			t.Errorf("unexpected annotations request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedPaths  []string
+		expectedTotal  int
+		truncated      bool
+	}{
+		{
+			name: "annotations of a workflow run, most severe first",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					&github.WorkflowRun{ID: github.Ptr(int64(12345)), CheckSuiteID: github.Ptr(int64(99))},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckSuitesCheckRunsByOwnerByRepoByCheckSuiteId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/check-suites/99/check-runs", r.URL.Path)
+						mockResponse(t, http.StatusOK, checkRuns)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					annotationsHandler,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectedPaths: []string{"main_test.go", "main.go", "util.go"},
+			expectedTotal: 3,
+		},
+		{
+			name: "failures and warnings of a commit, truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					annotationsHandler,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"ref":             "abc123",
+				"level":           "warning",
+				"max_annotations": float64(1),
+			},
+			expectedPaths: []string{"main_test.go"},
+			expectedTotal: 2,
+			truncated:     true,
+		},
+		{
+			name:         "both run_id and ref",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+				"ref":    "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of run_id or ref must be provided",
+		},
+		{
+			name: "workflow run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCheckAnnotations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				CheckRuns   int               `json:"check_runs"`
+				TotalCount  int               `json:"total_count"`
+				Truncated   bool              `json:"truncated"`
+				Annotations []CheckAnnotation `json:"annotations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, 3, response.CheckRuns)
+			assert.Equal(t, tc.expectedTotal, response.TotalCount)
+			assert.Equal(t, tc.truncated, response.Truncated)
+			paths := make([]string, 0, len(response.Annotations))
+			for _, a := range response.Annotations {
+				paths = append(paths, a.Path)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+			assert.Equal(t, "test", response.Annotations[0].CheckRun)
+			assert.Equal(t, "failure", response.Annotations[0].Level)
+			assert.Equal(t, 42, response.Annotations[0].StartLine)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalAutolink is the trimmed output type for an autolink reference of a repository.
+type MinimalAutolink struct {
+	ID             int64  `json:"id"`
+	KeyPrefix      string `json:"key_prefix"`
+	URLTemplate    string `json:"url_template"`
+	IsAlphanumeric bool   `json:"is_alphanumeric"`
+}
+
+func convertToMinimalAutolink(autolink *github.Autolink) MinimalAutolink {
+	return MinimalAutolink{
+		ID:             autolink.GetID(),
+		KeyPrefix:      autolink.GetKeyPrefix(),
+		URLTemplate:    autolink.GetURLTemplate(),
+		IsAlphanumeric: autolink.GetIsAlphanumeric(),
+	}
+}
+
+// ListAutolinks creates a tool to list the autolink references of a repository.
+func ListAutolinks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_autolinks",
+			mcp.WithDescription(t("TOOL_LIST_AUTOLINKS_DESCRIPTION", "List the autolink references of a GitHub repository, which turn references such as JIRA-123 in issues, pull requests and commit messages into links to an external tracker. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_AUTOLINKS_USER_TITLE", "List autolinks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list autolinks of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalAutolinks := make([]MinimalAutolink, 0, len(autolinks))
+			for _, autolink := range autolinks {
+				minimalAutolinks = append(minimalAutolinks, convertToMinimalAutolink(autolink))
+			}
+			return MarshalledTextResult(minimalAutolinks), nil
+		}
+}
+
+// CreateAutolink creates a tool to add an autolink reference to a repository.
+func CreateAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_autolink",
+			mcp.WithDescription(t("TOOL_CREATE_AUTOLINK_DESCRIPTION", "Add an autolink reference to a GitHub repository, so that references made of the key prefix and an identifier, such as JIRA-123 or LIN-abc, link to an external tracker like Jira or Linear. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AUTOLINK_USER_TITLE", "Create autolink"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Required(),
+				mcp.Description("Prefix of the references to link, including any separator, for example 'JIRA-'"),
+			),
+			mcp.WithString("url_template",
+				mcp.Required(),
+				mcp.Description("URL the references link to, where <num> stands for the identifier following the prefix, for example 'https://example.atlassian.net/browse/JIRA-<num>'"),
+			),
+			mcp.WithBoolean("is_alphanumeric",
+				mcp.Description("Whether identifiers may contain letters as well as digits. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyPrefix, err := RequiredParam[string](request, "key_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			urlTemplate, err := RequiredParam[string](request, "url_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !strings.Contains(urlTemplate, "<num>") {
+				return mcp.NewToolResultError("url_template must contain <num>, which is replaced by the identifier of the reference"), nil
+			}
+			isAlphanumeric, err := OptionalBoolParamWithDefault(request, "is_alphanumeric", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.AddAutolink(ctx, owner, repo, &github.AutolinkOptions{
+				KeyPrefix:      github.Ptr(keyPrefix),
+				URLTemplate:    github.Ptr(urlTemplate),
+				IsAlphanumeric: github.Ptr(isAlphanumeric),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create autolink in %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalAutolink(created)), nil
+		}
+}
+
+// DeleteAutolink creates a tool to delete an autolink reference from a repository.
+func DeleteAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_autolink",
+			mcp.WithDescription(t("TOOL_DELETE_AUTOLINK_DESCRIPTION", "Delete an autolink reference from a GitHub repository. References already written stay as plain text. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_AUTOLINK_USER_TITLE", "Delete autolink"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("autolink_id",
+				mcp.Required(),
+				mcp.Description("ID of the autolink, as returned by list_autolinks"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			autolinkID, err := RequiredInt(request, "autolink_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteAutolink(ctx, owner, repo, int64(autolinkID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete autolink %d", autolinkID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted autolink %d from %s/%s", autolinkID, owner, repo)), nil
+		}
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAutolinks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAutolinks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_autolinks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposAutolinksByOwnerByRepo,
+			expectPath(t, "/repos/octo-org/octo-repo/autolinks").andThen(
+				mockResponse(t, http.StatusOK, []*github.Autolink{
+					{
+						ID:             github.Ptr(int64(1)),
+						KeyPrefix:      github.Ptr("JIRA-"),
+						URLTemplate:    github.Ptr("https://example.atlassian.net/browse/JIRA-<num>"),
+						IsAlphanumeric: github.Ptr(false),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListAutolinks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "octo-org",
+		"repo":  "octo-repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var autolinks []MinimalAutolink
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &autolinks))
+	assert.Equal(t, []MinimalAutolink{
+		{ID: 1, KeyPrefix: "JIRA-", URLTemplate: "https://example.atlassian.net/browse/JIRA-<num>"},
+	}, autolinks)
+}
+
+func Test_CreateAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_autolink", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "key_prefix", "url_template"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedAutolink MinimalAutolink
+	}{
+		{
+			name: "alphanumeric by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"key_prefix":      "LIN-",
+						"url_template":    "https://linear.app/octo/issue/LIN-<num>",
+						"is_alphanumeric": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Autolink{
+							ID:             github.Ptr(int64(2)),
+							KeyPrefix:      github.Ptr("LIN-"),
+							URLTemplate:    github.Ptr("https://linear.app/octo/issue/LIN-<num>"),
+							IsAlphanumeric: github.Ptr(true),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repo":         "octo-repo",
+				"key_prefix":   "LIN-",
+				"url_template": "https://linear.app/octo/issue/LIN-<num>",
+			},
+			expectedAutolink: MinimalAutolink{ID: 2, KeyPrefix: "LIN-", URLTemplate: "https://linear.app/octo/issue/LIN-<num>", IsAlphanumeric: true},
+		},
+		{
+			name:         "template without placeholder",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repo":         "octo-repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://example.atlassian.net/browse/",
+			},
+			expectError:    true,
+			expectedErrMsg: "url_template must contain <num>",
+		},
+		{
+			name: "prefix already in use",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repo":         "octo-repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://example.atlassian.net/browse/JIRA-<num>",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create autolink in octo-org/octo-repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var autolink MinimalAutolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &autolink))
+			assert.Equal(t, tc.expectedAutolink, autolink)
+		})
+	}
+}
+
+func Test_DeleteAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_autolink", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "autolink_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+			expectPath(t, "/repos/octo-org/octo-repo/autolinks/2").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "octo-org",
+		"repo":        "octo-repo",
+		"autolink_id": float64(2),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Successfully deleted autolink 2 from octo-org/octo-repo", getTextResult(t, result).Text)
+}
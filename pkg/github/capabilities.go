@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// toolSchemaRequirements lists the GraphQL schema elements a tool relies on, either a type name or
+// "Type.field". GitHub Enterprise Server releases lag behind github.com, so on older servers these
+// tools are removed instead of failing when they are called. The REST endpoints of sub-issues and
+// issue types shipped together with their schema counterparts, which makes the schema a reliable
+// probe for them as well.
+var toolSchemaRequirements = map[string][]string{
+	"add_sub_issue":              {"Issue.subIssues"},
+	"list_sub_issues":            {"Issue.subIssues"},
+	"remove_sub_issue":           {"Issue.subIssues"},
+	"reprioritize_sub_issue":     {"Issue.subIssues"},
+	"list_issue_types":           {"IssueType"},
+	"get_merge_queue":            {"Repository.mergeQueue"},
+	"list_merge_queue_entries":   {"Repository.mergeQueue"},
+	"provision_project":          {"ProjectV2"},
+	"get_project_insights":       {"ProjectV2"},
+	"export_project_items":       {"ProjectV2"},
+	"list_project_repos":         {"ProjectV2"},
+	"list_project_workflows":     {"ProjectV2.workflows"},
+	"search_project_issues":      {"ProjectV2"},
+	"find_item_in_projects":      {"ProjectV2", "Issue.projectItems"},
+	"resolve_project":            {"ProjectV2"},
+	"update_draft_issue":         {"ProjectV2"},
+	"comment_on_project_item":    {"ProjectV2"},
+	"clear_project_item_field":   {"ProjectV2"},
+	"list_project_iterations":    {"ProjectV2IterationField"},
+	"set_item_iteration":         {"ProjectV2IterationField"},
+	"mark_project_as_template":   {"Mutation.markProjectV2AsTemplate"},
+	"unmark_project_as_template": {"Mutation.unmarkProjectV2AsTemplate"},
+	"list_project_templates":     {"ProjectV2.template"},
+}
+
+// paramSchemaRequirements lists tool parameters that rely on a GraphQL schema element. On servers
+// that lack it, the parameter is removed and the rest of the tool is kept.
+var paramSchemaRequirements = map[string]map[string]string{
+	"create_issue": {"type": "IssueType"},
+	"update_issue": {"type": "IssueType"},
+}
+
+// SchemaCapabilities records which of the schema elements tools rely on a server supports.
+type SchemaCapabilities struct {
+	// types maps the probed type names to their fields, with a nil map for types the server lacks
+	types map[string]map[string]bool
+}
+
+// Supports reports whether the server has the given type or "Type.field". Elements that were not
+// probed are assumed to be supported.
+func (c *SchemaCapabilities) Supports(element string) bool {
+	typeName, field, hasField := strings.Cut(element, ".")
+	fields, probed := c.types[typeName]
+	if !probed {
+		return true
+	}
+	if fields == nil {
+		return false
+	}
+	return !hasField || fields[field]
+}
+
+// schemaTypes returns the names of the types the requirement tables refer to.
+func schemaTypes() []string {
+	names := map[string]bool{}
+	add := func(element string) {
+		typeName, _, _ := strings.Cut(element, ".")
+		names[typeName] = true
+	}
+	for _, elements := range toolSchemaRequirements {
+		for _, element := range elements {
+			add(element)
+		}
+	}
+	for _, params := range paramSchemaRequirements {
+		for _, element := range params {
+			add(element)
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// ProbeSchemaCapabilities introspects the GraphQL schema of the server for the types and fields
+// tools rely on.
+func ProbeSchemaCapabilities(ctx context.Context, client *githubv4.Client) (*SchemaCapabilities, error) {
+	capabilities := &SchemaCapabilities{types: map[string]map[string]bool{}}
+	for _, typeName := range schemaTypes() {
+		var query struct {
+			Type *struct {
+				Fields []struct {
+					Name githubv4.String
+				} `graphql:"fields(includeDeprecated: true)"`
+			} `graphql:"__type(name: $name)"`
+		}
+		vars := map[string]any{
+			"name": githubv4.String(typeName),
+		}
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+
+		if query.Type == nil {
+			capabilities.types[typeName] = nil
+			continue
+		}
+		fields := make(map[string]bool, len(query.Type.Fields))
+		for _, field := range query.Type.Fields {
+			fields[string(field.Name)] = true
+		}
+		capabilities.types[typeName] = fields
+	}
+	return capabilities, nil
+}
+
+// UnsupportedTool is a tool, or a parameter of a tool, the server lacks the schema elements for.
+type UnsupportedTool struct {
+	Tool    string   `json:"tool"`
+	Toolset string   `json:"toolset"`
+	Param   string   `json:"param,omitempty"`
+	Missing []string `json:"missing"`
+}
+
+// GateUnsupportedTools removes the tools of tsg the server lacks the schema elements for, and the
+// parameters of other tools that rely on missing elements. It returns what was removed, sorted by
+// toolset and tool name.
+func GateUnsupportedTools(tsg *toolsets.ToolsetGroup, capabilities *SchemaCapabilities) []UnsupportedTool {
+	unsupported := []UnsupportedTool{}
+	for name, toolset := range tsg.Toolsets {
+		var remove []string
+		for _, tool := range toolset.GetAvailableTools() {
+			var missing []string
+			for _, element := range toolSchemaRequirements[tool.Tool.Name] {
+				if !capabilities.Supports(element) {
+					missing = append(missing, element)
+				}
+			}
+			if len(missing) > 0 {
+				remove = append(remove, tool.Tool.Name)
+				unsupported = append(unsupported, UnsupportedTool{Tool: tool.Tool.Name, Toolset: name, Missing: missing})
+			}
+		}
+		toolset.RemoveTools(remove...)
+
+		toolset.WrapTools(func(tool server.ServerTool) server.ServerTool {
+			params := paramSchemaRequirements[tool.Tool.Name]
+			if len(params) == 0 {
+				return tool
+			}
+			properties := maps.Clone(tool.Tool.InputSchema.Properties)
+			for param, element := range params {
+				if capabilities.Supports(element) {
+					continue
+				}
+				delete(properties, param)
+				tool.Tool.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.Tool.InputSchema.Required), func(required string) bool {
+					return required == param
+				})
+				unsupported = append(unsupported, UnsupportedTool{Tool: tool.Tool.Name, Toolset: name, Param: param, Missing: []string{element}})
+			}
+			tool.Tool.InputSchema.Properties = properties
+			return tool
+		})
+	}
+	sort.Slice(unsupported, func(i, j int) bool {
+		if unsupported[i].Toolset != unsupported[j].Toolset {
+			return unsupported[i].Toolset < unsupported[j].Toolset
+		}
+		if unsupported[i].Tool != unsupported[j].Tool {
+			return unsupported[i].Tool < unsupported[j].Tool
+		}
+		return unsupported[i].Param < unsupported[j].Param
+	})
+	return unsupported
+}
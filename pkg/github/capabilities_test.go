@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SchemaRequirementsNameExistingTools(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	tools := map[string]map[string]any{}
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = tool.Tool.InputSchema.Properties
+		}
+	}
+
+	for name := range toolSchemaRequirements {
+		assert.Contains(t, tools, name)
+	}
+	for name, params := range paramSchemaRequirements {
+		require.Contains(t, tools, name)
+		for param := range params {
+			assert.Contains(t, tools[name], param)
+		}
+	}
+}
+
+func schemaTypeMatcher(name string, fields ...string) githubv4mock.Matcher {
+	var schemaType map[string]any
+	if fields != nil {
+		nodes := make([]any, len(fields))
+		for i, field := range fields {
+			nodes[i] = map[string]any{"name": field}
+		}
+		schemaType = map[string]any{"fields": nodes}
+	}
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Type *struct {
+				Fields []struct {
+					Name githubv4.String
+				} `graphql:"fields(includeDeprecated: true)"`
+			} `graphql:"__type(name: $name)"`
+		}{},
+		map[string]any{
+			"name": githubv4.String(name),
+		},
+		githubv4mock.DataResponse(map[string]any{"__type": schemaType}),
+	)
+}
+
+func Test_GateUnsupportedTools(t *testing.T) {
+	// An older server: no sub-issues, issue types or merge queue, and projects without templates
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		schemaTypeMatcher("Issue", "number", "title", "projectItems"),
+		schemaTypeMatcher("IssueType"),
+		schemaTypeMatcher("Mutation", "createIssue"),
+		schemaTypeMatcher("ProjectV2", "id", "title"),
+		schemaTypeMatcher("ProjectV2IterationField", "id", "configuration"),
+		schemaTypeMatcher("Repository", "name", "issues"),
+	)
+	capabilities, err := ProbeSchemaCapabilities(context.Background(), githubv4.NewClient(httpClient))
+	require.NoError(t, err)
+
+	assert.True(t, capabilities.Supports("ProjectV2"))
+	assert.True(t, capabilities.Supports("Issue.projectItems"))
+	assert.False(t, capabilities.Supports("Issue.subIssues"))
+	assert.False(t, capabilities.Supports("IssueType"))
+	assert.False(t, capabilities.Supports("IssueType.name"))
+	// Elements that were not probed are assumed to exist
+	assert.True(t, capabilities.Supports("Discussion.category"))
+
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	unsupported := GateUnsupportedTools(tsg, capabilities)
+
+	removed := map[string]UnsupportedTool{}
+	for _, tool := range unsupported {
+		removed[tool.Tool+"/"+tool.Param] = tool
+	}
+	assert.Equal(t, UnsupportedTool{Tool: "add_sub_issue", Toolset: "issues", Missing: []string{"Issue.subIssues"}}, removed["add_sub_issue/"])
+	assert.Contains(t, removed, "list_issue_types/")
+	assert.Contains(t, removed, "get_merge_queue/")
+	assert.Contains(t, removed, "mark_project_as_template/")
+	assert.Contains(t, removed, "list_project_workflows/")
+	assert.Equal(t, UnsupportedTool{Tool: "create_issue", Toolset: "issues", Param: "type", Missing: []string{"IssueType"}}, removed["create_issue/type"])
+	assert.NotContains(t, removed, "provision_project/")
+	assert.NotContains(t, removed, "set_item_iteration/")
+
+	issues, err := tsg.GetToolset("issues")
+	require.NoError(t, err)
+	tools := map[string]map[string]any{}
+	for _, tool := range issues.GetAvailableTools() {
+		tools[tool.Tool.Name] = tool.Tool.InputSchema.Properties
+	}
+	assert.NotContains(t, tools, "add_sub_issue")
+	require.Contains(t, tools, "create_issue")
+	assert.NotContains(t, tools["create_issue"], "type")
+	assert.Contains(t, tools["create_issue"], "title")
+}
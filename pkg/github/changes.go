@@ -0,0 +1,310 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fileChange is a file written or deleted by a change.
+type fileChange struct {
+	Path    string
+	Content string
+	Delete  bool
+}
+
+// changeSpec describes a change proposed through a pull request: the files committed to a new
+// branch created from base, and the pull request opened for them.
+type changeSpec struct {
+	Base    string
+	Branch  string
+	Message string
+	Title   string
+	Body    string
+	Draft   bool
+	Files   []fileChange
+}
+
+// ChangeResult describes the branch, commit and pull request created for a change.
+type ChangeResult struct {
+	Branch            string `json:"branch"`
+	Base              string `json:"base"`
+	CommitSHA         string `json:"commit_sha"`
+	PullRequestNumber int    `json:"pull_request_number"`
+	PullRequestURL    string `json:"pull_request_url"`
+}
+
+// proposeChange commits the files of a change on top of its base branch through the Git data API,
+// creates the branch pointing at that commit and opens a pull request from it. The commit is created
+// before the branch so that the branch never exists without it, and the branch is deleted again if
+// the pull request cannot be opened, so a failed change leaves nothing behind in the repository.
+func proposeChange(ctx context.Context, client *github.Client, owner, repo string, spec changeSpec) (*ChangeResult, *github.Response, error) {
+	baseRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+spec.Base)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get branch %s: %w", spec.Base, err)
+	}
+	_ = resp.Body.Close()
+
+	baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, baseRef.GetObject().GetSHA())
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get base commit: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	// Files that already exist keep their mode, so executables stay executable and symlinks stay
+	// symlinks. Trees too large to be listed at once are truncated, files beyond that become
+	// regular files.
+	baseTree, resp, err := client.Git.GetTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), true)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get base tree: %w", err)
+	}
+	_ = resp.Body.Close()
+	modes := make(map[string]string, len(baseTree.Entries))
+	for _, entry := range baseTree.Entries {
+		if entry.GetType() == "blob" {
+			modes[entry.GetPath()] = entry.GetMode()
+		}
+	}
+
+	entries := make([]*github.TreeEntry, 0, len(spec.Files))
+	for _, file := range spec.Files {
+		mode, ok := modes[file.Path]
+		if !ok {
+			mode = "100644"
+		}
+		entry := &github.TreeEntry{
+			Path: github.Ptr(file.Path),
+			Mode: github.Ptr(mode),
+			Type: github.Ptr("blob"),
+		}
+		// An entry without content or SHA removes the path from the tree.
+		if !file.Delete {
+			entry.Content = github.Ptr(file.Content)
+		}
+		entries = append(entries, entry)
+	}
+	tree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to create tree: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(spec.Message),
+		Tree:    &github.Tree{SHA: tree.SHA},
+		Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+	}, nil)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to create commit: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.Ptr("refs/heads/" + spec.Branch),
+		Object: &github.GitObject{SHA: commit.SHA},
+	})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to create branch %s: %w", spec.Branch, err)
+	}
+	_ = resp.Body.Close()
+
+	pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.Ptr(spec.Title),
+		Head:  github.Ptr(spec.Branch),
+		Base:  github.Ptr(spec.Base),
+		Body:  github.Ptr(spec.Body),
+		Draft: github.Ptr(spec.Draft),
+	})
+	if err != nil {
+		deleteResp, deleteErr := client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+spec.Branch)
+		if deleteErr != nil {
+			return nil, resp, fmt.Errorf("failed to open a pull request, and failed to delete branch %s again (%v): %w", spec.Branch, deleteErr, err)
+		}
+		_ = deleteResp.Body.Close()
+		return nil, resp, fmt.Errorf("failed to open a pull request, branch %s was deleted again: %w", spec.Branch, err)
+	}
+	_ = resp.Body.Close()
+
+	return &ChangeResult{
+		Branch:            spec.Branch,
+		Base:              spec.Base,
+		CommitSHA:         commit.GetSHA(),
+		PullRequestNumber: pr.GetNumber(),
+		PullRequestURL:    pr.GetHTMLURL(),
+	}, nil, nil
+}
+
+// parseFileChanges parses the files parameter of create_change.
+func parseFileChanges(request mcp.CallToolRequest) ([]fileChange, error) {
+	filesObj, ok := request.GetArguments()["files"].([]interface{})
+	if !ok || len(filesObj) == 0 {
+		return nil, fmt.Errorf("files must be a non-empty array of objects with path and content")
+	}
+
+	files := make([]fileChange, 0, len(filesObj))
+	for _, file := range filesObj {
+		fileMap, ok := file.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("each file must be an object with path and content")
+		}
+		path, ok := fileMap["path"].(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("each file must have a path")
+		}
+		deleteFile, _ := fileMap["delete"].(bool)
+		content, hasContent := fileMap["content"].(string)
+		if deleteFile == hasContent {
+			return nil, fmt.Errorf("file %s must have either content or delete set to true", path)
+		}
+		files = append(files, fileChange{Path: path, Content: content, Delete: deleteFile})
+	}
+	return files, nil
+}
+
+// CreateChange creates a tool that commits file changes to a new branch and opens a pull request for them in one call.
+func CreateChange(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_change",
+			mcp.WithDescription(t("TOOL_CREATE_CHANGE_DESCRIPTION", "Propose a change to a GitHub repository in one call: creates a new branch from the base branch, commits the given file changes to it in a single commit and opens a pull request. If any step fails nothing is left behind, the branch is deleted again if the pull request cannot be opened. Prefer this over create_branch, push_files and create_pull_request when making a change from scratch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CHANGE_USER_TITLE", "Create change with pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the branch to create, it must not exist yet"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to start from and open the pull request against. Defaults to the default branch"),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "new file content, required unless the file is deleted",
+							},
+							"delete": map[string]interface{}{
+								"type":        "boolean",
+								"description": "delete the file instead of writing it",
+							},
+						},
+					}),
+				mcp.Description("Array of file objects to change, each object with path (string) and either content (string) or delete (true)"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Commit message. Defaults to the pull request title"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Pull request title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Pull request description"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Open the pull request as a draft"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			files, err := parseFileChanges(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			draft, err := OptionalParam[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if message == "" {
+				message = title
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+			if branch == base {
+				return mcp.NewToolResultError("branch must differ from the base branch"), nil
+			}
+
+			change, resp, err := proposeChange(ctx, client, owner, repo, changeSpec{
+				Base:    base,
+				Branch:  branch,
+				Message: message,
+				Title:   title,
+				Body:    body,
+				Draft:   draft,
+				Files:   files,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create change",
+					resp,
+					err,
+				), nil
+			}
+			return MarshalledTextResult(change), nil
+		}
+}
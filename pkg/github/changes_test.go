@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateChange(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateChange(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_change", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "title"})
+
+	files := []any{
+		map[string]any{"path": "src/main.go", "content": "package main"},
+		map[string]any{"path": "src/old.go", "delete": true},
+		map[string]any{"path": "scripts/build.sh", "content": "#!/bin/sh\nmake"},
+		map[string]any{"path": "bin/tool", "content": "../scripts/build.sh"},
+	}
+
+	// upToCommit mocks the steps that create the commit of the change on top of main
+	upToCommit := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+					mockResponse(t, http.StatusOK, github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, github.Commit{SHA: github.Ptr("base-sha"), Tree: &github.Tree{SHA: github.Ptr("base-tree")}}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+					mockResponse(t, http.StatusOK, github.Tree{
+						SHA: github.Ptr("base-tree"),
+						Entries: []*github.TreeEntry{
+							{Path: github.Ptr("src"), Mode: github.Ptr("040000"), Type: github.Ptr("tree")},
+							{Path: github.Ptr("src/old.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob")},
+							{Path: github.Ptr("scripts/build.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob")},
+							{Path: github.Ptr("bin/tool"), Mode: github.Ptr("120000"), Type: github.Ptr("blob")},
+						},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"base_tree": "base-tree",
+					"tree": []any{
+						map[string]any{"path": "src/main.go", "mode": "100644", "type": "blob", "content": "package main"},
+						map[string]any{"path": "src/old.go", "mode": "100644", "type": "blob", "sha": nil},
+						map[string]any{"path": "scripts/build.sh", "mode": "100755", "type": "blob", "content": "#!/bin/sh\nmake"},
+						map[string]any{"path": "bin/tool", "mode": "120000", "type": "blob", "content": "../scripts/build.sh"},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.Tree{SHA: github.Ptr("new-tree")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"message": "Replace old.go",
+					"tree":    "new-tree",
+					"parents": []any{"base-sha"},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.Commit{SHA: github.Ptr("new-sha")}),
+				),
+			),
+		}
+	}
+	createRef := func() mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposGitRefsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{"ref": "refs/heads/replace-old", "sha": "new-sha"}).andThen(
+				mockResponse(t, http.StatusCreated, github.Reference{Ref: github.Ptr("refs/heads/replace-old")}),
+			),
+		)
+	}
+	withSteps := func(steps ...mock.MockBackendOption) *http.Client {
+		return mock.NewMockedHTTPClient(append(upToCommit(), steps...)...)
+	}
+	requestArgs := map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"branch": "replace-old",
+		"files":  files,
+		"title":  "Replace old.go",
+		"body":   "Moves everything to main.go",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       ChangeResult
+	}{
+		{
+			name: "creates branch, commit and pull request",
+			mockedClient: withSteps(
+				createRef(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title": "Replace old.go",
+						"head":  "replace-old",
+						"base":  "main",
+						"body":  "Moves everything to main.go",
+						"draft": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, github.PullRequest{Number: github.Ptr(12), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/12")}),
+					),
+				),
+			),
+			requestArgs: requestArgs,
+			expected: ChangeResult{
+				Branch:            "replace-old",
+				Base:              "main",
+				CommitSHA:         "new-sha",
+				PullRequestNumber: 12,
+				PullRequestURL:    "https://github.com/owner/repo/pull/12",
+			},
+		},
+		{
+			name: "branch already exists",
+			mockedClient: withSteps(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference already exists"}),
+				),
+			),
+			requestArgs:    requestArgs,
+			expectError:    true,
+			expectedErrMsg: "failed to create branch replace-old",
+		},
+		{
+			name: "reports a branch left behind",
+			mockedClient: withSteps(
+				createRef(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs:    requestArgs,
+			expectError:    true,
+			expectedErrMsg: "failed to open a pull request, and failed to delete branch replace-old again",
+		},
+		{
+			name:         "file without content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "replace-old",
+				"files":  []any{map[string]any{"path": "src/main.go"}},
+				"title":  "Replace old.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "file src/main.go must have either content or delete set to true",
+		},
+		{
+			name: "branch is the base branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files":  files,
+				"title":  "Replace old.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch must differ from the base branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateChange(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var change ChangeResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &change))
+			assert.Equal(t, tc.expected, change)
+		})
+	}
+}
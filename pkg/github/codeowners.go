@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// codeownersLocations are the paths GitHub looks for a CODEOWNERS file at, in order.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// maxCodeownersPullRequestFiles is the number of changed files of a pull request that are resolved at most.
+const maxCodeownersPullRequestFiles = 3000
+
+// CodeownersRule is a line of a CODEOWNERS file assigning owners to a pattern.
+type CodeownersRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+	Line    int      `json:"line"`
+
+	re *regexp.Regexp
+}
+
+// PathOwners lists the owners of a path and the rule they come from.
+type PathOwners struct {
+	Path   string          `json:"path"`
+	Owners []string        `json:"owners"`
+	Rule   *CodeownersRule `json:"rule,omitempty"`
+}
+
+// MinimalCodeownersError is a syntax error GitHub detected in a CODEOWNERS file.
+type MinimalCodeownersError struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// parseCodeowners parses the rules of a CODEOWNERS file. Lines with invalid patterns are skipped,
+// they are reported by the CODEOWNERS errors endpoint.
+func parseCodeowners(content string) []CodeownersRule {
+	var rules []CodeownersRule
+	for i, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := codeownersPatternRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeownersRule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			Line:    i + 1,
+			re:      re,
+		})
+	}
+	return rules
+}
+
+// codeownersPatternRegexp converts a CODEOWNERS pattern, which follows most gitignore rules, to a regular expression.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	// Patterns with a slash at the start or in the middle are relative to the root,
+	// others match at any depth.
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			b.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(trimmed[i : i+1]))
+		}
+	}
+	switch {
+	case strings.HasSuffix(pattern, "/"):
+		// Only the contents of a directory match.
+		b.WriteString("/.*")
+	case strings.HasSuffix(pattern, "/*"):
+		// Files directly in a directory, but not in its subdirectories.
+	default:
+		// A path or anything below it, if it is a directory.
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// codeownersFor returns the owners of path. As in GitHub, the last matching rule takes precedence.
+func codeownersFor(rules []CodeownersRule, path string) PathOwners {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(path) {
+			return PathOwners{Path: path, Owners: rules[i].Owners, Rule: &rules[i]}
+		}
+	}
+	return PathOwners{Path: path, Owners: []string{}}
+}
+
+// getCodeownersFile returns the path and content of the CODEOWNERS file GitHub uses at ref, or
+// an empty path if the repository has none.
+func getCodeownersFile(ctx context.Context, client *github.Client, owner, repo, ref string) (string, string, *github.Response, error) {
+	for _, location := range codeownersLocations {
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, location, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", "", resp, err
+		}
+		_ = resp.Body.Close()
+		if fileContent == nil {
+			continue
+		}
+		content, err := fileContent.GetContent()
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to decode CODEOWNERS file: %w", err)
+		}
+		return location, content, resp, nil
+	}
+	return "", "", nil, nil
+}
+
+// GetCodeownersForPath creates a tool that resolves the code owners of paths or of the files changed by a pull request.
+func GetCodeownersForPath(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners_for_path",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_FOR_PATH_DESCRIPTION", "Resolve the code owners (users and teams) of paths, or of the files changed by a pull request, from the repository's CODEOWNERS file. Also reports the syntax errors GitHub detected in the CODEOWNERS file. Use this to route reviews and escalations.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_FOR_PATH_USER_TITLE", "Get code owners for paths"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Paths to resolve the code owners of, relative to the repository root. Required unless pull_number is given"),
+				mcp.Items(map[string]interface{}{
+					"type": "string",
+				}),
+			),
+			mcp.WithNumber("pull_number",
+				mcp.Description("Pull request whose changed files to resolve the code owners of. The CODEOWNERS file of the pull request's base branch is used"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the CODEOWNERS file from. Defaults to the default branch, or to the base branch when pull_number is given"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pull_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 && pullNumber == 0 {
+				return mcp.NewToolResultError("either paths or pull_number must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			truncated := false
+			if pullNumber != 0 {
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if ref == "" {
+					ref = pr.GetBase().GetRef()
+				}
+
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to list pull request files",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, file := range files {
+						paths = append(paths, file.GetFilename())
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					if len(paths) >= maxCodeownersPullRequestFiles {
+						truncated = true
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			codeownersPath, content, resp, err := getCodeownersFile(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS file",
+					resp,
+					err,
+				), nil
+			}
+			if codeownersPath == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("no CODEOWNERS file found in %s/%s, looked for %s", owner, repo, strings.Join(codeownersLocations, ", "))), nil
+			}
+
+			codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS errors",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			minimalErrors := make([]MinimalCodeownersError, 0, len(codeownersErrors.Errors))
+			for _, e := range codeownersErrors.Errors {
+				minimalErrors = append(minimalErrors, MinimalCodeownersError{
+					Line:       e.Line,
+					Column:     e.Column,
+					Kind:       e.Kind,
+					Message:    e.Message,
+					Suggestion: e.GetSuggestion(),
+				})
+			}
+
+			rules := parseCodeowners(content)
+			files := make([]PathOwners, 0, len(paths))
+			owners := map[string]int{}
+			unowned := 0
+			for _, path := range paths {
+				pathOwners := codeownersFor(rules, path)
+				files = append(files, pathOwners)
+				if len(pathOwners.Owners) == 0 {
+					unowned++
+				}
+				for _, o := range pathOwners.Owners {
+					owners[o]++
+				}
+			}
+			ownerNames := make([]string, 0, len(owners))
+			for o := range owners {
+				ownerNames = append(ownerNames, o)
+			}
+			sort.Strings(ownerNames)
+
+			return MarshalledTextResult(map[string]any{
+				"codeowners_path": codeownersPath,
+				"errors":          minimalErrors,
+				"files":           files,
+				"owners":          ownerNames,
+				"files_per_owner": owners,
+				"unowned_files":   unowned,
+				"truncated":       truncated,
+			}), nil
+		}
+}
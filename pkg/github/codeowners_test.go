@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeowners = `# Default owners
+*                  @octo-org/core
+
+*.js               @js-owner
+/docs/             @octo-org/docs
+apps/              @app-owner
+/scripts/*         @scripts-owner
+**/logs            @logger
+/build/**/out      @builder
+/vendor/           # no owners
+`
+
+func Test_CodeownersFor(t *testing.T) {
+	rules := parseCodeowners(testCodeowners)
+	require.Len(t, rules, 8)
+
+	tests := []struct {
+		path   string
+		owners []string
+		line   int
+	}{
+		{"README.md", []string{"@octo-org/core"}, 2},
+		{"src/app.js", []string{"@js-owner"}, 4},
+		{"docs/guide/intro.md", []string{"@octo-org/docs"}, 5},
+		{"src/docs/intro.md", []string{"@octo-org/core"}, 2},
+		{"apps/web/main.go", []string{"@app-owner"}, 6},
+		{"services/apps/main.go", []string{"@app-owner"}, 6},
+		{"scripts/release.sh", []string{"@scripts-owner"}, 7},
+		{"scripts/ci/release.sh", []string{"@octo-org/core"}, 2},
+		{"var/logs/app.log", []string{"@logger"}, 8},
+		{"build/linux/amd64/out", []string{"@builder"}, 9},
+		{"/build/out", []string{"@builder"}, 9},
+		{"vendor/lib/lib.go", []string{}, 10},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			owners := codeownersFor(rules, tc.path)
+			assert.Equal(t, tc.owners, owners.Owners)
+			require.NotNil(t, owners.Rule)
+			assert.Equal(t, tc.line, owners.Rule.Line)
+		})
+	}
+
+	t.Run("no matching rule", func(t *testing.T) {
+		owners := codeownersFor(parseCodeowners("/docs/ @docs"), "main.go")
+		assert.Empty(t, owners.Owners)
+		assert.Nil(t, owners.Rule)
+	})
+}
+
+func Test_GetCodeownersForPath(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeownersForPath(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners_for_path", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Only the root CODEOWNERS file exists
+	contentsHandler := func(t *testing.T, expectedRef string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, expectedRef, r.URL.Query().Get("ref"))
+			if !strings.HasSuffix(r.URL.Path, "/contents/CODEOWNERS") {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, &github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Path:     github.Ptr("CODEOWNERS"),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(testCodeowners))),
+			})(w, r)
+		}
+	}
+	codeownersErrors := &github.CodeownersErrors{Errors: []*github.CodeownersError{{
+		Line:       12,
+		Column:     1,
+		Kind:       "Unknown owner",
+		Message:    "Unknown owner on line 12",
+		Suggestion: github.Ptr("make sure @ghost exists"),
+		Path:       "CODEOWNERS",
+	}}}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedFiles  []PathOwners
+		expectedOwners []string
+		expectedErrors int
+	}{
+		{
+			name: "resolve paths",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler(t, "")),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, codeownersErrors),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"docs/intro.md", "src/app.js"},
+			},
+			expectedFiles: []PathOwners{
+				{Path: "docs/intro.md", Owners: []string{"@octo-org/docs"}},
+				{Path: "src/app.js", Owners: []string{"@js-owner"}},
+			},
+			expectedOwners: []string{"@js-owner", "@octo-org/docs"},
+			expectedErrors: 1,
+		},
+		{
+			name: "resolve pull request files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+					Number: github.Ptr(42),
+					Base:   &github.PullRequestBranch{Ref: github.Ptr("release")},
+				}),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, []*github.CommitFile{
+					{Filename: github.Ptr("README.md")},
+					{Filename: github.Ptr("vendor/lib/lib.go")},
+				}),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler(t, "release")),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, &github.CodeownersErrors{}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pull_number": float64(42),
+			},
+			expectedFiles: []PathOwners{
+				{Path: "README.md", Owners: []string{"@octo-org/core"}},
+				{Path: "vendor/lib/lib.go", Owners: []string{}},
+			},
+			expectedOwners: []string{"@octo-org/core"},
+		},
+		{
+			name:         "missing paths and pull request",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "either paths or pull_number must be provided",
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"main.go"},
+			},
+			expectError:    true,
+			expectedErrMsg: "no CODEOWNERS file found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeownersForPath(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				CodeownersPath string                   `json:"codeowners_path"`
+				Errors         []MinimalCodeownersError `json:"errors"`
+				Files          []PathOwners             `json:"files"`
+				Owners         []string                 `json:"owners"`
+				UnownedFiles   int                      `json:"unowned_files"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "CODEOWNERS", returned.CodeownersPath)
+			assert.Len(t, returned.Errors, tc.expectedErrors)
+			require.Len(t, returned.Files, len(tc.expectedFiles))
+			for i, expected := range tc.expectedFiles {
+				assert.Equal(t, expected.Path, returned.Files[i].Path)
+				assert.Equal(t, expected.Owners, returned.Files[i].Owners)
+			}
+			assert.Equal(t, tc.expectedOwners, returned.Owners)
+		})
+	}
+}
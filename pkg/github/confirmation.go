@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ConfirmationTokenParam is the parameter destructive tools accept a confirmation token in
+// when confirmations are required.
+const ConfirmationTokenParam = "confirmation_token"
+
+// DefaultConfirmationTTL is how long a confirmation token stays valid.
+const DefaultConfirmationTTL = 5 * time.Minute
+
+type pendingConfirmation struct {
+	tool      string
+	args      string
+	expiresAt time.Time
+}
+
+// ConfirmationPolicy selects the tools that have to be confirmed. By default these are the
+// tools annotated as destructive; Require adds tools by name and Exempt removes them, which
+// takes precedence.
+type ConfirmationPolicy struct {
+	Require []string
+	Exempt  []string
+}
+
+// applies reports whether tool has to be confirmed under the policy.
+func (p ConfirmationPolicy) applies(tool mcp.Tool) bool {
+	if slices.Contains(p.Exempt, tool.Name) {
+		return false
+	}
+	if slices.Contains(p.Require, tool.Name) {
+		return true
+	}
+	hint := tool.Annotations.DestructiveHint
+	return hint != nil && *hint
+}
+
+// Confirmations implements a two-phase flow for destructive tools: the first call returns a
+// summary of what would happen together with a single-use token, and only a second call with
+// the same arguments and that token performs the operation. It is safe for concurrent use.
+type Confirmations struct {
+	ttl    time.Duration
+	policy ConfirmationPolicy
+
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// NewConfirmations creates a confirmation store whose tokens expire after ttl, requiring
+// confirmation for the tools policy selects.
+func NewConfirmations(ttl time.Duration, policy ConfirmationPolicy) *Confirmations {
+	return &Confirmations{
+		ttl:     ttl,
+		policy:  policy,
+		pending: make(map[string]pendingConfirmation),
+		now:     time.Now,
+	}
+}
+
+// RequireConfirmation wraps tool so that it has to be confirmed before it runs, if the policy
+// selects it. Other tools are returned unchanged.
+func (c *Confirmations) RequireConfirmation(tool server.ServerTool) server.ServerTool {
+	if !c.policy.applies(tool.Tool) {
+		return tool
+	}
+
+	// Copy the properties so the original tool definition is left untouched
+	wrapped := tool.Tool
+	wrapped.InputSchema.Properties = maps.Clone(tool.Tool.InputSchema.Properties)
+	if wrapped.InputSchema.Properties == nil {
+		wrapped.InputSchema.Properties = make(map[string]any)
+	}
+	wrapped.InputSchema.Properties[ConfirmationTokenParam] = map[string]any{
+		"type":        "string",
+		"description": "Token confirming this destructive operation. Call the tool without it first to get a summary of the operation and a token, show the summary to the user, and call the tool again with the same arguments and the token once the user agrees",
+	}
+	wrapped.Description = tool.Tool.Description + " This operation must be confirmed: the first call only returns a summary and a confirmation token."
+
+	next := tool.Handler
+	return server.ServerTool{
+		Tool: wrapped,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			token, err := OptionalParam[string](request, ConfirmationTokenParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			args := make(map[string]any, len(request.GetArguments()))
+			for k, v := range request.GetArguments() {
+				if k != ConfirmationTokenParam {
+					args[k] = v
+				}
+			}
+			encodedArgs, err := json.Marshal(args)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+			}
+
+			if token == "" {
+				// Don't have a call confirmed that fails right away
+				if err := checkRequiredParams(tool.Tool, args); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				token, expiresAt, err := c.issue(tool.Tool.Name, string(encodedArgs))
+				if err != nil {
+					return nil, err
+				}
+				return MarshalledTextResult(map[string]any{
+					"confirmation_required": true,
+					"summary":               summarizeToolCall(tool.Tool, args),
+					"confirmation_token":    token,
+					"expires_at":            expiresAt,
+				}), nil
+			}
+
+			if err := c.redeem(token, tool.Tool.Name, string(encodedArgs)); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			request.Params.Arguments = args
+			return next(ctx, request)
+		},
+	}
+}
+
+func (c *Confirmations) issue(tool, args string) (string, time.Time, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for t, p := range c.pending {
+		if now.After(p.expiresAt) {
+			delete(c.pending, t)
+		}
+	}
+	expiresAt := now.Add(c.ttl)
+	c.pending[token] = pendingConfirmation{tool: tool, args: args, expiresAt: expiresAt}
+	return token, expiresAt, nil
+}
+
+// redeem consumes token, failing if it is unknown, expired, or was issued for another call.
+func (c *Confirmations) redeem(token, tool, args string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.pending[token]
+	if !ok {
+		return fmt.Errorf("unknown or already used confirmation token, call %s without %s to get a new one", tool, ConfirmationTokenParam)
+	}
+	if c.now().After(p.expiresAt) {
+		delete(c.pending, token)
+		return fmt.Errorf("confirmation token expired, call %s without %s to get a new one", tool, ConfirmationTokenParam)
+	}
+	if p.tool != tool || p.args != args {
+		// Leave the token in place, it may still be used for the call it was issued for
+		return fmt.Errorf("confirmation token was issued for a different call, the arguments must match the confirmed call exactly")
+	}
+	delete(c.pending, token)
+	return nil
+}
+
+// schemaParamTypes maps JSON schema types to the Go types arguments decode to.
+var schemaParamTypes = map[string]func(any) bool{
+	"string":  func(v any) bool { _, ok := v.(string); return ok },
+	"number":  func(v any) bool { _, ok := v.(float64); return ok },
+	"integer": func(v any) bool { _, ok := v.(float64); return ok },
+	"boolean": func(v any) bool { _, ok := v.(bool); return ok },
+	"array":   func(v any) bool { _, ok := v.([]any); return ok },
+	"object":  func(v any) bool { _, ok := v.(map[string]any); return ok },
+}
+
+// checkRequiredParams checks that args has a value of the right type for every parameter the
+// schema of tool requires, failing like RequiredParam does for missing and empty strings.
+func checkRequiredParams(tool mcp.Tool, args map[string]any) error {
+	for _, name := range tool.InputSchema.Required {
+		v, ok := args[name]
+		if !ok || v == nil || v == "" {
+			return fmt.Errorf("missing required parameter: %s", name)
+		}
+		property, _ := tool.InputSchema.Properties[name].(map[string]any)
+		schemaType, _ := property["type"].(string)
+		if isType, ok := schemaParamTypes[schemaType]; ok && !isType(v) {
+			return fmt.Errorf("parameter %s is not of type %s", name, schemaType)
+		}
+	}
+	return nil
+}
+
+// summarizeToolCall describes a tool call for a human to confirm, e.g. "Delete file: owner=octo, path=README.md".
+func summarizeToolCall(tool mcp.Tool, args map[string]any) string {
+	title := tool.Annotations.Title
+	if title == "" {
+		title = tool.Name
+	}
+
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, args[k]))
+	}
+	return fmt.Sprintf("%s: %s", title, strings.Join(parts, ", "))
+}
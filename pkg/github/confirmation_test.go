@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfirmationTestTool(calls *[]map[string]any) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("delete_thing",
+			mcp.WithDescription("Delete a thing."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete thing",
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner", mcp.Required()),
+			mcp.WithNumber("id", mcp.Required()),
+		),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*calls = append(*calls, request.GetArguments())
+			return mcp.NewToolResultText("deleted"), nil
+		},
+	}
+}
+
+func requestConfirmation(t *testing.T, tool server.ServerTool, args map[string]interface{}) map[string]any {
+	t.Helper()
+	result, err := tool.Handler(context.Background(), createMCPRequest(args))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	return response
+}
+
+func Test_RequireConfirmation(t *testing.T) {
+	t.Run("leaves non-destructive tools alone", func(t *testing.T) {
+		confirmations := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{})
+		tool := toolsets.NewServerTool(GetMe(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper))
+		wrapped := confirmations.RequireConfirmation(tool)
+		assert.NotContains(t, wrapped.Tool.InputSchema.Properties, ConfirmationTokenParam)
+		assert.Equal(t, tool.Tool.Description, wrapped.Tool.Description)
+	})
+
+	t.Run("adds the token parameter without changing the original tool", func(t *testing.T) {
+		var calls []map[string]any
+		tool := newConfirmationTestTool(&calls)
+		wrapped := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{}).RequireConfirmation(tool)
+		assert.Contains(t, wrapped.Tool.InputSchema.Properties, ConfirmationTokenParam)
+		assert.NotContains(t, wrapped.Tool.InputSchema.Required, ConfirmationTokenParam)
+		assert.NotContains(t, tool.Tool.InputSchema.Properties, ConfirmationTokenParam)
+	})
+
+	t.Run("two-phase flow", func(t *testing.T) {
+		var calls []map[string]any
+		tool := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{}).RequireConfirmation(newConfirmationTestTool(&calls))
+		args := map[string]interface{}{"owner": "octo", "id": float64(42)}
+
+		response := requestConfirmation(t, tool, args)
+		assert.Empty(t, calls)
+		assert.Equal(t, true, response["confirmation_required"])
+		assert.Equal(t, "Delete thing: id=42, owner=octo", response["summary"])
+		token, ok := response["confirmation_token"].(string)
+		require.True(t, ok)
+		require.NotEmpty(t, token)
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":                "octo",
+			"id":                   float64(42),
+			ConfirmationTokenParam: token,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "deleted", getTextResult(t, result).Text)
+		require.Len(t, calls, 1)
+		assert.Equal(t, map[string]any{"owner": "octo", "id": float64(42)}, calls[0])
+
+		// Tokens are single-use
+		result, err = tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":                "octo",
+			"id":                   float64(42),
+			ConfirmationTokenParam: token,
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "unknown or already used confirmation token")
+		assert.Len(t, calls, 1)
+	})
+
+	t.Run("checks required parameters before issuing a token", func(t *testing.T) {
+		var calls []map[string]any
+		tool := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{}).RequireConfirmation(newConfirmationTestTool(&calls))
+
+		tests := []struct {
+			name           string
+			args           map[string]interface{}
+			expectedErrMsg string
+		}{
+			{
+				name:           "missing parameter",
+				args:           map[string]interface{}{"owner": "octo"},
+				expectedErrMsg: "missing required parameter: id",
+			},
+			{
+				name:           "empty string",
+				args:           map[string]interface{}{"owner": "", "id": float64(42)},
+				expectedErrMsg: "missing required parameter: owner",
+			},
+			{
+				name:           "wrong type",
+				args:           map[string]interface{}{"owner": "octo", "id": "42"},
+				expectedErrMsg: "parameter id is not of type number",
+			},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				result, err := tool.Handler(context.Background(), createMCPRequest(tc.args))
+				require.NoError(t, err)
+				errorContent := getErrorResult(t, result)
+				assert.Equal(t, tc.expectedErrMsg, errorContent.Text)
+				assert.NotContains(t, errorContent.Text, "confirmation_token")
+				assert.Empty(t, calls)
+			})
+		}
+	})
+
+	t.Run("rejects a token issued for different arguments", func(t *testing.T) {
+		var calls []map[string]any
+		tool := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{}).RequireConfirmation(newConfirmationTestTool(&calls))
+
+		token := requestConfirmation(t, tool, map[string]interface{}{"owner": "octo", "id": float64(42)})["confirmation_token"]
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":                "octo",
+			"id":                   float64(43),
+			ConfirmationTokenParam: token,
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "issued for a different call")
+		assert.Empty(t, calls)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		var calls []map[string]any
+		confirmations := NewConfirmations(time.Minute, ConfirmationPolicy{})
+		now := time.Now()
+		confirmations.now = func() time.Time { return now }
+		tool := confirmations.RequireConfirmation(newConfirmationTestTool(&calls))
+
+		token := requestConfirmation(t, tool, map[string]interface{}{"owner": "octo", "id": float64(42)})["confirmation_token"]
+		now = now.Add(2 * time.Minute)
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":                "octo",
+			"id":                   float64(42),
+			ConfirmationTokenParam: token,
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "confirmation token expired")
+		assert.Empty(t, calls)
+	})
+}
+
+func Test_ConfirmationPolicy(t *testing.T) {
+	getMe := toolsets.NewServerTool(GetMe(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper))
+	var calls []map[string]any
+	deleteThing := newConfirmationTestTool(&calls)
+
+	tests := []struct {
+		name    string
+		policy  ConfirmationPolicy
+		tool    server.ServerTool
+		confirm bool
+	}{
+		{name: "destructive tool by default", tool: deleteThing, confirm: true},
+		{name: "other tool by default", tool: getMe},
+		{name: "required tool", policy: ConfirmationPolicy{Require: []string{"get_me"}}, tool: getMe, confirm: true},
+		{name: "exempt tool", policy: ConfirmationPolicy{Exempt: []string{"delete_thing"}}, tool: deleteThing},
+		{name: "exemption takes precedence", policy: ConfirmationPolicy{Require: []string{"delete_thing"}, Exempt: []string{"delete_thing"}}, tool: deleteThing},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := NewConfirmations(DefaultConfirmationTTL, tc.policy).RequireConfirmation(tc.tool)
+			if tc.confirm {
+				assert.Contains(t, wrapped.Tool.InputSchema.Properties, ConfirmationTokenParam)
+			} else {
+				assert.NotContains(t, wrapped.Tool.InputSchema.Properties, ConfirmationTokenParam)
+			}
+		})
+	}
+}
+
+func Test_DeletingToolsAreDestructive(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	confirmations := NewConfirmations(DefaultConfirmationTTL, ConfirmationPolicy{})
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			name := tool.Tool.Name
+			if !strings.HasPrefix(name, "delete_") && !strings.HasPrefix(name, "remove_") {
+				continue
+			}
+			wrapped := confirmations.RequireConfirmation(tool)
+			assert.Contains(t, wrapped.Tool.InputSchema.Properties, ConfirmationTokenParam, "tool %s is not confirmed", name)
+		}
+	}
+}
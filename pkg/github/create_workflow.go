@@ -0,0 +1,278 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GitHub's starter workflows, which the "Actions" tab of a repository offers as templates.
+const (
+	starterWorkflowsOwner = "actions"
+	starterWorkflowsRepo  = "starter-workflows"
+)
+
+// starterWorkflowPath returns the path of a starter workflow. Templates are named after their file,
+// and are looked up in the ci directory unless a directory is given, as in deployments/azure-webapps-node.
+func starterWorkflowPath(template string) string {
+	template = strings.TrimSuffix(template, ".yml")
+	if !strings.Contains(template, "/") {
+		template = "ci/" + template
+	}
+	return template + ".yml"
+}
+
+// fillStarterWorkflow replaces the placeholders of a starter workflow.
+func fillStarterWorkflow(content, defaultBranch string) string {
+	return strings.NewReplacer(
+		"$default-branch", defaultBranch,
+		"$protected-branches", defaultBranch,
+		"$cron-daily", "0 0 * * *",
+	).Replace(content)
+}
+
+// CreateWorkflow creates a tool that adds a workflow file to a repository through a pull request.
+func CreateWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_workflow",
+			mcp.WithDescription(t("TOOL_CREATE_WORKFLOW_DESCRIPTION", "Add a GitHub Actions workflow to a repository. Writes the workflow to .github/workflows on a new branch and opens a pull request. The workflow is either given as content, or taken from one of GitHub's starter workflows (e.g., go, node.js, python-package, docker-image) with the default branch filled in. The workflow is validated first and nothing is written if it has errors.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_WORKFLOW_USER_TITLE", "Create workflow"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("filename",
+				mcp.Required(),
+				mcp.Description("File name of the workflow in .github/workflows (e.g., ci.yml)"),
+			),
+			mcp.WithString("content",
+				mcp.Description("The workflow YAML. Required unless template is given"),
+			),
+			mcp.WithString("template",
+				mcp.Description("Name of a starter workflow from github.com/actions/starter-workflows, such as go or node.js, or a path within it without .yml such as deployments/azure-webapps-node. Required unless content is given"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Name of the branch to create. Defaults to add-workflow-<filename without extension>"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to open the pull request against. Defaults to the default branch"),
+			),
+			mcp.WithString("title",
+				mcp.Description("Pull request title. Defaults to \"Add <filename> workflow\""),
+			),
+			mcp.WithString("body",
+				mcp.Description("Pull request description"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Open the pull request as a draft"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filename, err := RequiredParam[string](request, "filename")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			template, err := OptionalParam[string](request, "template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			draft, err := OptionalParam[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if (content == "") == (template == "") {
+				return mcp.NewToolResultError("exactly one of content or template is required"), nil
+			}
+			if strings.Contains(filename, "/") || !(strings.HasSuffix(filename, ".yml") || strings.HasSuffix(filename, ".yaml")) {
+				return mcp.NewToolResultError("filename must be a .yml or .yaml file name without a directory"), nil
+			}
+			path := workflowDir + filename
+			name := strings.TrimSuffix(strings.TrimSuffix(filename, ".yml"), ".yaml")
+			if branch == "" {
+				branch = "add-workflow-" + name
+			}
+			if title == "" {
+				title = fmt.Sprintf("Add %s workflow", filename)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+
+			if template != "" {
+				templatePath := starterWorkflowPath(template)
+				fileContent, _, resp, err := client.Repositories.GetContents(ctx, starterWorkflowsOwner, starterWorkflowsRepo, templatePath, nil)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("starter workflow %s not found in %s/%s", templatePath, starterWorkflowsOwner, starterWorkflowsRepo)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get starter workflow",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if fileContent == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("starter workflow %s is a directory", templatePath)), nil
+				}
+				if content, err = fileContent.GetContent(); err != nil {
+					return nil, fmt.Errorf("failed to decode starter workflow: %w", err)
+				}
+				content = fillStarterWorkflow(content, base)
+			}
+
+			errors, warnings := validateWorkflow(content)
+			if len(errors) > 0 {
+				return MarshalledTextResult(map[string]any{
+					"created": false,
+					"message": "the workflow has errors, fix them and try again",
+					"validation": WorkflowValidation{
+						Source:   path,
+						Errors:   errors,
+						Warnings: append([]WorkflowIssue{}, warnings...),
+					},
+				}), nil
+			}
+
+			// Refuse to replace a workflow through a pull request that looks like it adds one.
+			_, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: base})
+			if err == nil {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("%s already exists on %s", path, base)), nil
+			}
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to check for an existing workflow file",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			baseRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch %s", base),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + branch),
+				Object: &github.GitObject{SHA: baseRef.Object.SHA},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			commit, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+				Message: github.Ptr(title),
+				Content: []byte(content),
+				Branch:  github.Ptr(branch),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("created branch %s but failed to write %s", branch, path),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr(title),
+				Head:  github.Ptr(branch),
+				Base:  github.Ptr(base),
+				Body:  github.Ptr(body),
+				Draft: github.Ptr(draft),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("wrote %s to branch %s but failed to open a pull request", path, branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			result := map[string]any{
+				"created":             true,
+				"path":                path,
+				"branch":              branch,
+				"commit_sha":          commit.GetSHA(),
+				"pull_request_number": pr.GetNumber(),
+				"pull_request_url":    pr.GetHTMLURL(),
+			}
+			if template != "" {
+				result["template"] = starterWorkflowPath(template)
+			}
+			if len(warnings) > 0 {
+				result["warnings"] = warnings
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateWorkflow(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateWorkflow(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_workflow", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "filename"})
+
+	starterGo := "on:\n  push:\n    branches: [ \"$default-branch\" ]\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n"
+	filledGo := "on:\n  push:\n    branches: [ \"main\" ]\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n"
+	// contents serves the starter workflows, and reports that the repository has no workflow yet
+	contents := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/actions/starter-workflows/contents/ci/go.yml":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(starterGo))),
+			})
+		case "/repos/owner/repo/contents/.github/workflows/exists.yml":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(github.RepositoryContent{Type: github.Ptr("file")})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+	withWrites := func(expectedContent string) []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contents),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+					mockResponse(t, http.StatusOK, github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{"ref": "refs/heads/add-workflow-ci", "sha": "base-sha"}).andThen(
+					mockResponse(t, http.StatusCreated, github.Reference{Ref: github.Ptr("refs/heads/add-workflow-ci")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutReposContentsByOwnerByRepoByPath,
+				expectRequestBody(t, map[string]any{
+					"message": "Add ci.yml workflow",
+					"content": base64.StdEncoding.EncodeToString([]byte(expectedContent)),
+					"branch":  "add-workflow-ci",
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.RepositoryContentResponse{Commit: github.Commit{SHA: github.Ptr("commit-sha")}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"title": "Add ci.yml workflow",
+					"head":  "add-workflow-ci",
+					"base":  "main",
+					"body":  "",
+					"draft": false,
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.PullRequest{Number: github.Ptr(7), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/7")}),
+				),
+			),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name:         "from a starter workflow",
+			mockedClient: mock.NewMockedHTTPClient(withWrites(filledGo)...),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "filename": "ci.yml", "template": "go"},
+			expected: map[string]any{
+				"created":             true,
+				"path":                ".github/workflows/ci.yml",
+				"branch":              "add-workflow-ci",
+				"commit_sha":          "commit-sha",
+				"pull_request_number": float64(7),
+				"pull_request_url":    "https://github.com/owner/repo/pull/7",
+				"template":            "ci/go.yml",
+			},
+		},
+		{
+			name:         "from content",
+			mockedClient: mock.NewMockedHTTPClient(withWrites(filledGo)...),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "filename": "ci.yml", "content": filledGo},
+			expected: map[string]any{
+				"created":             true,
+				"path":                ".github/workflows/ci.yml",
+				"branch":              "add-workflow-ci",
+				"commit_sha":          "commit-sha",
+				"pull_request_number": float64(7),
+				"pull_request_url":    "https://github.com/owner/repo/pull/7",
+			},
+		},
+		{
+			name: "invalid workflow is not written",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "filename": "ci.yml", "content": "on: push\n"},
+			expected: map[string]any{
+				"created": false,
+				"message": "the workflow has errors, fix them and try again",
+				"validation": map[string]any{
+					"source":   ".github/workflows/ci.yml",
+					"valid":    false,
+					"errors":   []any{map[string]any{"line": float64(1), "message": `missing required key "jobs"`}},
+					"warnings": []any{},
+				},
+			},
+		},
+		{
+			name: "workflow already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contents),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "filename": "exists.yml", "content": filledGo, "base": "main"},
+			expectError:    true,
+			expectedErrMsg: ".github/workflows/exists.yml already exists on main",
+		},
+		{
+			name: "unknown starter workflow",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contents),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "filename": "ci.yml", "template": "cobol", "base": "main"},
+			expectError:    true,
+			expectedErrMsg: "starter workflow ci/cobol.yml not found in actions/starter-workflows",
+		},
+		{
+			name:           "content and template",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "filename": "ci.yml", "content": filledGo, "template": "go"},
+			expectError:    true,
+			expectedErrMsg: "exactly one of content or template is required",
+		},
+		{
+			name:           "filename with a directory",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "filename": ".github/workflows/ci.yml", "template": "go"},
+			expectError:    true,
+			expectedErrMsg: "filename must be a .yml or .yaml file name without a directory",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateWorkflow(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var created map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &created))
+			assert.Equal(t, tc.expected, created)
+		})
+	}
+}
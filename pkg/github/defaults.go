@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepoDefaults are the owner and repository tools fall back to when the model omits them,
+// for assistants that work in a single repository or organization.
+type RepoDefaults struct {
+	Owner string
+	Repo  string
+}
+
+// Apply makes the owner and repo parameters of tool optional, filling them in from the defaults
+// when a call omits them. The repo default is only used for calls that target the default owner.
+// Tools without those parameters, or whose parameters have no default, are returned unchanged.
+func (d RepoDefaults) Apply(tool server.ServerTool) server.ServerTool {
+	defaults := map[string]string{}
+	if _, ok := tool.Tool.InputSchema.Properties["owner"]; ok && d.Owner != "" {
+		defaults["owner"] = d.Owner
+	}
+	if _, ok := tool.Tool.InputSchema.Properties["repo"]; ok && d.Owner != "" && d.Repo != "" {
+		defaults["repo"] = d.Repo
+	}
+	if len(defaults) == 0 {
+		return tool
+	}
+
+	// Copy the schema so the original tool definition is left untouched
+	wrapped := tool.Tool
+	wrapped.InputSchema.Properties = maps.Clone(tool.Tool.InputSchema.Properties)
+	wrapped.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.Tool.InputSchema.Required), func(name string) bool {
+		_, ok := defaults[name]
+		return ok
+	})
+	for name, value := range defaults {
+		property, ok := wrapped.InputSchema.Properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		property = maps.Clone(property)
+		description, _ := property["description"].(string)
+		property["description"] = fmt.Sprintf("%s. Defaults to %q", strings.TrimSuffix(description, "."), value)
+		wrapped.InputSchema.Properties[name] = property
+	}
+
+	next := tool.Handler
+	return server.ServerTool{
+		Tool: wrapped,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := maps.Clone(request.GetArguments())
+			if args == nil {
+				args = map[string]any{}
+			}
+			if _, ok := args["owner"]; !ok && defaults["owner"] != "" {
+				args["owner"] = defaults["owner"]
+			}
+			if _, ok := args["repo"]; !ok && defaults["repo"] != "" && args["owner"] == d.Owner {
+				args["repo"] = defaults["repo"]
+			}
+			request.Params.Arguments = args
+			return next(ctx, request)
+		},
+	}
+}
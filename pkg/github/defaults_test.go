@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RepoDefaults(t *testing.T) {
+	var received map[string]any
+	tool, _ := GetIssue(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	original := server.ServerTool{
+		Tool: tool,
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			received = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+
+	wrapped := RepoDefaults{Owner: "octo-org", Repo: "octo-repo"}.Apply(original)
+
+	assert.ElementsMatch(t, []string{"issue_number"}, wrapped.Tool.InputSchema.Required)
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties["owner"].(map[string]any)["description"], `Defaults to "octo-org"`)
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties["repo"].(map[string]any)["description"], `Defaults to "octo-repo"`)
+	// The original definition is left untouched
+	assert.Contains(t, original.Tool.InputSchema.Required, "owner")
+	assert.NotContains(t, original.Tool.InputSchema.Properties["owner"].(map[string]any)["description"], "Defaults to")
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "both omitted",
+			args:     map[string]any{"issue_number": float64(1)},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "issue_number": float64(1)},
+		},
+		{
+			name:     "explicit values win",
+			args:     map[string]any{"owner": "octo-org", "repo": "other", "issue_number": float64(1)},
+			expected: map[string]any{"owner": "octo-org", "repo": "other", "issue_number": float64(1)},
+		},
+		{
+			name:     "repo default is not used for another owner",
+			args:     map[string]any{"owner": "someone", "issue_number": float64(1)},
+			expected: map[string]any{"owner": "someone", "issue_number": float64(1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := wrapped.Handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, received)
+		})
+	}
+
+	t.Run("tools without defaults are unchanged", func(t *testing.T) {
+		searchTool, _ := SearchRepositories(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		unchanged := RepoDefaults{Owner: "octo-org"}.Apply(server.ServerTool{Tool: searchTool})
+		assert.Equal(t, searchTool, unchanged.Tool)
+	})
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// DependencyGraphManifestsQuery lists the manifests GitHub detected in a repository, with their dependencies.
+type DependencyGraphManifestsQuery struct {
+	Repository struct {
+		DependencyGraphManifests struct {
+			TotalCount githubv4.Int
+			PageInfo   PageInfoFragment
+			Nodes      []struct {
+				Filename          githubv4.String
+				BlobPath          githubv4.String
+				Parseable         githubv4.Boolean
+				ExceedsMaxSize    githubv4.Boolean
+				DependenciesCount githubv4.Int
+				Dependencies      struct {
+					Nodes []struct {
+						PackageName     githubv4.String
+						PackageManager  githubv4.String
+						Requirements    githubv4.String
+						HasDependencies githubv4.Boolean
+						Repository      *struct {
+							NameWithOwner githubv4.String
+						}
+					}
+				} `graphql:"dependencies(first: 100)"`
+			}
+		} `graphql:"dependencyGraphManifests(first: $first, after: $after, withDependencies: true)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// ManifestDependency is a single dependency declared in a manifest.
+type ManifestDependency struct {
+	PackageName     string `json:"package_name"`
+	PackageManager  string `json:"package_manager"`
+	Requirements    string `json:"requirements"`
+	HasDependencies bool   `json:"has_dependencies"`
+	Repository      string `json:"repository,omitempty"`
+}
+
+// DependencyManifest is a manifest or lock file detected by the dependency graph.
+type DependencyManifest struct {
+	Filename          string               `json:"filename"`
+	BlobPath          string               `json:"blob_path"`
+	Parseable         bool                 `json:"parseable"`
+	ExceedsMaxSize    bool                 `json:"exceeds_max_size,omitempty"`
+	DependenciesCount int                  `json:"dependencies_count"`
+	Dependencies      []ManifestDependency `json:"dependencies"`
+}
+
+// GetDependencyGraph creates a tool to list the manifests and dependencies of a repository.
+func GetDependencyGraph(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependency_graph",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_GRAPH_DESCRIPTION", "Get the dependency graph of a repository: each detected manifest or lock file with the packages it depends on and their version requirements. Use package to check whether a repository depends on a specific package.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDENCY_GRAPH_USER_TITLE", "Get dependency graph"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("package",
+				mcp.Description("Only return dependencies whose package name contains this value (case-insensitive). Manifests without a match are omitted"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageFilter, err := OptionalParam[string](request, "package")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query DependencyGraphManifestsQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}
+			paginationParams.Vars(vars)
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get dependency graph", err), nil
+			}
+
+			packageFilter = strings.ToLower(packageFilter)
+			manifests := []DependencyManifest{}
+			for _, node := range query.Repository.DependencyGraphManifests.Nodes {
+				manifest := DependencyManifest{
+					Filename:          string(node.Filename),
+					BlobPath:          string(node.BlobPath),
+					Parseable:         bool(node.Parseable),
+					ExceedsMaxSize:    bool(node.ExceedsMaxSize),
+					DependenciesCount: int(node.DependenciesCount),
+					Dependencies:      []ManifestDependency{},
+				}
+				for _, dep := range node.Dependencies.Nodes {
+					if packageFilter != "" && !strings.Contains(strings.ToLower(string(dep.PackageName)), packageFilter) {
+						continue
+					}
+					dependency := ManifestDependency{
+						PackageName:     string(dep.PackageName),
+						PackageManager:  string(dep.PackageManager),
+						Requirements:    string(dep.Requirements),
+						HasDependencies: bool(dep.HasDependencies),
+					}
+					if dep.Repository != nil {
+						dependency.Repository = string(dep.Repository.NameWithOwner)
+					}
+					manifest.Dependencies = append(manifest.Dependencies, dependency)
+				}
+				if packageFilter != "" && len(manifest.Dependencies) == 0 {
+					continue
+				}
+				manifests = append(manifests, manifest)
+			}
+
+			connection := query.Repository.DependencyGraphManifests
+			return MarshalledTextResult(map[string]any{
+				"manifests":  manifests,
+				"pagination": connection.PageInfo.Pagination(int(connection.TotalCount)),
+			}), nil
+		}
+}
+
+// ExportSBOM creates a tool to export the software bill of materials of a repository.
+func ExportSBOM(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("export_sbom",
+			mcp.WithDescription(t("TOOL_EXPORT_SBOM_DESCRIPTION", "Export the software bill of materials (SBOM) of a repository as an SPDX JSON document, generated from its dependency graph.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPORT_SBOM_USER_TITLE", "Export SBOM"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sbom, resp, err := client.DependencyGraph.GetSBOM(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to export SBOM for %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(sbom.GetSBOM()), nil
+		}
+}
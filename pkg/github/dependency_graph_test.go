@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetDependencyGraph(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetDependencyGraph(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_dependency_graph", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "package")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	manifestsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"dependencyGraphManifests": map[string]any{
+				"totalCount": 2,
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "c1",
+					"endCursor":       "c2",
+				},
+				"nodes": []any{
+					map[string]any{
+						"filename":          "pom.xml",
+						"blobPath":          "/owner/repo/blob/main/pom.xml",
+						"parseable":         true,
+						"exceedsMaxSize":    false,
+						"dependenciesCount": 2,
+						"dependencies": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"packageName":     "org.apache.logging.log4j:log4j-core",
+									"packageManager":  "MAVEN",
+									"requirements":    "= 2.14.1",
+									"hasDependencies": true,
+									"repository":      map[string]any{"nameWithOwner": "apache/logging-log4j2"},
+								},
+								map[string]any{
+									"packageName":     "junit:junit",
+									"packageManager":  "MAVEN",
+									"requirements":    "= 4.13.2",
+									"hasDependencies": false,
+									"repository":      nil,
+								},
+							},
+						},
+					},
+					map[string]any{
+						"filename":          "package-lock.json",
+						"blobPath":          "/owner/repo/blob/main/package-lock.json",
+						"parseable":         true,
+						"exceedsMaxSize":    false,
+						"dependenciesCount": 1,
+						"dependencies": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"packageName":     "left-pad",
+									"packageManager":  "NPM",
+									"requirements":    "= 1.3.0",
+									"hasDependencies": false,
+									"repository":      nil,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"first": githubv4.Int(30),
+		"after": (*githubv4.String)(nil),
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		response          githubv4mock.GQLResponse
+		expectError       bool
+		expectedErrMsg    string
+		expectedManifests []string
+		expectedPackages  []string
+	}{
+		{
+			name: "all manifests",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			response:          manifestsResponse,
+			expectedManifests: []string{"pom.xml", "package-lock.json"},
+			expectedPackages:  []string{"org.apache.logging.log4j:log4j-core", "junit:junit", "left-pad"},
+		},
+		{
+			name: "filtered by package",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"package": "LOG4J",
+			},
+			response:          manifestsResponse,
+			expectedManifests: []string{"pom.xml"},
+			expectedPackages:  []string{"org.apache.logging.log4j:log4j-core"},
+		},
+		{
+			name: "query fails",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			response:       githubv4mock.ErrorResponse("Could not resolve to a Repository"),
+			expectError:    true,
+			expectedErrMsg: "failed to get dependency graph",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(DependencyGraphManifestsQuery{}, vars, tc.response),
+			)
+			_, handler := GetDependencyGraph(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Manifests  []DependencyManifest `json:"manifests"`
+				Pagination Pagination           `json:"pagination"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.NotNil(t, response.Pagination.TotalCount)
+			assert.Equal(t, 2, *response.Pagination.TotalCount)
+
+			var manifests, packages []string
+			for _, m := range response.Manifests {
+				manifests = append(manifests, m.Filename)
+				for _, d := range m.Dependencies {
+					packages = append(packages, d.PackageName)
+				}
+			}
+			assert.Equal(t, tc.expectedManifests, manifests)
+			assert.Equal(t, tc.expectedPackages, packages)
+			assert.Equal(t, "apache/logging-log4j2", response.Manifests[0].Dependencies[0].Repository)
+		})
+	}
+}
+
+func Test_ExportSBOM(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ExportSBOM(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "export_sbom", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockSBOM := &github.SBOM{
+		SBOM: &github.SBOMInfo{
+			SPDXID:      github.Ptr("SPDXRef-DOCUMENT"),
+			SPDXVersion: github.Ptr("SPDX-2.3"),
+			Name:        github.Ptr("com.github.owner/repo"),
+			Packages: []*github.RepoDependencies{
+				{
+					SPDXID:      github.Ptr("SPDXRef-maven-log4j-core-2.14.1"),
+					Name:        github.Ptr("maven:org.apache.logging.log4j:log4j-core"),
+					VersionInfo: github.Ptr("2.14.1"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful export",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/dependency-graph/sbom").andThen(
+						mockResponse(t, http.StatusOK, mockSBOM),
+					),
+				),
+			),
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Dependency graph is not enabled"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to export SBOM for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ExportSBOM(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var sbom github.SBOMInfo
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &sbom))
+			assert.Equal(t, *mockSBOM.SBOM, sbom)
+		})
+	}
+}
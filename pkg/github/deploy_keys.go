@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListDeployKeys creates a tool to list the deploy keys of a repository.
+func ListDeployKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deploy_keys",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOY_KEYS_DESCRIPTION", "List the deploy keys of a GitHub repository: the SSH keys granting machines access to this repository alone, whether they can push, who added them and when they were last used. Use this to audit and rotate machine access. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOY_KEYS_USER_TITLE", "List deploy keys"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Repositories.ListKeys(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list deploy keys of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalKeys := make([]MinimalSSHKey, 0, len(keys))
+			for _, key := range keys {
+				minimalKeys = append(minimalKeys, convertToMinimalSSHKey(key))
+			}
+			return MarshalledTextResult(minimalKeys), nil
+		}
+}
+
+// AddDeployKey creates a tool to add a deploy key to a repository.
+func AddDeployKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_deploy_key",
+			mcp.WithDescription(t("TOOL_ADD_DEPLOY_KEY_DESCRIPTION", "Add a deploy key to a GitHub repository, granting whoever holds the private key access to this repository over SSH. Keys are read-only unless read_only is set to false, which also allows pushing. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_DEPLOY_KEY_USER_TITLE", "Add deploy key"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Descriptive name for the key, for example the machine or service it belongs to"),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("Public SSH key, for example 'ssh-ed25519 AAAA... deploy@host'"),
+			),
+			mcp.WithBoolean("read_only",
+				mcp.Description("Whether the key can only read the repository. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := RequiredParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key = strings.TrimSpace(key)
+			if strings.Contains(key, "PRIVATE KEY") {
+				return mcp.NewToolResultError("key looks like a private key, only the public key must be added"), nil
+			}
+			readOnly, err := OptionalBoolParamWithDefault(request, "read_only", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateKey(ctx, owner, repo, &github.Key{
+				Title:    github.Ptr(title),
+				Key:      github.Ptr(key),
+				ReadOnly: github.Ptr(readOnly),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add deploy key to %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalSSHKey(created)), nil
+		}
+}
+
+// DeleteDeployKey creates a tool to delete a deploy key from a repository.
+func DeleteDeployKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_deploy_key",
+			mcp.WithDescription(t("TOOL_DELETE_DEPLOY_KEY_DESCRIPTION", "Delete a deploy key from a GitHub repository, revoking its access. Deploy keys cannot be changed, so to rotate one add the new key first and then delete the old one. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_DEPLOY_KEY_USER_TITLE", "Delete deploy key"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("key_id",
+				mcp.Required(),
+				mcp.Description("ID of the deploy key, as returned by list_deploy_keys"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyID, err := RequiredInt(request, "key_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteKey(ctx, owner, repo, int64(keyID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete deploy key %d", keyID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted deploy key %d from %s/%s", keyID, owner, repo)), nil
+		}
+}
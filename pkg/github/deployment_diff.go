@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxDeploymentLookups bounds how many deployments of an environment get_deployment_diff checks
+// for a successful one, newest first.
+const maxDeploymentLookups = 30
+
+// EnvironmentDiff compares the commit deployed to an environment with a candidate ref.
+type EnvironmentDiff struct {
+	Environment  string             `json:"environment"`
+	DeploymentID int64              `json:"deployment_id,omitempty"`
+	DeployedSHA  string             `json:"deployed_sha,omitempty"`
+	DeployedRef  string             `json:"deployed_ref,omitempty"`
+	DeployedAt   *time.Time         `json:"deployed_at,omitempty"`
+	Comparison   *MinimalComparison `json:"comparison,omitempty"`
+	Message      string             `json:"message,omitempty"`
+}
+
+// latestSuccessfulDeployment returns the newest deployment to an environment whose latest status is
+// success, and the time it succeeded. It returns a nil deployment if none of the recent ones succeeded.
+func latestSuccessfulDeployment(ctx context.Context, client *github.Client, owner, repo, environment string) (*github.Deployment, time.Time, *github.Response, error) {
+	opts := &github.DeploymentsListOptions{Environment: environment, ListOptions: github.ListOptions{PerPage: maxDeploymentLookups}}
+	deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, time.Time{}, resp, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	for _, deployment := range deployments {
+		statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, time.Time{}, resp, fmt.Errorf("failed to list statuses of deployment %d: %w", deployment.GetID(), err)
+		}
+		_ = resp.Body.Close()
+		if len(statuses) > 0 && statuses[0].GetState() == "success" {
+			return deployment, statuses[0].GetCreatedAt().Time, nil, nil
+		}
+	}
+	return nil, time.Time{}, nil, nil
+}
+
+// GetDeploymentDiff creates a tool that compares what is deployed to environments with a candidate ref.
+func GetDeploymentDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_deployment_diff",
+			mcp.WithDescription(t("TOOL_GET_DEPLOYMENT_DIFF_DESCRIPTION", "Show what deploying a ref would change in each environment: finds the commit currently deployed to the environment (its latest successful deployment) and returns the commits and changed files between it and the candidate ref. Use this to review a change before approving a deployment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPLOYMENT_DIFF_USER_TITLE", "Get deployment diff"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Candidate commit SHA, branch or tag to deploy"),
+			),
+			mcp.WithArray("environments",
+				mcp.Description("Environments to compare, for example production. Defaults to every environment with a recent deployment"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("include_files",
+				mcp.Description("Also return the files changed between the deployed commit and the candidate. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environments, err := OptionalStringArrayParam(request, "environments")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeFiles, err := OptionalBoolParamWithDefault(request, "include_files", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if len(environments) == 0 {
+				deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{ListOptions: github.ListOptions{PerPage: 100}})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list deployments",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				seen := map[string]bool{}
+				for _, deployment := range deployments {
+					if environment := deployment.GetEnvironment(); !seen[environment] {
+						seen[environment] = true
+						environments = append(environments, environment)
+					}
+				}
+				if len(environments) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("%s/%s has no deployments", owner, repo)), nil
+				}
+			}
+
+			diffs := make([]EnvironmentDiff, 0, len(environments))
+			for _, environment := range environments {
+				diff := EnvironmentDiff{Environment: environment}
+				deployment, deployedAt, resp, err := latestSuccessfulDeployment(ctx, client, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to find the deployment of %s", environment),
+						resp,
+						err,
+					), nil
+				}
+				if deployment == nil {
+					diff.Message = fmt.Sprintf("no successful deployment among the %d most recent deployments", maxDeploymentLookups)
+					diffs = append(diffs, diff)
+					continue
+				}
+				diff.DeploymentID = deployment.GetID()
+				diff.DeployedSHA = deployment.GetSHA()
+				diff.DeployedRef = deployment.GetRef()
+				diff.DeployedAt = &deployedAt
+
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, diff.DeployedSHA, ref, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to compare %s...%s", diff.DeployedSHA, ref),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				minimalComparison := convertToMinimalComparison(diff.DeployedSHA, ref, comparison, includeFiles)
+				diff.Comparison = &minimalComparison
+				if comparison.GetBehindBy() > 0 {
+					diff.Message = fmt.Sprintf("%s is missing %d commits deployed to %s, deploying it would roll them back", ref, comparison.GetBehindBy(), environment)
+				}
+				diffs = append(diffs, diff)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"ref":          ref,
+				"environments": diffs,
+			}), nil
+		}
+}
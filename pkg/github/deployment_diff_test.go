@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetDeploymentDiff(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDeploymentDiff(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_deployment_diff", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	deployedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	production := []*github.Deployment{
+		{ID: github.Ptr(int64(2)), SHA: github.Ptr("failed-sha"), Ref: github.Ptr("main"), Environment: github.Ptr("production")},
+		{ID: github.Ptr(int64(1)), SHA: github.Ptr("deployed-sha"), Ref: github.Ptr("v1.2.0"), Environment: github.Ptr("production")},
+	}
+	staging := []*github.Deployment{
+		{ID: github.Ptr(int64(3)), SHA: github.Ptr("staging-sha"), Environment: github.Ptr("staging")},
+	}
+	deployments := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("environment") {
+		case "production":
+			_ = json.NewEncoder(w).Encode(production)
+		case "staging":
+			_ = json.NewEncoder(w).Encode(staging)
+		default:
+			_ = json.NewEncoder(w).Encode(append(append([]*github.Deployment{}, staging...), production...))
+		}
+	})
+	statuses := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "failure"
+		if r.URL.Path == "/repos/owner/repo/deployments/1/statuses" {
+			state = "success"
+		}
+		_ = json.NewEncoder(w).Encode([]*github.DeploymentStatus{{State: github.Ptr(state), CreatedAt: &github.Timestamp{Time: deployedAt}}})
+	})
+	compare := expectPath(t, "/repos/owner/repo/compare/deployed-sha...main").andThen(
+		mockResponse(t, http.StatusOK, github.CommitsComparison{
+			Status:       github.Ptr("ahead"),
+			AheadBy:      github.Ptr(1),
+			TotalCommits: github.Ptr(1),
+			Commits:      []*github.RepositoryCommit{{SHA: github.Ptr("new-sha")}},
+			Files:        []*github.CommitFile{{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Changes: github.Ptr(3)}},
+		}),
+	)
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]any
+		expected    []EnvironmentDiff
+	}{
+		{
+			name: "every environment with a recent deployment",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expected: []EnvironmentDiff{
+				{
+					Environment: "staging",
+					Message:     "no successful deployment among the 30 most recent deployments",
+				},
+				{
+					Environment:  "production",
+					DeploymentID: 1,
+					DeployedSHA:  "deployed-sha",
+					DeployedRef:  "v1.2.0",
+					DeployedAt:   &deployedAt,
+					Comparison: &MinimalComparison{
+						Base:         "deployed-sha",
+						Head:         "main",
+						Status:       "ahead",
+						AheadBy:      1,
+						TotalCommits: 1,
+						Commits:      []MinimalCommit{{SHA: "new-sha"}},
+						Files:        []MinimalCommitFile{{Filename: "main.go", Status: "modified", Additions: 3, Changes: 3}},
+					},
+				},
+			},
+		},
+		{
+			name: "selected environment without files",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ref":           "main",
+				"environments":  []any{"production"},
+				"include_files": false,
+			},
+			expected: []EnvironmentDiff{
+				{
+					Environment:  "production",
+					DeploymentID: 1,
+					DeployedSHA:  "deployed-sha",
+					DeployedRef:  "v1.2.0",
+					DeployedAt:   &deployedAt,
+					Comparison: &MinimalComparison{
+						Base:         "deployed-sha",
+						Head:         "main",
+						Status:       "ahead",
+						AheadBy:      1,
+						TotalCommits: 1,
+						Commits:      []MinimalCommit{{SHA: "new-sha"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposDeploymentsByOwnerByRepo, deployments),
+				mock.WithRequestMatchHandler(mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId, statuses),
+				mock.WithRequestMatchHandler(mock.GetReposCompareByOwnerByRepoByBasehead, compare),
+			))
+			_, handler := GetDeploymentDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var diff struct {
+				Ref          string            `json:"ref"`
+				Environments []EnvironmentDiff `json:"environments"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &diff))
+			assert.Equal(t, "main", diff.Ref)
+			assert.Equal(t, tc.expected, diff.Environments)
+		})
+	}
+}
@@ -64,6 +64,11 @@ type PageInfoFragment struct {
 	EndCursor       githubv4.String
 }
 
+// Pagination creates the pagination envelope of the connection the page belongs to.
+func (p PageInfoFragment) Pagination(totalCount int) Pagination {
+	return NewCursorPagination(p.HasNextPage, string(p.EndCursor), totalCount)
+}
+
 type BasicNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after)"`
@@ -198,13 +203,8 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			vars := map[string]interface{}{
 				"owner": githubv4.String(owner),
 				"repo":  githubv4.String(repo),
-				"first": githubv4.Int(*paginationParams.First),
-			}
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				vars["after"] = (*githubv4.String)(nil)
 			}
+			paginationParams.Vars(vars)
 
 			// this is an extra check in case the tool description is misinterpreted, because
 			// we shouldn't use ordering unless both a 'field' and 'direction' are provided
@@ -239,13 +239,7 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			// Create response with pagination info
 			response := map[string]interface{}{
 				"discussions": discussions,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
-				},
-				"totalCount": totalCount,
+				"pagination":  pageInfo.Pagination(int(totalCount)),
 			}
 
 			out, err := json.Marshal(response)
@@ -389,12 +383,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 							Nodes []struct {
 								Body githubv4.String
 							}
-							PageInfo struct {
-								HasNextPage     githubv4.Boolean
-								HasPreviousPage githubv4.Boolean
-								StartCursor     githubv4.String
-								EndCursor       githubv4.String
-							}
+							PageInfo   PageInfoFragment
 							TotalCount int
 						} `graphql:"comments(first: $first, after: $after)"`
 					} `graphql:"discussion(number: $discussionNumber)"`
@@ -404,13 +393,8 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				"owner":            githubv4.String(params.Owner),
 				"repo":             githubv4.String(params.Repo),
 				"discussionNumber": githubv4.Int(params.DiscussionNumber),
-				"first":            githubv4.Int(*paginationParams.First),
-			}
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				vars["after"] = (*githubv4.String)(nil)
 			}
+			paginationParams.Vars(vars)
 			if err := client.Query(ctx, &q, vars); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -422,14 +406,8 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			// Create response with pagination info
 			response := map[string]interface{}{
-				"comments": comments,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.Discussion.Comments.TotalCount,
+				"comments":   comments,
+				"pagination": q.Repository.Discussion.Comments.PageInfo.Pagination(q.Repository.Discussion.Comments.TotalCount),
 			}
 
 			out, err := json.Marshal(response)
@@ -455,6 +433,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			mcp.WithString("repo",
 				mcp.Description("Repository name. If not provided, discussion categories will be queried at the organisation level."),
 			),
+			WithCursorPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -470,6 +449,14 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			if repo == "" {
 				repo = ".github"
 			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getGQLClient(ctx)
 			if err != nil {
@@ -483,21 +470,16 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 							ID   githubv4.ID
 							Name githubv4.String
 						}
-						PageInfo struct {
-							HasNextPage     githubv4.Boolean
-							HasPreviousPage githubv4.Boolean
-							StartCursor     githubv4.String
-							EndCursor       githubv4.String
-						}
+						PageInfo   PageInfoFragment
 						TotalCount int
-					} `graphql:"discussionCategories(first: $first)"`
+					} `graphql:"discussionCategories(first: $first, after: $after)"`
 				} `graphql:"repository(owner: $owner, name: $repo)"`
 			}
 			vars := map[string]interface{}{
 				"owner": githubv4.String(owner),
 				"repo":  githubv4.String(repo),
-				"first": githubv4.Int(25),
 			}
+			paginationParams.Vars(vars)
 			if err := client.Query(ctx, &q, vars); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -513,13 +495,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			// Create response with pagination info
 			response := map[string]interface{}{
 				"categories": categories,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.DiscussionCategories.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.DiscussionCategories.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.DiscussionCategories.TotalCount,
+				"pagination": q.Repository.DiscussionCategories.PageInfo.Pagination(q.Repository.DiscussionCategories.TotalCount),
 			}
 
 			out, err := json.Marshal(response)
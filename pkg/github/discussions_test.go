@@ -444,13 +444,7 @@ func Test_ListDiscussions(t *testing.T) {
 			// Parse the structured response with pagination info
 			var response struct {
 				Discussions []*github.Discussion `json:"discussions"`
-				PageInfo    struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
+				Pagination  Pagination           `json:"pagination"`
 			}
 			err = json.Unmarshal([]byte(text), &response)
 			require.NoError(t, err)
@@ -619,18 +613,13 @@ func Test_GetDiscussionComments(t *testing.T) {
 	// (Lines removed)
 
 	var response struct {
-		Comments []*github.IssueComment `json:"comments"`
-		PageInfo struct {
-			HasNextPage     bool   `json:"hasNextPage"`
-			HasPreviousPage bool   `json:"hasPreviousPage"`
-			StartCursor     string `json:"startCursor"`
-			EndCursor       string `json:"endCursor"`
-		} `json:"pageInfo"`
-		TotalCount int `json:"totalCount"`
+		Comments   []*github.IssueComment `json:"comments"`
+		Pagination Pagination             `json:"pagination"`
 	}
 	err = json.Unmarshal([]byte(textContent.Text), &response)
 	require.NoError(t, err)
 	assert.Len(t, response.Comments, 2)
+	assert.Equal(t, Pagination{HasMore: false, TotalCount: github.Ptr(2)}, response.Pagination)
 	expectedBodies := []string{"This is the first comment", "This is the second comment"}
 	for i, comment := range response.Comments {
 		assert.Equal(t, expectedBodies[i], *comment.Body)
@@ -648,20 +637,22 @@ func Test_ListDiscussionCategories(t *testing.T) {
 	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner"})
 
 	// Use exact string query that matches implementation output
-	qListCategories := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first){nodes{id,name},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	qListCategories := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
 
 	// Variables for repository-level categories
 	varsRepo := map[string]interface{}{
 		"owner": "owner",
 		"repo":  "repo",
-		"first": float64(25),
+		"first": float64(30),
+		"after": (*string)(nil),
 	}
 
 	// Variables for organization-level categories (using .github repo)
 	varsOrg := map[string]interface{}{
 		"owner": "owner",
 		"repo":  ".github",
-		"first": float64(25),
+		"first": float64(30),
+		"after": (*string)(nil),
 	}
 
 	mockRespRepo := githubv4mock.DataResponse(map[string]any{
@@ -763,13 +754,7 @@ func Test_ListDiscussionCategories(t *testing.T) {
 
 			var response struct {
 				Categories []map[string]string `json:"categories"`
-				PageInfo   struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
+				Pagination Pagination          `json:"pagination"`
 			}
 			require.NoError(t, json.Unmarshal([]byte(text), &response))
 			assert.Equal(t, tc.expectedCategories, response.Categories)
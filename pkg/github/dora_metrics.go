@@ -0,0 +1,484 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// doraConcurrency bounds how many deployments get_dora_metrics looks up at the same time.
+	doraConcurrency = 5
+	// maxDORAPullRequests bounds how many closed pull requests get_dora_metrics reads.
+	maxDORAPullRequests = 1000
+	// maxDORAWorkflowRuns bounds how many workflow runs get_dora_metrics reads.
+	maxDORAWorkflowRuns = 1000
+)
+
+// DORAWindow is the period DORA metrics are computed over.
+type DORAWindow struct {
+	Since string  `json:"since"`
+	Until string  `json:"until"`
+	Days  float64 `json:"days"`
+}
+
+// DeploymentFrequency counts the deployments to an environment.
+type DeploymentFrequency struct {
+	Successful int     `json:"successful"`
+	Failed     int     `json:"failed"`
+	Other      int     `json:"other"`
+	PerDay     float64 `json:"per_day"`
+	PerWeek    float64 `json:"per_week"`
+	Truncated  bool    `json:"truncated"`
+}
+
+// LeadTime is the time from merging a pull request to deploying it, in hours.
+type LeadTime struct {
+	PullRequests int     `json:"pull_requests"`
+	Undeployed   int     `json:"undeployed_pull_requests"`
+	MedianHours  float64 `json:"median_hours"`
+	P90Hours     float64 `json:"p90_hours"`
+	MeanHours    float64 `json:"mean_hours"`
+}
+
+// ChangeFailure holds proxies for the change failure rate.
+type ChangeFailure struct {
+	FailedDeploymentRate  float64 `json:"failed_deployment_rate"`
+	MergedPullRequests    int     `json:"merged_pull_requests"`
+	RevertPullRequests    int     `json:"revert_pull_requests"`
+	HotfixPullRequests    int     `json:"hotfix_pull_requests"`
+	RevertOrHotfixRate    float64 `json:"revert_or_hotfix_rate"`
+	WorkflowRuns          int     `json:"workflow_runs"`
+	FailedWorkflowRuns    int     `json:"failed_workflow_runs"`
+	FailedWorkflowRunRate float64 `json:"failed_workflow_run_rate"`
+}
+
+// DORAMetrics are delivery performance metrics of a repository over a window.
+type DORAMetrics struct {
+	Environment         string              `json:"environment"`
+	Branch              string              `json:"branch"`
+	Window              DORAWindow          `json:"window"`
+	DeploymentFrequency DeploymentFrequency `json:"deployment_frequency"`
+	LeadTime            LeadTime            `json:"lead_time"`
+	ChangeFailure       ChangeFailure       `json:"change_failure"`
+}
+
+// doraDeployment is a deployment with the outcome of its latest status.
+type doraDeployment struct {
+	deployment *github.Deployment
+	state      string
+	deployedAt time.Time
+	// commits are the SHAs the deployment shipped since the previous successful deployment.
+	commits map[string]bool
+}
+
+// roundTo1 rounds f to one decimal.
+func roundTo1(f float64) float64 {
+	return math.Round(f*10) / 10
+}
+
+// ratio returns n/total rounded to three decimals, or 0 if total is 0.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)*1000/float64(total)) / 1000
+}
+
+// percentile returns the nearest-rank percentile p of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// isRevertOrHotfix reports whether a pull request reverts or hot-fixes an earlier change, from its title and labels.
+func isRevertOrHotfix(pr *github.PullRequest) (revert, hotfix bool) {
+	title := strings.ToLower(pr.GetTitle())
+	revert = strings.HasPrefix(title, "revert")
+	hotfix = strings.Contains(title, "hotfix") || strings.Contains(title, "hot fix")
+	for _, label := range pr.Labels {
+		name := strings.ToLower(label.GetName())
+		revert = revert || strings.Contains(name, "revert")
+		hotfix = hotfix || strings.Contains(name, "hotfix")
+	}
+	return revert, hotfix
+}
+
+// GetDORAMetrics creates a tool that computes deployment frequency, lead time and change failure proxies of a repository.
+func GetDORAMetrics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dora_metrics",
+			mcp.WithDescription(t("TOOL_GET_DORA_METRICS_DESCRIPTION", "Compute DORA style delivery metrics of a repository over a date window, aggregated server-side: deployment frequency to an environment, lead time from pull request merge to deployment (median, p90 and mean hours), and change failure proxies (failed deployments, revert and hotfix pull requests, failed workflow runs on the default branch).")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DORA_METRICS_USER_TITLE", "Get DORA metrics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Deployment environment to measure (default production)"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the window as an ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to 30 days before until"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the window as an ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to now"),
+			),
+			mcp.WithNumber("max_deployments",
+				mcp.Description("Maximum number of deployments to analyze, most recent first (default 100, max 500)"),
+				mcp.Min(1),
+				mcp.Max(500),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if environment == "" {
+				environment = "production"
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			untilParam, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until := time.Now().UTC()
+			if untilParam != "" {
+				if until, err = parseISOTimestamp(untilParam); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse until: %s", err)), nil
+				}
+			}
+			since := until.AddDate(0, 0, -30)
+			if sinceParam != "" {
+				if since, err = parseISOTimestamp(sinceParam); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err)), nil
+				}
+			}
+			if !since.Before(until) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+			maxDeployments, err := OptionalIntParamWithDefault(request, "max_deployments", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			days := until.Sub(since).Hours() / 24
+			metrics := DORAMetrics{
+				Environment: environment,
+				Branch:      repository.GetDefaultBranch(),
+				Window: DORAWindow{
+					Since: since.Format(time.RFC3339),
+					Until: until.Format(time.RFC3339),
+					Days:  roundTo1(days),
+				},
+			}
+
+			// Deployments are listed newest first. The newest one before the window is kept as the
+			// baseline the first deployment of the window shipped changes on top of.
+			var deployments []*doraDeployment
+			var baseline *github.Deployment
+			opts := &github.DeploymentsListOptions{Environment: environment, ListOptions: github.ListOptions{PerPage: 100}}
+			for baseline == nil {
+				page, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list deployments",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, deployment := range page {
+					createdAt := deployment.GetCreatedAt().Time
+					if createdAt.After(until) {
+						continue
+					}
+					if createdAt.Before(since) {
+						baseline = deployment
+						break
+					}
+					if len(deployments) == maxDeployments {
+						metrics.DeploymentFrequency.Truncated = true
+						baseline = deployment
+						break
+					}
+					deployments = append(deployments, &doraDeployment{deployment: deployment, deployedAt: createdAt})
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			if result := fetchDeploymentStates(ctx, client, owner, repo, deployments); result != nil {
+				return result, nil
+			}
+
+			// Oldest first, so that each deployment ships the commits since the previous successful one.
+			sort.Slice(deployments, func(i, j int) bool {
+				return deployments[i].deployedAt.Before(deployments[j].deployedAt)
+			})
+			var successful []*doraDeployment
+			for _, d := range deployments {
+				switch d.state {
+				case "success":
+					successful = append(successful, d)
+				case "failure", "error":
+					metrics.DeploymentFrequency.Failed++
+				default:
+					metrics.DeploymentFrequency.Other++
+				}
+			}
+			frequency := &metrics.DeploymentFrequency
+			frequency.Successful = len(successful)
+			frequency.PerDay = roundTo1(float64(frequency.Successful) / days)
+			frequency.PerWeek = roundTo1(float64(frequency.Successful) / days * 7)
+			metrics.ChangeFailure.FailedDeploymentRate = ratio(frequency.Failed, frequency.Successful+frequency.Failed)
+
+			if result := fetchDeployedCommits(ctx, client, owner, repo, baseline, successful); result != nil {
+				return result, nil
+			}
+
+			// Merged pull requests of the window, by recently updated first. Pull requests merged in
+			// the window were updated in it at least once, so paging stops at the first older one.
+			var leadTimes []float64
+			revertsOrHotfixes := 0
+			prOpts := &github.PullRequestListOptions{
+				State:       "closed",
+				Base:        metrics.Branch,
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			read := 0
+			for done := false; !done; {
+				prs, resp, err := client.PullRequests.List(ctx, owner, repo, prOpts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list pull requests",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, pr := range prs {
+					read++
+					if pr.GetUpdatedAt().Before(since) || read > maxDORAPullRequests {
+						done = true
+						break
+					}
+					mergedAt := pr.GetMergedAt().Time
+					if pr.MergedAt == nil || mergedAt.Before(since) || mergedAt.After(until) {
+						continue
+					}
+					metrics.ChangeFailure.MergedPullRequests++
+					revert, hotfix := isRevertOrHotfix(pr)
+					if revert {
+						metrics.ChangeFailure.RevertPullRequests++
+					}
+					if hotfix {
+						metrics.ChangeFailure.HotfixPullRequests++
+					}
+					if revert || hotfix {
+						revertsOrHotfixes++
+					}
+
+					deployed := false
+					for _, d := range successful {
+						if d.commits[pr.GetMergeCommitSHA()] && !d.deployedAt.Before(mergedAt) {
+							leadTimes = append(leadTimes, d.deployedAt.Sub(mergedAt).Hours())
+							deployed = true
+							break
+						}
+					}
+					if !deployed {
+						metrics.LeadTime.Undeployed++
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				prOpts.Page = resp.NextPage
+			}
+			metrics.ChangeFailure.RevertOrHotfixRate = ratio(revertsOrHotfixes, metrics.ChangeFailure.MergedPullRequests)
+
+			sort.Float64s(leadTimes)
+			metrics.LeadTime.PullRequests = len(leadTimes)
+			if len(leadTimes) > 0 {
+				sum := 0.0
+				for _, hours := range leadTimes {
+					sum += hours
+				}
+				metrics.LeadTime.MedianHours = roundTo1(percentile(leadTimes, 50))
+				metrics.LeadTime.P90Hours = roundTo1(percentile(leadTimes, 90))
+				metrics.LeadTime.MeanHours = roundTo1(sum / float64(len(leadTimes)))
+			}
+
+			runOpts := &github.ListWorkflowRunsOptions{
+				Branch:      metrics.Branch,
+				Status:      "completed",
+				Created:     fmt.Sprintf("%s..%s", since.Format(time.RFC3339), until.Format(time.RFC3339)),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for metrics.ChangeFailure.WorkflowRuns < maxDORAWorkflowRuns {
+				runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, runOpts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list workflow runs",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, run := range runs.WorkflowRuns {
+					metrics.ChangeFailure.WorkflowRuns++
+					if run.GetConclusion() == "failure" {
+						metrics.ChangeFailure.FailedWorkflowRuns++
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				runOpts.Page = resp.NextPage
+			}
+			metrics.ChangeFailure.FailedWorkflowRunRate = ratio(metrics.ChangeFailure.FailedWorkflowRuns, metrics.ChangeFailure.WorkflowRuns)
+
+			return MarshalledTextResult(metrics), nil
+		}
+}
+
+// fetchDeploymentStates sets the state of each deployment from its latest status, and the time of a
+// successful deployment to the time it succeeded. It returns an error result if a lookup failed.
+func fetchDeploymentStates(ctx context.Context, client *github.Client, owner, repo string, deployments []*doraDeployment) *mcp.CallToolResult {
+	errs := make([]error, len(deployments))
+	responses := make([]*github.Response, len(deployments))
+	semaphore := make(chan struct{}, doraConcurrency)
+	var wg sync.WaitGroup
+	for i, d := range deployments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, d.deployment.GetID(), &github.ListOptions{PerPage: 1})
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			responses[i], errs[i] = resp, err
+			if err != nil || len(statuses) == 0 {
+				return
+			}
+			d.state = statuses[0].GetState()
+			if d.state == "success" {
+				d.deployedAt = statuses[0].GetCreatedAt().Time
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to list statuses of deployment %d", deployments[i].deployment.GetID()),
+				responses[i],
+				err,
+			)
+		}
+	}
+	return nil
+}
+
+// fetchDeployedCommits sets the commits each successful deployment shipped, by comparing it to the
+// previous successful deployment, or to baseline for the first one. Deployments are ordered oldest first.
+func fetchDeployedCommits(ctx context.Context, client *github.Client, owner, repo string, baseline *github.Deployment, successful []*doraDeployment) *mcp.CallToolResult {
+	errs := make([]error, len(successful))
+	responses := make([]*github.Response, len(successful))
+	semaphore := make(chan struct{}, doraConcurrency)
+	var wg sync.WaitGroup
+	for i, d := range successful {
+		d.commits = map[string]bool{d.deployment.GetSHA(): true}
+		base := baseline.GetSHA()
+		if i > 0 {
+			base = successful[i-1].deployment.GetSHA()
+		}
+		if base == "" || base == d.deployment.GetSHA() {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, d.deployment.GetSHA(), nil)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			responses[i], errs[i] = resp, err
+			if err != nil {
+				return
+			}
+			for _, commit := range comparison.Commits {
+				d.commits[commit.GetSHA()] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to compare the commits of deployment %d", successful[i].deployment.GetID()),
+				responses[i],
+				err,
+			)
+		}
+	}
+	return nil
+}
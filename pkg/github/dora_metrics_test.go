@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetDORAMetrics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDORAMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_dora_metrics", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	at := func(month time.Month, day, hour int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)}
+	}
+	deployment := func(id int64, sha string, createdAt *github.Timestamp) *github.Deployment {
+		return &github.Deployment{ID: github.Ptr(id), SHA: github.Ptr(sha), CreatedAt: createdAt}
+	}
+	pr := func(title, mergeSHA string, updatedAt, mergedAt *github.Timestamp, labels ...string) *github.PullRequest {
+		p := &github.PullRequest{
+			Title:          github.Ptr(title),
+			MergeCommitSHA: github.Ptr(mergeSHA),
+			UpdatedAt:      updatedAt,
+			MergedAt:       mergedAt,
+		}
+		for _, label := range labels {
+			p.Labels = append(p.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+		return p
+	}
+	commits := func(shas ...string) *github.CommitsComparison {
+		c := &github.CommitsComparison{}
+		for _, sha := range shas {
+			c.Commits = append(c.Commits, &github.RepositoryCommit{SHA: github.Ptr(sha)})
+		}
+		return c
+	}
+
+	deployments := []*github.Deployment{
+		deployment(4, "ddd", at(time.June, 2, 12)),
+		deployment(3, "ccc", at(time.May, 20, 12)),
+		deployment(2, "bbb", at(time.May, 10, 12)),
+		deployment(1, "aaa", at(time.May, 5, 11)),
+		deployment(0, "base", at(time.April, 20, 12)),
+	}
+	statuses := map[string]*github.DeploymentStatus{
+		"1": {State: github.Ptr("success"), CreatedAt: at(time.May, 5, 12)},
+		"2": {State: github.Ptr("failure"), CreatedAt: at(time.May, 10, 13)},
+		"3": {State: github.Ptr("success"), CreatedAt: at(time.May, 20, 19)},
+	}
+	comparisons := map[string]*github.CommitsComparison{
+		"base...aaa": commits("m1", "aaa"),
+		"aaa...ccc":  commits("m2", "ccc"),
+	}
+	pulls := []*github.PullRequest{
+		pr("Fix login", "m3", at(time.May, 26, 0), at(time.May, 25, 0), "hotfix"),
+		pr("Draft", "", at(time.May, 24, 0), nil),
+		pr("Revert \"Add cache\"", "m2", at(time.May, 19, 14), at(time.May, 19, 13)),
+		pr("Add cache", "m1", at(time.May, 4, 13), at(time.May, 4, 12)),
+		pr("Old", "old", at(time.April, 1, 0), at(time.April, 1, 0)),
+	}
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(4),
+		WorkflowRuns: []*github.WorkflowRun{
+			{Conclusion: github.Ptr("success")},
+			{Conclusion: github.Ptr("failure")},
+			{Conclusion: github.Ptr("success")},
+			{Conclusion: github.Ptr("cancelled")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       DORAMetrics
+	}{
+		{
+			name: "metrics of a window",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"environment": "production", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, deployments),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						id := strings.Split(r.URL.Path, "/")[5]
+						mockResponse(t, http.StatusOK, []*github.DeploymentStatus{statuses[id]})(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						basehead := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/compare/")
+						comparison, ok := comparisons[basehead]
+						if !ok {
+							t.Errorf("unexpected comparison %s", basehead)
+						}
+						mockResponse(t, http.StatusOK, comparison)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"state": "closed", "base": "main", "sort": "updated", "direction": "desc", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, pulls),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"branch": "main", "status": "completed", "created": "2024-05-01T00:00:00Z..2024-05-31T00:00:00Z", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, runs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-05-01",
+				"until": "2024-05-31",
+			},
+			expected: DORAMetrics{
+				Environment: "production",
+				Branch:      "main",
+				Window:      DORAWindow{Since: "2024-05-01T00:00:00Z", Until: "2024-05-31T00:00:00Z", Days: 30},
+				DeploymentFrequency: DeploymentFrequency{
+					Successful: 2,
+					Failed:     1,
+					PerDay:     0.1,
+					PerWeek:    0.5,
+				},
+				LeadTime: LeadTime{
+					PullRequests: 2,
+					Undeployed:   1,
+					MedianHours:  24,
+					P90Hours:     30,
+					MeanHours:    27,
+				},
+				ChangeFailure: ChangeFailure{
+					FailedDeploymentRate:  0.333,
+					MergedPullRequests:    3,
+					RevertPullRequests:    1,
+					HotfixPullRequests:    1,
+					RevertOrHotfixRate:    0.667,
+					WorkflowRuns:          4,
+					FailedWorkflowRuns:    1,
+					FailedWorkflowRunRate: 0.25,
+				},
+			},
+		},
+		{
+			name:         "since after until",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-06-01",
+				"until": "2024-05-01",
+			},
+			expectError:    true,
+			expectedErrMsg: "since must be before until",
+		},
+		{
+			name: "deployments not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list deployments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDORAMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var metrics DORAMetrics
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &metrics))
+			assert.Equal(t, tc.expected, metrics)
+		})
+	}
+}
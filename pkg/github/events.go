@@ -0,0 +1,244 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalEvent is the trimmed output type for an activity event, with its payload reduced to a
+// one-line summary.
+type MinimalEvent struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Actor      string `json:"actor"`
+	Repository string `json:"repository"`
+	Action     string `json:"action,omitempty"`
+	Summary    string `json:"summary,omitempty"`
+	URL        string `json:"url,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// convertToMinimalEvent converts an event to its trimmed output type. Payloads that cannot be
+// parsed only leave the summary empty.
+func convertToMinimalEvent(event *github.Event) MinimalEvent {
+	minimal := MinimalEvent{
+		ID:         event.GetID(),
+		Type:       event.GetType(),
+		Actor:      event.GetActor().GetLogin(),
+		Repository: event.GetRepo().GetName(),
+		CreatedAt:  event.GetCreatedAt().Format(time.RFC3339),
+	}
+
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return minimal
+	}
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		minimal.Summary = fmt.Sprintf("pushed %d commits to %s", p.GetSize(), strings.TrimPrefix(p.GetRef(), "refs/heads/"))
+	case *github.CreateEvent:
+		minimal.Summary = fmt.Sprintf("created %s %s", p.GetRefType(), p.GetRef())
+	case *github.DeleteEvent:
+		minimal.Summary = fmt.Sprintf("deleted %s %s", p.GetRefType(), p.GetRef())
+	case *github.IssuesEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("%s issue #%d: %s", p.GetAction(), p.GetIssue().GetNumber(), p.GetIssue().GetTitle())
+		minimal.URL = p.GetIssue().GetHTMLURL()
+	case *github.IssueCommentEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("commented on #%d: %s", p.GetIssue().GetNumber(), p.GetIssue().GetTitle())
+		minimal.URL = p.GetComment().GetHTMLURL()
+	case *github.PullRequestEvent:
+		minimal.Action = p.GetAction()
+		action := p.GetAction()
+		if action == "closed" && p.GetPullRequest().GetMerged() {
+			action = "merged"
+		}
+		minimal.Summary = fmt.Sprintf("%s pull request #%d: %s", action, p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle())
+		minimal.URL = p.GetPullRequest().GetHTMLURL()
+	case *github.PullRequestReviewEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("reviewed pull request #%d (%s): %s", p.GetPullRequest().GetNumber(), strings.ToLower(p.GetReview().GetState()), p.GetPullRequest().GetTitle())
+		minimal.URL = p.GetReview().GetHTMLURL()
+	case *github.PullRequestReviewCommentEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("commented on the diff of pull request #%d: %s", p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle())
+		minimal.URL = p.GetComment().GetHTMLURL()
+	case *github.ReleaseEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("%s release %s", p.GetAction(), p.GetRelease().GetTagName())
+		minimal.URL = p.GetRelease().GetHTMLURL()
+	case *github.ForkEvent:
+		minimal.Summary = fmt.Sprintf("forked to %s", p.GetForkee().GetFullName())
+		minimal.URL = p.GetForkee().GetHTMLURL()
+	case *github.WatchEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = "starred the repository"
+	case *github.MemberEvent:
+		minimal.Action = p.GetAction()
+		minimal.Summary = fmt.Sprintf("%s collaborator %s", p.GetAction(), p.GetMember().GetLogin())
+	case *github.PublicEvent:
+		minimal.Summary = "made the repository public"
+	}
+	return minimal
+}
+
+// normalizeEventType turns the short names of event types users write, such as push or
+// pull_request, into the type names of the events API, such as PushEvent or PullRequestEvent.
+func normalizeEventType(eventType string) string {
+	name := strings.TrimSuffix(strings.ToLower(strings.ReplaceAll(eventType, "_", "")), "event")
+	return name + "event"
+}
+
+// withEventFilters adds the parameters filtering events to a tool definition.
+func withEventFilters() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("types",
+			mcp.Description("Only return events of these types, such as PushEvent, PullRequestEvent or IssuesEvent. Short names like push or pull_request are accepted as well"),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+		mcp.WithString("since",
+			mcp.Description("Only return events created at or after this time (ISO 8601 timestamp)"),
+		)(tool)
+	}
+}
+
+// listEvents fetches a page of events with list and applies the filters added by withEventFilters.
+// Events are returned newest first, so pagination stops once a page reaches past since.
+func listEvents(ctx context.Context, request mcp.CallToolRequest, subject string, list func(opts *github.ListOptions) ([]*github.Event, *github.Response, error)) (*mcp.CallToolResult, error) {
+	types, err := OptionalStringArrayParam(request, "types")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sinceParam, err := OptionalParam[string](request, "since")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var since time.Time
+	if sinceParam != "" {
+		if since, err = parseISOTimestamp(sinceParam); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid since timestamp: %s", err)), nil
+		}
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	wanted := make(map[string]bool, len(types))
+	for _, eventType := range types {
+		wanted[normalizeEventType(eventType)] = true
+	}
+
+	events, resp, err := list(&github.ListOptions{
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list events of %s", subject), resp, err), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	nextPage := resp.NextPage
+	result := make([]MinimalEvent, 0, len(events))
+	for _, event := range events {
+		if !since.IsZero() && event.GetCreatedAt().Before(since) {
+			nextPage = 0
+			break
+		}
+		if len(wanted) > 0 && !wanted[normalizeEventType(event.GetType())] {
+			continue
+		}
+		result = append(result, convertToMinimalEvent(event))
+	}
+
+	response := map[string]any{
+		"events": result,
+	}
+	if nextPage != 0 {
+		response["next_page"] = nextPage
+	}
+	return MarshalledTextResult(response), nil
+}
+
+// ListRepoEvents creates a tool to list the recent activity in a repository.
+func ListRepoEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_events",
+			mcp.WithDescription(t("TOOL_LIST_REPO_EVENTS_DESCRIPTION", "List the recent activity in a GitHub repository, newest first: pushes, branches and tags created or deleted, issues, pull requests, reviews, comments, releases, forks and stars, each with a one-line summary. Use this to answer what happened in a repository recently. Only events of the last 90 days are available.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_EVENTS_USER_TITLE", "List repository events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withEventFilters(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return listEvents(ctx, request, fmt.Sprintf("repository %s/%s", owner, repo), func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+				return client.Activity.ListRepositoryEvents(ctx, owner, repo, opts)
+			})
+		}
+}
+
+// ListOrgEvents creates a tool to list the recent public activity in an organization.
+func ListOrgEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_events",
+			mcp.WithDescription(t("TOOL_LIST_ORG_EVENTS_DESCRIPTION", "List the recent public activity across the repositories of a GitHub organization, newest first, each event with a one-line summary. Only events of the last 90 days are available.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_EVENTS_USER_TITLE", "List organization events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			withEventFilters(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return listEvents(ctx, request, fmt.Sprintf("organization %s", org), func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+				return client.Activity.ListEventsForOrganization(ctx, org, opts)
+			})
+		}
+}
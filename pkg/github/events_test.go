@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent(t *testing.T, id, eventType string, createdAt time.Time, payload any) *github.Event {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	rawPayload := json.RawMessage(raw)
+	return &github.Event{
+		ID:         github.Ptr(id),
+		Type:       github.Ptr(eventType),
+		Actor:      &github.User{Login: github.Ptr("octocat")},
+		Repo:       &github.Repository{Name: github.Ptr("octo-org/octo-repo")},
+		CreatedAt:  &github.Timestamp{Time: createdAt},
+		RawPayload: &rawPayload,
+	}
+}
+
+func Test_ListRepoEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repo_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "types")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	events := []*github.Event{
+		newTestEvent(t, "3", "PullRequestEvent", now, map[string]any{
+			"action": "closed",
+			"pull_request": map[string]any{
+				"number":   7,
+				"title":    "Add feature",
+				"merged":   true,
+				"html_url": "https://github.com/octo-org/octo-repo/pull/7",
+			},
+		}),
+		newTestEvent(t, "2", "PushEvent", now.Add(-time.Hour), map[string]any{
+			"ref":  "refs/heads/main",
+			"size": 2,
+		}),
+		newTestEvent(t, "1", "IssuesEvent", now.Add(-48*time.Hour), map[string]any{
+			"action": "opened",
+			"issue": map[string]any{
+				"number":   6,
+				"title":    "Bug",
+				"html_url": "https://github.com/octo-org/octo-repo/issues/6",
+			},
+		}),
+	}
+	eventsHandler := mock.WithRequestMatchHandler(
+		mock.GetReposEventsByOwnerByRepo,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Link", `<https://api.github.com/repos/octo-org/octo-repo/events?page=2>; rel="next"`)
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(events)
+		}),
+	)
+
+	mergedEvent := MinimalEvent{
+		ID:         "3",
+		Type:       "PullRequestEvent",
+		Actor:      "octocat",
+		Repository: "octo-org/octo-repo",
+		Action:     "closed",
+		Summary:    "merged pull request #7: Add feature",
+		URL:        "https://github.com/octo-org/octo-repo/pull/7",
+		CreatedAt:  "2024-05-02T12:00:00Z",
+	}
+	pushEvent := MinimalEvent{
+		ID:         "2",
+		Type:       "PushEvent",
+		Actor:      "octocat",
+		Repository: "octo-org/octo-repo",
+		Summary:    "pushed 2 commits to main",
+		CreatedAt:  "2024-05-02T11:00:00Z",
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedEvents   []MinimalEvent
+		expectedNextPage int
+	}{
+		{
+			name:         "all events",
+			mockedClient: mock.NewMockedHTTPClient(eventsHandler),
+			requestArgs:  map[string]any{"owner": "octo-org", "repo": "octo-repo"},
+			expectedEvents: []MinimalEvent{mergedEvent, pushEvent, {
+				ID:         "1",
+				Type:       "IssuesEvent",
+				Actor:      "octocat",
+				Repository: "octo-org/octo-repo",
+				Action:     "opened",
+				Summary:    "opened issue #6: Bug",
+				URL:        "https://github.com/octo-org/octo-repo/issues/6",
+				CreatedAt:  "2024-04-30T12:00:00Z",
+			}},
+			expectedNextPage: 2,
+		},
+		{
+			name:             "filtered by short type names",
+			mockedClient:     mock.NewMockedHTTPClient(eventsHandler),
+			requestArgs:      map[string]any{"owner": "octo-org", "repo": "octo-repo", "types": []any{"push", "pull_request"}},
+			expectedEvents:   []MinimalEvent{mergedEvent, pushEvent},
+			expectedNextPage: 2,
+		},
+		{
+			name:           "since stops pagination",
+			mockedClient:   mock.NewMockedHTTPClient(eventsHandler),
+			requestArgs:    map[string]any{"owner": "octo-org", "repo": "octo-repo", "since": "2024-05-01T00:00:00Z"},
+			expectedEvents: []MinimalEvent{mergedEvent, pushEvent},
+		},
+		{
+			name:           "invalid since",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "octo-org", "repo": "octo-repo", "since": "yesterday"},
+			expectError:    true,
+			expectedErrMsg: "invalid since timestamp",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org", "repo": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list events of repository octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned struct {
+				Events   []MinimalEvent `json:"events"`
+				NextPage int            `json:"next_page"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedEvents, returned.Events)
+			assert.Equal(t, tc.expectedNextPage, returned.NextPage)
+		})
+	}
+}
+
+func Test_ListOrgEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_events", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsEventsByOrg,
+			[]*github.Event{
+				newTestEvent(t, "2", "CreateEvent", now, map[string]any{"ref": "v1.0.0", "ref_type": "tag"}),
+				newTestEvent(t, "1", "WatchEvent", now, map[string]any{"action": "started"}),
+			},
+		),
+	)
+	_, handler := ListOrgEvents(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org", "types": []any{"CreateEvent"}}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		Events []MinimalEvent `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, []MinimalEvent{{
+		ID:         "2",
+		Type:       "CreateEvent",
+		Actor:      "octocat",
+		Repository: "octo-org/octo-repo",
+		Summary:    "created tag v1.0.0",
+		CreatedAt:  "2024-05-02T12:00:00Z",
+	}}, returned.Events)
+}
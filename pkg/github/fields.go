@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// FieldsParam is the parameter read tools accept a list of result fields to keep in.
+const FieldsParam = "fields"
+
+// fieldTree is a set of JSON paths, with a nil subtree meaning the whole value is kept.
+type fieldTree map[string]fieldTree
+
+func newFieldTree(paths []string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		parts := strings.Split(strings.TrimSpace(path), ".")
+		for i, part := range parts {
+			child, seen := node[part]
+			if seen && child == nil {
+				// A shorter path already keeps the whole value
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if child == nil {
+				child = fieldTree{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// project keeps only the fields of v selected by the tree. Arrays are projected element by element,
+// so 'labels.name' keeps the name of every label.
+func (tree fieldTree) project(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		projected := make(map[string]any, len(tree))
+		for key, subtree := range tree {
+			field, ok := value[key]
+			if !ok {
+				continue
+			}
+			if subtree == nil {
+				projected[key] = field
+			} else {
+				projected[key] = subtree.project(field)
+			}
+		}
+		return projected
+	case []any:
+		projected := make([]any, len(value))
+		for i, element := range value {
+			projected[i] = tree.project(element)
+		}
+		return projected
+	default:
+		return v
+	}
+}
+
+// WithFieldProjection adds the fields parameter to read-only get, list and search tools, which
+// reduces their JSON result to the requested fields. Other tools are returned unchanged.
+func WithFieldProjection(tool server.ServerTool) server.ServerTool {
+	readOnly := tool.Tool.Annotations.ReadOnlyHint
+	if readOnly == nil || !*readOnly {
+		return tool
+	}
+	if !strings.HasPrefix(tool.Tool.Name, "get_") && !strings.HasPrefix(tool.Tool.Name, "list_") && !strings.HasPrefix(tool.Tool.Name, "search_") {
+		return tool
+	}
+	if _, ok := tool.Tool.InputSchema.Properties[FieldsParam]; ok {
+		return tool
+	}
+
+	// Copy the properties so the original tool definition is left untouched
+	wrapped := tool.Tool
+	wrapped.InputSchema.Properties = maps.Clone(tool.Tool.InputSchema.Properties)
+	if wrapped.InputSchema.Properties == nil {
+		wrapped.InputSchema.Properties = make(map[string]any)
+	}
+	wrapped.InputSchema.Properties[FieldsParam] = map[string]any{
+		"type":        "array",
+		"description": "Only return these fields of the JSON result, as dot-separated paths such as 'user.login'",
+		"items": map[string]any{
+			"type": "string",
+		},
+	}
+
+	next := tool.Handler
+	return server.ServerTool{
+		Tool: wrapped,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fields, err := OptionalStringArrayParam(request, FieldsParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(fields) > 0 {
+				args := maps.Clone(request.GetArguments())
+				delete(args, FieldsParam)
+				request.Params.Arguments = args
+			}
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError || len(fields) == 0 || len(result.Content) != 1 {
+				return result, err
+			}
+			text, ok := result.Content[0].(mcp.TextContent)
+			if !ok {
+				return result, nil
+			}
+			var value any
+			if err := json.Unmarshal([]byte(text.Text), &value); err != nil {
+				// Not a JSON result, there is nothing to project
+				return result, nil
+			}
+			projected, err := json.Marshal(newFieldTree(fields).project(value))
+			if err != nil {
+				return result, nil
+			}
+			text.Text = string(projected)
+			result.Content[0] = text
+			return result, nil
+		},
+	}
+}
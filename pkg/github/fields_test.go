@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FieldTreeProject(t *testing.T) {
+	value := []any{
+		map[string]any{
+			"number": float64(1),
+			"title":  "First",
+			"user":   map[string]any{"login": "mona", "id": float64(7)},
+			"labels": []any{
+				map[string]any{"name": "bug", "color": "red"},
+				map[string]any{"name": "ui", "color": "blue"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected any
+	}{
+		{
+			name:   "top-level and nested fields",
+			fields: []string{"number", "user.login"},
+			expected: []any{
+				map[string]any{"number": float64(1), "user": map[string]any{"login": "mona"}},
+			},
+		},
+		{
+			name:   "fields of array elements",
+			fields: []string{"labels.name"},
+			expected: []any{
+				map[string]any{"labels": []any{map[string]any{"name": "bug"}, map[string]any{"name": "ui"}}},
+			},
+		},
+		{
+			name:   "a whole value wins over its fields",
+			fields: []string{"user.login", "user"},
+			expected: []any{
+				map[string]any{"user": map[string]any{"login": "mona", "id": float64(7)}},
+			},
+		},
+		{
+			name:     "unknown fields are ignored",
+			fields:   []string{"missing"},
+			expected: []any{map[string]any{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, newFieldTree(tc.fields).project(value))
+		})
+	}
+}
+
+func Test_WithFieldProjection(t *testing.T) {
+	var received map[string]any
+	getTool, _ := GetIssue(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	wrapped := WithFieldProjection(server.ServerTool{
+		Tool: getTool,
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			received = request.GetArguments()
+			return mcp.NewToolResultText(`{"number": 42, "title": "Bug", "body": "A long body"}`), nil
+		},
+	})
+
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties, FieldsParam)
+	assert.NotContains(t, getTool.InputSchema.Properties, FieldsParam)
+
+	result, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"fields":       []any{"number", "title"},
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number": 42, "title": "Bug"}`, getTextResult(t, result).Text)
+	assert.NotContains(t, received, FieldsParam)
+
+	result, err = wrapped.Handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number": 42, "title": "Bug", "body": "A long body"}`, getTextResult(t, result).Text)
+
+	t.Run("write tools are unchanged", func(t *testing.T) {
+		createTool, _ := CreateIssue(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		unchanged := WithFieldProjection(server.ServerTool{Tool: createTool})
+		assert.Equal(t, createTool, unchanged.Tool)
+	})
+}
+
+func Test_WithFieldProjection_GetIssue(t *testing.T) {
+	// The projection applies to every read tool, not only project tools. The mocked issue is
+	// served once, so every case gets a tool of its own.
+	newTool := func() server.ServerTool {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{
+					Number: github.Ptr(42),
+					Title:  github.Ptr("Bug"),
+					Body:   github.Ptr("A long body"),
+					User:   &github.User{Login: github.Ptr("mona"), ID: github.Ptr(int64(7))},
+					Labels: []*github.Label{
+						{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a")},
+						{Name: github.Ptr("ui"), Color: github.Ptr("0075ca")},
+					},
+				},
+			),
+		)
+		return WithFieldProjection(toolsets.NewServerTool(GetIssue(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)))
+	}
+
+	tests := []struct {
+		name     string
+		fields   []any
+		expected string
+	}{
+		{
+			name:     "fields of the elements of a nested array",
+			fields:   []any{"number", "labels.name", "user.login"},
+			expected: `{"number": 42, "labels": [{"name": "bug"}, {"name": "ui"}], "user": {"login": "mona"}}`,
+		},
+		{
+			name:     "unknown fields are left out",
+			fields:   []any{"title", "milestone.title", "assignee"},
+			expected: `{"title": "Bug"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := newTool().Handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"fields":       tc.fields,
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.JSONEq(t, tc.expected, getTextResult(t, result).Text)
+		})
+	}
+}
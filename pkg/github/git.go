@@ -0,0 +1,338 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GitRef is the output type for git references.
+type GitRef struct {
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	Type string `json:"type"`
+}
+
+func toGitRef(ref *github.Reference) GitRef {
+	return GitRef{
+		Ref:  ref.GetRef(),
+		SHA:  ref.GetObject().GetSHA(),
+		Type: ref.GetObject().GetType(),
+	}
+}
+
+// qualifiedRef returns ref prefixed with "refs/", accepting both "heads/main" and "refs/heads/main".
+func qualifiedRef(ref string) string {
+	return "refs/" + strings.TrimPrefix(ref, "refs/")
+}
+
+func withRefParam() mcp.ToolOption {
+	return mcp.WithString("ref",
+		mcp.Required(),
+		mcp.Description("Fully qualified reference, e.g. 'refs/heads/main' or 'refs/tags/v1.0.0'. The 'refs/' prefix may be omitted"),
+	)
+}
+
+// GetRef creates a tool to get a git reference.
+func GetRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ref",
+			mcp.WithDescription(t("TOOL_GET_REF_DESCRIPTION", "Get a git reference (branch, tag or other ref) of a repository and the object it points to. For annotated tags the object is the tag object, not the tagged commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REF_USER_TITLE", "Get git reference"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withRefParam(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.GetRef(ctx, owner, repo, qualifiedRef(ref))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(toGitRef(reference)), nil
+		}
+}
+
+// CreateRef creates a tool to create a git reference.
+func CreateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_ref",
+			mcp.WithDescription(t("TOOL_CREATE_REF_DESCRIPTION", "Create a git reference pointing at an object, e.g. a branch or lightweight tag at a specific commit. Fails if the reference already exists.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REF_USER_TITLE", "Create git reference"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withRefParam(),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the object the reference points to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(qualifiedRef(ref)),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(toGitRef(reference)), nil
+		}
+}
+
+// UpdateRef creates a tool to point a git reference at another object.
+func UpdateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ref",
+			mcp.WithDescription(t("TOOL_UPDATE_REF_DESCRIPTION", "Point an existing git reference at another commit, e.g. to move a rollback pointer. Without force, only fast-forward updates are allowed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_REF_USER_TITLE", "Update git reference"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withRefParam(),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit the reference should point to"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Allow updates that are not fast-forwards, discarding commits that are only reachable from the reference. Defaults to false"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(qualifiedRef(ref)),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}, force)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(toGitRef(reference)), nil
+		}
+}
+
+// CreateAnnotatedTag creates a tool to create an annotated tag object and its tag reference.
+func CreateAnnotatedTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_annotated_tag",
+			mcp.WithDescription(t("TOOL_CREATE_ANNOTATED_TAG_DESCRIPTION", "Create an annotated tag with a message on a commit, along with the refs/tags reference pointing to it, e.g. to tag a release.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_ANNOTATED_TAG_USER_TITLE", "Create annotated tag"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name, e.g. 'v1.0.0'"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Tag message"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to tag"),
+			),
+			mcp.WithString("tagger_name",
+				mcp.Description("Name of the tagger. Defaults to the authenticated user"),
+			),
+			mcp.WithString("tagger_email",
+				mcp.Description("Email of the tagger, required together with tagger_name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tagName, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerName, err := OptionalParam[string](request, "tagger_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerEmail, err := OptionalParam[string](request, "tagger_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (taggerName == "") != (taggerEmail == "") {
+				return mcp.NewToolResultError("tagger_name and tagger_email must be provided together"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			tag := &github.Tag{
+				Tag:     github.Ptr(tagName),
+				Message: github.Ptr(message),
+				Object:  &github.GitObject{SHA: github.Ptr(sha), Type: github.Ptr("commit")},
+			}
+			if taggerName != "" {
+				tag.Tagger = &github.CommitAuthor{Name: github.Ptr(taggerName), Email: github.Ptr(taggerEmail)}
+			}
+			tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, tag)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tag object",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// The tag object is only reachable once a reference points to it
+			reference, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tagName),
+				Object: &github.GitObject{SHA: tagObj.SHA},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("created tag object %s but failed to create its reference", tagObj.GetSHA()),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"tag":        tagObj.GetTag(),
+				"ref":        reference.GetRef(),
+				"tag_sha":    tagObj.GetSHA(),
+				"commit_sha": sha,
+				"message":    tagObj.GetMessage(),
+			}), nil
+		}
+}
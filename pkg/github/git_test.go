@@ -0,0 +1,353 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ref", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/tags/v1.0.0"),
+		Object: &github.GitObject{SHA: github.Ptr("tag-sha"), Type: github.Ptr("tag")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRef    GitRef
+		expectedErrMsg string
+	}{
+		{
+			name: "ref without refs prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/tags/v1.0.0").andThen(
+						mockResponse(t, http.StatusOK, mockRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "tags/v1.0.0",
+			},
+			expectedRef: GitRef{Ref: "refs/tags/v1.0.0", SHA: "tag-sha", Type: "tag"},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref GitRef
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+func Test_CreateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ref", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitRefsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"ref": "refs/heads/rollback",
+				"sha": "abc123",
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Reference{
+					Ref:    github.Ptr("refs/heads/rollback"),
+					Object: &github.GitObject{SHA: github.Ptr("abc123"), Type: github.Ptr("commit")},
+				}),
+			),
+		),
+	))
+	_, handler := CreateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"ref":   "heads/rollback",
+		"sha":   "abc123",
+	}))
+	require.NoError(t, err)
+
+	var ref GitRef
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+	assert.Equal(t, GitRef{Ref: "refs/heads/rollback", SHA: "abc123", Type: "commit"}, ref)
+}
+
+func Test_UpdateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ref", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	updatedRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/release"),
+		Object: &github.GitObject{SHA: github.Ptr("def456"), Type: github.Ptr("commit")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "fast-forward update",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{
+						"sha":   "def456",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/release",
+				"sha":   "def456",
+			},
+		},
+		{
+			name: "forced update",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{
+						"sha":   "def456",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/release",
+				"sha":   "def456",
+				"force": true,
+			},
+		},
+		{
+			name: "not a fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Update is not a fast forward"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/release",
+				"sha":   "def456",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref GitRef
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+			assert.Equal(t, GitRef{Ref: "refs/heads/release", SHA: "def456", Type: "commit"}, ref)
+		})
+	}
+}
+
+func Test_CreateAnnotatedTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAnnotatedTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_annotated_tag", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag", "message", "sha"})
+
+	mockTag := &github.Tag{
+		Tag:     github.Ptr("v1.0.0"),
+		SHA:     github.Ptr("tag-sha"),
+		Message: github.Ptr("Release 1.0.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "tag with tagger",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag":     "v1.0.0",
+						"message": "Release 1.0.0",
+						"object":  "abc123",
+						"type":    "commit",
+						"tagger":  map[string]any{"name": "Release Bot", "email": "bot@example.com"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTag),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "tag-sha",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/tags/v1.0.0"),
+							Object: &github.GitObject{SHA: github.Ptr("tag-sha"), Type: github.Ptr("tag")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"message":      "Release 1.0.0",
+				"sha":          "abc123",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "bot@example.com",
+			},
+			expectedResult: map[string]any{
+				"tag":        "v1.0.0",
+				"ref":        "refs/tags/v1.0.0",
+				"tag_sha":    "tag-sha",
+				"commit_sha": "abc123",
+				"message":    "Release 1.0.0",
+			},
+		},
+		{
+			name:         "tagger name without email",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag":         "v1.0.0",
+				"message":     "Release 1.0.0",
+				"sha":         "abc123",
+				"tagger_name": "Release Bot",
+			},
+			expectError:    true,
+			expectedErrMsg: "tagger_name and tagger_email must be provided together",
+		},
+		{
+			name: "tag reference already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.PostReposGitTagsByOwnerByRepo, mockTag),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference already exists"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"tag":     "v1.0.0",
+				"message": "Release 1.0.0",
+				"sha":     "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "created tag object tag-sha but failed to create its reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAnnotatedTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetGraphQLBudgetFn returns the GraphQL budget of the account tool calls currently act as.
+type GetGraphQLBudgetFn func(context.Context) (*ratelimit.GraphQLBudget, error)
+
+// graphQLCostError returns the reason the GraphQL budget refused to run a query, or nil if err has
+// another cause. Tools that read many pages return the pages they already have in that case.
+func graphQLCostError(err error) *ratelimit.GraphQLCostError {
+	var costErr *ratelimit.GraphQLCostError
+	if errors.As(err, &costErr) {
+		return costErr
+	}
+	return nil
+}
+
+// GetGraphQLBudget creates a tool that reports the GraphQL rate limit points spent and left.
+func GetGraphQLBudget(getBudget GetGraphQLBudgetFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_graphql_budget",
+			mcp.WithDescription(t("TOOL_GET_GRAPHQL_BUDGET_DESCRIPTION", "Get the GraphQL rate limit budget of the current account: the points left this hour and when they reset, as last reported by GitHub, and the points spent by the queries of this server. GraphQL queries cost points by the number of items they may return, so check the budget before reading large projects or many pages. Also returns the cost limits queries are refused above, if configured.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GRAPHQL_BUDGET_USER_TITLE", "Get GraphQL budget"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			budget, err := getBudget(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GraphQL budget: %w", err)
+			}
+			return MarshalledTextResult(budget.State()), nil
+		}
+}
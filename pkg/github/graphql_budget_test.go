@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetExceededTransport lets the first allowed requests through and refuses the rest as a
+// GraphQL budget would.
+type budgetExceededTransport struct {
+	transport http.RoundTripper
+	allowed   int
+}
+
+func (b *budgetExceededTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if b.allowed == 0 {
+		return nil, &ratelimit.GraphQLCostError{Cost: 120, Max: 100, PerCall: true}
+	}
+	b.allowed--
+	return b.transport.RoundTrip(req)
+}
+
+func Test_GetGraphQLBudget(t *testing.T) {
+	budget := ratelimit.NewGraphQLBudget(ratelimit.GraphQLBudgetConfig{MaxCallCost: 500})
+	getBudget := func(_ context.Context) (*ratelimit.GraphQLBudget, error) { return budget, nil }
+
+	tool, handler := GetGraphQLBudget(getBudget, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_graphql_budget", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	// Spend points with a query made through the budget
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"},"githubMCPRateLimit":{"cost":3,"limit":5000,"remaining":4997,"resetAt":"2024-05-01T13:00:00Z"}}}`))
+	}))
+	defer ts.Close()
+	client := githubv4.NewEnterpriseClient(ts.URL, &http.Client{Transport: budget.Transport(nil)})
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var state ratelimit.GraphQLBudgetState
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &state))
+	resetAt := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	assert.Equal(t, ratelimit.GraphQLBudgetState{
+		Limit:       5000,
+		Remaining:   4997,
+		ResetAt:     &resetAt,
+		Queries:     1,
+		TotalCost:   3,
+		LastCost:    3,
+		HighestCost: 3,
+		MaxCallCost: 500,
+	}, state)
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// healthFile is a community health file GitHub picks up from a repository.
+type healthFile struct {
+	// Param is the tool parameter holding the contents to add.
+	Param string
+	// Path is where the file is added when it is missing.
+	Path string
+	// Locations are the paths GitHub looks for the file at.
+	Locations []string
+}
+
+// healthFiles are the files propose_health_files checks for, in the order they are reported.
+var healthFiles = []healthFile{
+	{Param: "security", Path: "SECURITY.md", Locations: []string{"SECURITY.md", ".github/SECURITY.md", "docs/SECURITY.md"}},
+	{Param: "contributing", Path: "CONTRIBUTING.md", Locations: []string{"CONTRIBUTING.md", ".github/CONTRIBUTING.md", "docs/CONTRIBUTING.md"}},
+	{Param: "code_of_conduct", Path: "CODE_OF_CONDUCT.md", Locations: []string{"CODE_OF_CONDUCT.md", ".github/CODE_OF_CONDUCT.md", "docs/CODE_OF_CONDUCT.md"}},
+	{Param: "codeowners", Path: ".github/CODEOWNERS", Locations: codeownersLocations},
+}
+
+// HealthFilesProposal reports the health files a repository has, the ones added by the proposed
+// pull request and the ones still missing.
+type HealthFilesProposal struct {
+	Existing map[string]string `json:"existing,omitempty"`
+	Added    []string          `json:"added,omitempty"`
+	Missing  []string          `json:"missing,omitempty"`
+	Change   *ChangeResult     `json:"change,omitempty"`
+	Message  string            `json:"message,omitempty"`
+}
+
+// findHealthFile returns the location of a health file at ref, or an empty string if it is missing.
+func findHealthFile(ctx context.Context, client *github.Client, owner, repo, ref string, file healthFile) (string, *github.Response, error) {
+	for _, location := range file.Locations {
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, location, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", resp, err
+		}
+		_ = resp.Body.Close()
+		if fileContent != nil {
+			return location, nil, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// ProposeHealthFiles creates a tool that opens a pull request adding the community health files a repository is missing.
+func ProposeHealthFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("propose_health_files",
+			mcp.WithDescription(t("TOOL_PROPOSE_HEALTH_FILES_DESCRIPTION", "Detect which community health files (SECURITY.md, CONTRIBUTING.md, CODE_OF_CONDUCT.md, CODEOWNERS) a repository is missing, and open a pull request adding the given contents for them on a new branch. Files that already exist are never overwritten. Call it without contents first to find out which files are missing.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PROPOSE_HEALTH_FILES_USER_TITLE", "Propose community health files"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("security",
+				mcp.Description("Contents of SECURITY.md, added if the repository has no security policy"),
+			),
+			mcp.WithString("contributing",
+				mcp.Description("Contents of CONTRIBUTING.md, added if the repository has no contributing guidelines"),
+			),
+			mcp.WithString("code_of_conduct",
+				mcp.Description("Contents of CODE_OF_CONDUCT.md, added if the repository has no code of conduct"),
+			),
+			mcp.WithString("codeowners",
+				mcp.Description("Contents of .github/CODEOWNERS, added if the repository has no CODEOWNERS file"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Name of the branch to create. Defaults to add-health-files"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to open the pull request against. Defaults to the default branch"),
+			),
+			mcp.WithString("title",
+				mcp.Description("Pull request title. Defaults to \"Add community health files\""),
+			),
+			mcp.WithString("body",
+				mcp.Description("Pull request description. Defaults to a list of the added files"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Open the pull request as a draft"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contents := make(map[string]string, len(healthFiles))
+			for _, file := range healthFiles {
+				content, err := OptionalParam[string](request, file.Param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				contents[file.Param] = content
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			draft, err := OptionalParam[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if branch == "" {
+				branch = "add-health-files"
+			}
+			if title == "" {
+				title = "Add community health files"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+
+			proposal := HealthFilesProposal{Existing: map[string]string{}}
+			var files []fileChange
+			for _, file := range healthFiles {
+				location, resp, err := findHealthFile(ctx, client, owner, repo, base, file)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to check for %s", file.Path),
+						resp,
+						err,
+					), nil
+				}
+				switch {
+				case location != "":
+					proposal.Existing[file.Param] = location
+				case contents[file.Param] != "":
+					files = append(files, fileChange{Path: file.Path, Content: contents[file.Param]})
+					proposal.Added = append(proposal.Added, file.Path)
+				default:
+					proposal.Missing = append(proposal.Missing, file.Path)
+				}
+			}
+
+			if len(files) == 0 {
+				proposal.Message = "nothing to add, provide the contents of the missing files to open a pull request adding them"
+				return MarshalledTextResult(proposal), nil
+			}
+
+			if body == "" {
+				body = "Adds the following community health files:\n\n- " + strings.Join(proposal.Added, "\n- ")
+			}
+			change, resp, err := proposeChange(ctx, client, owner, repo, changeSpec{
+				Base:    base,
+				Branch:  branch,
+				Message: title,
+				Title:   title,
+				Body:    body,
+				Draft:   draft,
+				Files:   files,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to propose health files",
+					resp,
+					err,
+				), nil
+			}
+			proposal.Change = change
+			return MarshalledTextResult(proposal), nil
+		}
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProposeHealthFiles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ProposeHealthFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "propose_health_files", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// contents reports a security policy in .github and no other health file
+	contents := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/contents/.github/SECURITY.md" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(github.RepositoryContent{Type: github.Ptr("file")})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+	withChange := func(pullRequest http.HandlerFunc, extra ...mock.MockBackendOption) *http.Client {
+		return mock.NewMockedHTTPClient(append([]mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contents),
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, github.Commit{SHA: github.Ptr("base-sha"), Tree: &github.Tree{SHA: github.Ptr("base-tree")}}),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, github.Tree{SHA: github.Ptr("base-tree")}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"base_tree": "base-tree",
+					"tree": []any{
+						map[string]any{"path": "CONTRIBUTING.md", "mode": "100644", "type": "blob", "content": "# Contributing"},
+						map[string]any{"path": ".github/CODEOWNERS", "mode": "100644", "type": "blob", "content": "* @octo-org/maintainers"},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.Tree{SHA: github.Ptr("new-tree")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"message": "Add community health files",
+					"tree":    "new-tree",
+					"parents": []any{"base-sha"},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, github.Commit{SHA: github.Ptr("new-sha")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{"ref": "refs/heads/add-health-files", "sha": "new-sha"}).andThen(
+					mockResponse(t, http.StatusCreated, github.Reference{Ref: github.Ptr("refs/heads/add-health-files")}),
+				),
+			),
+			mock.WithRequestMatchHandler(mock.PostReposPullsByOwnerByRepo, pullRequest),
+		}, extra...)...)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       HealthFilesProposal
+	}{
+		{
+			name: "adds the missing files that have contents",
+			mockedClient: withChange(expectRequestBody(t, map[string]any{
+				"title": "Add community health files",
+				"head":  "add-health-files",
+				"base":  "main",
+				"body":  "Adds the following community health files:\n\n- CONTRIBUTING.md\n- .github/CODEOWNERS",
+				"draft": false,
+			}).andThen(
+				mockResponse(t, http.StatusCreated, github.PullRequest{Number: github.Ptr(7), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/7")}),
+			)),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"security":     "# Security",
+				"contributing": "# Contributing",
+				"codeowners":   "* @octo-org/maintainers",
+			},
+			expected: HealthFilesProposal{
+				Existing: map[string]string{"security": ".github/SECURITY.md"},
+				Added:    []string{"CONTRIBUTING.md", ".github/CODEOWNERS"},
+				Missing:  []string{"CODE_OF_CONDUCT.md"},
+				Change: &ChangeResult{
+					Branch:            "add-health-files",
+					Base:              "main",
+					CommitSHA:         "new-sha",
+					PullRequestNumber: 7,
+					PullRequestURL:    "https://github.com/owner/repo/pull/7",
+				},
+			},
+		},
+		{
+			name: "only detects without contents",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contents),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "develop",
+			},
+			expected: HealthFilesProposal{
+				Existing: map[string]string{"security": ".github/SECURITY.md"},
+				Missing:  []string{"CONTRIBUTING.md", "CODE_OF_CONDUCT.md", ".github/CODEOWNERS"},
+				Message:  "nothing to add, provide the contents of the missing files to open a pull request adding them",
+			},
+		},
+		{
+			name: "deletes the branch when the pull request cannot be opened",
+			mockedClient: withChange(
+				mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/heads/add-health-files").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"contributing": "# Contributing",
+				"codeowners":   "* @octo-org/maintainers",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to open a pull request, branch add-health-files was deleted again",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ProposeHealthFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var proposal HealthFilesProposal
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &proposal))
+			assert.Equal(t, tc.expected, proposal)
+		})
+	}
+}
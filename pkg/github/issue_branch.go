@@ -0,0 +1,255 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// StartedWork describes the branch, pull request and assignment created to start work on an issue.
+type StartedWork struct {
+	Issue             int    `json:"issue"`
+	Branch            string `json:"branch"`
+	Base              string `json:"base"`
+	BaseSHA           string `json:"base_sha"`
+	PullRequestNumber int    `json:"pull_request_number,omitempty"`
+	PullRequestURL    string `json:"pull_request_url,omitempty"`
+	Assignee          string `json:"assignee,omitempty"`
+}
+
+// StartWorkOnIssue creates a tool that creates a branch linked to an issue, optionally with a draft pull request, and assigns the issue.
+func StartWorkOnIssue(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("start_work_on_issue",
+			mcp.WithDescription(t("TOOL_START_WORK_ON_ISSUE_DESCRIPTION", "Start working on a GitHub issue, like the \"Create a branch\" button of an issue: creates a branch linked to the issue in its Development section, named after the issue number and title unless a name is given, and assigns the issue to the authenticated user. Optionally opens a draft pull request that closes the issue, starting from an empty commit on the branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_START_WORK_ON_ISSUE_USER_TITLE", "Start work on issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Name of the branch to create. Defaults to the issue number and title, as in 42-fix-login-redirect"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to start from. Defaults to the default branch"),
+			),
+			mcp.WithBoolean("draft_pr",
+				mcp.Description("Open a draft pull request that closes the issue"),
+			),
+			mcp.WithString("assignee",
+				mcp.Description("Username to assign the issue to. Defaults to the authenticated user"),
+			),
+			mcp.WithBoolean("assign",
+				mcp.Description("Whether to assign the issue. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			draftPR, err := OptionalParam[bool](request, "draft_pr")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignee, err := OptionalParam[string](request, "assignee")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assign, err := OptionalBoolParamWithDefault(request, "assign", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get issue #%d", issueNumber),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if issue.IsPullRequest() {
+				return mcp.NewToolResultError(fmt.Sprintf("#%d is a pull request, not an issue", issueNumber)), nil
+			}
+
+			if base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+
+			baseRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch %s", base),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			baseSHA := baseRef.GetObject().GetSHA()
+
+			// The linked branch mutation is what the issue page uses, it shows the branch under Development.
+			var mutation struct {
+				CreateLinkedBranch struct {
+					LinkedBranch struct {
+						Ref struct {
+							Name githubv4.String
+						}
+					}
+				} `graphql:"createLinkedBranch(input: $input)"`
+			}
+			input := githubv4.CreateLinkedBranchInput{
+				IssueID: githubv4.ID(issue.GetNodeID()),
+				Oid:     githubv4.GitObjectID(baseSHA),
+			}
+			if branch != "" {
+				input.Name = githubv4.NewString(githubv4.String(branch))
+			}
+			if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create linked branch", err), nil
+			}
+
+			work := StartedWork{
+				Issue:   issueNumber,
+				Branch:  string(mutation.CreateLinkedBranch.LinkedBranch.Ref.Name),
+				Base:    base,
+				BaseSHA: baseSHA,
+			}
+
+			if draftPR {
+				// A pull request needs a commit that differs from its base, so start the branch with an empty one.
+				baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, baseSHA)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get base commit",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+					Message: github.Ptr(fmt.Sprintf("Start work on #%d", issueNumber)),
+					Tree:    &github.Tree{SHA: baseCommit.GetTree().SHA},
+					Parents: []*github.Commit{{SHA: github.Ptr(baseSHA)}},
+				}, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("created branch %s but failed to commit to it", work.Branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				_, resp, err = client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+					Ref:    github.Ptr("refs/heads/" + work.Branch),
+					Object: &github.GitObject{SHA: commit.SHA},
+				}, false)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("created branch %s but failed to commit to it", work.Branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+					Title: github.Ptr(issue.GetTitle()),
+					Head:  github.Ptr(work.Branch),
+					Base:  github.Ptr(base),
+					Body:  github.Ptr(fmt.Sprintf("Closes #%d", issueNumber)),
+					Draft: github.Ptr(true),
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("created branch %s but failed to open a draft pull request", work.Branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				work.PullRequestNumber = pr.GetNumber()
+				work.PullRequestURL = pr.GetHTMLURL()
+			}
+
+			if assign {
+				if assignee == "" {
+					user, resp, err := client.Users.Get(ctx, "")
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to get authenticated user",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					assignee = user.GetLogin()
+				}
+				_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, issueNumber, []string{assignee})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("created branch %s but failed to assign issue #%d to %s", work.Branch, issueNumber, assignee),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				work.Assignee = assignee
+			}
+
+			return MarshalledTextResult(work), nil
+		}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StartWorkOnIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StartWorkOnIssue(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "start_work_on_issue", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	issue := github.Issue{Number: github.Ptr(42), NodeID: github.Ptr("I_42"), Title: github.Ptr("Fix login redirect")}
+	linkedBranch := func(name *githubv4.String, created string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CreateLinkedBranch struct {
+					LinkedBranch struct {
+						Ref struct {
+							Name githubv4.String
+						}
+					}
+				} `graphql:"createLinkedBranch(input: $input)"`
+			}{},
+			githubv4.CreateLinkedBranchInput{
+				IssueID: githubv4.ID("I_42"),
+				Oid:     githubv4.GitObjectID("base-sha"),
+				Name:    name,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"createLinkedBranch": map[string]any{
+					"linkedBranch": map[string]any{
+						"ref": map[string]any{"name": created},
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		gqlMatchers    []githubv4mock.Matcher
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       StartedWork
+	}{
+		{
+			name: "linked branch, draft pull request and self-assignment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, issue),
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("main")}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+						mockResponse(t, http.StatusOK, github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, github.Commit{SHA: github.Ptr("base-sha"), Tree: &github.Tree{SHA: github.Ptr("base-tree")}}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"message": "Start work on #42",
+						"tree":    "base-tree",
+						"parents": []any{"base-sha"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, github.Commit{SHA: github.Ptr("empty-sha")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{"sha": "empty-sha", "force": false}).andThen(
+						mockResponse(t, http.StatusOK, github.Reference{Ref: github.Ptr("refs/heads/42-fix-login-redirect")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title": "Fix login redirect",
+						"head":  "42-fix-login-redirect",
+						"base":  "main",
+						"body":  "Closes #42",
+						"draft": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, github.PullRequest{Number: github.Ptr(43), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/43")}),
+					),
+				),
+				mock.WithRequestMatch(mock.GetUser, github.User{Login: github.Ptr("octocat")}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"assignees": []any{"octocat"}}).andThen(
+						mockResponse(t, http.StatusCreated, issue),
+					),
+				),
+			),
+			gqlMatchers: []githubv4mock.Matcher{linkedBranch(nil, "42-fix-login-redirect")},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"draft_pr":     true,
+			},
+			expected: StartedWork{
+				Issue:             42,
+				Branch:            "42-fix-login-redirect",
+				Base:              "main",
+				BaseSHA:           "base-sha",
+				PullRequestNumber: 43,
+				PullRequestURL:    "https://github.com/owner/repo/pull/43",
+				Assignee:          "octocat",
+			},
+		},
+		{
+			name: "named branch without assignment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, issue),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/heads/develop").andThen(
+						mockResponse(t, http.StatusOK, github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+					),
+				),
+			),
+			gqlMatchers: []githubv4mock.Matcher{linkedBranch(githubv4.NewString("fix/login"), "fix/login")},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"branch":       "fix/login",
+				"base":         "develop",
+				"assign":       false,
+			},
+			expected: StartedWork{
+				Issue:   42,
+				Branch:  "fix/login",
+				Base:    "develop",
+				BaseSHA: "base-sha",
+			},
+		},
+		{
+			name: "pull request instead of issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, github.Issue{
+					Number:           github.Ptr(42),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42")},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "#42 is a pull request, not an issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...))
+			_, handler := StartWorkOnIssue(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var work StartedWork
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &work))
+			assert.Equal(t, tc.expected, work)
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// issueTemplateDir is the directory GitHub reads issue templates and forms from.
+const issueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// stringList is a YAML value that is either a list of strings or a comma separated string,
+// as the labels and assignees of issue templates may be written both ways.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, s := range strings.Split(value.Value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*l = append(*l, s)
+			}
+		}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// IssueFormOption is an option of a dropdown or checkboxes field of an issue form.
+type IssueFormOption struct {
+	Label    string `json:"label" yaml:"label"`
+	Required bool   `json:"required,omitempty" yaml:"required"`
+}
+
+func (o *IssueFormOption) UnmarshalYAML(value *yaml.Node) error {
+	// Dropdown options are plain strings, checkboxes are objects.
+	if value.Kind == yaml.ScalarNode {
+		o.Label = value.Value
+		return nil
+	}
+	type option IssueFormOption
+	return value.Decode((*option)(o))
+}
+
+// IssueFormField is an element of the body of an issue form.
+type IssueFormField struct {
+	Type        string            `json:"type"`
+	ID          string            `json:"id,omitempty"`
+	Label       string            `json:"label,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Placeholder string            `json:"placeholder,omitempty"`
+	Value       string            `json:"value,omitempty"`
+	Render      string            `json:"render,omitempty"`
+	Multiple    bool              `json:"multiple,omitempty"`
+	Options     []IssueFormOption `json:"options,omitempty"`
+	Required    bool              `json:"required"`
+}
+
+func (f *IssueFormField) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Type       string `yaml:"type"`
+		ID         string `yaml:"id"`
+		Attributes struct {
+			Label       string            `yaml:"label"`
+			Description string            `yaml:"description"`
+			Placeholder string            `yaml:"placeholder"`
+			Value       string            `yaml:"value"`
+			Render      string            `yaml:"render"`
+			Multiple    bool              `yaml:"multiple"`
+			Options     []IssueFormOption `yaml:"options"`
+		} `yaml:"attributes"`
+		Validations struct {
+			Required bool `yaml:"required"`
+		} `yaml:"validations"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	*f = IssueFormField{
+		Type:        raw.Type,
+		ID:          raw.ID,
+		Label:       raw.Attributes.Label,
+		Description: raw.Attributes.Description,
+		Placeholder: raw.Attributes.Placeholder,
+		Value:       raw.Attributes.Value,
+		Render:      raw.Attributes.Render,
+		Multiple:    raw.Attributes.Multiple,
+		Options:     raw.Attributes.Options,
+		Required:    raw.Validations.Required,
+	}
+	return nil
+}
+
+// IssueTemplate is a Markdown issue template or a YAML issue form.
+type IssueTemplate struct {
+	File        string           `json:"file"`
+	Kind        string           `json:"kind"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Title       string           `json:"title,omitempty"`
+	Labels      []string         `json:"labels,omitempty"`
+	Assignees   []string         `json:"assignees,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Body        string           `json:"body,omitempty"`
+	Fields      []IssueFormField `json:"fields,omitempty"`
+}
+
+// IssueTemplateContactLink is a link shown in the template chooser instead of a template.
+type IssueTemplateContactLink struct {
+	Name  string `json:"name" yaml:"name"`
+	URL   string `json:"url" yaml:"url"`
+	About string `json:"about" yaml:"about"`
+}
+
+// IssueTemplateConfig is the configuration of the template chooser from config.yml.
+type IssueTemplateConfig struct {
+	BlankIssuesEnabled *bool                      `json:"blank_issues_enabled,omitempty" yaml:"blank_issues_enabled"`
+	ContactLinks       []IssueTemplateContactLink `json:"contact_links,omitempty" yaml:"contact_links"`
+}
+
+// IssueTemplateError reports a template that could not be parsed.
+type IssueTemplateError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// IssueTemplates are the issue templates of a repository.
+type IssueTemplates struct {
+	Templates []IssueTemplate      `json:"templates"`
+	Config    *IssueTemplateConfig `json:"config,omitempty"`
+	Errors    []IssueTemplateError `json:"errors,omitempty"`
+}
+
+// parseIssueForm parses a YAML issue form.
+func parseIssueForm(file string, content []byte) (IssueTemplate, error) {
+	var form struct {
+		Name        string           `yaml:"name"`
+		Description string           `yaml:"description"`
+		Title       string           `yaml:"title"`
+		Labels      stringList       `yaml:"labels"`
+		Assignees   stringList       `yaml:"assignees"`
+		Type        string           `yaml:"type"`
+		Body        []IssueFormField `yaml:"body"`
+	}
+	if err := yaml.Unmarshal(content, &form); err != nil {
+		return IssueTemplate{}, err
+	}
+	if form.Name == "" || len(form.Body) == 0 {
+		return IssueTemplate{}, fmt.Errorf("issue forms need a name and a body")
+	}
+	return IssueTemplate{
+		File:        file,
+		Kind:        "form",
+		Name:        form.Name,
+		Description: form.Description,
+		Title:       form.Title,
+		Labels:      form.Labels,
+		Assignees:   form.Assignees,
+		Type:        form.Type,
+		Fields:      form.Body,
+	}, nil
+}
+
+// parseMarkdownIssueTemplate parses a Markdown issue template, whose settings are in YAML front matter.
+func parseMarkdownIssueTemplate(file string, content []byte) (IssueTemplate, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	rest, ok := bytes.CutPrefix(content, []byte("---\n"))
+	if !ok {
+		return IssueTemplate{}, fmt.Errorf("missing YAML front matter")
+	}
+	frontMatter, body, ok := bytes.Cut(rest, []byte("\n---"))
+	if !ok {
+		return IssueTemplate{}, fmt.Errorf("unterminated YAML front matter")
+	}
+	var settings struct {
+		Name      string     `yaml:"name"`
+		About     string     `yaml:"about"`
+		Title     string     `yaml:"title"`
+		Labels    stringList `yaml:"labels"`
+		Assignees stringList `yaml:"assignees"`
+		Type      string     `yaml:"type"`
+	}
+	if err := yaml.Unmarshal(frontMatter, &settings); err != nil {
+		return IssueTemplate{}, err
+	}
+	if settings.Name == "" {
+		return IssueTemplate{}, fmt.Errorf("issue templates need a name")
+	}
+	return IssueTemplate{
+		File:        file,
+		Kind:        "markdown",
+		Name:        settings.Name,
+		Description: settings.About,
+		Title:       settings.Title,
+		Labels:      settings.Labels,
+		Assignees:   settings.Assignees,
+		Type:        settings.Type,
+		Body:        strings.TrimSpace(strings.TrimPrefix(string(body), "\n")),
+	}, nil
+}
+
+// getIssueTemplates reads and parses the issue templates and the template chooser configuration
+// of a repository. Templates that cannot be parsed are reported as errors, as GitHub skips them too.
+func getIssueTemplates(ctx context.Context, client *github.Client, owner, repo, ref string) (*IssueTemplates, *github.Response, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	templates := &IssueTemplates{Templates: []IssueTemplate{}}
+
+	_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, issueTemplateDir, opts)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return templates, resp, nil
+		}
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	for _, entry := range entries {
+		if entry.GetType() != "file" {
+			continue
+		}
+		name := entry.GetName()
+		ext := strings.ToLower(path.Ext(name))
+		if ext != ".md" && ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, entry.GetPath(), opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to decode %s: %w", entry.GetPath(), err)
+		}
+
+		if strings.TrimSuffix(name, ext) == "config" && ext != ".md" {
+			var config IssueTemplateConfig
+			if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+				templates.Errors = append(templates.Errors, IssueTemplateError{File: entry.GetPath(), Error: err.Error()})
+				continue
+			}
+			templates.Config = &config
+			continue
+		}
+
+		var template IssueTemplate
+		if ext == ".md" {
+			template, err = parseMarkdownIssueTemplate(entry.GetPath(), []byte(content))
+		} else {
+			template, err = parseIssueForm(entry.GetPath(), []byte(content))
+		}
+		if err != nil {
+			templates.Errors = append(templates.Errors, IssueTemplateError{File: entry.GetPath(), Error: err.Error()})
+			continue
+		}
+		templates.Templates = append(templates.Templates, template)
+	}
+	return templates, resp, nil
+}
+
+// GetIssueTemplates creates a tool to get the issue templates and forms of a repository.
+func GetIssueTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_templates",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TEMPLATES_DESCRIPTION", "Get the issue templates and issue forms of a repository, parsed from .github/ISSUE_TEMPLATE: their default title, labels and assignees, the body of Markdown templates, and the fields of issue forms with their options and whether they are required. Also returns the template chooser configuration including contact links. Use this before creating an issue in a repository that requires a template.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TEMPLATES_USER_TITLE", "Get issue templates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the templates from. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			templates, resp, err := getIssueTemplates(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get issue templates",
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(templates), nil
+		}
+}
+
+// issueFormNoResponse is what GitHub renders for fields of an issue form that were left empty.
+const issueFormNoResponse = "_No response_"
+
+// findIssueTemplate finds a template by its name or file name, ignoring case.
+func (t *IssueTemplates) findIssueTemplate(name string) (IssueTemplate, bool) {
+	for _, template := range t.Templates {
+		file := path.Base(template.File)
+		if strings.EqualFold(template.Name, name) ||
+			strings.EqualFold(file, name) ||
+			strings.EqualFold(strings.TrimSuffix(file, path.Ext(file)), name) {
+			return template, true
+		}
+	}
+	return IssueTemplate{}, false
+}
+
+// answerStrings converts the answer to a field, which is a string or a list of strings, to a list.
+func answerStrings(answer any) ([]string, error) {
+	switch v := answer.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("answers must be strings or lists of strings, got %T", item)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("answers must be strings or lists of strings, got %T", answer)
+	}
+}
+
+// renderIssueForm renders the answers to the fields of an issue form in the Markdown layout GitHub
+// uses for issues created from the form: a heading per field followed by its answer. Answers are
+// keyed by field id or label. It fails if answers are unknown, invalid, or required ones are missing.
+func renderIssueForm(template IssueTemplate, answers map[string]any) (string, error) {
+	remaining := make(map[string]any, len(answers))
+	for key, answer := range answers {
+		remaining[key] = answer
+	}
+	answerFor := func(field IssueFormField) any {
+		for _, key := range []string{field.ID, field.Label} {
+			if answer, ok := remaining[key]; ok && key != "" {
+				delete(remaining, key)
+				return answer
+			}
+		}
+		return nil
+	}
+
+	var sections, problems []string
+	for _, field := range template.Fields {
+		if field.Type == "markdown" {
+			continue
+		}
+		values, err := answerStrings(answerFor(field))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", field.Label, err))
+			continue
+		}
+
+		var rendered string
+		switch field.Type {
+		case "checkboxes":
+			checked := make(map[string]bool, len(values))
+			for _, v := range values {
+				checked[v] = true
+			}
+			lines := make([]string, 0, len(field.Options))
+			for _, option := range field.Options {
+				mark := " "
+				if checked[option.Label] {
+					mark = "X"
+					delete(checked, option.Label)
+				} else if option.Required {
+					problems = append(problems, fmt.Sprintf("%s: option %q must be checked", field.Label, option.Label))
+				}
+				lines = append(lines, fmt.Sprintf("- [%s] %s", mark, option.Label))
+			}
+			for v := range checked {
+				problems = append(problems, fmt.Sprintf("%s: unknown option %q", field.Label, v))
+			}
+			rendered = strings.Join(lines, "\n")
+		case "dropdown":
+			if len(values) > 1 && !field.Multiple {
+				problems = append(problems, fmt.Sprintf("%s: only one option may be selected", field.Label))
+			}
+			for _, v := range values {
+				known := false
+				for _, option := range field.Options {
+					known = known || option.Label == v
+				}
+				if !known {
+					problems = append(problems, fmt.Sprintf("%s: unknown option %q", field.Label, v))
+				}
+			}
+			rendered = strings.Join(values, ", ")
+		default:
+			rendered = strings.Join(values, "\n")
+			if rendered != "" && field.Render != "" {
+				rendered = fmt.Sprintf("```%s\n%s\n```", field.Render, rendered)
+			}
+		}
+
+		if len(values) == 0 {
+			if field.Required {
+				problems = append(problems, fmt.Sprintf("%s: an answer is required", field.Label))
+			}
+			if field.Type != "checkboxes" {
+				rendered = issueFormNoResponse
+			}
+		}
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", field.Label, rendered))
+	}
+	for key := range remaining {
+		problems = append(problems, fmt.Sprintf("%s: the form has no such field", key))
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return "", fmt.Errorf("the answers do not conform to the %s form:\n- %s", template.Name, strings.Join(problems, "\n- "))
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// mergeUnique appends the values of b missing from a to it.
+func mergeUnique(a, b []string) []string {
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			a = append(a, v)
+		}
+	}
+	return a
+}
@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBugReportForm = `name: Bug report
+description: File a bug report
+title: "[Bug]: "
+labels: ["bug", "triage"]
+assignees: octocat
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this bug report!
+  - type: textarea
+    id: what-happened
+    attributes:
+      label: What happened?
+      placeholder: Tell us what you see!
+    validations:
+      required: true
+  - type: dropdown
+    id: version
+    attributes:
+      label: Version
+      multiple: false
+      options:
+        - 1.0.2 (Default)
+        - 1.0.3 (Edge)
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow this project's Code of Conduct
+          required: true
+`
+
+const testFeatureRequestTemplate = `---
+name: Feature request
+about: Suggest an idea for this project
+title: "[Feature] "
+labels: enhancement, needs-triage
+assignees: ''
+---
+
+## Problem
+
+Describe the problem.
+`
+
+const testIssueTemplateConfig = `blank_issues_enabled: false
+contact_links:
+  - name: Community support
+    url: https://github.com/orgs/octo-org/discussions
+    about: Please ask and answer questions here.
+`
+
+func Test_ParseIssueForm(t *testing.T) {
+	template, err := parseIssueForm(".github/ISSUE_TEMPLATE/bug.yml", []byte(testBugReportForm))
+	require.NoError(t, err)
+
+	assert.Equal(t, "form", template.Kind)
+	assert.Equal(t, "Bug report", template.Name)
+	assert.Equal(t, "File a bug report", template.Description)
+	assert.Equal(t, "[Bug]: ", template.Title)
+	assert.Equal(t, []string{"bug", "triage"}, template.Labels)
+	assert.Equal(t, []string{"octocat"}, template.Assignees)
+	assert.Equal(t, []IssueFormField{
+		{Type: "markdown", Value: "Thanks for taking the time to fill out this bug report!"},
+		{Type: "textarea", ID: "what-happened", Label: "What happened?", Placeholder: "Tell us what you see!", Required: true},
+		{Type: "dropdown", ID: "version", Label: "Version", Options: []IssueFormOption{{Label: "1.0.2 (Default)"}, {Label: "1.0.3 (Edge)"}}},
+		{Type: "checkboxes", ID: "terms", Label: "Code of Conduct", Options: []IssueFormOption{{Label: "I agree to follow this project's Code of Conduct", Required: true}}},
+	}, template.Fields)
+
+	_, err = parseIssueForm("empty.yml", []byte("name: Empty\n"))
+	assert.EqualError(t, err, "issue forms need a name and a body")
+}
+
+func Test_ParseMarkdownIssueTemplate(t *testing.T) {
+	template, err := parseMarkdownIssueTemplate(".github/ISSUE_TEMPLATE/feature.md", []byte(strings.ReplaceAll(testFeatureRequestTemplate, "\n", "\r\n")))
+	require.NoError(t, err)
+
+	assert.Equal(t, "markdown", template.Kind)
+	assert.Equal(t, "Feature request", template.Name)
+	assert.Equal(t, "Suggest an idea for this project", template.Description)
+	assert.Equal(t, "[Feature] ", template.Title)
+	assert.Equal(t, []string{"enhancement", "needs-triage"}, template.Labels)
+	assert.Empty(t, template.Assignees)
+	assert.Equal(t, "## Problem\n\nDescribe the problem.", template.Body)
+
+	_, err = parseMarkdownIssueTemplate("plain.md", []byte("## Problem\n"))
+	assert.EqualError(t, err, "missing YAML front matter")
+}
+
+func Test_GetIssueTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_templates", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	files := map[string]string{
+		"bug.yml":    testBugReportForm,
+		"feature.md": testFeatureRequestTemplate,
+		"config.yml": testIssueTemplateConfig,
+		"broken.yml": "name: [unterminated",
+	}
+	contentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/contents/.github/ISSUE_TEMPLATE") {
+			entries := []*github.RepositoryContent{
+				{Type: github.Ptr("dir"), Name: github.Ptr("images"), Path: github.Ptr(".github/ISSUE_TEMPLATE/images")},
+				{Type: github.Ptr("file"), Name: github.Ptr("README.txt"), Path: github.Ptr(".github/ISSUE_TEMPLATE/README.txt")},
+			}
+			for _, name := range []string{"bug.yml", "broken.yml", "config.yml", "feature.md"} {
+				entries = append(entries, &github.RepositoryContent{
+					Type: github.Ptr("file"),
+					Name: github.Ptr(name),
+					Path: github.Ptr(".github/ISSUE_TEMPLATE/" + name),
+				})
+			}
+			mockResponse(t, http.StatusOK, entries)(w, r)
+			return
+		}
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Name:     github.Ptr(name),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(files[name]))),
+		})(w, r)
+	})
+
+	t.Run("templates and config", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+		))
+		_, handler := GetIssueTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var templates IssueTemplates
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &templates))
+		require.Len(t, templates.Templates, 2)
+		assert.Equal(t, "Bug report", templates.Templates[0].Name)
+		assert.Len(t, templates.Templates[0].Fields, 4)
+		assert.Equal(t, "Feature request", templates.Templates[1].Name)
+		require.NotNil(t, templates.Config)
+		require.NotNil(t, templates.Config.BlankIssuesEnabled)
+		assert.False(t, *templates.Config.BlankIssuesEnabled)
+		assert.Equal(t, []IssueTemplateContactLink{{
+			Name:  "Community support",
+			URL:   "https://github.com/orgs/octo-org/discussions",
+			About: "Please ask and answer questions here.",
+		}}, templates.Config.ContactLinks)
+		require.Len(t, templates.Errors, 1)
+		assert.Equal(t, ".github/ISSUE_TEMPLATE/broken.yml", templates.Errors[0].File)
+	})
+
+	t.Run("no templates", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := GetIssueTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.JSONEq(t, `{"templates": []}`, getTextResult(t, result).Text)
+	})
+}
+
+func Test_RenderIssueForm(t *testing.T) {
+	template, err := parseIssueForm(".github/ISSUE_TEMPLATE/bug.yml", []byte(testBugReportForm))
+	require.NoError(t, err)
+	template.Fields = append(template.Fields, IssueFormField{Type: "textarea", ID: "logs", Label: "Logs", Render: "shell"})
+
+	tests := []struct {
+		name           string
+		answers        map[string]any
+		expectedBody   string
+		expectedErrMsg string
+	}{
+		{
+			name: "all answered",
+			answers: map[string]any{
+				"what-happened": "It crashed",
+				"Version":       "1.0.3 (Edge)",
+				"terms":         []any{"I agree to follow this project's Code of Conduct"},
+				"logs":          "panic: oops",
+			},
+			expectedBody: "### What happened?\n\nIt crashed\n\n" +
+				"### Version\n\n1.0.3 (Edge)\n\n" +
+				"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct\n\n" +
+				"### Logs\n\n```shell\npanic: oops\n```",
+		},
+		{
+			name: "optional fields left empty",
+			answers: map[string]any{
+				"what-happened": "It crashed",
+				"terms":         []any{"I agree to follow this project's Code of Conduct"},
+			},
+			expectedBody: "### What happened?\n\nIt crashed\n\n" +
+				"### Version\n\n_No response_\n\n" +
+				"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct\n\n" +
+				"### Logs\n\n_No response_",
+		},
+		{
+			name: "invalid answers",
+			answers: map[string]any{
+				"Version":  []any{"1.0.2 (Default)", "2.0"},
+				"severity": "high",
+			},
+			expectedErrMsg: "the answers do not conform to the Bug report form:\n" +
+				"- Code of Conduct: option \"I agree to follow this project's Code of Conduct\" must be checked\n" +
+				"- Version: only one option may be selected\n" +
+				"- Version: unknown option \"2.0\"\n" +
+				"- What happened?: an answer is required\n" +
+				"- severity: the form has no such field",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := renderIssueForm(template, tc.answers)
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
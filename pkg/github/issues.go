@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -130,13 +132,8 @@ type IssueQueryResult interface {
 }
 
 type IssueQueryFragment struct {
-	Nodes    []IssueFragment `graphql:"nodes"`
-	PageInfo struct {
-		HasNextPage     githubv4.Boolean
-		HasPreviousPage githubv4.Boolean
-		StartCursor     githubv4.String
-		EndCursor       githubv4.String
-	}
+	Nodes      []IssueFragment `graphql:"nodes"`
+	PageInfo   PageInfoFragment
 	TotalCount int
 }
 
@@ -595,8 +592,9 @@ func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc)
 	return mcp.NewTool("remove_sub_issue",
 			mcp.WithDescription(t("TOOL_REMOVE_SUB_ISSUE_DESCRIPTION", "Remove a sub-issue from a parent issue in a GitHub repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:           t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -830,7 +828,7 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 // CreateIssue creates a tool to create a new issue in a GitHub repository.
 func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_issue",
-			mcp.WithDescription(t("TOOL_CREATE_ISSUE_DESCRIPTION", "Create a new issue in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_CREATE_ISSUE_DESCRIPTION", "Create a new issue in a GitHub repository. If the repository has issue templates or forms (see get_issue_templates), pass the template to use; for issue forms, answer its fields instead of passing a body, and the body is rendered in the form's layout.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_ISSUE_USER_TITLE", "Open new issue"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -872,6 +870,12 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("type",
 				mcp.Description("Type of this issue"),
 			),
+			mcp.WithString("template",
+				mcp.Description("Name or file name of the issue template or form to create the issue from. Its default title prefix, labels, assignees and type are applied"),
+			),
+			mcp.WithObject("fields",
+				mcp.Description("Answers to the fields of the issue form given as template, keyed by field id or label. Answers are strings, or arrays of strings for multi-select dropdowns and for the checked options of checkboxes"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -922,6 +926,63 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			templateName, err := OptionalParam[string](request, "template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fields, err := OptionalParam[map[string]any](request, "fields")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if templateName == "" && len(fields) > 0 {
+				return mcp.NewToolResultError("fields can only be answered when creating the issue from a template"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if templateName != "" {
+				templates, resp, err := getIssueTemplates(ctx, client, owner, repo, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get issue templates",
+						resp,
+						err,
+					), nil
+				}
+				template, ok := templates.findIssueTemplate(templateName)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("issue template %s not found in %s/%s", templateName, owner, repo)), nil
+				}
+
+				switch template.Kind {
+				case "form":
+					if body != "" {
+						return mcp.NewToolResultError(fmt.Sprintf("%s is an issue form, answer its fields instead of passing a body", template.Name)), nil
+					}
+					if body, err = renderIssueForm(template, fields); err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				default:
+					if len(fields) > 0 {
+						return mcp.NewToolResultError(fmt.Sprintf("%s is a Markdown template without fields, pass a body following it instead", template.Name)), nil
+					}
+					if body == "" {
+						body = template.Body
+					}
+				}
+				if !strings.HasPrefix(title, template.Title) {
+					title = template.Title + title
+				}
+				labels = mergeUnique(labels, template.Labels)
+				assignees = mergeUnique(assignees, template.Assignees)
+				if issueType == "" {
+					issueType = template.Type
+				}
+			}
+
 			// Create the issue request
 			issueRequest := &github.IssueRequest{
 				Title:     github.Ptr(title),
@@ -935,10 +996,6 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				issueRequest.Type = github.Ptr(issueType)
 			}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create issue: %w", err)
@@ -971,7 +1028,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 // ListIssues creates a tool to list and filter repository issues
 func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_issues",
-			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'nextCursor' from the previous response's 'pagination' in the 'after' parameter.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1113,15 +1170,9 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				"states":    states,
 				"orderBy":   githubv4.IssueOrderField(orderBy),
 				"direction": githubv4.OrderDirection(direction),
-				"first":     githubv4.Int(*paginationParams.First),
 			}
 
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				// Used within query, therefore must be set to nil and provided as $after
-				vars["after"] = (*githubv4.String)(nil)
-			}
+			paginationParams.Vars(vars)
 
 			// Ensure optional parameters are set
 			if hasLabels {
@@ -1144,12 +1195,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 
 			// Extract and convert all issue nodes using the common interface
 			var issues []*github.Issue
-			var pageInfo struct {
-				HasNextPage     githubv4.Boolean
-				HasPreviousPage githubv4.Boolean
-				StartCursor     githubv4.String
-				EndCursor       githubv4.String
-			}
+			var pageInfo PageInfoFragment
 			var totalCount int
 
 			if queryResult, ok := issueQuery.(IssueQueryResult); ok {
@@ -1163,14 +1209,8 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 
 			// Create response with issues
 			response := map[string]interface{}{
-				"issues": issues,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
-				},
-				"totalCount": totalCount,
+				"issues":     issues,
+				"pagination": pageInfo.Pagination(totalCount),
 			}
 			out, err := json.Marshal(response)
 			if err != nil {
@@ -1437,6 +1477,216 @@ func UpdateIssue(getClient GetClientFn, getGQLClient GetGQLClientFn, t translati
 		}
 }
 
+const (
+	// maxBulkIssues bounds how many issues a single bulk_update_issues call may change.
+	maxBulkIssues = 100
+	// bulkUpdateConcurrency bounds how many issues bulk_update_issues changes at the same time.
+	bulkUpdateConcurrency = 5
+)
+
+// BulkIssueResult is the outcome of a bulk operation on a single issue.
+type BulkIssueResult struct {
+	Number int    `json:"number"`
+	OK     bool   `json:"ok"`
+	URL    string `json:"url,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkUpdateIssues creates a tool that applies one operation to many issues of a repository.
+func BulkUpdateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_issues",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_ISSUES_DESCRIPTION", "Apply one operation to many issues of a repository at once, such as labelling, assigning or closing them during a triage sweep. Issues are updated in parallel and the result reports the outcome for each issue; a failure on one issue does not stop the others.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_ISSUES_USER_TITLE", "Bulk update issues"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Numbers of the issues to update (at most %d)", maxBulkIssues)),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("operation",
+				mcp.Required(),
+				mcp.Description("Operation to apply to every issue"),
+				mcp.Enum("add_labels", "remove_labels", "assign", "unassign", "set_milestone", "close", "reopen"),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Labels to add or remove, for add_labels and remove_labels"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("assignees",
+				mcp.Description("Usernames to assign or unassign, for assign and unassign"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Description("Milestone number for set_milestone"),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for close, defaults to completed"),
+				mcp.Enum("completed", "not_planned"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumbers = slices.Compact(slices.Sorted(slices.Values(issueNumbers)))
+			if len(issueNumbers) == 0 {
+				return mcp.NewToolResultError("issue_numbers must contain at least one issue"), nil
+			}
+			if len(issueNumbers) > maxBulkIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d issues can be updated at once", maxBulkIssues)), nil
+			}
+			operation, err := RequiredParam[string](request, "operation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := OptionalIntParam(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// apply performs the operation on one issue and returns its URL
+			var apply func(number int) (string, *github.Response, error)
+			edit := func(issueRequest *github.IssueRequest) func(number int) (string, *github.Response, error) {
+				return func(number int) (string, *github.Response, error) {
+					issue, resp, err := client.Issues.Edit(ctx, owner, repo, number, issueRequest)
+					return issue.GetHTMLURL(), resp, err
+				}
+			}
+			switch operation {
+			case "add_labels", "remove_labels":
+				if len(labels) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("labels are required for %s", operation)), nil
+				}
+				apply = func(number int) (string, *github.Response, error) {
+					if operation == "add_labels" {
+						_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+						return "", resp, err
+					}
+					for _, label := range labels {
+						resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+						// Removing a label the issue does not have is not an error of the sweep
+						if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+							return "", resp, err
+						}
+					}
+					return "", nil, nil
+				}
+			case "assign", "unassign":
+				if len(assignees) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("assignees are required for %s", operation)), nil
+				}
+				apply = func(number int) (string, *github.Response, error) {
+					var issue *github.Issue
+					var resp *github.Response
+					var err error
+					if operation == "assign" {
+						issue, resp, err = client.Issues.AddAssignees(ctx, owner, repo, number, assignees)
+					} else {
+						issue, resp, err = client.Issues.RemoveAssignees(ctx, owner, repo, number, assignees)
+					}
+					return issue.GetHTMLURL(), resp, err
+				}
+			case "set_milestone":
+				if milestone == 0 {
+					return mcp.NewToolResultError("milestone is required for set_milestone"), nil
+				}
+				apply = edit(&github.IssueRequest{Milestone: github.Ptr(milestone)})
+			case "close":
+				if stateReason == "" {
+					stateReason = "completed"
+				}
+				apply = edit(&github.IssueRequest{State: github.Ptr("closed"), StateReason: github.Ptr(stateReason)})
+			case "reopen":
+				apply = edit(&github.IssueRequest{State: github.Ptr("open")})
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported operation %q", operation)), nil
+			}
+
+			results := make([]BulkIssueResult, len(issueNumbers))
+			semaphore := make(chan struct{}, bulkUpdateConcurrency)
+			var wg sync.WaitGroup
+			for i, number := range issueNumbers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }()
+
+					url, resp, err := apply(number)
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					results[i] = BulkIssueResult{Number: number, OK: err == nil, URL: url}
+					if err != nil {
+						results[i].Error = err.Error()
+					}
+				}()
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, result := range results {
+				if result.OK {
+					succeeded++
+				}
+			}
+			return MarshalledTextResult(map[string]any{
+				"operation": operation,
+				"succeeded": succeeded,
+				"failed":    len(results) - succeeded,
+				"results":   results,
+			}), nil
+		}
+}
+
 // GetIssueComments creates a tool to get comments for a GitHub issue.
 func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue_comments",
@@ -1511,6 +1761,1005 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// assigneesResult summarizes the assignees of an issue after an assignee change.
+// GitHub silently ignores logins that cannot be assigned, so these are reported back explicitly.
+func assigneesResult(issue *github.Issue, requested []string, adding bool) map[string]any {
+	assignees := make([]string, 0, len(issue.Assignees))
+	current := make(map[string]bool, len(issue.Assignees))
+	for _, u := range issue.Assignees {
+		assignees = append(assignees, u.GetLogin())
+		current[strings.ToLower(u.GetLogin())] = true
+	}
+
+	ignored := []string{}
+	for _, login := range requested {
+		// A login that should have been added but is missing, or should have been removed but is still present
+		if current[strings.ToLower(login)] != adding {
+			ignored = append(ignored, login)
+		}
+	}
+
+	return map[string]any{
+		"number":    issue.GetNumber(),
+		"html_url":  issue.GetHTMLURL(),
+		"assignees": assignees,
+		"ignored":   ignored,
+	}
+}
+
+// AddAssignees creates a tool to add assignees to an issue or pull request without modifying other fields.
+func AddAssignees(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_assignees",
+			mcp.WithDescription(t("TOOL_ADD_ASSIGNEES_DESCRIPTION", "Add assignees to an issue or pull request, keeping existing assignees. Logins that cannot be assigned are returned in 'ignored'.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_ASSIGNEES_USER_TITLE", "Add assignees"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description("Usernames to assign"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("missing required parameter: assignees"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.AddAssignees(ctx, owner, repo, issueNumber, assignees)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add assignees", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(assigneesResult(issue, assignees, true)), nil
+		}
+}
+
+// RemoveAssignees creates a tool to remove assignees from an issue or pull request without modifying other fields.
+func RemoveAssignees(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_assignees",
+			mcp.WithDescription(t("TOOL_REMOVE_ASSIGNEES_DESCRIPTION", "Remove assignees from an issue or pull request, keeping the remaining assignees. Logins that are still assigned afterwards are returned in 'ignored'.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_ASSIGNEES_USER_TITLE", "Remove assignees"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description("Usernames to unassign"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("missing required parameter: assignees"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, issueNumber, assignees)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove assignees", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(assigneesResult(issue, assignees, false)), nil
+		}
+}
+
+// LockIssue creates a tool to lock the conversation of an issue or pull request.
+func LockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("lock_issue",
+			mcp.WithDescription(t("TOOL_LOCK_ISSUE_DESCRIPTION", "Lock the conversation of an issue or pull request, so that only collaborators with push access can comment on it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LOCK_ISSUE_USER_TITLE", "Lock issue conversation"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithString("lock_reason",
+				mcp.Description("Reason for locking the conversation, shown to contributors"),
+				mcp.Enum("off-topic", "too heated", "resolved", "spam"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lockReason, err := OptionalParam[string](request, "lock_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var opts *github.LockIssueOptions
+			if lockReason != "" {
+				opts = &github.LockIssueOptions{LockReason: lockReason}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to lock issue", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"number": issueNumber,
+				"locked": true,
+			}
+			if lockReason != "" {
+				result["lock_reason"] = lockReason
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// UnlockIssue creates a tool to unlock the conversation of an issue or pull request.
+func UnlockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unlock_issue",
+			mcp.WithDescription(t("TOOL_UNLOCK_ISSUE_DESCRIPTION", "Unlock the conversation of an issue or pull request, so that everyone can comment on it again.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNLOCK_ISSUE_USER_TITLE", "Unlock issue conversation"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Unlock(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to unlock issue", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"number": issueNumber,
+				"locked": false,
+			}), nil
+		}
+}
+
+// TransferIssue creates a tool to move an issue to another repository of the same owner.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository of the same owner. The issue gets a new number in the target repository, and the old URL redirects to it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to, owned by the same owner"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create the issue's labels in the target repository if they don't exist there. Otherwise labels missing from the target repository are dropped"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := RequiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			createLabels, err := OptionalParam[bool](request, "create_labels_if_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.EqualFold(targetRepo, repo) {
+				return mcp.NewToolResultError("target_repo must be different from repo"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			issueID, err := getIssueOrPullRequestID(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue", err), nil
+			}
+			repositoryID, err := getRepositoryID(ctx, client, owner, targetRepo)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find target repository", err), nil
+			}
+
+			var mutation struct {
+				TransferIssue struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.String
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}
+			input := githubv4.TransferIssueInput{
+				IssueID:      issueID,
+				RepositoryID: repositoryID,
+			}
+			if createLabels {
+				input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to transfer issue", err), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"repository": fmt.Sprintf("%s/%s", owner, targetRepo),
+				"number":     mutation.TransferIssue.Issue.Number,
+				"url":        mutation.TransferIssue.Issue.URL,
+			}), nil
+		}
+}
+
+// CloseIssueAsDuplicate creates a tool to close an issue as a duplicate of another one.
+func CloseIssueAsDuplicate(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_issue_as_duplicate",
+			mcp.WithDescription(t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_DESCRIPTION", "Close an issue as a duplicate of another issue. The issue is marked as a duplicate of the canonical issue, which links the two on GitHub.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_USER_TITLE", "Close issue as duplicate"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue to close"),
+			),
+			mcp.WithNumber("duplicate_of",
+				mcp.Required(),
+				mcp.Description("Number of the canonical issue"),
+			),
+			mcp.WithString("duplicate_of_repo",
+				mcp.Description("Repository of the canonical issue as 'owner/name', if it is not in the same repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOf, err := RequiredInt(request, "duplicate_of")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOfRepo, err := OptionalParam[string](request, "duplicate_of_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			canonicalOwner, canonicalRepo := owner, repo
+			if duplicateOfRepo != "" {
+				before, after, found := strings.Cut(duplicateOfRepo, "/")
+				if !found || before == "" || after == "" {
+					return mcp.NewToolResultError("duplicate_of_repo must be in the form 'owner/name'"), nil
+				}
+				canonicalOwner, canonicalRepo = before, after
+			}
+			if strings.EqualFold(canonicalOwner, owner) && strings.EqualFold(canonicalRepo, repo) && duplicateOf == issueNumber {
+				return mcp.NewToolResultError("an issue cannot be a duplicate of itself"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			issueID, err := getIssueOrPullRequestID(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue", err), nil
+			}
+			duplicateIssueID, err := getIssueOrPullRequestID(ctx, client, canonicalOwner, canonicalRepo, duplicateOf)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find canonical issue", err), nil
+			}
+
+			var mutation struct {
+				CloseIssue struct {
+					Issue struct {
+						Number      githubv4.Int
+						URL         githubv4.String
+						State       githubv4.String
+						StateReason githubv4.String
+					}
+				} `graphql:"closeIssue(input: $input)"`
+			}
+			stateReason := IssueClosedStateReasonDuplicate
+			if err := client.Mutate(ctx, &mutation, CloseIssueInput{
+				IssueID:          issueID,
+				StateReason:      &stateReason,
+				DuplicateIssueID: &duplicateIssueID,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to close issue", err), nil
+			}
+
+			issue := mutation.CloseIssue.Issue
+			return MarshalledTextResult(map[string]any{
+				"number":       issue.Number,
+				"url":          issue.URL,
+				"state":        issue.State,
+				"state_reason": issue.StateReason,
+				"duplicate_of": fmt.Sprintf("%s/%s#%d", canonicalOwner, canonicalRepo, duplicateOf),
+			}), nil
+		}
+}
+
+// PinIssue creates a tool to pin an issue to the top of a repository's issue list.
+func PinIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("pin_issue",
+			mcp.WithDescription(t("TOOL_PIN_ISSUE_DESCRIPTION", "Pin an issue to the top of the repository's issue list, e.g. to surface an announcement or release tracking issue. A repository can have at most three pinned issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PIN_ISSUE_USER_TITLE", "Pin issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withIssueParams(),
+		),
+		setIssuePinnedHandler(getGQLClient, true)
+}
+
+// UnpinIssue creates a tool to unpin an issue from a repository's issue list.
+func UnpinIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unpin_issue",
+			mcp.WithDescription(t("TOOL_UNPIN_ISSUE_DESCRIPTION", "Unpin an issue from the top of the repository's issue list.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNPIN_ISSUE_USER_TITLE", "Unpin issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withIssueParams(),
+		),
+		setIssuePinnedHandler(getGQLClient, false)
+}
+
+// withIssueParams adds the parameters identifying an issue to a tool definition.
+func withIssueParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithNumber("issue_number",
+			mcp.Required(),
+			mcp.Description("Issue number"),
+		)(tool)
+	}
+}
+
+type pinnedIssueState struct {
+	Number   githubv4.Int
+	Title    githubv4.String
+	URL      githubv4.String
+	IsPinned githubv4.Boolean
+}
+
+func setIssuePinnedHandler(getGQLClient GetGQLClientFn, pinned bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		repo, err := RequiredParam[string](request, "repo")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		issueNumber, err := RequiredInt(request, "issue_number")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+
+		issueID, err := getIssueOrPullRequestID(ctx, client, owner, repo, issueNumber)
+		if err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue", err), nil
+		}
+
+		var issue pinnedIssueState
+		if pinned {
+			var mutation struct {
+				PinIssue struct {
+					Issue pinnedIssueState
+				} `graphql:"pinIssue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.PinIssueInput{IssueID: issueID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to pin issue", err), nil
+			}
+			issue = mutation.PinIssue.Issue
+		} else {
+			var mutation struct {
+				UnpinIssue struct {
+					Issue pinnedIssueState
+				} `graphql:"unpinIssue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.UnpinIssueInput{IssueID: issueID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unpin issue", err), nil
+			}
+			issue = mutation.UnpinIssue.Issue
+		}
+
+		return MarshalledTextResult(map[string]any{
+			"number": issue.Number,
+			"title":  issue.Title,
+			"url":    issue.URL,
+			"pinned": issue.IsPinned,
+		}), nil
+	}
+}
+
+// PinnedIssue is an issue pinned to a repository's issue list.
+type PinnedIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// ListPinnedIssues creates a tool to list the issues pinned to a repository's issue list.
+func ListPinnedIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pinned_issues",
+			mcp.WithDescription(t("TOOL_LIST_PINNED_ISSUES_DESCRIPTION", "List the issues pinned to the top of a repository's issue list, in the order they are shown.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PINNED_ISSUES_USER_TITLE", "List pinned issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query struct {
+				Repository struct {
+					PinnedIssues struct {
+						Nodes []struct {
+							Issue struct {
+								Number githubv4.Int
+								Title  githubv4.String
+								State  githubv4.String
+								URL    githubv4.String
+							}
+						}
+					} `graphql:"pinnedIssues(first: 3)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := client.Query(ctx, &query, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list pinned issues", err), nil
+			}
+
+			issues := make([]PinnedIssue, 0, len(query.Repository.PinnedIssues.Nodes))
+			for _, node := range query.Repository.PinnedIssues.Nodes {
+				issues = append(issues, PinnedIssue{
+					Number: int(node.Issue.Number),
+					Title:  string(node.Issue.Title),
+					State:  strings.ToLower(string(node.Issue.State)),
+					URL:    string(node.Issue.URL),
+				})
+			}
+			return MarshalledTextResult(issues), nil
+		}
+}
+
+// ListAssignableUsers creates a tool to list the users that can be assigned to issues in a repository.
+func ListAssignableUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_assignable_users",
+			mcp.WithDescription(t("TOOL_LIST_ASSIGNABLE_USERS_DESCRIPTION", "List the users that can be assigned to issues and pull requests in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ASSIGNABLE_USERS_USER_TITLE", "List assignable users"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			users, resp, err := client.Issues.ListAssignees(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list assignable users", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalUsers(users)), nil
+		}
+}
+
+// MinimalTimelineSource is the issue or pull request that referenced another issue.
+type MinimalTimelineSource struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	HTMLURL       string `json:"html_url"`
+	State         string `json:"state,omitempty"`
+	IsPullRequest bool   `json:"is_pull_request"`
+	Repository    string `json:"repository,omitempty"`
+}
+
+// MinimalTimelineEvent is the trimmed output type for issue timeline events.
+type MinimalTimelineEvent struct {
+	Event         string                 `json:"event"`
+	Actor         string                 `json:"actor,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+	Label         string                 `json:"label,omitempty"`
+	Assignee      string                 `json:"assignee,omitempty"`
+	Milestone     string                 `json:"milestone,omitempty"`
+	RenamedFrom   string                 `json:"renamed_from,omitempty"`
+	RenamedTo     string                 `json:"renamed_to,omitempty"`
+	Reviewer      string                 `json:"requested_reviewer,omitempty"`
+	RequestedTeam string                 `json:"requested_team,omitempty"`
+	ReviewState   string                 `json:"review_state,omitempty"`
+	CommitID      string                 `json:"commit_id,omitempty"`
+	Source        *MinimalTimelineSource `json:"source,omitempty"`
+}
+
+func convertToMinimalTimelineEvent(e *github.Timeline) MinimalTimelineEvent {
+	event := MinimalTimelineEvent{
+		Event:         e.GetEvent(),
+		Actor:         e.GetActor().GetLogin(),
+		Label:         e.GetLabel().GetName(),
+		Assignee:      e.GetAssignee().GetLogin(),
+		Milestone:     e.GetMilestone().GetTitle(),
+		RenamedFrom:   e.GetRename().GetFrom(),
+		RenamedTo:     e.GetRename().GetTo(),
+		Reviewer:      e.GetReviewer().GetLogin(),
+		RequestedTeam: e.GetRequestedTeam().GetSlug(),
+		ReviewState:   e.GetState(),
+		CommitID:      e.GetCommitID(),
+	}
+	if event.Actor == "" {
+		// Comments and reviews report their author as the user rather than the actor
+		event.Actor = e.GetUser().GetLogin()
+	}
+	if e.CreatedAt != nil {
+		event.CreatedAt = e.GetCreatedAt().Format("2006-01-02T15:04:05Z")
+	} else if e.SubmittedAt != nil {
+		event.CreatedAt = e.GetSubmittedAt().Format("2006-01-02T15:04:05Z")
+	}
+	if issue := e.GetSource().GetIssue(); issue != nil {
+		event.Source = &MinimalTimelineSource{
+			Number:        issue.GetNumber(),
+			Title:         issue.GetTitle(),
+			HTMLURL:       issue.GetHTMLURL(),
+			State:         issue.GetState(),
+			IsPullRequest: issue.IsPullRequest(),
+			Repository:    issue.GetRepository().GetFullName(),
+		}
+	}
+	return event
+}
+
+// GetIssueTimeline creates a tool to get the timeline of events for an issue or pull request.
+func GetIssueTimeline(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of an issue or pull request: cross-references from other issues and pull requests, label changes, assignments, review requests, renames, closings and more. Useful for reconstructing the history of an issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Description("Only return these event types, e.g. 'cross-referenced', 'labeled', 'unlabeled', 'assigned', 'review_requested', 'closed', 'commented'"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			eventTypes, err := OptionalStringArrayParam(request, "event_types")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			timeline, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue timeline", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			wanted := make(map[string]bool, len(eventTypes))
+			for _, eventType := range eventTypes {
+				wanted[eventType] = true
+			}
+
+			events := make([]MinimalTimelineEvent, 0, len(timeline))
+			for _, e := range timeline {
+				if len(wanted) > 0 && !wanted[e.GetEvent()] {
+					continue
+				}
+				events = append(events, convertToMinimalTimelineEvent(e))
+			}
+
+			return MarshalledTextResult(events), nil
+		}
+}
+
+// LinkedItem is a pull request or issue linked to another via closing references.
+type LinkedItem struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	State      string `json:"state"`
+	Merged     bool   `json:"merged,omitempty"`
+	Repository string `json:"repository"`
+}
+
+// LinkedItemsResult is the output of get_linked_items.
+type LinkedItemsResult struct {
+	Number int          `json:"number"`
+	Type   string       `json:"type"`
+	Items  []LinkedItem `json:"linked_items"`
+}
+
+type linkedPullRequestNode struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	URL        githubv4.String
+	State      githubv4.String
+	Merged     githubv4.Boolean
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+type linkedIssueNode struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	URL        githubv4.String
+	State      githubv4.String
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+// LinkedItemsQuery resolves closing references in both directions: the pull
+// requests that close an issue, or the issues closed by a pull request.
+type LinkedItemsQuery struct {
+	Repository struct {
+		IssueOrPullRequest struct {
+			Typename githubv4.String `graphql:"__typename"`
+			Issue    struct {
+				ClosedByPullRequestsReferences struct {
+					Nodes []linkedPullRequestNode
+				} `graphql:"closedByPullRequestsReferences(first: 25, includeClosedPrs: true)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ClosingIssuesReferences struct {
+					Nodes []linkedIssueNode
+				} `graphql:"closingIssuesReferences(first: 25)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// GetLinkedItems creates a tool to find the pull requests that close an issue, or the issues a pull request closes.
+func GetLinkedItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_linked_items",
+			mcp.WithDescription(t("TOOL_GET_LINKED_ITEMS_DESCRIPTION", "Get the items linked to an issue or pull request through closing references. For an issue, returns the pull requests that close (or closed) it. For a pull request, returns the issues it will close when merged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LINKED_ITEMS_USER_TITLE", "Get linked issues and pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query LinkedItemsQuery
+			vars := map[string]any{
+				"owner":  githubv4.String(owner),
+				"repo":   githubv4.String(repo),
+				"number": githubv4.Int(number), // #nosec G115 - issue numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked items", err), nil
+			}
+
+			node := query.Repository.IssueOrPullRequest
+			result := LinkedItemsResult{
+				Number: number,
+				Items:  []LinkedItem{},
+			}
+			switch node.Typename {
+			case "Issue":
+				result.Type = "issue"
+				for _, pr := range node.Issue.ClosedByPullRequestsReferences.Nodes {
+					result.Items = append(result.Items, LinkedItem{
+						Number:     int(pr.Number),
+						Title:      string(pr.Title),
+						URL:        string(pr.URL),
+						State:      string(pr.State),
+						Merged:     bool(pr.Merged),
+						Repository: string(pr.Repository.NameWithOwner),
+					})
+				}
+			case "PullRequest":
+				result.Type = "pull_request"
+				for _, issue := range node.PullRequest.ClosingIssuesReferences.Nodes {
+					result.Items = append(result.Items, LinkedItem{
+						Number:     int(issue.Number),
+						Title:      string(issue.Title),
+						URL:        string(issue.URL),
+						State:      string(issue.State),
+						Repository: string(issue.Repository.NameWithOwner),
+					})
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("no issue or pull request #%d found in %s/%s", number, owner, repo)), nil
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // mvpDescription is an MVP idea for generating tool descriptions from structured data in a shared format.
 // It is not intended for widespread usage and is not a complete implementation.
 type mvpDescription struct {
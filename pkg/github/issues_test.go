@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -722,6 +723,167 @@ func Test_CreateIssue(t *testing.T) {
 	}
 }
 
+func Test_CreateIssueFromTemplate(t *testing.T) {
+	templatesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/contents/.github/ISSUE_TEMPLATE") {
+			mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+				{Type: github.Ptr("file"), Name: github.Ptr("bug.yml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/bug.yml")},
+				{Type: github.Ptr("file"), Name: github.Ptr("feature.md"), Path: github.Ptr(".github/ISSUE_TEMPLATE/feature.md")},
+			})(w, r)
+			return
+		}
+		content := testBugReportForm
+		if strings.HasSuffix(r.URL.Path, "feature.md") {
+			content = testFeatureRequestTemplate
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		})(w, r)
+	})
+	mockIssue := &github.Issue{
+		ID:      github.Ptr(int64(1)),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/1"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "issue form",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, templatesHandler),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title": "[Bug]: Crash on start",
+						"body": "### What happened?\n\nIt crashed\n\n" +
+							"### Version\n\n_No response_\n\n" +
+							"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct",
+						"labels":    []any{"regression", "bug", "triage"},
+						"assignees": []any{"octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockIssue),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "Bug report",
+				"labels":   []any{"regression", "bug"},
+				"fields": map[string]any{
+					"what-happened": "It crashed",
+					"terms":         []any{"I agree to follow this project's Code of Conduct"},
+				},
+			},
+		},
+		{
+			name: "markdown template by file name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, templatesHandler),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":     "[Feature] Dark mode",
+						"body":      "## Problem\n\nDescribe the problem.",
+						"labels":    []any{"enhancement", "needs-triage"},
+						"assignees": []any{},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockIssue),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "[Feature] Dark mode",
+				"template": "feature",
+			},
+		},
+		{
+			name: "missing required answers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, templatesHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "bug.yml",
+				"fields":   map[string]any{"what-happened": "It crashed"},
+			},
+			expectError:    true,
+			expectedErrMsg: "Code of Conduct: option \"I agree to follow this project's Code of Conduct\" must be checked",
+		},
+		{
+			name: "body for an issue form",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, templatesHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "Bug report",
+				"body":     "It crashed",
+			},
+			expectError:    true,
+			expectedErrMsg: "Bug report is an issue form, answer its fields instead of passing a body",
+		},
+		{
+			name: "unknown template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, templatesHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "question",
+			},
+			expectError:    true,
+			expectedErrMsg: "issue template question not found in owner/repo",
+		},
+		{
+			name:         "fields without template",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "Crash on start",
+				"fields": map[string]any{"what-happened": "It crashed"},
+			},
+			expectError:    true,
+			expectedErrMsg: "fields can only be answered when creating the issue from a template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "https://github.com/owner/repo/issues/1", returned.URL)
+		})
+	}
+}
+
 func Test_ListIssues(t *testing.T) {
 	// Verify tool definition
 	mockClient := githubv4.NewClient(nil)
@@ -1001,14 +1163,8 @@ func Test_ListIssues(t *testing.T) {
 
 			// Parse the structured response with pagination info
 			var response struct {
-				Issues   []*github.Issue `json:"issues"`
-				PageInfo struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
+				Issues     []*github.Issue `json:"issues"`
+				Pagination Pagination      `json:"pagination"`
 			}
 			err = json.Unmarshal([]byte(text), &response)
 			require.NoError(t, err)
@@ -1511,6 +1667,174 @@ func Test_UpdateIssue(t *testing.T) {
 	}
 }
 
+func Test_BulkUpdateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "bulk_update_issues", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers", "operation"})
+
+	// Issue 3 does not exist, every other issue can be updated
+	issueHandler := func(t *testing.T, expectedBody map[string]any, response any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/issues/3") {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			if expectedBody != nil {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, expectedBody, body)
+			}
+			mockResponse(t, http.StatusOK, response)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedResults []BulkIssueResult
+	}{
+		{
+			name: "close issues as not planned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					issueHandler(t, map[string]any{"state": "closed", "state_reason": "not_planned"}, &github.Issue{HTMLURL: github.Ptr("https://github.com/owner/repo/issues/1")}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(3), float64(1), float64(1)},
+				"operation":     "close",
+				"state_reason":  "not_planned",
+			},
+			expectedResults: []BulkIssueResult{
+				{Number: 1, OK: true, URL: "https://github.com/owner/repo/issues/1"},
+				{Number: 3, OK: false},
+			},
+		},
+		{
+			name: "add labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					issueHandler(t, nil, []*github.Label{{Name: github.Ptr("triaged")}}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"operation":     "add_labels",
+				"labels":        []any{"triaged"},
+			},
+			expectedResults: []BulkIssueResult{
+				{Number: 1, OK: true},
+				{Number: 2, OK: true},
+			},
+		},
+		{
+			name: "remove labels ignores labels the issue lacks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.HasSuffix(r.URL.Path, "/needs-triage") {
+							w.WriteHeader(http.StatusNotFound)
+							_, _ = w.Write([]byte(`{"message": "Label does not exist"}`))
+							return
+						}
+						mockResponse(t, http.StatusOK, []*github.Label{})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+				"operation":     "remove_labels",
+				"labels":        []any{"needs-triage", "stale"},
+			},
+			expectedResults: []BulkIssueResult{
+				{Number: 1, OK: true},
+			},
+		},
+		{
+			name:         "missing labels",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+				"operation":     "add_labels",
+			},
+			expectError:    true,
+			expectedErrMsg: "labels are required for add_labels",
+		},
+		{
+			name:         "too many issues",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: func() map[string]any {
+				numbers := make([]any, maxBulkIssues+1)
+				for i := range numbers {
+					numbers[i] = float64(i + 1)
+				}
+				return map[string]any{
+					"owner":         "owner",
+					"repo":          "repo",
+					"issue_numbers": numbers,
+					"operation":     "reopen",
+				}
+			}(),
+			expectError:    true,
+			expectedErrMsg: "at most 100 issues can be updated at once",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				Succeeded int               `json:"succeeded"`
+				Failed    int               `json:"failed"`
+				Results   []BulkIssueResult `json:"results"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned.Results, len(tc.expectedResults))
+			failed := 0
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.Number, returned.Results[i].Number)
+				assert.Equal(t, expected.OK, returned.Results[i].OK)
+				assert.Equal(t, expected.URL, returned.Results[i].URL)
+				if !expected.OK {
+					failed++
+					assert.Contains(t, returned.Results[i].Error, "404")
+				}
+			}
+			assert.Equal(t, failed, returned.Failed)
+			assert.Equal(t, len(tc.expectedResults)-failed, returned.Succeeded)
+		})
+	}
+}
+
 func Test_ParseISOTimestamp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -3224,3 +3548,976 @@ func Test_ListIssueTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_AddAssignees(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := AddAssignees(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_assignees", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+		Assignees: []*github.User{
+			{Login: github.Ptr("existing")},
+			{Login: github.Ptr("alice")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedResult  []string
+		expectedIgnored []string
+	}{
+		{
+			name: "adds assignees and reports ignored logins",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"assignees": []any{"alice", "not-a-collaborator"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockIssue),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{"alice", "not-a-collaborator"},
+			},
+			expectedResult:  []string{"existing", "alice"},
+			expectedIgnored: []string{"not-a-collaborator"},
+		},
+		{
+			name:         "empty assignees",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: assignees",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"assignees":    []any{"alice"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add assignees",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddAssignees(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Number    int      `json:"number"`
+				Assignees []string `json:"assignees"`
+				Ignored   []string `json:"ignored"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 42, response.Number)
+			assert.Equal(t, tc.expectedResult, response.Assignees)
+			assert.Equal(t, tc.expectedIgnored, response.Ignored)
+		})
+	}
+}
+
+func Test_RemoveAssignees(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveAssignees(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_assignees", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+			expectRequestBody(t, map[string]any{
+				"assignees": []any{"alice"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Issue{
+					Number:    github.Ptr(42),
+					Assignees: []*github.User{{Login: github.Ptr("bob")}},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := RemoveAssignees(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"assignees":    []any{"alice"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		Assignees []string `json:"assignees"`
+		Ignored   []string `json:"ignored"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, []string{"bob"}, response.Assignees)
+	assert.Empty(t, response.Ignored)
+}
+
+func Test_LockIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := LockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "lock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "lock_reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "lock with reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"lock_reason": "too heated",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"lock_reason":  "too heated",
+			},
+			expectedResponse: map[string]any{"number": float64(42), "locked": true, "lock_reason": "too heated"},
+		},
+		{
+			name: "lock fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to lock issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := LockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_UnlockIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := UnlockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unlock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := UnlockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, map[string]any{"number": float64(42), "locked": false}, response)
+}
+
+func issueOrPullRequestIDMatcher(owner, repo string, number int, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				IssueOrPullRequest struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"... on Issue"`
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"... on PullRequest"`
+				} `graphql:"issueOrPullRequest(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":  githubv4.String(owner),
+			"repo":   githubv4.String(repo),
+			"number": githubv4.Int(number),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issueOrPullRequest": map[string]any{"id": id},
+			},
+		}),
+	)
+}
+
+func Test_TransferIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := TransferIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "create_labels_if_missing")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+
+	repositoryMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("other-repo"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"id": "R_other"},
+		}),
+	)
+	transferMutation := struct {
+		TransferIssue struct {
+			Issue struct {
+				Number githubv4.Int
+				URL    githubv4.String
+			}
+		} `graphql:"transferIssue(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name             string
+		matchers         []githubv4mock.Matcher
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "transfer creating missing labels",
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				repositoryMatcher,
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:               "I_42",
+						RepositoryID:          "R_other",
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"transferIssue": map[string]any{
+							"issue": map[string]any{"number": 7, "url": "https://github.com/owner/other-repo/issues/7"},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(42),
+				"target_repo":              "other-repo",
+				"create_labels_if_missing": true,
+			},
+			expectedResponse: map[string]any{
+				"repository": "owner/other-repo",
+				"number":     float64(7),
+				"url":        "https://github.com/owner/other-repo/issues/7",
+			},
+		},
+		{
+			name: "transfer fails",
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				repositoryMatcher,
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:      "I_42",
+						RepositoryID: "R_other",
+					},
+					nil,
+					githubv4mock.ErrorResponse("Issues can only be transferred between repositories with the same owner"),
+				),
+			},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer issue",
+		},
+		{
+			name: "same repository",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "Repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "target_repo must be different from repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			_, handler := TransferIssue(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_CloseIssueAsDuplicate(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CloseIssueAsDuplicate(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_issue_as_duplicate", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of_repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "duplicate_of"})
+
+	closeMatcher := func(duplicateIssueID githubv4.ID) githubv4mock.Matcher {
+		stateReason := IssueClosedStateReasonDuplicate
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CloseIssue struct {
+					Issue struct {
+						Number      githubv4.Int
+						URL         githubv4.String
+						State       githubv4.String
+						StateReason githubv4.String
+					}
+				} `graphql:"closeIssue(input: $input)"`
+			}{},
+			CloseIssueInput{
+				IssueID:          "I_42",
+				StateReason:      &stateReason,
+				DuplicateIssueID: &duplicateIssueID,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"closeIssue": map[string]any{
+					"issue": map[string]any{
+						"number":      42,
+						"url":         "https://github.com/owner/repo/issues/42",
+						"state":       "CLOSED",
+						"stateReason": "DUPLICATE",
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name             string
+		matchers         []githubv4mock.Matcher
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "duplicate in the same repository",
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				issueOrPullRequestIDMatcher("owner", "repo", 10, "I_10"),
+				closeMatcher("I_10"),
+			},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"duplicate_of": float64(10),
+			},
+			expectedResponse: map[string]any{
+				"number":       float64(42),
+				"url":          "https://github.com/owner/repo/issues/42",
+				"state":        "CLOSED",
+				"state_reason": "DUPLICATE",
+				"duplicate_of": "owner/repo#10",
+			},
+		},
+		{
+			name: "duplicate in another repository",
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				issueOrPullRequestIDMatcher("other-owner", "other-repo", 42, "I_other"),
+				closeMatcher("I_other"),
+			},
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(42),
+				"duplicate_of":      float64(42),
+				"duplicate_of_repo": "other-owner/other-repo",
+			},
+			expectedResponse: map[string]any{
+				"number":       float64(42),
+				"url":          "https://github.com/owner/repo/issues/42",
+				"state":        "CLOSED",
+				"state_reason": "DUPLICATE",
+				"duplicate_of": "other-owner/other-repo#42",
+			},
+		},
+		{
+			name: "duplicate of itself",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"duplicate_of": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "an issue cannot be a duplicate of itself",
+		},
+		{
+			name: "invalid canonical repository",
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(42),
+				"duplicate_of":      float64(10),
+				"duplicate_of_repo": "other-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "duplicate_of_repo must be in the form 'owner/name'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			_, handler := CloseIssueAsDuplicate(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_PinIssue(t *testing.T) {
+	// Verify tool definitions
+	mockClient := githubv4.NewClient(nil)
+	pinTool, _ := PinIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(pinTool.Name, pinTool))
+	unpinTool, _ := UnpinIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unpinTool.Name, unpinTool))
+
+	assert.Equal(t, "pin_issue", pinTool.Name)
+	assert.Equal(t, "unpin_issue", unpinTool.Name)
+	assert.ElementsMatch(t, pinTool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.ElementsMatch(t, unpinTool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	args := map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}
+
+	t.Run("pin", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					PinIssue struct {
+						Issue pinnedIssueState
+					} `graphql:"pinIssue(input: $input)"`
+				}{},
+				githubv4.PinIssueInput{IssueID: "I_42"},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"pinIssue": map[string]any{
+						"issue": map[string]any{
+							"number":   42,
+							"title":    "Release 1.0 tracking",
+							"url":      "https://github.com/owner/repo/issues/42",
+							"isPinned": true,
+						},
+					},
+				}),
+			),
+		)
+		_, handler := PinIssue(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, map[string]any{
+			"number": float64(42),
+			"title":  "Release 1.0 tracking",
+			"url":    "https://github.com/owner/repo/issues/42",
+			"pinned": true,
+		}, response)
+	})
+
+	t.Run("unpin fails", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					UnpinIssue struct {
+						Issue pinnedIssueState
+					} `graphql:"unpinIssue(input: $input)"`
+				}{},
+				githubv4.UnpinIssueInput{IssueID: "I_42"},
+				nil,
+				githubv4mock.ErrorResponse("Resource not accessible by integration"),
+			),
+		)
+		_, handler := UnpinIssue(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to unpin issue")
+	})
+}
+
+func Test_ListPinnedIssues(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListPinnedIssues(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_pinned_issues", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					PinnedIssues struct {
+						Nodes []struct {
+							Issue struct {
+								Number githubv4.Int
+								Title  githubv4.String
+								State  githubv4.String
+								URL    githubv4.String
+							}
+						}
+					} `graphql:"pinnedIssues(first: 3)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pinnedIssues": map[string]any{
+						"nodes": []any{
+							map[string]any{"issue": map[string]any{"number": 42, "title": "Release 1.0 tracking", "state": "OPEN", "url": "https://github.com/owner/repo/issues/42"}},
+							map[string]any{"issue": map[string]any{"number": 7, "title": "Roadmap", "state": "CLOSED", "url": "https://github.com/owner/repo/issues/7"}},
+						},
+					},
+				},
+			}),
+		),
+	)
+	_, handler := ListPinnedIssues(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	var issues []PinnedIssue
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issues))
+	assert.Equal(t, []PinnedIssue{
+		{Number: 42, Title: "Release 1.0 tracking", State: "open", URL: "https://github.com/owner/repo/issues/42"},
+		{Number: 7, Title: "Roadmap", State: "closed", URL: "https://github.com/owner/repo/issues/7"},
+	}, issues)
+}
+
+func Test_ListAssignableUsers(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAssignableUsers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_assignable_users", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposAssigneesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "2",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("alice"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/alice")},
+					{Login: github.Ptr("bob"), ID: github.Ptr(int64(2))},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListAssignableUsers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var users []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &users))
+	require.Len(t, users, 2)
+	assert.Equal(t, MinimalUser{Login: "alice", ID: 1, ProfileURL: "https://github.com/alice"}, users[0])
+	assert.Equal(t, "bob", users[1].Login)
+}
+
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	createdAt := github.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	timeline := []*github.Timeline{
+		{
+			Event:     github.Ptr("labeled"),
+			Actor:     &github.User{Login: github.Ptr("alice")},
+			CreatedAt: &createdAt,
+			Label:     &github.Label{Name: github.Ptr("bug")},
+		},
+		{
+			Event:     github.Ptr("cross-referenced"),
+			Actor:     &github.User{Login: github.Ptr("bob")},
+			CreatedAt: &createdAt,
+			Source: &github.Source{
+				Type: github.Ptr("issue"),
+				Issue: &github.Issue{
+					Number:           github.Ptr(7),
+					Title:            github.Ptr("Fix the bug"),
+					HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/7"),
+					State:            github.Ptr("open"),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/7")},
+					Repository:       &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			},
+		},
+		{
+			Event:     github.Ptr("review_requested"),
+			Actor:     &github.User{Login: github.Ptr("alice")},
+			CreatedAt: &createdAt,
+			Reviewer:  &github.User{Login: github.Ptr("carol")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedEvents []MinimalTimelineEvent
+	}{
+		{
+			name: "all events",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedEvents: []MinimalTimelineEvent{
+				{Event: "labeled", Actor: "alice", CreatedAt: "2024-03-01T12:00:00Z", Label: "bug"},
+				{
+					Event:     "cross-referenced",
+					Actor:     "bob",
+					CreatedAt: "2024-03-01T12:00:00Z",
+					Source: &MinimalTimelineSource{
+						Number:        7,
+						Title:         "Fix the bug",
+						HTMLURL:       "https://github.com/owner/repo/pull/7",
+						State:         "open",
+						IsPullRequest: true,
+						Repository:    "owner/repo",
+					},
+				},
+				{Event: "review_requested", Actor: "alice", CreatedAt: "2024-03-01T12:00:00Z", Reviewer: "carol"},
+			},
+		},
+		{
+			name: "filtered by event type",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"event_types":  []any{"labeled", "review_requested"},
+			},
+			expectedEvents: []MinimalTimelineEvent{
+				{Event: "labeled", Actor: "alice", CreatedAt: "2024-03-01T12:00:00Z", Label: "bug"},
+				{Event: "review_requested", Actor: "alice", CreatedAt: "2024-03-01T12:00:00Z", Reviewer: "carol"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					timeline,
+				),
+			)
+
+			client := github.NewClient(mockedClient)
+			_, handler := GetIssueTimeline(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var events []MinimalTimelineEvent
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &events))
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
+
+func Test_GetLinkedItems(t *testing.T) {
+	// Verify tool definition
+	tool, _ := GetLinkedItems(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_linked_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "number"})
+
+	vars := map[string]any{
+		"owner":  githubv4.String("owner"),
+		"repo":   githubv4.String("repo"),
+		"number": githubv4.Int(42),
+	}
+
+	tests := []struct {
+		name           string
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedErrMsg string
+		expectedResult LinkedItemsResult
+	}{
+		{
+			name: "issue closed by pull requests",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issueOrPullRequest": map[string]any{
+						"__typename": "Issue",
+						"closedByPullRequestsReferences": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"number":     7,
+									"title":      "Fix the bug",
+									"url":        "https://github.com/owner/repo/pull/7",
+									"state":      "MERGED",
+									"merged":     true,
+									"repository": map[string]any{"nameWithOwner": "owner/repo"},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedResult: LinkedItemsResult{
+				Number: 42,
+				Type:   "issue",
+				Items: []LinkedItem{
+					{Number: 7, Title: "Fix the bug", URL: "https://github.com/owner/repo/pull/7", State: "MERGED", Merged: true, Repository: "owner/repo"},
+				},
+			},
+		},
+		{
+			name: "pull request closing issues",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issueOrPullRequest": map[string]any{
+						"__typename": "PullRequest",
+						"closingIssuesReferences": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"number":     3,
+									"title":      "Something is broken",
+									"url":        "https://github.com/other/repo/issues/3",
+									"state":      "OPEN",
+									"repository": map[string]any{"nameWithOwner": "other/repo"},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedResult: LinkedItemsResult{
+				Number: 42,
+				Type:   "pull_request",
+				Items: []LinkedItem{
+					{Number: 3, Title: "Something is broken", URL: "https://github.com/other/repo/issues/3", State: "OPEN", Repository: "other/repo"},
+				},
+			},
+		},
+		{
+			name:           "query fails",
+			response:       githubv4mock.ErrorResponse("Could not resolve to an issue or pull request with the number of 42."),
+			expectError:    true,
+			expectedErrMsg: "failed to get linked items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(LinkedItemsQuery{}, vars, tc.response),
+			)
+			_, handler := GetLinkedItems(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"number": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var linked LinkedItemsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &linked))
+			assert.Equal(t, tc.expectedResult, linked)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// MergeQueueQuery gets the merge queue of a branch together with its configuration.
+type MergeQueueQuery struct {
+	Repository struct {
+		MergeQueue *struct {
+			URL           githubv4.String
+			Configuration *struct {
+				CheckResponseTimeout          githubv4.Int
+				MaximumEntriesToBuild         githubv4.Int
+				MaximumEntriesToMerge         githubv4.Int
+				MinimumEntriesToMerge         githubv4.Int
+				MinimumEntriesToMergeWaitTime githubv4.Int
+				MergeMethod                   githubv4.String
+				MergingStrategy               githubv4.String
+			}
+			Entries struct {
+				TotalCount githubv4.Int
+			}
+		} `graphql:"mergeQueue(branch: $branch)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// MergeQueueEntriesQuery lists the entries of a branch's merge queue in queue order.
+type MergeQueueEntriesQuery struct {
+	Repository struct {
+		MergeQueue *struct {
+			Entries struct {
+				TotalCount githubv4.Int
+				PageInfo   PageInfoFragment
+				Nodes      []struct {
+					Position             githubv4.Int
+					State                githubv4.String
+					EnqueuedAt           githubv4.DateTime
+					EstimatedTimeToMerge *githubv4.Int
+					Jump                 githubv4.Boolean
+					Solo                 githubv4.Boolean
+					HeadCommit           *struct {
+						OID githubv4.GitObjectID `graphql:"oid"`
+					}
+					Enqueuer struct {
+						Login githubv4.String
+					}
+					PullRequest *struct {
+						Number githubv4.Int
+						Title  githubv4.String
+						URL    githubv4.String
+					}
+				}
+			} `graphql:"entries(first: $first, after: $after)"`
+		} `graphql:"mergeQueue(branch: $branch)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// MergeQueueConfiguration is the configuration of a merge queue. Durations are in seconds.
+type MergeQueueConfiguration struct {
+	CheckResponseTimeout          int    `json:"check_response_timeout"`
+	MaximumEntriesToBuild         int    `json:"maximum_entries_to_build"`
+	MaximumEntriesToMerge         int    `json:"maximum_entries_to_merge"`
+	MinimumEntriesToMerge         int    `json:"minimum_entries_to_merge"`
+	MinimumEntriesToMergeWaitTime int    `json:"minimum_entries_to_merge_wait_time"`
+	MergeMethod                   string `json:"merge_method"`
+	MergingStrategy               string `json:"merging_strategy"`
+}
+
+// MergeQueueEntry is a pull request waiting in a merge queue.
+type MergeQueueEntry struct {
+	Position int    `json:"position"`
+	State    string `json:"state"`
+	// EstimatedTimeToMerge is in seconds and is omitted when GitHub cannot estimate it.
+	EstimatedTimeToMerge *int      `json:"estimated_time_to_merge,omitempty"`
+	EnqueuedAt           time.Time `json:"enqueued_at"`
+	EnqueuedBy           string    `json:"enqueued_by,omitempty"`
+	Jump                 bool      `json:"jump,omitempty"`
+	Solo                 bool      `json:"solo,omitempty"`
+	HeadSHA              string    `json:"head_sha,omitempty"`
+	PullRequestNumber    int       `json:"pull_request_number,omitempty"`
+	PullRequestTitle     string    `json:"pull_request_title,omitempty"`
+	PullRequestURL       string    `json:"pull_request_url,omitempty"`
+}
+
+func mergeQueueBranchVar(branch string) any {
+	if branch == "" {
+		return (*githubv4.String)(nil)
+	}
+	return githubv4.String(branch)
+}
+
+func noMergeQueueResult(owner, repo, branch string) *mcp.CallToolResult {
+	if branch == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("the default branch of %s/%s does not use a merge queue", owner, repo))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s does not use a merge queue", branch, owner, repo))
+}
+
+// GetMergeQueue creates a tool to get the merge queue configuration of a branch.
+func GetMergeQueue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_queue",
+			mcp.WithDescription(t("TOOL_GET_MERGE_QUEUE_DESCRIPTION", "Get the merge queue of a branch: its configuration (merge method, merging strategy, batch sizes and timeouts) and the number of pull requests currently queued. Use list_merge_queue_entries to see the queued pull requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_QUEUE_USER_TITLE", "Get merge queue"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch the merge queue belongs to. Defaults to the repository's default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query MergeQueueQuery
+			vars := map[string]any{
+				"owner":  githubv4.String(owner),
+				"repo":   githubv4.String(repo),
+				"branch": mergeQueueBranchVar(branch),
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get merge queue", err), nil
+			}
+
+			queue := query.Repository.MergeQueue
+			if queue == nil {
+				return noMergeQueueResult(owner, repo, branch), nil
+			}
+
+			result := map[string]any{
+				"url":           string(queue.URL),
+				"entries_count": int(queue.Entries.TotalCount),
+			}
+			if cfg := queue.Configuration; cfg != nil {
+				result["configuration"] = MergeQueueConfiguration{
+					CheckResponseTimeout:          int(cfg.CheckResponseTimeout),
+					MaximumEntriesToBuild:         int(cfg.MaximumEntriesToBuild),
+					MaximumEntriesToMerge:         int(cfg.MaximumEntriesToMerge),
+					MinimumEntriesToMerge:         int(cfg.MinimumEntriesToMerge),
+					MinimumEntriesToMergeWaitTime: int(cfg.MinimumEntriesToMergeWaitTime),
+					MergeMethod:                   string(cfg.MergeMethod),
+					MergingStrategy:               string(cfg.MergingStrategy),
+				}
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListMergeQueueEntries creates a tool to list the pull requests in the merge queue of a branch.
+func ListMergeQueueEntries(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_merge_queue_entries",
+			mcp.WithDescription(t("TOOL_LIST_MERGE_QUEUE_ENTRIES_DESCRIPTION", "List the pull requests in the merge queue of a branch in queue order, with each entry's position, state and estimated time to merge in seconds. Use pullNumber to find the position of a single pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MERGE_QUEUE_ENTRIES_USER_TITLE", "List merge queue entries"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch the merge queue belongs to. Defaults to the repository's default branch"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Description("Only return the entry of this pull request"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query MergeQueueEntriesQuery
+			vars := map[string]any{
+				"owner":  githubv4.String(owner),
+				"repo":   githubv4.String(repo),
+				"branch": mergeQueueBranchVar(branch),
+			}
+			paginationParams.Vars(vars)
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list merge queue entries", err), nil
+			}
+
+			queue := query.Repository.MergeQueue
+			if queue == nil {
+				return noMergeQueueResult(owner, repo, branch), nil
+			}
+
+			entries := []MergeQueueEntry{}
+			for _, node := range queue.Entries.Nodes {
+				entry := MergeQueueEntry{
+					Position:   int(node.Position),
+					State:      string(node.State),
+					EnqueuedAt: node.EnqueuedAt.Time,
+					EnqueuedBy: string(node.Enqueuer.Login),
+					Jump:       bool(node.Jump),
+					Solo:       bool(node.Solo),
+				}
+				if node.EstimatedTimeToMerge != nil {
+					estimate := int(*node.EstimatedTimeToMerge)
+					entry.EstimatedTimeToMerge = &estimate
+				}
+				if node.HeadCommit != nil {
+					entry.HeadSHA = string(node.HeadCommit.OID)
+				}
+				if node.PullRequest != nil {
+					entry.PullRequestNumber = int(node.PullRequest.Number)
+					entry.PullRequestTitle = string(node.PullRequest.Title)
+					entry.PullRequestURL = string(node.PullRequest.URL)
+				}
+				if pullNumber != 0 && entry.PullRequestNumber != pullNumber {
+					continue
+				}
+				entries = append(entries, entry)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"entries":    entries,
+				"pagination": queue.Entries.PageInfo.Pagination(int(queue.Entries.TotalCount)),
+			}), nil
+		}
+}
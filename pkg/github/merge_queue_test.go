@@ -0,0 +1,261 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMergeQueue(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetMergeQueue(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_merge_queue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "get merge queue of a branch",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					MergeQueueQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"repo":   githubv4.String("repo"),
+						"branch": githubv4.String("main"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"mergeQueue": map[string]any{
+								"url": "https://github.com/owner/repo/queue/main",
+								"configuration": map[string]any{
+									"checkResponseTimeout":          3600,
+									"maximumEntriesToBuild":         5,
+									"maximumEntriesToMerge":         5,
+									"minimumEntriesToMerge":         1,
+									"minimumEntriesToMergeWaitTime": 300,
+									"mergeMethod":                   "SQUASH",
+									"mergingStrategy":               "ALLGREEN",
+								},
+								"entries": map[string]any{
+									"totalCount": 3,
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+		},
+		{
+			name: "default branch without merge queue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					MergeQueueQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"repo":   githubv4.String("repo"),
+						"branch": (*githubv4.String)(nil),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"mergeQueue": nil,
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "the default branch of owner/repo does not use a merge queue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetMergeQueue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				URL           string                  `json:"url"`
+				EntriesCount  int                     `json:"entries_count"`
+				Configuration MergeQueueConfiguration `json:"configuration"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "https://github.com/owner/repo/queue/main", response.URL)
+			assert.Equal(t, 3, response.EntriesCount)
+			assert.Equal(t, "SQUASH", response.Configuration.MergeMethod)
+			assert.Equal(t, "ALLGREEN", response.Configuration.MergingStrategy)
+			assert.Equal(t, 3600, response.Configuration.CheckResponseTimeout)
+		})
+	}
+}
+
+func Test_ListMergeQueueEntries(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListMergeQueueEntries(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_merge_queue_entries", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	entriesResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"mergeQueue": map[string]any{
+				"entries": map[string]any{
+					"totalCount": 2,
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "c1",
+						"endCursor":       "c2",
+					},
+					"nodes": []any{
+						map[string]any{
+							"position":             1,
+							"state":                "AWAITING_CHECKS",
+							"enqueuedAt":           "2024-01-01T10:00:00Z",
+							"estimatedTimeToMerge": 600,
+							"jump":                 false,
+							"solo":                 false,
+							"headCommit":           map[string]any{"oid": "abc123"},
+							"enqueuer":             map[string]any{"login": "octocat"},
+							"pullRequest": map[string]any{
+								"number": 41,
+								"title":  "First change",
+								"url":    "https://github.com/owner/repo/pull/41",
+							},
+						},
+						map[string]any{
+							"position":             2,
+							"state":                "QUEUED",
+							"enqueuedAt":           "2024-01-01T10:05:00Z",
+							"estimatedTimeToMerge": nil,
+							"jump":                 false,
+							"solo":                 true,
+							"headCommit":           nil,
+							"enqueuer":             map[string]any{"login": "hubot"},
+							"pullRequest": map[string]any{
+								"number": 42,
+								"title":  "Second change",
+								"url":    "https://github.com/owner/repo/pull/42",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]interface{}
+		expectedNumbers []int
+	}{
+		{
+			name: "list all entries",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedNumbers: []int{41, 42},
+		},
+		{
+			name: "find a single pull request",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedNumbers: []int{42},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					MergeQueueEntriesQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"repo":   githubv4.String("repo"),
+						"branch": (*githubv4.String)(nil),
+						"first":  githubv4.Int(30),
+						"after":  (*githubv4.String)(nil),
+					},
+					entriesResponse,
+				),
+			)
+			client := githubv4.NewClient(mockedClient)
+			_, handler := ListMergeQueueEntries(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Entries    []MergeQueueEntry `json:"entries"`
+				Pagination Pagination        `json:"pagination"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.NotNil(t, response.Pagination.TotalCount)
+			assert.Equal(t, 2, *response.Pagination.TotalCount)
+
+			numbers := make([]int, 0, len(response.Entries))
+			for _, entry := range response.Entries {
+				numbers = append(numbers, entry.PullRequestNumber)
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+
+			if tc.expectedNumbers[0] == 41 {
+				require.NotNil(t, response.Entries[0].EstimatedTimeToMerge)
+				assert.Equal(t, 600, *response.Entries[0].EstimatedTimeToMerge)
+				assert.Equal(t, "abc123", response.Entries[0].HeadSHA)
+				assert.Nil(t, response.Entries[1].EstimatedTimeToMerge)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// milestoneDueSoon is how close to its due date an open milestone is reported as due soon.
+const milestoneDueSoon = 7 * 24 * time.Hour
+
+// IssueCounts holds the number of open and closed issues of a group.
+type IssueCounts struct {
+	Open   int `json:"open"`
+	Closed int `json:"closed"`
+}
+
+// MilestoneProgress summarizes how far the issues of a milestone have progressed.
+type MilestoneProgress struct {
+	Number          int                    `json:"number"`
+	Title           string                 `json:"title"`
+	State           string                 `json:"state"`
+	URL             string                 `json:"url"`
+	DueOn           *time.Time             `json:"due_on,omitempty"`
+	DueStatus       string                 `json:"due_status"`
+	DaysRemaining   *int                   `json:"days_remaining,omitempty"`
+	Issues          IssueCounts            `json:"issues"`
+	PercentComplete float64                `json:"percent_complete"`
+	ByLabel         map[string]IssueCounts `json:"by_label"`
+	Weight          *IssueCounts           `json:"weight,omitempty"`
+	Unweighted      *IssueCounts           `json:"unweighted_issues,omitempty"`
+	Analyzed        int                    `json:"analyzed_issues"`
+	Truncated       bool                   `json:"truncated"`
+}
+
+// add counts issue towards the progress. Labels starting with weightPrefix and followed by a
+// number, such as "points: 3", add that number to the weight of the issue.
+func (p *MilestoneProgress) add(issue *github.Issue, weightPrefix string) {
+	p.Analyzed++
+	closed := issue.GetState() == "closed"
+	count := func(c *IssueCounts, n int) {
+		if closed {
+			c.Closed += n
+		} else {
+			c.Open += n
+		}
+	}
+
+	weight, weighted := 0, false
+	for _, label := range issue.Labels {
+		name := label.GetName()
+		counts := p.ByLabel[name]
+		count(&counts, 1)
+		p.ByLabel[name] = counts
+
+		if weightPrefix == "" || !strings.HasPrefix(name, weightPrefix) {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(name, weightPrefix))); err == nil {
+			weight += n
+			weighted = true
+		}
+	}
+	if weightPrefix == "" {
+		return
+	}
+	if weighted {
+		count(p.Weight, weight)
+	} else {
+		count(p.Unweighted, 1)
+	}
+}
+
+// milestoneDueStatus describes whether a milestone is on schedule at now.
+func milestoneDueStatus(milestone *github.Milestone, now time.Time) (string, *int) {
+	if milestone.DueOn == nil {
+		return "no_due_date", nil
+	}
+	days := int(math.Ceil(milestone.GetDueOn().Sub(now).Hours() / 24))
+	switch {
+	case milestone.GetState() == "closed":
+		return "closed", &days
+	case now.After(milestone.GetDueOn().Time):
+		return "overdue", &days
+	case milestone.GetDueOn().Sub(now) <= milestoneDueSoon:
+		return "due_soon", &days
+	default:
+		return "on_track", &days
+	}
+}
+
+// GetMilestoneProgress creates a tool that summarizes the progress of a milestone without returning its issues.
+func GetMilestoneProgress(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone_progress",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_PROGRESS_DESCRIPTION", "Get a summary of the progress of a milestone: open and closed issue and pull request counts overall and by label, optional weights taken from labels, and whether the milestone is on track for its due date. Issues are aggregated server-side across all pages, so this is the preferred way to write status reports without listing every issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_PROGRESS_USER_TITLE", "Get milestone progress"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Required(),
+				mcp.Description("The number of the milestone"),
+			),
+			mcp.WithString("weight_label_prefix",
+				mcp.Description("Prefix of labels that carry the weight of an issue, e.g. 'points: ' for labels like 'points: 3'. If set, open and closed weights are summed up as well"),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description("Maximum number of issues to analyze (default 1000, max 10000). The result is flagged as truncated if the milestone has more issues."),
+				mcp.Min(1),
+				mcp.Max(10000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weightPrefix, err := OptionalParam[string](request, "weight_label_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", 1000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, number)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get milestone", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			progress := MilestoneProgress{
+				Number:  milestone.GetNumber(),
+				Title:   milestone.GetTitle(),
+				State:   milestone.GetState(),
+				URL:     milestone.GetHTMLURL(),
+				ByLabel: map[string]IssueCounts{},
+			}
+			if milestone.DueOn != nil {
+				progress.DueOn = &milestone.DueOn.Time
+			}
+			progress.DueStatus, progress.DaysRemaining = milestoneDueStatus(milestone, time.Now())
+			if weightPrefix != "" {
+				progress.Weight = &IssueCounts{}
+				progress.Unweighted = &IssueCounts{}
+			}
+
+			opts := &github.IssueListByRepoOptions{
+				Milestone:   strconv.Itoa(number),
+				State:       "all",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for progress.Analyzed < maxItems {
+				issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list milestone issues", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, issue := range issues {
+					if progress.Analyzed >= maxItems {
+						break
+					}
+					progress.add(issue, weightPrefix)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.ListOptions.Page = resp.NextPage
+			}
+
+			// Like the milestone counts, the analyzed issues include pull requests. The counts are
+			// used for the totals as the analyzed issues may be truncated.
+			progress.Issues = IssueCounts{Open: milestone.GetOpenIssues(), Closed: milestone.GetClosedIssues()}
+			if total := progress.Issues.Open + progress.Issues.Closed; total > 0 {
+				progress.PercentComplete = math.Round(float64(progress.Issues.Closed)/float64(total)*1000) / 10
+			}
+			progress.Truncated = progress.Analyzed < progress.Issues.Open+progress.Issues.Closed
+
+			return MarshalledTextResult(progress), nil
+		}
+}
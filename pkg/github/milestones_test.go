@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMilestoneProgress(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestoneProgress(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_milestone_progress", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone"})
+
+	dueOn := github.Timestamp{Time: time.Now().Add(30 * 24 * time.Hour)}
+	milestone := &github.Milestone{
+		Number:       github.Ptr(3),
+		Title:        github.Ptr("v1.0"),
+		State:        github.Ptr("open"),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/milestone/3"),
+		DueOn:        &dueOn,
+		OpenIssues:   github.Ptr(2),
+		ClosedIssues: github.Ptr(2),
+	}
+	labels := func(names ...string) []*github.Label {
+		result := make([]*github.Label, 0, len(names))
+		for _, name := range names {
+			result = append(result, &github.Label{Name: github.Ptr(name)})
+		}
+		return result
+	}
+	pages := [][]*github.Issue{
+		{
+			{Number: github.Ptr(1), State: github.Ptr("closed"), Labels: labels("bug", "points: 3")},
+			{Number: github.Ptr(2), State: github.Ptr("open"), Labels: labels("bug", "points: 5")},
+		},
+		{
+			{Number: github.Ptr(3), State: github.Ptr("closed"), Labels: labels("docs")},
+			{Number: github.Ptr(4), State: github.Ptr("open"), Labels: labels("points: 2")},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedProgress MilestoneProgress
+	}{
+		{
+			name: "counts by label",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"milestone": float64(3),
+			},
+			expectedProgress: MilestoneProgress{
+				Issues:          IssueCounts{Open: 2, Closed: 2},
+				PercentComplete: 50,
+				ByLabel: map[string]IssueCounts{
+					"bug":       {Open: 1, Closed: 1},
+					"docs":      {Closed: 1},
+					"points: 3": {Closed: 1},
+					"points: 5": {Open: 1},
+					"points: 2": {Open: 1},
+				},
+				Analyzed: 4,
+			},
+		},
+		{
+			name: "weights from labels",
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"milestone":           float64(3),
+				"weight_label_prefix": "points:",
+			},
+			expectedProgress: MilestoneProgress{
+				Weight:     &IssueCounts{Open: 7, Closed: 3},
+				Unweighted: &IssueCounts{Closed: 1},
+				Analyzed:   4,
+			},
+		},
+		{
+			name: "truncated",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"milestone": float64(3),
+				"max_items": float64(2),
+			},
+			expectedProgress: MilestoneProgress{
+				Analyzed:  2,
+				Truncated: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber, milestone),
+				mock.WithRequestMatchPages(mock.GetReposIssuesByOwnerByRepo, pages[0], pages[1]),
+			))
+			_, handler := GetMilestoneProgress(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var progress MilestoneProgress
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &progress))
+			assert.Equal(t, "v1.0", progress.Title)
+			assert.Equal(t, "on_track", progress.DueStatus)
+			require.NotNil(t, progress.DaysRemaining)
+			assert.Equal(t, 30, *progress.DaysRemaining)
+			assert.Equal(t, tc.expectedProgress.Analyzed, progress.Analyzed)
+			assert.Equal(t, tc.expectedProgress.Truncated, progress.Truncated)
+			if tc.expectedProgress.ByLabel != nil {
+				assert.Equal(t, tc.expectedProgress.Issues, progress.Issues)
+				assert.Equal(t, tc.expectedProgress.PercentComplete, progress.PercentComplete)
+				assert.Equal(t, tc.expectedProgress.ByLabel, progress.ByLabel)
+			}
+			assert.Equal(t, tc.expectedProgress.Weight, progress.Weight)
+			assert.Equal(t, tc.expectedProgress.Unweighted, progress.Unweighted)
+		})
+	}
+
+	t.Run("milestone not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+		))
+		_, handler := GetMilestoneProgress(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":     "owner",
+			"repo":      "repo",
+			"milestone": float64(99),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get milestone")
+	})
+}
+
+func Test_MilestoneDueStatus(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	due := func(d time.Duration) *github.Timestamp { return &github.Timestamp{Time: now.Add(d)} }
+
+	tests := []struct {
+		name      string
+		milestone *github.Milestone
+		status    string
+		days      *int
+	}{
+		{"no due date", &github.Milestone{State: github.Ptr("open")}, "no_due_date", nil},
+		{"on track", &github.Milestone{State: github.Ptr("open"), DueOn: due(10 * 24 * time.Hour)}, "on_track", github.Ptr(10)},
+		{"due soon", &github.Milestone{State: github.Ptr("open"), DueOn: due(36 * time.Hour)}, "due_soon", github.Ptr(2)},
+		{"overdue", &github.Milestone{State: github.Ptr("open"), DueOn: due(-3 * 24 * time.Hour)}, "overdue", github.Ptr(-3)},
+		{"closed", &github.Milestone{State: github.Ptr("closed"), DueOn: due(-3 * 24 * time.Hour)}, "closed", github.Ptr(-3)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, days := milestoneDueStatus(tc.milestone, now)
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, tc.days, days)
+		})
+	}
+}
@@ -86,6 +86,20 @@ type MinimalCommit struct {
 	Files     []MinimalCommitFile `json:"files,omitempty"`
 }
 
+// MinimalComparison is the trimmed output type for commit comparisons.
+type MinimalComparison struct {
+	Base         string              `json:"base"`
+	Head         string              `json:"head"`
+	Status       string              `json:"status"`
+	AheadBy      int                 `json:"ahead_by"`
+	BehindBy     int                 `json:"behind_by"`
+	TotalCommits int                 `json:"total_commits"`
+	MergeBaseSHA string              `json:"merge_base_sha,omitempty"`
+	HTMLURL      string              `json:"html_url,omitempty"`
+	Commits      []MinimalCommit     `json:"commits"`
+	Files        []MinimalCommitFile `json:"files,omitempty"`
+}
+
 // MinimalRelease is the trimmed output type for release objects.
 type MinimalRelease struct {
 	ID          int64        `json:"id"`
@@ -106,6 +120,18 @@ type MinimalBranch struct {
 	Protected bool   `json:"protected"`
 }
 
+// MinimalRepositoryInvitation is the trimmed output type for repository invitation objects.
+type MinimalRepositoryInvitation struct {
+	ID          int64  `json:"id"`
+	Repository  string `json:"repository"`
+	Invitee     string `json:"invitee,omitempty"`
+	Inviter     string `json:"inviter,omitempty"`
+	Permissions string `json:"permissions"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	Expired     bool   `json:"expired"`
+	HTMLURL     string `json:"html_url,omitempty"`
+}
+
 // MinimalResponse represents a minimal response for all CRUD operations.
 // Success is implicit in the HTTP response status, and all other information
 // can be derived from the URL or fetched separately if needed.
@@ -195,6 +221,37 @@ func convertToMinimalCommit(commit *github.RepositoryCommit, includeDiffs bool)
 	return minimalCommit
 }
 
+// convertToMinimalComparison converts a GitHub API CommitsComparison to MinimalComparison
+func convertToMinimalComparison(base, head string, comparison *github.CommitsComparison, includeFiles bool) MinimalComparison {
+	minimalComparison := MinimalComparison{
+		Base:         base,
+		Head:         head,
+		Status:       comparison.GetStatus(),
+		AheadBy:      comparison.GetAheadBy(),
+		BehindBy:     comparison.GetBehindBy(),
+		TotalCommits: comparison.GetTotalCommits(),
+		MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+		HTMLURL:      comparison.GetHTMLURL(),
+		Commits:      make([]MinimalCommit, 0, len(comparison.Commits)),
+	}
+	for _, commit := range comparison.Commits {
+		minimalComparison.Commits = append(minimalComparison.Commits, convertToMinimalCommit(commit, false))
+	}
+	if includeFiles {
+		minimalComparison.Files = make([]MinimalCommitFile, 0, len(comparison.Files))
+		for _, file := range comparison.Files {
+			minimalComparison.Files = append(minimalComparison.Files, MinimalCommitFile{
+				Filename:  file.GetFilename(),
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+			})
+		}
+	}
+	return minimalComparison
+}
+
 // convertToMinimalBranch converts a GitHub API Branch to MinimalBranch
 func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 	return MinimalBranch{
@@ -203,3 +260,38 @@ func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 		Protected: branch.GetProtected(),
 	}
 }
+
+// convertToMinimalUsers converts GitHub API Users to MinimalUsers
+func convertToMinimalUsers(users []*github.User) []MinimalUser {
+	minimalUsers := make([]MinimalUser, 0, len(users))
+	for _, u := range users {
+		minimalUsers = append(minimalUsers, MinimalUser{
+			Login:      u.GetLogin(),
+			ID:         u.GetID(),
+			ProfileURL: u.GetHTMLURL(),
+			AvatarURL:  u.GetAvatarURL(),
+		})
+	}
+	return minimalUsers
+}
+
+// convertToMinimalRepositoryInvitations converts GitHub API RepositoryInvitations to MinimalRepositoryInvitations
+func convertToMinimalRepositoryInvitations(invitations []*github.RepositoryInvitation) []MinimalRepositoryInvitation {
+	minimalInvitations := make([]MinimalRepositoryInvitation, 0, len(invitations))
+	for _, inv := range invitations {
+		minimalInvitation := MinimalRepositoryInvitation{
+			ID:          inv.GetID(),
+			Repository:  inv.GetRepo().GetFullName(),
+			Invitee:     inv.GetInvitee().GetLogin(),
+			Inviter:     inv.GetInviter().GetLogin(),
+			Permissions: inv.GetPermissions(),
+			Expired:     inv.GetExpired(),
+			HTMLURL:     inv.GetHTMLURL(),
+		}
+		if inv.CreatedAt != nil {
+			minimalInvitation.CreatedAt = inv.CreatedAt.Format("2006-01-02T15:04:05Z")
+		}
+		minimalInvitations = append(minimalInvitations, minimalInvitation)
+	}
+	return minimalInvitations
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 const (
@@ -523,3 +525,112 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// setThreadSubscription sets the subscription of the authenticated user to an issue or pull request.
+func setThreadSubscription(ctx context.Context, getGQLClient GetGQLClientFn, request mcp.CallToolRequest, state githubv4.SubscriptionState) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	number, err := RequiredInt(request, "issue_number")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+
+	subscribableID, err := getIssueOrPullRequestID(ctx, client, owner, repo, number)
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue or pull request", err), nil
+	}
+
+	var mutation struct {
+		UpdateSubscription struct {
+			Subscribable struct {
+				ViewerSubscription githubv4.SubscriptionState
+			}
+		} `graphql:"updateSubscription(input: $input)"`
+	}
+	if err := client.Mutate(ctx, &mutation, githubv4.UpdateSubscriptionInput{
+		SubscribableID: subscribableID,
+		State:          state,
+	}, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update subscription", err), nil
+	}
+
+	return MarshalledTextResult(map[string]any{
+		"repository":   fmt.Sprintf("%s/%s", owner, repo),
+		"issue_number": number,
+		"subscription": strings.ToLower(string(mutation.UpdateSubscription.Subscribable.ViewerSubscription)),
+	}), nil
+}
+
+// SubscribeToThread creates a tool to watch an issue or pull request.
+func SubscribeToThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("subscribe_to_thread",
+			mcp.WithDescription(t("TOOL_SUBSCRIBE_TO_THREAD_DESCRIPTION", "Subscribe the authenticated user to an issue or pull request, so they are notified of all its activity.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBSCRIBE_TO_THREAD_USER_TITLE", "Subscribe to issue or pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setThreadSubscription(ctx, getGQLClient, request, githubv4.SubscriptionStateSubscribed)
+		}
+}
+
+// UnsubscribeFromThread creates a tool to stop watching an issue or pull request.
+func UnsubscribeFromThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unsubscribe_from_thread",
+			mcp.WithDescription(t("TOOL_UNSUBSCRIBE_FROM_THREAD_DESCRIPTION", "Unsubscribe the authenticated user from an issue or pull request. By default they are still notified when they participate or are @mentioned, set ignore to never be notified.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNSUBSCRIBE_FROM_THREAD_USER_TITLE", "Unsubscribe from issue or pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+			mcp.WithBoolean("ignore",
+				mcp.Description("Never notify the user of the issue or pull request, even when they participate or are @mentioned"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ignore, err := OptionalParam[bool](request, "ignore")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state := githubv4.SubscriptionStateUnsubscribed
+			if ignore {
+				state = githubv4.SubscriptionStateIgnored
+			}
+			return setThreadSubscription(ctx, getGQLClient, request, state)
+		}
+}
@@ -6,10 +6,14 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -763,3 +767,112 @@ func Test_GetNotificationDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_SubscribeToThread(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := SubscribeToThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "subscribe_to_thread", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	unsubscribeTool, _ := UnsubscribeFromThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unsubscribeTool.Name, unsubscribeTool))
+	assert.Contains(t, unsubscribeTool.InputSchema.Properties, "ignore")
+
+	subscriptionMutation := func(state githubv4.SubscriptionState, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateSubscription struct {
+					Subscribable struct {
+						ViewerSubscription githubv4.SubscriptionState
+					}
+				} `graphql:"updateSubscription(input: $input)"`
+			}{},
+			githubv4.UpdateSubscriptionInput{SubscribableID: "I_42", State: state},
+			nil,
+			response,
+		)
+	}
+	subscribed := func(state string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"updateSubscription": map[string]any{
+				"subscribable": map[string]any{"viewerSubscription": state},
+			},
+		})
+	}
+
+	tests := []struct {
+		name             string
+		newTool          func(GetGQLClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		matchers         []githubv4mock.Matcher
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name:    "subscribe",
+			newTool: SubscribeToThread,
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				subscriptionMutation(githubv4.SubscriptionStateSubscribed, subscribed("SUBSCRIBED")),
+			},
+			requestArgs:      map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)},
+			expectedResponse: map[string]any{"repository": "owner/repo", "issue_number": float64(42), "subscription": "subscribed"},
+		},
+		{
+			name:    "unsubscribe",
+			newTool: UnsubscribeFromThread,
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				subscriptionMutation(githubv4.SubscriptionStateUnsubscribed, subscribed("UNSUBSCRIBED")),
+			},
+			requestArgs:      map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)},
+			expectedResponse: map[string]any{"repository": "owner/repo", "issue_number": float64(42), "subscription": "unsubscribed"},
+		},
+		{
+			name:    "ignore",
+			newTool: UnsubscribeFromThread,
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				subscriptionMutation(githubv4.SubscriptionStateIgnored, subscribed("IGNORED")),
+			},
+			requestArgs:      map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42), "ignore": true},
+			expectedResponse: map[string]any{"repository": "owner/repo", "issue_number": float64(42), "subscription": "ignored"},
+		},
+		{
+			name:    "update fails",
+			newTool: SubscribeToThread,
+			matchers: []githubv4mock.Matcher{
+				issueOrPullRequestIDMatcher("owner", "repo", 42, "I_42"),
+				subscriptionMutation(githubv4.SubscriptionStateSubscribed, githubv4mock.ErrorResponse("Resource not accessible by integration")),
+			},
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "failed to update subscription",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			_, handler := tc.newTool(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,576 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalMembership is the trimmed output type for an organization membership.
+type MinimalMembership struct {
+	User         string `json:"user"`
+	Organization string `json:"organization"`
+	State        string `json:"state"`
+	Role         string `json:"role"`
+}
+
+// ListOrgMembers creates a tool to list the members of an organization.
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of a GitHub organization, optionally filtered by role or by members without two-factor authentication. Concealed members are only listed if the authenticated user is a member of the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Filter members by role: 'admin' for organization owners, 'member' for non-owner members. Defaults to 'all'"),
+				mcp.Enum("all", "admin", "member"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Set to '2fa_disabled' to only list members without two-factor authentication enabled (requires organization owner)"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			members, resp, err := client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{
+				Role:   role,
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalUsers(members)), nil
+		}
+}
+
+// maxOrgMemberNameLookups bounds how many profiles search_org_members fetches to match the query
+// against names, as every lookup is a separate API call.
+const maxOrgMemberNameLookups = 50
+
+// OrgMemberMatch is a member of an organization found by search_org_members.
+type OrgMemberMatch struct {
+	Login      string `json:"login"`
+	Name       string `json:"name,omitempty"`
+	ProfileURL string `json:"profile_url,omitempty"`
+}
+
+// OrgMemberSearchResult is the output of search_org_members.
+type OrgMemberSearchResult struct {
+	Members []OrgMemberMatch `json:"members"`
+	// Incomplete is set when members were skipped because too many names had to be looked up.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// SearchOrgMembers creates a tool to find members of an organization by login, name or team, and
+// optionally only those who can be assigned to issues in one of its repositories.
+func SearchOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_org_members",
+			mcp.WithDescription(t("TOOL_SEARCH_ORG_MEMBERS_DESCRIPTION", "Find members of a GitHub organization whose login or name contains a query, optionally limited to a team and to users who can be assigned to issues in a repository of the organization. Use it to get valid logins for assignment and review requests instead of guessing them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_ORG_MEMBERS_USER_TITLE", "Search organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Text the login or name of the member must contain, case-insensitively. Omit to list all members"),
+			),
+			mcp.WithString("team",
+				mcp.Description("Slug of a team of the organization to search the members of"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Name of a repository of the organization; only members who can be assigned to its issues and pull requests are returned"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of members to return"),
+				mcp.Min(1),
+				mcp.Max(100),
+				mcp.DefaultNumber(30),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			team, err := OptionalParam[string](request, "team")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query = strings.ToLower(query)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var assignable map[string]bool
+			if repo != "" {
+				assignable = map[string]bool{}
+				opts := &github.ListOptions{PerPage: 100, Page: 1}
+				for {
+					users, resp, err := client.Issues.ListAssignees(ctx, org, repo, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list assignable users", resp, err), nil
+					}
+					_ = resp.Body.Close()
+					for _, u := range users {
+						assignable[u.GetLogin()] = true
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			result := OrgMemberSearchResult{Members: []OrgMemberMatch{}}
+			lookups := 0
+			page := 1
+			for len(result.Members) < limit {
+				var members []*github.User
+				var resp *github.Response
+				listOpts := github.ListOptions{PerPage: 100, Page: page}
+				if team != "" {
+					members, resp, err = client.Teams.ListTeamMembersBySlug(ctx, org, team, &github.TeamListTeamMembersOptions{ListOptions: listOpts})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list members of team %s", team), resp, err), nil
+					}
+				} else {
+					members, resp, err = client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{ListOptions: listOpts})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list members of organization %s", org), resp, err), nil
+					}
+				}
+				_ = resp.Body.Close()
+
+				for _, member := range members {
+					if len(result.Members) == limit {
+						break
+					}
+					login := member.GetLogin()
+					if assignable != nil && !assignable[login] {
+						continue
+					}
+					match := OrgMemberMatch{Login: login, ProfileURL: member.GetHTMLURL()}
+					if query != "" && !strings.Contains(strings.ToLower(login), query) {
+						// The member listing has no names, so they are only looked up when the login does not match
+						if lookups == maxOrgMemberNameLookups {
+							result.Incomplete = true
+							continue
+						}
+						lookups++
+						user, resp, err := client.Users.Get(ctx, login)
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get user %s", login), resp, err), nil
+						}
+						_ = resp.Body.Close()
+						if !strings.Contains(strings.ToLower(user.GetName()), query) {
+							continue
+						}
+						match.Name = user.GetName()
+					}
+					result.Members = append(result.Members, match)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				page = resp.NextPage
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// GetOrgMembership creates a tool to get a user's membership in an organization.
+func GetOrgMembership(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_membership",
+			mcp.WithDescription(t("TOOL_GET_ORG_MEMBERSHIP_DESCRIPTION", "Get a user's membership in a GitHub organization, including their role (admin or member) and whether the membership is active or still pending. Omit username to get the authenticated user's membership.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_MEMBERSHIP_USER_TITLE", "Get organization membership"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Description("Username to get the membership of. Defaults to the authenticated user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			membership, resp, err := client.Organizations.GetOrgMembership(ctx, username, org)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound && username != "" {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not a member of organization %s", username, org)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get membership in organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalMembership{
+				User:         membership.GetUser().GetLogin(),
+				Organization: membership.GetOrganization().GetLogin(),
+				State:        membership.GetState(),
+				Role:         membership.GetRole(),
+			}), nil
+		}
+}
+
+// ListOutsideCollaborators creates a tool to list the outside collaborators of an organization.
+func ListOutsideCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_outside_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_OUTSIDE_COLLABORATORS_DESCRIPTION", "List users who have access to at least one repository in a GitHub organization without being a member of it. Requires organization owner permissions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_OUTSIDE_COLLABORATORS_USER_TITLE", "List outside collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Set to '2fa_disabled' to only list outside collaborators without two-factor authentication enabled"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			collaborators, resp, err := client.Organizations.ListOutsideCollaborators(ctx, org, &github.ListOutsideCollaboratorsOptions{
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list outside collaborators of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalUsers(collaborators)), nil
+		}
+}
+
+// MinimalInstallation is the trimmed output type for a GitHub App installation.
+type MinimalInstallation struct {
+	ID                  int64                           `json:"id"`
+	AppID               int64                           `json:"app_id"`
+	AppSlug             string                          `json:"app_slug"`
+	RepositorySelection string                          `json:"repository_selection"`
+	Permissions         *github.InstallationPermissions `json:"permissions,omitempty"`
+	Events              []string                        `json:"events,omitempty"`
+	SuspendedAt         string                          `json:"suspended_at,omitempty"`
+	CreatedAt           string                          `json:"created_at,omitempty"`
+	UpdatedAt           string                          `json:"updated_at,omitempty"`
+	HTMLURL             string                          `json:"html_url,omitempty"`
+}
+
+// ListOrgInstallations creates a tool to list the GitHub Apps installed in an organization.
+func ListOrgInstallations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_installations",
+			mcp.WithDescription(t("TOOL_LIST_ORG_INSTALLATIONS_DESCRIPTION", "List the GitHub Apps installed in a GitHub organization, with the permissions and events each was granted and whether it can access all repositories or selected ones. Use this to review third-party access. Requires organization owner permissions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_INSTALLATIONS_USER_TITLE", "List organization app installations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			installations, resp, err := client.Organizations.ListInstallations(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list app installations of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalInstallation, 0, len(installations.Installations))
+			for _, installation := range installations.Installations {
+				minimal := MinimalInstallation{
+					ID:                  installation.GetID(),
+					AppID:               installation.GetAppID(),
+					AppSlug:             installation.GetAppSlug(),
+					RepositorySelection: installation.GetRepositorySelection(),
+					Permissions:         installation.Permissions,
+					Events:              installation.Events,
+					HTMLURL:             installation.GetHTMLURL(),
+				}
+				if installation.SuspendedAt != nil {
+					minimal.SuspendedAt = installation.SuspendedAt.Format("2006-01-02T15:04:05Z")
+				}
+				if installation.CreatedAt != nil {
+					minimal.CreatedAt = installation.CreatedAt.Format("2006-01-02T15:04:05Z")
+				}
+				if installation.UpdatedAt != nil {
+					minimal.UpdatedAt = installation.UpdatedAt.Format("2006-01-02T15:04:05Z")
+				}
+				result = append(result, minimal)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"total_count":   installations.GetTotalCount(),
+				"installations": result,
+			}), nil
+		}
+}
+
+// MinimalHook is the trimmed output type for a webhook. The secret is left out.
+type MinimalHook struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Active      bool     `json:"active"`
+	Events      []string `json:"events"`
+	URL         string   `json:"url,omitempty"`
+	ContentType string   `json:"content_type,omitempty"`
+	InsecureSSL bool     `json:"insecure_ssl"`
+	HasSecret   bool     `json:"has_secret"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+// convertToMinimalHook converts a webhook to its trimmed output type.
+func convertToMinimalHook(hook *github.Hook) MinimalHook {
+	minimal := MinimalHook{
+		ID:     hook.GetID(),
+		Name:   hook.GetName(),
+		Active: hook.GetActive(),
+		Events: hook.Events,
+	}
+	if config := hook.Config; config != nil {
+		minimal.URL = config.GetURL()
+		minimal.ContentType = config.GetContentType()
+		minimal.InsecureSSL = config.GetInsecureSSL() == "1"
+		minimal.HasSecret = config.GetSecret() != ""
+	}
+	if hook.CreatedAt != nil {
+		minimal.CreatedAt = hook.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if hook.UpdatedAt != nil {
+		minimal.UpdatedAt = hook.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimal
+}
+
+// ListOrgHooks creates a tool to list the webhooks of an organization.
+func ListOrgHooks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_hooks",
+			mcp.WithDescription(t("TOOL_LIST_ORG_HOOKS_DESCRIPTION", "List the webhooks of a GitHub organization: where they deliver, which events they subscribe to, whether they are active, use a secret and verify TLS certificates. Secrets are never returned. Requires organization owner permissions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_HOOKS_USER_TITLE", "List organization webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hooks, resp, err := client.Organizations.ListHooks(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list webhooks of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalHook, 0, len(hooks))
+			for _, hook := range hooks {
+				result = append(result, convertToMinimalHook(hook))
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// RemoveOutsideCollaborator creates a tool to remove an outside collaborator from all repositories of an organization.
+func RemoveOutsideCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_outside_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_OUTSIDE_COLLABORATOR_DESCRIPTION", "Remove an outside collaborator from every repository in a GitHub organization. Requires organization owner permissions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_OUTSIDE_COLLABORATOR_USER_TITLE", "Remove outside collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the outside collaborator to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Organizations.RemoveOutsideCollaborator(ctx, org, username)
+			if err != nil {
+				// GitHub answers 422 when the user is an organization member rather than an outside collaborator
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is a member of organization %s, not an outside collaborator", username, org)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove outside collaborator %s from organization %s", username, org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully removed outside collaborator %s from organization %s", username, org)), nil
+		}
+}
@@ -0,0 +1,585 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedLogins []string
+	}{
+		{
+			name: "list admins",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					expectQueryParams(t, map[string]string{
+						"role":     "admin",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.User{
+							{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":  "acme",
+				"role": "admin",
+			},
+			expectedLogins: []string{"octocat"},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list members of organization missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var users []MinimalUser
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &users))
+			logins := make([]string, 0, len(users))
+			for _, u := range users {
+				logins = append(logins, u.Login)
+			}
+			assert.Equal(t, tc.expectedLogins, logins)
+		})
+	}
+}
+
+func Test_SearchOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "team")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	members := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetOrgsMembersByOrg,
+			[]*github.User{
+				{Login: github.Ptr("octocat")},
+				{Login: github.Ptr("hubot")},
+				{Login: github.Ptr("monalisa")},
+			},
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       OrgMemberSearchResult
+	}{
+		{
+			name: "matches logins and names",
+			mockedClient: mock.NewMockedHTTPClient(
+				members(),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						names := map[string]string{"/users/hubot": "Hubot", "/users/monalisa": "Mona Octo Lisa"}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&github.User{Name: github.Ptr(names[r.URL.Path])})
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":   "acme",
+				"query": "OCTO",
+			},
+			expected: OrgMemberSearchResult{Members: []OrgMemberMatch{
+				{Login: "octocat"},
+				{Login: "monalisa", Name: "Mona Octo Lisa"},
+			}},
+		},
+		{
+			name: "team members assignable in a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					[]*github.User{{Login: github.Ptr("octocat")}, {Login: github.Ptr("hubot")}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposAssigneesByOwnerByRepo,
+					[]*github.User{{Login: github.Ptr("hubot")}, {Login: github.Ptr("monalisa")}},
+				),
+			),
+			requestArgs: map[string]any{
+				"org":  "acme",
+				"team": "bots",
+				"repo": "app",
+			},
+			expected: OrgMemberSearchResult{Members: []OrgMemberMatch{{Login: "hubot"}}},
+		},
+		{
+			name:         "limit",
+			mockedClient: mock.NewMockedHTTPClient(members()),
+			requestArgs: map[string]any{
+				"org":   "acme",
+				"limit": float64(2),
+			},
+			expected: OrgMemberSearchResult{Members: []OrgMemberMatch{{Login: "octocat"}, {Login: "hubot"}}},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list members of organization missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got OrgMemberSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_GetOrgMembership(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgMembership(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_membership", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedMembership MinimalMembership
+	}{
+		{
+			name: "membership of another user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					expectPath(t, "/orgs/acme/memberships/octocat").andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{
+							State:        github.Ptr("active"),
+							Role:         github.Ptr("admin"),
+							User:         &github.User{Login: github.Ptr("octocat")},
+							Organization: &github.Organization{Login: github.Ptr("acme")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":      "acme",
+				"username": "octocat",
+			},
+			expectedMembership: MinimalMembership{User: "octocat", Organization: "acme", State: "active", Role: "admin"},
+		},
+		{
+			name: "membership of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserMembershipsOrgsByOrg,
+					expectPath(t, "/user/memberships/orgs/acme").andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{
+							State:        github.Ptr("pending"),
+							Role:         github.Ptr("member"),
+							User:         &github.User{Login: github.Ptr("me")},
+							Organization: &github.Organization{Login: github.Ptr("acme")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "acme",
+			},
+			expectedMembership: MinimalMembership{User: "me", Organization: "acme", State: "pending", Role: "member"},
+		},
+		{
+			name: "user is not a member",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":      "acme",
+				"username": "stranger",
+			},
+			expectError:    true,
+			expectedErrMsg: "stranger is not a member of organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgMembership(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var membership MinimalMembership
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &membership))
+			assert.Equal(t, tc.expectedMembership, membership)
+		})
+	}
+}
+
+func Test_ListOutsideCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOutsideCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_outside_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsOutsideCollaboratorsByOrg,
+			expectQueryParams(t, map[string]string{
+				"filter":   "2fa_disabled",
+				"page":     "2",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("contractor"), ID: github.Ptr(int64(7)), HTMLURL: github.Ptr("https://github.com/contractor")},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListOutsideCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":     "acme",
+		"filter":  "2fa_disabled",
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var users []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &users))
+	assert.Equal(t, []MinimalUser{{Login: "contractor", ID: 7, ProfileURL: "https://github.com/contractor"}}, users)
+}
+
+func Test_ListOrgInstallations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgInstallations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_installations", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	createdAt := &github.Timestamp{Time: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []MinimalInstallation
+	}{
+		{
+			name: "installed apps",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, &github.OrganizationInstallations{
+							TotalCount: github.Ptr(1),
+							Installations: []*github.Installation{{
+								ID:                  github.Ptr(int64(11)),
+								AppID:               github.Ptr(int64(22)),
+								AppSlug:             github.Ptr("deploy-bot"),
+								RepositorySelection: github.Ptr("all"),
+								Permissions:         &github.InstallationPermissions{Contents: github.Ptr("write"), Metadata: github.Ptr("read")},
+								Events:              []string{"push"},
+								CreatedAt:           createdAt,
+								AccessTokensURL:     github.Ptr("https://api.github.com/app/installations/11/access_tokens"),
+							}},
+						}),
+					),
+				),
+			),
+			expected: []MinimalInstallation{{
+				ID:                  11,
+				AppID:               22,
+				AppSlug:             "deploy-bot",
+				RepositorySelection: "all",
+				Permissions:         &github.InstallationPermissions{Contents: github.Ptr("write"), Metadata: github.Ptr("read")},
+				Events:              []string{"push"},
+				CreatedAt:           "2024-03-01T09:00:00Z",
+			}},
+		},
+		{
+			name: "forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list app installations of organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgInstallations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "acme"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				TotalCount    int                   `json:"total_count"`
+				Installations []MinimalInstallation `json:"installations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, len(tc.expected), returned.TotalCount)
+			assert.Equal(t, tc.expected, returned.Installations)
+		})
+	}
+}
+
+func Test_ListOrgHooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgHooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_hooks", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsHooksByOrg,
+			[]*github.Hook{
+				{
+					ID:     github.Ptr(int64(1)),
+					Name:   github.Ptr("web"),
+					Active: github.Ptr(true),
+					Events: []string{"push", "pull_request"},
+					Config: &github.HookConfig{
+						URL:         github.Ptr("https://ci.example.com/hook"),
+						ContentType: github.Ptr("json"),
+						InsecureSSL: github.Ptr("0"),
+						Secret:      github.Ptr("********"),
+					},
+				},
+				{
+					ID:     github.Ptr(int64(2)),
+					Name:   github.Ptr("web"),
+					Active: github.Ptr(false),
+					Events: []string{"*"},
+					Config: &github.HookConfig{
+						URL:         github.Ptr("http://legacy.example.com/hook"),
+						InsecureSSL: github.Ptr("1"),
+					},
+				},
+			},
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListOrgHooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "acme"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := getTextResult(t, result).Text
+	assert.NotContains(t, text, "********")
+	var hooks []MinimalHook
+	require.NoError(t, json.Unmarshal([]byte(text), &hooks))
+	assert.Equal(t, []MinimalHook{
+		{ID: 1, Name: "web", Active: true, Events: []string{"push", "pull_request"}, URL: "https://ci.example.com/hook", ContentType: "json", HasSecret: true},
+		{ID: 2, Name: "web", Events: []string{"*"}, URL: "http://legacy.example.com/hook", InsecureSSL: true},
+	}, hooks)
+}
+
+func Test_RemoveOutsideCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveOutsideCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_outside_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	tests := []struct {
+		name           string
+		status         int
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "successful removal",
+			status: http.StatusNoContent,
+		},
+		{
+			name:           "user is an organization member",
+			status:         http.StatusUnprocessableEntity,
+			expectError:    true,
+			expectedErrMsg: "contractor is a member of organization acme, not an outside collaborator",
+		},
+		{
+			name:           "forbidden",
+			status:         http.StatusForbidden,
+			expectError:    true,
+			expectedErrMsg: "failed to remove outside collaborator contractor from organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsOutsideCollaboratorsByOrgByUsername,
+					expectPath(t, "/orgs/acme/outside_collaborators/contractor").andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(tc.status)
+							if tc.status != http.StatusNoContent {
+								_, _ = w.Write([]byte(`{"message": "error"}`))
+							}
+						}),
+					),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			_, handler := RemoveOutsideCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"org":      "acme",
+				"username": "contractor",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Successfully removed outside collaborator contractor from organization acme", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// permissionLevels orders the access levels of fine-grained permissions. Each level implies the lower ones.
+var permissionLevels = map[string]int{
+	"read":  1,
+	"write": 2,
+	"admin": 3,
+}
+
+// permissionRequirement lists the fine-grained permissions a tool needs, all of which are required.
+// Permissions are written "resource:level", using the names of the GitHub App permissions that
+// fine-grained personal access tokens share.
+type permissionRequirement struct {
+	read  []string
+	write []string
+}
+
+// toolsetPermissions are the permissions the tools of a toolset need, split by whether the tool is read-only.
+// Toolsets whose tools work on public data without any permission are not listed.
+var toolsetPermissions = map[string]permissionRequirement{
+	"repos":             {read: []string{"contents:read"}, write: []string{"contents:write"}},
+	"issues":            {read: []string{"issues:read"}, write: []string{"issues:write"}},
+	"pull_requests":     {read: []string{"pull_requests:read"}, write: []string{"pull_requests:write"}},
+	"actions":           {read: []string{"actions:read"}, write: []string{"actions:write"}},
+	"discussions":       {read: []string{"discussions:read"}, write: []string{"discussions:write"}},
+	"code_security":     {read: []string{"security_events:read"}, write: []string{"security_events:write"}},
+	"secret_protection": {read: []string{"secret_scanning_alerts:read"}, write: []string{"secret_scanning_alerts:write"}},
+	"dependabot":        {read: []string{"vulnerability_alerts:read"}, write: []string{"vulnerability_alerts:write"}},
+	"dependency_graph":  {read: []string{"contents:read"}},
+	"notifications":     {read: []string{"notifications:read"}, write: []string{"notifications:write"}},
+	"gists":             {write: []string{"gists:write"}},
+	"projects":          {read: []string{"organization_projects:read"}, write: []string{"organization_projects:write"}},
+	"orgs":              {read: []string{"members:read"}, write: []string{"members:write"}},
+}
+
+// toolPermissions override toolsetPermissions for tools that need something other than the rest of their toolset.
+// An empty list marks a tool that needs no permission.
+var toolPermissions = map[string][]string{
+	"get_check_annotations":                   {"actions:read", "checks:read"},
+	"get_dora_metrics":                        {"actions:read", "deployments:read", "pull_requests:read", "contents:read"},
+	"validate_workflow_file":                  {"contents:read"},
+	"list_pending_deployments":                {"actions:read", "deployments:read"},
+	"get_deployment_diff":                     {"deployments:read", "contents:read"},
+	"review_pending_deployment":               {"actions:read", "deployments:write"},
+	"get_actions_permissions":                 {"administration:read"},
+	"set_actions_permissions":                 {"administration:write"},
+	"create_repository_dispatch_event":        {"contents:write"},
+	"create_workflow":                         {"contents:write", "pull_requests:write", "workflows:write"},
+	"create_change":                           {"contents:write", "pull_requests:write"},
+	"propose_health_files":                    {"contents:write", "pull_requests:write"},
+	"get_repo_security_settings":              {"administration:read", "security_events:read", "vulnerability_alerts:read"},
+	"get_org_security_overview":               {"administration:read", "security_events:read", "vulnerability_alerts:read", "secret_scanning_alerts:read"},
+	"get_teams":                               {"members:read"},
+	"get_team_members":                        {"members:read"},
+	"get_issue_templates":                     {"contents:read"},
+	"get_linked_items":                        {"issues:read", "pull_requests:read"},
+	"search_orgs":                             {},
+	"list_org_installations":                  {"organization_administration:read"},
+	"list_org_hooks":                          {"organization_hooks:read"},
+	"list_org_events":                         {"metadata:read"},
+	"aggregate_org_languages":                 {"metadata:read"},
+	"search_org_members":                      {"members:read", "metadata:read"},
+	"search_project_issues":                   {"organization_projects:read", "issues:read"},
+	"find_item_in_projects":                   {"organization_projects:read", "issues:read"},
+	"resolve_project":                         {"organization_projects:read"},
+	"comment_on_project_item":                 {"organization_projects:read", "issues:write", "pull_requests:write"},
+	"start_work_on_issue":                     {"issues:write", "contents:write", "pull_requests:write"},
+	"mark_project_as_template":                {"organization_projects:admin"},
+	"unmark_project_as_template":              {"organization_projects:admin"},
+	"list_project_templates":                  {"organization_projects:read"},
+	"get_pull_request_status":                 {"pull_requests:read", "statuses:read"},
+	"wait_for_checks":                         {"pull_requests:read", "checks:read", "statuses:read"},
+	"compare_checks_to_protection":            {"pull_requests:read", "checks:read", "administration:read"},
+	"suggest_reviewers":                       {"pull_requests:read", "contents:read"},
+	"get_merge_queue":                         {"merge_queues:read"},
+	"list_merge_queue_entries":                {"merge_queues:read"},
+	"merge_pull_request":                      {"contents:write", "pull_requests:write"},
+	"update_pull_request_branch":              {"contents:write", "pull_requests:write"},
+	"enable_pull_request_auto_merge":          {"contents:write", "pull_requests:write"},
+	"search_repositories":                     {"metadata:read"},
+	"get_release_notes_data":                  {"contents:read", "pull_requests:read"},
+	"list_starred_repositories":               {"starring:read"},
+	"star_repository":                         {"starring:write"},
+	"unstar_repository":                       {"starring:write"},
+	"get_custom_property_values":              {"repository_custom_properties:read"},
+	"set_custom_property_values":              {"repository_custom_properties:write"},
+	"get_contributors_stats":                  {"metadata:read"},
+	"get_commit_activity":                     {"metadata:read"},
+	"get_repo_languages":                      {"metadata:read"},
+	"list_repo_events":                        {"metadata:read"},
+	"get_stargazer_timeline":                  {"metadata:read"},
+	"check_push_allowed":                      {"administration:read", "metadata:read"},
+	"create_repository":                       {"administration:write"},
+	"fork_repository":                         {"administration:write", "contents:read"},
+	"rename_branch":                           {"administration:write", "pull_requests:read"},
+	"set_repo_topics":                         {"administration:write"},
+	"list_repository_invitations":             {"administration:read"},
+	"accept_repository_invitation":            {},
+	"decline_repository_invitation":           {},
+	"delete_repository_invitation":            {"administration:write"},
+	"list_deploy_keys":                        {"administration:read"},
+	"add_deploy_key":                          {"administration:write"},
+	"delete_deploy_key":                       {"administration:write"},
+	"list_autolinks":                          {"administration:read"},
+	"create_autolink":                         {"administration:write"},
+	"delete_autolink":                         {"administration:write"},
+	"archive_repository":                      {"administration:write"},
+	"unarchive_repository":                    {"administration:write"},
+	"transfer_repository":                     {"administration:write", "members:read"},
+	"list_repository_security_advisories":     {"repository_advisories:read"},
+	"list_org_repository_security_advisories": {"repository_advisories:read"},
+	"list_public_ssh_keys":                    {"keys:read"},
+	"add_ssh_key":                             {"keys:write"},
+	"delete_ssh_key":                          {"keys:write"},
+	"list_gpg_keys":                           {"gpg_keys:read"},
+}
+
+// classicOnlyPermissions are permissions fine-grained personal access tokens cannot be granted. Tools
+// that need them only work with a classic token or an OAuth token.
+var classicOnlyPermissions = map[string]bool{
+	"notifications": true,
+}
+
+// RequiredPermissions returns the fine-grained permissions a tool needs, all of which are required.
+// It returns nil when the tool works without any particular permission.
+func RequiredPermissions(toolset string, tool mcp.Tool) []string {
+	if permissions, ok := toolPermissions[tool.Name]; ok {
+		return permissions
+	}
+	requirement := toolsetPermissions[toolset]
+	if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+		return requirement.read
+	}
+	return requirement.write
+}
+
+// parsePermission splits a "resource:level" permission.
+func parsePermission(permission string) (resource, level string, err error) {
+	resource, level, ok := strings.Cut(permission, ":")
+	if !ok || resource == "" {
+		return "", "", fmt.Errorf("invalid permission %q, must be resource:level", permission)
+	}
+	if _, ok := permissionLevels[level]; !ok {
+		return "", "", fmt.Errorf("invalid level %q of permission %s, must be read, write or admin", level, resource)
+	}
+	return resource, level, nil
+}
+
+// permissionScope returns whether a fine-grained permission applies to repositories, organizations or the user account.
+func permissionScope(resource string) string {
+	switch {
+	case resource == "members" || strings.HasPrefix(resource, "organization_"):
+		return "organization"
+	case resource == "gists" || resource == "gpg_keys" || resource == "keys" || resource == "starring" || resource == "notifications":
+		return "account"
+	}
+	return "repository"
+}
+
+// mergePermissions combines permissions into the highest level needed of each resource.
+func mergePermissions(permissions []string) map[string]string {
+	merged := map[string]string{}
+	for _, permission := range permissions {
+		resource, level, err := parsePermission(permission)
+		if err != nil {
+			continue
+		}
+		if permissionLevels[level] > permissionLevels[merged[resource]] {
+			merged[resource] = level
+		}
+	}
+	return merged
+}
+
+// PermissionCeiling is the highest level of each fine-grained permission tools may need to be registered.
+type PermissionCeiling struct {
+	levels map[string]string
+	// fallback is the level of the resources levels does not list, empty if they are not allowed
+	fallback string
+}
+
+// ParsePermissionCeiling parses "resource:level" entries into a ceiling. The resource "*" sets the
+// level of every resource that is not listed, for example "*:read" allows only read-only access.
+func ParsePermissionCeiling(entries []string) (*PermissionCeiling, error) {
+	ceiling := &PermissionCeiling{levels: map[string]string{}}
+	for _, entry := range entries {
+		resource, level, err := parsePermission(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err
+		}
+		if resource == "*" {
+			ceiling.fallback = level
+			continue
+		}
+		ceiling.levels[resource] = level
+	}
+	return ceiling, nil
+}
+
+// Allows reports whether permission is within the ceiling. metadata:read is always allowed, as every
+// token with access to a repository is granted it.
+func (c *PermissionCeiling) Allows(permission string) bool {
+	resource, level, err := parsePermission(permission)
+	if err != nil {
+		return false
+	}
+	if resource == "metadata" && level == "read" {
+		return true
+	}
+	allowed, ok := c.levels[resource]
+	if !ok {
+		allowed = c.fallback
+	}
+	return permissionLevels[level] <= permissionLevels[allowed]
+}
+
+// ExceedingTool is a tool that needs more permissions than the ceiling allows.
+type ExceedingTool struct {
+	Tool      string   `json:"tool"`
+	Toolset   string   `json:"toolset"`
+	Exceeding []string `json:"exceeding"`
+}
+
+// GatePermissionCeiling removes the tools of tsg that need a permission above the ceiling. It returns
+// what was removed, sorted by toolset and tool name.
+func GatePermissionCeiling(tsg *toolsets.ToolsetGroup, ceiling *PermissionCeiling) []ExceedingTool {
+	exceeding := []ExceedingTool{}
+	for name, toolset := range tsg.Toolsets {
+		var remove []string
+		for _, tool := range toolset.GetAvailableTools() {
+			var above []string
+			for _, permission := range RequiredPermissions(name, tool.Tool) {
+				if !ceiling.Allows(permission) {
+					above = append(above, permission)
+				}
+			}
+			if len(above) > 0 {
+				remove = append(remove, tool.Tool.Name)
+				exceeding = append(exceeding, ExceedingTool{Tool: tool.Tool.Name, Toolset: name, Exceeding: above})
+			}
+		}
+		toolset.RemoveTools(remove...)
+	}
+	sort.Slice(exceeding, func(i, j int) bool {
+		if exceeding[i].Toolset != exceeding[j].Toolset {
+			return exceeding[i].Toolset < exceeding[j].Toolset
+		}
+		return exceeding[i].Tool < exceeding[j].Tool
+	})
+	return exceeding
+}
+
+// PlannedToolPermissions is what a tool needs of a token.
+type PlannedToolPermissions struct {
+	Tool           string     `json:"tool"`
+	Toolset        string     `json:"toolset"`
+	Permissions    []string   `json:"permissions"`
+	ClassicScopes  [][]string `json:"classic_scopes,omitempty"`
+	FineGrainedPAT bool       `json:"fine_grained_pat_supported"`
+}
+
+// TokenPermissionPlan is the minimal set of permissions a token needs to use a set of tools.
+type TokenPermissionPlan struct {
+	Tools                   []PlannedToolPermissions `json:"tools"`
+	RepositoryPermissions   map[string]string        `json:"repository_permissions"`
+	OrganizationPermissions map[string]string        `json:"organization_permissions"`
+	AccountPermissions      map[string]string        `json:"account_permissions"`
+	ClassicScopes           []string                 `json:"classic_scopes"`
+	Notes                   []string                 `json:"notes,omitempty"`
+}
+
+// toolsetTool is a tool along with the name of its toolset.
+type toolsetTool struct {
+	toolset string
+	tool    mcp.Tool
+}
+
+// planTokenPermissions computes the permissions a token needs for the given tools, keyed by name.
+func planTokenPermissions(tools map[string]toolsetTool) TokenPermissionPlan {
+	plan := TokenPermissionPlan{
+		Tools:                   []PlannedToolPermissions{},
+		RepositoryPermissions:   map[string]string{},
+		OrganizationPermissions: map[string]string{},
+		AccountPermissions:      map[string]string{},
+		ClassicScopes:           []string{},
+	}
+	var all []string
+	classicScopes := map[string]bool{}
+	classicOnly := map[string]bool{}
+	for _, name := range slices.Sorted(maps.Keys(tools)) {
+		selected := tools[name]
+		permissions := RequiredPermissions(selected.toolset, selected.tool)
+		planned := PlannedToolPermissions{
+			Tool:           name,
+			Toolset:        selected.toolset,
+			Permissions:    append([]string{}, permissions...),
+			ClassicScopes:  RequiredScopes(selected.toolset, selected.tool),
+			FineGrainedPAT: true,
+		}
+		for _, permission := range permissions {
+			if resource, _, _ := strings.Cut(permission, ":"); classicOnlyPermissions[resource] {
+				planned.FineGrainedPAT = false
+				classicOnly[name] = true
+			}
+		}
+		// Any one scope of every group is sufficient, the first one is the most common choice.
+		for _, group := range planned.ClassicScopes {
+			classicScopes[group[0]] = true
+		}
+		plan.Tools = append(plan.Tools, planned)
+		all = append(all, permissions...)
+	}
+
+	for resource, level := range mergePermissions(all) {
+		switch permissionScope(resource) {
+		case "organization":
+			plan.OrganizationPermissions[resource] = level
+		case "account":
+			plan.AccountPermissions[resource] = level
+		default:
+			plan.RepositoryPermissions[resource] = level
+		}
+	}
+	if len(plan.RepositoryPermissions) > 0 && plan.RepositoryPermissions["metadata"] == "" {
+		plan.RepositoryPermissions["metadata"] = "read"
+		plan.Notes = append(plan.Notes, "metadata:read is granted with any repository permission and is listed for completeness")
+	}
+	if len(classicOnly) > 0 {
+		plan.Notes = append(plan.Notes, fmt.Sprintf("fine-grained personal access tokens cannot be granted the permissions of %s, use a classic token with the listed scopes for them", strings.Join(slices.Sorted(maps.Keys(classicOnly)), ", ")))
+	}
+	plan.ClassicScopes = slices.Sorted(maps.Keys(classicScopes))
+	return plan
+}
+
+// PlanTokenPermissions creates a tool that reports the permissions a token needs to use a set of tools.
+func PlanTokenPermissions(tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("plan_token_permissions",
+			mcp.WithDescription(t("TOOL_PLAN_TOKEN_PERMISSIONS_DESCRIPTION", "Report the minimal fine-grained personal access token permissions, split into repository, organization and account permissions, and the classic token scopes needed to use a set of tools of this server. Use this to help the user create a token with least privilege before they start a task.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PLAN_TOKEN_PERMISSIONS_USER_TITLE", "Plan token permissions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("tools",
+				mcp.Description("Names of the tools the user intends to use"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("toolsets",
+				mcp.Description("Names of toolsets to include all the tools of, in addition to tools"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolNames, err := OptionalStringArrayParam(request, "tools")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolsetNames, err := OptionalStringArrayParam(request, "toolsets")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(toolNames) == 0 && len(toolsetNames) == 0 {
+				return mcp.NewToolResultError("at least one of tools or toolsets is required"), nil
+			}
+
+			known := map[string]toolsetTool{}
+			for name, toolset := range tsg.Toolsets {
+				for _, tool := range toolset.GetAvailableTools() {
+					known[tool.Tool.Name] = toolsetTool{toolset: name, tool: tool.Tool}
+				}
+			}
+
+			selected := map[string]toolsetTool{}
+			var unknown []string
+			for _, name := range toolsetNames {
+				toolset, ok := tsg.Toolsets[name]
+				if !ok {
+					unknown = append(unknown, "toolset "+name)
+					continue
+				}
+				for _, tool := range toolset.GetAvailableTools() {
+					selected[tool.Tool.Name] = toolsetTool{toolset: name, tool: tool.Tool}
+				}
+			}
+			for _, name := range toolNames {
+				tool, ok := known[name]
+				if !ok {
+					unknown = append(unknown, "tool "+name)
+					continue
+				}
+				selected[name] = tool
+			}
+			if len(unknown) > 0 {
+				sort.Strings(unknown)
+				return mcp.NewToolResultError(fmt.Sprintf("unknown %s", strings.Join(unknown, ", "))), nil
+			}
+
+			return MarshalledTextResult(planTokenPermissions(selected)), nil
+		}
+}
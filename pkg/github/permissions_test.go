@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequiredPermissions(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+
+	tools := map[string]bool{}
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = true
+			// Write tools must declare what they need, or be listed explicitly as needing nothing,
+			// so that a permission ceiling cannot be bypassed by a tool missing from the registry
+			if _, listed := toolPermissions[tool.Tool.Name]; !listed && !*tool.Tool.Annotations.ReadOnlyHint {
+				assert.NotEmpty(t, RequiredPermissions(name, tool.Tool), "write tool %s has no permissions", tool.Tool.Name)
+			}
+			for _, permission := range RequiredPermissions(name, tool.Tool) {
+				_, _, err := parsePermission(permission)
+				assert.NoError(t, err, "tool %s", tool.Tool.Name)
+			}
+		}
+	}
+	for name := range toolPermissions {
+		assert.True(t, tools[name], "permissions are listed for unknown tool %s", name)
+	}
+
+	getFileContents, _ := GetFileContents(nil, nil, translations.NullTranslationHelper)
+	assert.Equal(t, []string{"contents:read"}, RequiredPermissions("repos", getFileContents))
+	forkRepository, _ := ForkRepository(nil, translations.NullTranslationHelper)
+	assert.Equal(t, []string{"administration:write", "contents:read"}, RequiredPermissions("repos", forkRepository))
+	searchOrgs, _ := SearchOrgs(nil, translations.NullTranslationHelper)
+	assert.Empty(t, RequiredPermissions("orgs", searchOrgs))
+}
+
+func Test_PermissionCeiling(t *testing.T) {
+	ceiling, err := ParsePermissionCeiling([]string{"*:read", "issues:write", " pull_requests:admin"})
+	require.NoError(t, err)
+	assert.True(t, ceiling.Allows("contents:read"))
+	assert.False(t, ceiling.Allows("contents:write"))
+	assert.True(t, ceiling.Allows("issues:write"))
+	assert.False(t, ceiling.Allows("issues:admin"))
+	assert.True(t, ceiling.Allows("pull_requests:write"))
+
+	// Without "*", only listed resources are allowed, apart from metadata:read
+	ceiling, err = ParsePermissionCeiling([]string{"contents:write"})
+	require.NoError(t, err)
+	assert.True(t, ceiling.Allows("contents:read"))
+	assert.True(t, ceiling.Allows("metadata:read"))
+	assert.False(t, ceiling.Allows("issues:read"))
+
+	_, err = ParsePermissionCeiling([]string{"contents"})
+	assert.ErrorContains(t, err, `invalid permission "contents"`)
+	_, err = ParsePermissionCeiling([]string{"contents:owner"})
+	assert.ErrorContains(t, err, `invalid level "owner"`)
+}
+
+func Test_GatePermissionCeiling(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	ceiling, err := ParsePermissionCeiling([]string{"*:read", "issues:write"})
+	require.NoError(t, err)
+
+	removed := map[string]ExceedingTool{}
+	for _, tool := range GatePermissionCeiling(tsg, ceiling) {
+		removed[tool.Tool] = tool
+	}
+	assert.Equal(t, ExceedingTool{Tool: "merge_pull_request", Toolset: "pull_requests", Exceeding: []string{"contents:write", "pull_requests:write"}}, removed["merge_pull_request"])
+	assert.Contains(t, removed, "create_or_update_file")
+	assert.Contains(t, removed, "mark_project_as_template")
+	assert.NotContains(t, removed, "create_issue")
+	assert.NotContains(t, removed, "get_file_contents")
+	assert.NotContains(t, removed, "search_orgs")
+
+	available := map[string]bool{}
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			available[tool.Tool.Name] = true
+		}
+	}
+	assert.False(t, available["merge_pull_request"])
+	assert.True(t, available["create_issue"])
+}
+
+func Test_PlanTokenPermissions(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+
+	// Verify tool definition once
+	tool, _ := PlanTokenPermissions(tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "plan_token_permissions", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       TokenPermissionPlan
+	}{
+		{
+			name: "tools across toolsets",
+			requestArgs: map[string]any{
+				"tools": []any{"get_file_contents", "merge_pull_request", "mark_project_as_template", "list_project_repos", "star_repository"},
+			},
+			expected: TokenPermissionPlan{
+				RepositoryPermissions:   map[string]string{"contents": "write", "pull_requests": "write", "metadata": "read"},
+				OrganizationPermissions: map[string]string{"organization_projects": "admin"},
+				AccountPermissions:      map[string]string{"starring": "write"},
+				ClassicScopes:           []string{"project", "read:project", "repo"},
+				Notes:                   []string{"metadata:read is granted with any repository permission and is listed for completeness"},
+			},
+		},
+		{
+			name: "toolset with classic only permissions",
+			requestArgs: map[string]any{
+				"toolsets": []any{"notifications"},
+			},
+			expected: TokenPermissionPlan{
+				RepositoryPermissions:   map[string]string{},
+				OrganizationPermissions: map[string]string{},
+				AccountPermissions:      map[string]string{"notifications": "write"},
+				ClassicScopes:           []string{"notifications"},
+				Notes:                   []string{"fine-grained personal access tokens cannot be granted the permissions of dismiss_notification, get_notification_details, list_notifications, manage_notification_subscription, manage_repository_notification_subscription, mark_all_notifications_read, subscribe_to_thread, unsubscribe_from_thread, use a classic token with the listed scopes for them"},
+			},
+		},
+		{
+			name: "unknown tools",
+			requestArgs: map[string]any{
+				"tools":    []any{"get_file_contents", "delete_everything"},
+				"toolsets": []any{"nope"},
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown tool delete_everything, toolset nope",
+		},
+		{
+			name:           "nothing to plan",
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "at least one of tools or toolsets is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := PlanTokenPermissions(tsg, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var plan TokenPermissionPlan
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &plan))
+			assert.Equal(t, tc.expected.RepositoryPermissions, plan.RepositoryPermissions)
+			assert.Equal(t, tc.expected.OrganizationPermissions, plan.OrganizationPermissions)
+			assert.Equal(t, tc.expected.AccountPermissions, plan.AccountPermissions)
+			assert.Equal(t, tc.expected.ClassicScopes, plan.ClassicScopes)
+			assert.Equal(t, tc.expected.Notes, plan.Notes)
+		})
+	}
+}
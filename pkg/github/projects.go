@@ -0,0 +1,2347 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// UpdateProjectV2FieldInput represents the input for updating a project field via the GraphQL API.
+// Used to extend the functionality of the githubv4 library, which does not yet support this mutation.
+type UpdateProjectV2FieldInput struct {
+	FieldID             githubv4.ID                                       `json:"fieldId"`
+	ClientMutationID    *githubv4.String                                  `json:"clientMutationId,omitempty"`
+	Name                *githubv4.String                                  `json:"name,omitempty"`
+	SingleSelectOptions *[]githubv4.ProjectV2SingleSelectFieldOptionInput `json:"singleSelectOptions,omitempty"`
+}
+
+// ProjectFieldSpec describes a custom field to create on a project.
+type ProjectFieldSpec struct {
+	Name    string   `mapstructure:"name"`
+	Type    string   `mapstructure:"type"`
+	Options []string `mapstructure:"options"`
+}
+
+// toSingleSelectOptions converts option names to the GraphQL input type. The API requires a
+// color and description for each option, so we default to gray with no description.
+func toSingleSelectOptions(names []string) []githubv4.ProjectV2SingleSelectFieldOptionInput {
+	options := make([]githubv4.ProjectV2SingleSelectFieldOptionInput, 0, len(names))
+	for _, name := range names {
+		options = append(options, githubv4.ProjectV2SingleSelectFieldOptionInput{
+			Name:        githubv4.String(name),
+			Color:       githubv4.ProjectV2SingleSelectFieldOptionColorGray,
+			Description: githubv4.String(""),
+		})
+	}
+	return options
+}
+
+// sendProgressNotification reports progress for a long-running tool call, if the client asked for it.
+// Failures to deliver the notification are ignored, as they must never fail the tool call itself.
+func sendProgressNotification(ctx context.Context, request mcp.CallToolRequest, progress, total int, message string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+	_ = s.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": request.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	})
+}
+
+// ProvisionProject creates a tool that creates a project along with its custom fields, statuses and linked repositories.
+func ProvisionProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("provision_project",
+			mcp.WithDescription(t("TOOL_PROVISION_PROJECT_DESCRIPTION", "Create a GitHub Project (v2) from a declarative spec in a single call: the project itself, its custom fields, the options of its Status field, and the repositories linked to it. Steps are executed in order and reported in the result; if a step fails, the steps already completed are returned alongside the error. Views cannot be created through the API and must be configured in the UI.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PROVISION_PROJECT_USER_TITLE", "Provision project"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that will own the project"),
+			),
+			mcp.WithString("owner_type",
+				mcp.Description("Whether the owner is a user or an organization. Detected from the login if omitted."),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Project title"),
+			),
+			mcp.WithArray("fields",
+				mcp.Description("Custom fields to create on the project"),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"name", "type"},
+						"properties": map[string]any{
+							"name": map[string]any{
+								"type":        "string",
+								"description": "Field name",
+							},
+							"type": map[string]any{
+								"type":        "string",
+								"description": "Field data type",
+								"enum":        []string{"text", "number", "date", "single_select"},
+							},
+							"options": map[string]any{
+								"type":        "array",
+								"description": "Option names, required for single_select fields",
+								"items": map[string]any{
+									"type": "string",
+								},
+							},
+						},
+					},
+				),
+			),
+			mcp.WithArray("statuses",
+				mcp.Description("Options for the built-in Status field, in order. Replaces the default Todo/In Progress/Done options."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repositories to link to the project, either 'repo' (owned by owner) or 'owner/repo'"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerType, err := OptionalParam[string](request, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var fields []ProjectFieldSpec
+			if raw, ok := request.GetArguments()["fields"]; ok && raw != nil {
+				if err := mapstructure.Decode(raw, &fields); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid fields: %v", err)), nil
+				}
+			}
+			for _, f := range fields {
+				if f.Name == "" {
+					return mcp.NewToolResultError("every field must have a name"), nil
+				}
+				switch f.Type {
+				case "text", "number", "date":
+				case "single_select":
+					if len(f.Options) == 0 {
+						return mcp.NewToolResultError(fmt.Sprintf("single_select field %q must have at least one option", f.Name)), nil
+					}
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("field %q has unsupported type %q", f.Name, f.Type)), nil
+				}
+			}
+
+			statuses, err := OptionalStringArrayParam(request, "statuses")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// Every mutation is a step: the project, each field, the statuses, and each repository link.
+			total := 1 + len(fields) + len(repositories)
+			if len(statuses) > 0 {
+				total++
+			}
+			var steps []string
+			progress := 0
+			completeStep := func(message string) {
+				progress++
+				steps = append(steps, message)
+				sendProgressNotification(ctx, request, progress, total, message)
+			}
+			partialFailure := func(message string, err error) *mcp.CallToolResult {
+				result := ghErrors.NewGitHubGraphQLErrorResponse(ctx, message, err)
+				if len(steps) > 0 {
+					result.Content = append(result.Content, mcp.NewTextContent("Completed steps before the failure:\n- "+strings.Join(steps, "\n- ")))
+				}
+				return result
+			}
+
+			var ownerID githubv4.ID
+			if ownerType == "" {
+				_, ownerID, err = detectOwner(ctx, client, owner)
+			} else {
+				ownerID, err = getOwnerID(ctx, client, owner, ownerType)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to resolve owner %s", owner), err), nil
+			}
+
+			var createProject struct {
+				CreateProjectV2 struct {
+					ProjectV2 ProjectFragment
+				} `graphql:"createProjectV2(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &createProject, githubv4.CreateProjectV2Input{
+				OwnerID: ownerID,
+				Title:   githubv4.String(title),
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create project", err), nil
+			}
+			project := createProject.CreateProjectV2.ProjectV2
+			completeStep(fmt.Sprintf("created project #%d %q", project.Number, project.Title))
+
+			for _, f := range fields {
+				input := githubv4.CreateProjectV2FieldInput{
+					ProjectID: project.ID,
+					DataType:  githubv4.ProjectV2CustomFieldType(strings.ToUpper(f.Type)),
+					Name:      githubv4.String(f.Name),
+				}
+				if f.Type == "single_select" {
+					options := toSingleSelectOptions(f.Options)
+					input.SingleSelectOptions = &options
+				}
+
+				var createField struct {
+					CreateProjectV2Field struct {
+						ProjectV2Field ProjectFieldFragment
+					} `graphql:"createProjectV2Field(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &createField, input, nil); err != nil {
+					return partialFailure(fmt.Sprintf("failed to create field %q", f.Name), err), nil
+				}
+				completeStep(fmt.Sprintf("created %s field %q", f.Type, f.Name))
+			}
+
+			if len(statuses) > 0 {
+				var statusQuery struct {
+					Node struct {
+						ProjectV2 struct {
+							Field struct {
+								SingleSelectField struct {
+									ID githubv4.ID
+								} `graphql:"... on ProjectV2SingleSelectField"`
+							} `graphql:"field(name: $fieldName)"`
+						} `graphql:"... on ProjectV2"`
+					} `graphql:"node(id: $projectId)"`
+				}
+				if err := client.Query(ctx, &statusQuery, map[string]any{
+					"projectId": project.ID,
+					"fieldName": githubv4.String("Status"),
+				}); err != nil {
+					return partialFailure("failed to find the Status field", err), nil
+				}
+
+				options := toSingleSelectOptions(statuses)
+				var updateField struct {
+					UpdateProjectV2Field struct {
+						ProjectV2Field ProjectFieldFragment
+					} `graphql:"updateProjectV2Field(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &updateField, UpdateProjectV2FieldInput{
+					FieldID:             statusQuery.Node.ProjectV2.Field.SingleSelectField.ID,
+					SingleSelectOptions: &options,
+				}, nil); err != nil {
+					return partialFailure("failed to set Status options", err), nil
+				}
+				completeStep(fmt.Sprintf("set Status options to %s", strings.Join(statuses, ", ")))
+			}
+
+			for _, fullName := range repositories {
+				repoOwner, repoName := owner, fullName
+				if before, after, found := strings.Cut(fullName, "/"); found {
+					repoOwner, repoName = before, after
+				}
+
+				repoID, err := getRepositoryID(ctx, client, repoOwner, repoName)
+				if err != nil {
+					return partialFailure(fmt.Sprintf("failed to find repository %s/%s", repoOwner, repoName), err), nil
+				}
+
+				var linkRepo struct {
+					LinkProjectV2ToRepository struct {
+						Repository struct {
+							ID githubv4.ID
+						}
+					} `graphql:"linkProjectV2ToRepository(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &linkRepo, githubv4.LinkProjectV2ToRepositoryInput{
+					ProjectID:    project.ID,
+					RepositoryID: repoID,
+				}, nil); err != nil {
+					return partialFailure(fmt.Sprintf("failed to link repository %s/%s", repoOwner, repoName), err), nil
+				}
+				completeStep(fmt.Sprintf("linked repository %s/%s", repoOwner, repoName))
+			}
+
+			response := map[string]any{
+				"project": map[string]any{
+					"id":     project.ID,
+					"number": project.Number,
+					"title":  project.Title,
+					"url":    project.URL,
+				},
+				"steps": steps,
+			}
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+// queryOwnerProject runs a query for a project owned by a user or an organization. Both expose projects
+// under different roots of the GraphQL schema, so T describes the project selection set and the
+// owner and number variables are added to vars. An empty ownerType is detected from the login.
+func queryOwnerProject[T any](ctx context.Context, client *githubv4.Client, owner, ownerType string, number int, vars map[string]any) (T, error) {
+	if ownerType == "" {
+		var err error
+		if ownerType, err = getOwnerType(ctx, client, owner); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	vars["owner"] = githubv4.String(owner)
+	vars["number"] = githubv4.Int(number) // #nosec G115 - project numbers are always small positive integers
+
+	if ownerType == "org" {
+		var query struct {
+			Organization struct {
+				ProjectV2 T `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}
+		err := client.Query(ctx, &query, vars)
+		return query.Organization.ProjectV2, err
+	}
+
+	var query struct {
+		User struct {
+			ProjectV2 T `graphql:"projectV2(number: $number)"`
+		} `graphql:"user(login: $owner)"`
+	}
+	err := client.Query(ctx, &query, vars)
+	return query.User.ProjectV2, err
+}
+
+// WithProjectOwner adds the parameters identifying a project to a tool definition.
+func WithProjectOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Login of the user or organization that owns the project"),
+		)(tool)
+		mcp.WithString("owner_type",
+			mcp.Description("Whether the owner is a user or an organization. Detected from the login if omitted."),
+			mcp.Enum("user", "org"),
+		)(tool)
+		mcp.WithNumber("project_number",
+			mcp.Required(),
+			mcp.Description("The project's number, as shown in its URL"),
+		)(tool)
+	}
+}
+
+// requiredProjectOwner reads the parameters added by WithProjectOwner.
+func requiredProjectOwner(request mcp.CallToolRequest) (owner, ownerType string, number int, err error) {
+	owner, err = RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", 0, err
+	}
+	ownerType, err = OptionalParam[string](request, "owner_type")
+	if err != nil {
+		return "", "", 0, err
+	}
+	number, err = RequiredInt(request, "project_number")
+	if err != nil {
+		return "", "", 0, err
+	}
+	return owner, ownerType, number, nil
+}
+
+// WithProjectItem adds the parameters identifying an item of a project to a tool definition. The item
+// can be given by its node ID, or by the issue or pull request it holds.
+func WithProjectItem() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("item_id",
+			mcp.Description("The node ID of the project item. Either this or item_number is required"),
+		)(tool)
+		mcp.WithString("item_repository",
+			mcp.Description("Repository of the issue or pull request the item holds, as 'owner/name' or just 'name' for repositories of the project owner"),
+		)(tool)
+		mcp.WithNumber("item_number",
+			mcp.Description("Number of the issue or pull request the item holds. Either this or item_id is required"),
+		)(tool)
+	}
+}
+
+// projectItemRef identifies a project item either by node ID or by the issue or pull request it holds.
+type projectItemRef struct {
+	id     string
+	owner  string
+	repo   string
+	number int
+}
+
+// requiredProjectItem reads the parameters added by WithProjectItem. Repository names without an
+// owner are taken to belong to projectOwner.
+func requiredProjectItem(request mcp.CallToolRequest, projectOwner string) (projectItemRef, error) {
+	id, err := OptionalParam[string](request, "item_id")
+	if err != nil {
+		return projectItemRef{}, err
+	}
+	repository, err := OptionalParam[string](request, "item_repository")
+	if err != nil {
+		return projectItemRef{}, err
+	}
+	number, err := OptionalIntParam(request, "item_number")
+	if err != nil {
+		return projectItemRef{}, err
+	}
+
+	switch {
+	case id != "" && number != 0:
+		return projectItemRef{}, fmt.Errorf("only one of item_id or item_number may be provided")
+	case id != "":
+		return projectItemRef{id: id}, nil
+	case number == 0:
+		return projectItemRef{}, fmt.Errorf("one of item_id or item_number must be provided")
+	case repository == "":
+		return projectItemRef{}, fmt.Errorf("item_repository is required with item_number")
+	}
+
+	owner, repo := projectOwner, repository
+	if before, after, found := strings.Cut(repository, "/"); found {
+		owner, repo = before, after
+	}
+	return projectItemRef{owner: owner, repo: repo, number: number}, nil
+}
+
+// resolve returns the node ID of the item in the project with the given ID.
+func (r projectItemRef) resolve(ctx context.Context, client *githubv4.Client, projectID githubv4.ID) (githubv4.ID, error) {
+	if r.id != "" {
+		return githubv4.ID(r.id), nil
+	}
+	return getProjectItemID(ctx, client, projectID, r.owner, r.repo, r.number)
+}
+
+type projectIteration struct {
+	ID        githubv4.String
+	Title     githubv4.String
+	StartDate githubv4.String
+	Duration  githubv4.Int
+}
+
+type projectIterationField struct {
+	ID            githubv4.ID
+	Name          githubv4.String
+	Configuration struct {
+		Duration            githubv4.Int
+		StartDay            githubv4.Int
+		Iterations          []projectIteration
+		CompletedIterations []projectIteration
+	}
+}
+
+type projectIterationFields struct {
+	ID     githubv4.ID
+	Fields struct {
+		PageInfo PageInfoFragment
+		Nodes    []struct {
+			IterationField projectIterationField `graphql:"... on ProjectV2IterationField"`
+		}
+	} `graphql:"fields(first: 50, after: $after)"`
+}
+
+// IterationSummary is an iteration of a project iteration field, with its end date and
+// whether it is completed, current or upcoming computed relative to today.
+type IterationSummary struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Duration  int    `json:"duration_days"`
+	State     string `json:"state"`
+}
+
+// IterationFieldSummary describes the configuration of a project iteration field.
+type IterationFieldSummary struct {
+	ID                  string             `json:"id"`
+	Name                string             `json:"name"`
+	Duration            int                `json:"duration_days"`
+	StartDay            int                `json:"start_day"`
+	Iterations          []IterationSummary `json:"iterations"`
+	CompletedIterations []IterationSummary `json:"completed_iterations"`
+}
+
+func summarizeIteration(it projectIteration, completed bool, today time.Time) IterationSummary {
+	summary := IterationSummary{
+		ID:        string(it.ID),
+		Title:     string(it.Title),
+		StartDate: string(it.StartDate),
+		Duration:  int(it.Duration),
+		State:     "upcoming",
+	}
+	start, err := time.Parse(time.DateOnly, string(it.StartDate))
+	if err != nil {
+		return summary
+	}
+	end := start.AddDate(0, 0, int(it.Duration))
+	// The end date is exclusive in the API, display the last day of the iteration instead.
+	summary.EndDate = end.AddDate(0, 0, -1).Format(time.DateOnly)
+	switch {
+	case completed || !today.Before(end):
+		summary.State = "completed"
+	case !today.Before(start):
+		summary.State = "current"
+	}
+	return summary
+}
+
+func summarizeIterationField(field projectIterationField, today time.Time) IterationFieldSummary {
+	summary := IterationFieldSummary{
+		ID:                  fmt.Sprint(field.ID),
+		Name:                string(field.Name),
+		Duration:            int(field.Configuration.Duration),
+		StartDay:            int(field.Configuration.StartDay),
+		Iterations:          []IterationSummary{},
+		CompletedIterations: []IterationSummary{},
+	}
+	for _, it := range field.Configuration.Iterations {
+		summary.Iterations = append(summary.Iterations, summarizeIteration(it, false, today))
+	}
+	for _, it := range field.Configuration.CompletedIterations {
+		summary.CompletedIterations = append(summary.CompletedIterations, summarizeIteration(it, true, today))
+	}
+	return summary
+}
+
+// getProjectIterationFields returns the project ID and its iteration fields, optionally filtered by name.
+func getProjectIterationFields(ctx context.Context, client *githubv4.Client, owner, ownerType string, number int, fieldName string) (githubv4.ID, []projectIterationField, error) {
+	var projectID githubv4.ID
+	var fields []projectIterationField
+	vars := map[string]any{
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		project, err := queryOwnerProject[projectIterationFields](ctx, client, owner, ownerType, number, vars)
+		if err != nil {
+			return nil, nil, err
+		}
+		projectID = project.ID
+		for _, node := range project.Fields.Nodes {
+			field := node.IterationField
+			if field.Name == "" {
+				// Not an iteration field
+				continue
+			}
+			if fieldName != "" && !strings.EqualFold(string(field.Name), fieldName) {
+				continue
+			}
+			fields = append(fields, field)
+		}
+		if !project.Fields.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = project.Fields.PageInfo.EndCursor
+	}
+	return projectID, fields, nil
+}
+
+// ListProjectIterations creates a tool to list the iterations configured on a project's iteration fields.
+func ListProjectIterations(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_iterations",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_ITERATIONS_DESCRIPTION", "List the iterations (sprints) of a GitHub Project's iteration fields. Each iteration includes its ID, title, start and end dates, and whether it is completed, current or upcoming.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_ITERATIONS_USER_TITLE", "List project iterations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			mcp.WithString("field_name",
+				mcp.Description("Name of the iteration field. If omitted, all iteration fields are returned."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldName, err := OptionalParam[string](request, "field_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			_, fields, err := getProjectIterationFields(ctx, client, owner, ownerType, number, fieldName)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project iteration fields", err), nil
+			}
+
+			today := time.Now().UTC().Truncate(24 * time.Hour)
+			summaries := make([]IterationFieldSummary, 0, len(fields))
+			for _, field := range fields {
+				summaries = append(summaries, summarizeIterationField(field, today))
+			}
+
+			out, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal iteration fields: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+// SetItemIteration creates a tool to move a project item to an iteration.
+func SetItemIteration(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_item_iteration",
+			mcp.WithDescription(t("TOOL_SET_ITEM_ITERATION_DESCRIPTION", "Set the iteration (sprint) of an item in a GitHub Project. The iteration can be given by ID, by title, or as 'current' or 'next' relative to today.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ITEM_ITERATION_USER_TITLE", "Set project item iteration"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithProjectOwner(),
+			WithProjectItem(),
+			mcp.WithString("iteration",
+				mcp.Required(),
+				mcp.Description("Iteration ID, iteration title, or one of 'current' or 'next'"),
+			),
+			mcp.WithString("field_name",
+				mcp.Description("Name of the iteration field. Required if the project has more than one iteration field."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			item, err := requiredProjectItem(request, owner)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			iteration, err := RequiredParam[string](request, "iteration")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldName, err := OptionalParam[string](request, "field_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			projectID, fields, err := getProjectIterationFields(ctx, client, owner, ownerType, number, fieldName)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project iteration fields", err), nil
+			}
+			switch len(fields) {
+			case 0:
+				return mcp.NewToolResultError("no matching iteration field found in the project"), nil
+			case 1:
+			default:
+				return mcp.NewToolResultError("the project has more than one iteration field, specify field_name"), nil
+			}
+
+			today := time.Now().UTC().Truncate(24 * time.Hour)
+			field := summarizeIterationField(fields[0], today)
+			target, err := resolveIteration(field, iteration)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			itemID, err := item.resolve(ctx, client, projectID)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project item", err), nil
+			}
+
+			var mutation struct {
+				UpdateProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+			}
+			iterationID := githubv4.String(target.ID)
+			if err := client.Mutate(ctx, &mutation, githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: projectID,
+				ItemID:    itemID,
+				FieldID:   fields[0].ID,
+				Value: githubv4.ProjectV2FieldValue{
+					IterationID: &iterationID,
+				},
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to set item iteration", err), nil
+			}
+
+			response := map[string]any{
+				"item_id":   itemID,
+				"field":     field.Name,
+				"iteration": target,
+			}
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+// resolveIteration finds the iteration matching the selector, which is either an iteration ID,
+// a title, or 'current'/'next'. Completed iterations can only be selected by ID or title.
+func resolveIteration(field IterationFieldSummary, selector string) (IterationSummary, error) {
+	switch strings.ToLower(selector) {
+	case "current":
+		for _, it := range field.Iterations {
+			if it.State == "current" {
+				return it, nil
+			}
+		}
+		return IterationSummary{}, fmt.Errorf("field %q has no current iteration", field.Name)
+	case "next":
+		for _, it := range field.Iterations {
+			if it.State == "upcoming" {
+				return it, nil
+			}
+		}
+		return IterationSummary{}, fmt.Errorf("field %q has no upcoming iteration", field.Name)
+	}
+
+	all := append(append([]IterationSummary{}, field.Iterations...), field.CompletedIterations...)
+	for _, it := range all {
+		if it.ID == selector {
+			return it, nil
+		}
+	}
+	for _, it := range all {
+		if strings.EqualFold(it.Title, selector) {
+			return it, nil
+		}
+	}
+	return IterationSummary{}, fmt.Errorf("no iteration matching %q found in field %q", selector, field.Name)
+}
+
+// ProjectInsights holds item counts of a project, grouped in different ways.
+type ProjectInsights struct {
+	Title       string         `json:"title"`
+	TotalItems  int            `json:"total_items"`
+	Analyzed    int            `json:"analyzed_items"`
+	Truncated   bool           `json:"truncated"`
+	ByStatus    map[string]int `json:"by_status"`
+	ByAssignee  map[string]int `json:"by_assignee"`
+	ByIteration map[string]int `json:"by_iteration"`
+	ByType      map[string]int `json:"by_type"`
+	ByState     map[string]int `json:"by_state"`
+	Note        string         `json:"note,omitempty"`
+}
+
+func (p *ProjectInsights) add(item ProjectItemFragment, statusField, iterationField string) {
+	p.Analyzed++
+	p.ByType[strings.ToLower(string(item.Type))]++
+	// Redacted items are not visible to the current user and have an unknown state
+	p.ByState[item.State()]++
+
+	assignees := item.ContentFields().Assignees
+	if len(assignees.Nodes) == 0 {
+		p.ByAssignee["(unassigned)"]++
+	}
+	for _, assignee := range assignees.Nodes {
+		p.ByAssignee[string(assignee.Login)]++
+	}
+
+	status, iteration := "(no status)", "(no iteration)"
+	for _, value := range item.FieldValues.Nodes {
+		switch value.Typename {
+		case "ProjectV2ItemFieldSingleSelectValue":
+			if strings.EqualFold(value.FieldName(), statusField) {
+				status = string(value.SingleSelect.Name)
+			}
+		case "ProjectV2ItemFieldIterationValue":
+			if iterationField == "" || strings.EqualFold(value.FieldName(), iterationField) {
+				iteration = string(value.Iteration.Title)
+			}
+		}
+	}
+	p.ByStatus[status]++
+	p.ByIteration[iteration]++
+}
+
+// GetProjectInsights creates a tool that summarizes the items of a project without returning them.
+func GetProjectInsights(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project_insights",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_INSIGHTS_DESCRIPTION", "Get a summary of the items in a GitHub Project: counts by status, assignee, iteration, item type and state. Items are aggregated server-side across all pages, so this is the preferred way to answer progress or burndown questions without listing every item.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PROJECT_INSIGHTS_USER_TITLE", "Get project insights"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			mcp.WithString("status_field",
+				mcp.Description("Name of the single select field to group by status. Defaults to 'Status'."),
+			),
+			mcp.WithString("iteration_field",
+				mcp.Description("Name of the iteration field to group by iteration. If omitted, any iteration field is used."),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description("Maximum number of items to analyze (default 1000, max 10000). The result is flagged as truncated if the project has more items."),
+				mcp.Min(1),
+				mcp.Max(10000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			statusField, err := OptionalParam[string](request, "status_field")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if statusField == "" {
+				statusField = "Status"
+			}
+			iterationField, err := OptionalParam[string](request, "iteration_field")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", 1000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			insights := ProjectInsights{
+				ByStatus:    map[string]int{},
+				ByAssignee:  map[string]int{},
+				ByIteration: map[string]int{},
+				ByType:      map[string]int{},
+				ByState:     map[string]int{},
+			}
+
+			vars := map[string]any{
+				"after": (*githubv4.String)(nil),
+			}
+			for {
+				project, err := queryOwnerProject[projectItemsPage](ctx, client, owner, ownerType, number, vars)
+				if err != nil {
+					if costErr := graphQLCostError(err); costErr != nil && insights.Analyzed > 0 {
+						insights.Note = fmt.Sprintf("Stopped after %d items to stay within the GraphQL budget: %v", insights.Analyzed, costErr)
+						break
+					}
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project items", err), nil
+				}
+				insights.Title = string(project.Title)
+				insights.TotalItems = int(project.Items.TotalCount)
+
+				for _, item := range project.Items.Nodes {
+					if insights.Analyzed >= maxItems {
+						break
+					}
+					insights.add(item, statusField, iterationField)
+				}
+
+				if !project.Items.PageInfo.HasNextPage || insights.Analyzed >= maxItems {
+					break
+				}
+				vars["after"] = project.Items.PageInfo.EndCursor
+			}
+			insights.Truncated = insights.Analyzed < insights.TotalItems
+
+			out, err := json.Marshal(insights)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal project insights: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+// projectExportColumns are the columns every export starts with, followed by the custom fields.
+var projectExportColumns = []string{"Type", "Title", "Number", "Repository", "URL", "State", "Assignees", "Archived", "Item ID", "Draft Body"}
+
+// projectExport collects the rows of a project export. Custom fields become columns in the order
+// they are first seen, as items only carry the fields they have a value for.
+type projectExport struct {
+	fields      []string
+	fieldColumn map[string]int
+	rows        [][]string
+}
+
+func (e *projectExport) add(item ProjectItemFragment) {
+	row := make([]string, len(projectExportColumns), len(projectExportColumns)+len(e.fields))
+	row[0] = strings.ToLower(string(item.Type))
+	row[5] = item.State()
+	if row[5] == "unknown" {
+		row[5] = ""
+	}
+	row[7] = fmt.Sprint(bool(item.IsArchived))
+	if item.ID != nil {
+		row[8] = fmt.Sprint(item.ID)
+	}
+	row[9] = string(item.Content.DraftIssue.Body)
+
+	content := item.ContentFields()
+	row[1] = string(content.Title)
+	if content.Number != 0 {
+		row[2] = fmt.Sprint(int(content.Number))
+	}
+	row[3] = string(content.Repository.NameWithOwner)
+	row[4] = string(content.URL)
+	assignees := make([]string, 0, len(content.Assignees.Nodes))
+	for _, assignee := range content.Assignees.Nodes {
+		assignees = append(assignees, string(assignee.Login))
+	}
+	row[6] = strings.Join(assignees, ", ")
+
+	for _, value := range item.FieldValues.Nodes {
+		text, ok := value.Value()
+		if !ok {
+			continue
+		}
+		name := value.FieldName()
+		if name == "Title" {
+			// The built-in title field is already a column
+			continue
+		}
+		column, ok := e.fieldColumn[name]
+		if !ok {
+			column = len(projectExportColumns) + len(e.fields)
+			e.fields = append(e.fields, name)
+			e.fieldColumn[name] = column
+		}
+		for len(row) <= column {
+			row = append(row, "")
+		}
+		row[column] = text
+	}
+	e.rows = append(e.rows, row)
+}
+
+// write writes the header and rows, padding rows that lack the fields seen after them.
+func (e *projectExport) write(w io.Writer, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	header := append(slices.Clone(projectExportColumns), e.fields...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range e.rows {
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportProjectItems creates a tool that exports every item of a project with its field values as CSV or TSV.
+// When exportDir is empty, the export is returned in the result instead of being stored as a file.
+func ExportProjectItems(getGQLClient GetGQLClientFn, exportDir string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Export the items of a GitHub Project as CSV or TSV for reporting or spreadsheet import. Every page of items is read, and each custom field (text, number, date, single select and iteration) becomes a column. Draft issues include their body, and every item its node ID, so drafts can be edited with update_draft_issue. Set summary to get counts per type, state, assignee and field value instead of the items."
+	if exportDir != "" {
+		description += " The export is stored in the server's archive directory and its path is returned."
+	}
+	return mcp.NewTool("export_project_items",
+			mcp.WithDescription(t("TOOL_EXPORT_PROJECT_ITEMS_DESCRIPTION", description)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPORT_PROJECT_ITEMS_USER_TITLE", "Export project items"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			mcp.WithString("format",
+				mcp.Description("Export format"),
+				mcp.Enum("csv", "tsv"),
+				mcp.DefaultString("csv"),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description("Maximum number of items to export (default 1000, max 10000)"),
+				mcp.Min(1),
+				mcp.Max(10000),
+			),
+			WithSummary(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comma := ','
+			switch format {
+			case "", "csv":
+				format = "csv"
+			case "tsv":
+				comma = '\t'
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported format %q", format)), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", 1000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			summary, err := OptionalParam[bool](request, SummaryParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			if summary {
+				return summarizeProjectItems(ctx, client, owner, ownerType, number)
+			}
+
+			export := projectExport{fieldColumn: map[string]int{}}
+			totalItems := 0
+			var budgetErr error
+			vars := map[string]any{
+				"after": (*githubv4.String)(nil),
+			}
+			for {
+				project, err := queryOwnerProject[projectItemsPage](ctx, client, owner, ownerType, number, vars)
+				if err != nil {
+					// Return the pages read so far rather than nothing once the budget runs out
+					if costErr := graphQLCostError(err); costErr != nil && len(export.rows) > 0 {
+						budgetErr = costErr
+						break
+					}
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project items", err), nil
+				}
+				totalItems = int(project.Items.TotalCount)
+
+				for _, item := range project.Items.Nodes {
+					if len(export.rows) >= maxItems {
+						break
+					}
+					export.add(item)
+				}
+
+				if !project.Items.PageInfo.HasNextPage || len(export.rows) >= maxItems {
+					break
+				}
+				vars["after"] = project.Items.PageInfo.EndCursor
+			}
+
+			var buf bytes.Buffer
+			if err := export.write(&buf, comma); err != nil {
+				return nil, fmt.Errorf("failed to write export: %w", err)
+			}
+			truncated := len(export.rows) < totalItems
+			note := ""
+			if budgetErr != nil {
+				note = fmt.Sprintf("Exported %d of %d items to stay within the GraphQL budget: %v", len(export.rows), totalItems, budgetErr)
+			} else if truncated {
+				note = fmt.Sprintf("Exported %d of %d items, raise max_items to export more.", len(export.rows), totalItems)
+			}
+
+			if exportDir == "" {
+				result := mcp.NewToolResultText(buf.String())
+				if note != "" {
+					result.Content = append(result.Content, mcp.NewTextContent(note))
+				}
+				return result, nil
+			}
+
+			name := archiveFileNameReplacer.Replace(fmt.Sprintf("%s-project-%d", owner, number)) + "." + format
+			path, size, err := storeFile(exportDir, name, &buf)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to store export: %v", err)), nil
+			}
+			stored := map[string]any{
+				"path":        path,
+				"size_bytes":  size,
+				"format":      format,
+				"items":       len(export.rows),
+				"total_items": totalItems,
+				"truncated":   truncated,
+			}
+			if budgetErr != nil {
+				stored["note"] = note
+			}
+			return MarshalledTextResult(stored), nil
+		}
+}
+
+// summarizeProjectItems reads the items of a project across all pages and counts them by type,
+// state, assignee and the value of each single select and iteration field. The first item of each
+// status is kept as a sample, or of each state in projects without a status field.
+func summarizeProjectItems(ctx context.Context, client *githubv4.Client, owner, ownerType string, number int) (*mcp.CallToolResult, error) {
+	summary := newListSummary("type", "state", "assignee")
+	var budgetErr error
+	vars := map[string]any{
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		project, err := queryOwnerProject[projectItemsPage](ctx, client, owner, ownerType, number, vars)
+		if err != nil {
+			// Summarize the pages read so far rather than nothing once the budget runs out
+			if costErr := graphQLCostError(err); costErr != nil && summary.Analyzed > 0 {
+				budgetErr = costErr
+				break
+			}
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project items", err), nil
+		}
+		summary.TotalCount = int(project.Items.TotalCount)
+
+		for _, item := range project.Items.Nodes {
+			if summary.Analyzed >= maxSummaryItems {
+				break
+			}
+			summary.Analyzed++
+			state := item.State()
+			summary.count("type", strings.ToLower(string(item.Type)))
+			summary.count("state", state)
+
+			content := item.ContentFields()
+			if len(content.Assignees.Nodes) == 0 {
+				summary.count("assignee", "")
+			}
+			for _, assignee := range content.Assignees.Nodes {
+				summary.count("assignee", string(assignee.Login))
+			}
+
+			fields := map[string]string{}
+			for _, value := range item.FieldValues.Nodes {
+				text, ok := value.Value()
+				if !ok || value.FieldName() == "Title" {
+					continue
+				}
+				fields[value.FieldName()] = text
+				if value.Typename == "ProjectV2ItemFieldSingleSelectValue" || value.Typename == "ProjectV2ItemFieldIterationValue" {
+					summary.count(value.FieldName(), text)
+				}
+			}
+
+			sampleKey := state
+			if status, ok := fields["Status"]; ok {
+				sampleKey = status
+			}
+			sample := map[string]any{
+				"type":   strings.ToLower(string(item.Type)),
+				"title":  string(content.Title),
+				"state":  state,
+				"fields": fields,
+			}
+			if item.ID != nil {
+				sample["item_id"] = fmt.Sprint(item.ID)
+			}
+			if content.Number != 0 {
+				sample["number"] = int(content.Number)
+				sample["repository"] = string(content.Repository.NameWithOwner)
+				sample["url"] = string(content.URL)
+			}
+			summary.sample(sampleKey, sample)
+		}
+
+		if !project.Items.PageInfo.HasNextPage || summary.Analyzed >= maxSummaryItems {
+			break
+		}
+		vars["after"] = project.Items.PageInfo.EndCursor
+	}
+	summary.Truncated = summary.Analyzed < summary.TotalCount
+
+	result := MarshalledTextResult(summary)
+	if budgetErr != nil {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Summarized %d of %d items to stay within the GraphQL budget: %v", summary.Analyzed, summary.TotalCount, budgetErr)))
+	}
+	return result, nil
+}
+
+// maxSearchQueryLength is the longest query the search API accepts.
+const maxSearchQueryLength = 256
+
+type projectRepositories struct {
+	Repositories struct {
+		TotalCount githubv4.Int
+		PageInfo   PageInfoFragment
+		Nodes      []struct {
+			NameWithOwner githubv4.String
+			URL           githubv4.String
+			Description   githubv4.String
+			IsArchived    githubv4.Boolean
+			IsPrivate     githubv4.Boolean
+		}
+	} `graphql:"repositories(first: $first, after: $after)"`
+}
+
+// ProjectRepository is a repository linked to a project.
+type ProjectRepository struct {
+	FullName    string `json:"full_name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Archived    bool   `json:"archived,omitempty"`
+	Private     bool   `json:"private,omitempty"`
+}
+
+// getProjectRepositories returns a page of the repositories linked to a project, along with the
+// pagination envelope of the page.
+func getProjectRepositories(ctx context.Context, client *githubv4.Client, owner, ownerType string, number int, pagination *GraphQLPaginationParams) ([]ProjectRepository, Pagination, error) {
+	vars := map[string]any{}
+	pagination.Vars(vars)
+	project, err := queryOwnerProject[projectRepositories](ctx, client, owner, ownerType, number, vars)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	repositories := make([]ProjectRepository, 0, len(project.Repositories.Nodes))
+	for _, node := range project.Repositories.Nodes {
+		repositories = append(repositories, ProjectRepository{
+			FullName:    string(node.NameWithOwner),
+			URL:         string(node.URL),
+			Description: string(node.Description),
+			Archived:    bool(node.IsArchived),
+			Private:     bool(node.IsPrivate),
+		})
+	}
+	return repositories, project.Repositories.PageInfo.Pagination(int(project.Repositories.TotalCount)), nil
+}
+
+// ListProjectRepos creates a tool to list the repositories linked to a project.
+func ListProjectRepos(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_repos",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_REPOS_DESCRIPTION", "List the repositories linked to a GitHub Project, which are the repositories the work on the board happens in.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_REPOS_USER_TITLE", "List project repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			repositories, page, err := getProjectRepositories(ctx, client, owner, ownerType, number, paginationParams)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project repositories", err), nil
+			}
+			return MarshalledTextResult(map[string]any{
+				"repositories": repositories,
+				"pagination":   page,
+			}), nil
+		}
+}
+
+type projectWorkflows struct {
+	Workflows struct {
+		TotalCount githubv4.Int
+		PageInfo   PageInfoFragment
+		Nodes      []struct {
+			ID        githubv4.ID
+			Number    githubv4.Int
+			Name      githubv4.String
+			Enabled   githubv4.Boolean
+			UpdatedAt githubv4.DateTime
+		}
+	} `graphql:"workflows(first: $first, after: $after, orderBy: {field: NUMBER, direction: ASC})"`
+}
+
+// builtInProjectWorkflows describes what the built-in workflows of a project do, by name. The API
+// only exposes the name and enabled state of a workflow, not its trigger or the values it sets.
+var builtInProjectWorkflows = map[string]string{
+	"Item added to project":          "Sets a field, usually Status, when an item is added to the project",
+	"Item reopened":                  "Sets a field, usually Status, when an issue or pull request of the project is reopened",
+	"Item closed":                    "Sets a field, usually Status, when an issue or pull request of the project is closed",
+	"Code changes requested":         "Sets a field, usually Status, when a review requests changes on a pull request of the project",
+	"Code review approved":           "Sets a field, usually Status, when a review approves a pull request of the project",
+	"Pull request merged":            "Sets a field, usually Status, when a pull request of the project is merged",
+	"Pull request linked to issue":   "Sets a field, usually Status, of an issue when a pull request is linked to it",
+	"Auto-add to project":            "Adds issues and pull requests matching a filter in a repository to the project",
+	"Auto-add sub-issues to project": "Adds the sub-issues of issues of the project to the project",
+	"Auto-archive items":             "Archives items of the project that match a filter, such as closed for a while",
+	"Auto-close issue":               "Closes an issue when its Status is set to Done",
+}
+
+// ProjectWorkflow is an automation workflow of a project.
+type ProjectWorkflow struct {
+	ID          string `json:"id"`
+	Number      int    `json:"number"`
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description,omitempty"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ListProjectWorkflows creates a tool to list the automation workflows of a project and whether they are enabled.
+func ListProjectWorkflows(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_workflows",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_WORKFLOWS_DESCRIPTION", "List the built-in automation workflows of a GitHub Project, such as setting the status of added or closed items and auto-archiving, with whether each is enabled. The enabled count covers the returned page. Use this to explain or verify how a board is automated.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_WORKFLOWS_USER_TITLE", "List project workflows"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{}
+			paginationParams.Vars(vars)
+			project, err := queryOwnerProject[projectWorkflows](ctx, client, owner, ownerType, number, vars)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project workflows", err), nil
+			}
+
+			workflows := make([]ProjectWorkflow, 0, len(project.Workflows.Nodes))
+			enabled := 0
+			for _, node := range project.Workflows.Nodes {
+				if node.Enabled {
+					enabled++
+				}
+				workflows = append(workflows, ProjectWorkflow{
+					ID:          fmt.Sprint(node.ID),
+					Number:      int(node.Number),
+					Name:        string(node.Name),
+					Enabled:     bool(node.Enabled),
+					Description: builtInProjectWorkflows[string(node.Name)],
+					UpdatedAt:   node.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+				})
+			}
+			return MarshalledTextResult(map[string]any{
+				"enabled_count": enabled,
+				"workflows":     workflows,
+				"pagination":    project.Workflows.PageInfo.Pagination(int(project.Workflows.TotalCount)),
+			}), nil
+		}
+}
+
+// SearchProjectIssues creates a tool to search issues and pull requests across the repositories linked to a project.
+func SearchProjectIssues(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_project_issues",
+			mcp.WithDescription(t("TOOL_SEARCH_PROJECT_ISSUES_DESCRIPTION", "Search issues and pull requests in the repositories linked to a GitHub Project, using GitHub issues search syntax. The search is scoped to those repositories automatically, so the query must not contain repo: filters.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_PROJECT_ISSUES_USER_TITLE", "Search issues in project repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub issues search syntax"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only return issues or pull requests. Defaults to both."),
+				mcp.Enum("issue", "pr"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("comments", "reactions", "interactions", "created", "updated"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if hasRepoFilter(query) {
+				return mcp.NewToolResultError("the query must not contain repo: filters, the search is scoped to the project's repositories"), nil
+			}
+			searchType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if searchType != "" && !hasSpecificFilter(query, "is", searchType) {
+				query = fmt.Sprintf("is:%s %s", searchType, query)
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			// Repositories beyond the first page would not fit in a search query anyway
+			first := int32(100)
+			repositories, page, err := getProjectRepositories(ctx, gqlClient, owner, ownerType, number, &GraphQLPaginationParams{First: &first})
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project repositories", err), nil
+			}
+			total := *page.TotalCount
+			if total == 0 {
+				return mcp.NewToolResultError("the project has no linked repositories, link some or use search_issues instead"), nil
+			}
+			if page.HasMore {
+				return mcp.NewToolResultError(fmt.Sprintf("the project links %d repositories, too many to search at once; use search_issues with repo: filters instead", total)), nil
+			}
+
+			filters := make([]string, 0, len(repositories))
+			for _, repository := range repositories {
+				filters = append(filters, "repo:"+repository.FullName)
+			}
+			scopedQuery := strings.Join(filters, " ") + " " + query
+			if len(scopedQuery) > maxSearchQueryLength {
+				return mcp.NewToolResultError(fmt.Sprintf("the project links %d repositories, too many to fit in a %d character search query; use search_issues with repo: filters instead", total, maxSearchQueryLength)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			result, resp, err := client.Search.Issues(ctx, scopedQuery, &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search issues", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+type projectItemMembershipNode struct {
+	ID         githubv4.ID
+	IsArchived githubv4.Boolean
+	Project    ProjectFragment
+	Status     *struct {
+		SingleSelect struct {
+			Name githubv4.String
+		} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	} `graphql:"status: fieldValueByName(name: $statusField)"`
+}
+
+type projectItemMemberships struct {
+	TotalCount githubv4.Int
+	PageInfo   PageInfoFragment
+	Nodes      []projectItemMembershipNode
+}
+
+type findItemInProjectsQuery struct {
+	Repository struct {
+		IssueOrPullRequest *struct {
+			Typename githubv4.String `graphql:"__typename"`
+			Issue    struct {
+				ProjectItems projectItemMemberships `graphql:"projectItems(first: 50, after: $after, includeArchived: $includeArchived)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ProjectItems projectItemMemberships `graphql:"projectItems(first: 50, after: $after, includeArchived: $includeArchived)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// ProjectMembership is a project an issue or pull request has been added to.
+type ProjectMembership struct {
+	ProjectID     string `json:"project_id"`
+	ProjectNumber int    `json:"project_number"`
+	ProjectTitle  string `json:"project_title"`
+	ProjectURL    string `json:"project_url"`
+	ProjectClosed bool   `json:"project_closed,omitempty"`
+	Owner         string `json:"owner"`
+	OwnerType     string `json:"owner_type"`
+	ItemID        string `json:"item_id"`
+	Status        string `json:"status,omitempty"`
+	Archived      bool   `json:"archived,omitempty"`
+}
+
+// FindItemInProjects creates a tool to find the projects an issue or pull request belongs to.
+func FindItemInProjects(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_item_in_projects",
+			mcp.WithDescription(t("TOOL_FIND_ITEM_IN_PROJECTS_DESCRIPTION", "Find all GitHub Projects an issue or pull request has been added to, with its project item ID and current status in each. Use this to answer which board tracks an issue, and to get the item ID and project owner needed by the other project tools.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_ITEM_IN_PROJECTS_USER_TITLE", "Find item in projects"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithString("status_field",
+				mcp.Description("Name of the single select field holding the status. Defaults to 'Status'."),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Also return projects in which the item has been archived. Defaults to false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			statusField, err := OptionalParam[string](request, "status_field")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if statusField == "" {
+				statusField = "Status"
+			}
+			includeArchived, err := OptionalParam[bool](request, "include_archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner":           githubv4.String(owner),
+				"repo":            githubv4.String(repo),
+				"number":          githubv4.Int(number), // #nosec G115 - issue numbers are always small positive integers
+				"statusField":     githubv4.String(statusField),
+				"includeArchived": githubv4.Boolean(includeArchived),
+				"after":           (*githubv4.String)(nil),
+			}
+			var typename githubv4.String
+			var nodes []projectItemMembershipNode
+			for {
+				var query findItemInProjectsQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find item in projects", err), nil
+				}
+
+				item := query.Repository.IssueOrPullRequest
+				if item == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("issue or pull request %s/%s#%d not found", owner, repo, number)), nil
+				}
+				typename = item.Typename
+				items := item.Issue.ProjectItems
+				if item.Typename == "PullRequest" {
+					items = item.PullRequest.ProjectItems
+				}
+				nodes = append(nodes, items.Nodes...)
+				if !items.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = items.PageInfo.EndCursor
+			}
+
+			projects := make([]ProjectMembership, 0, len(nodes))
+			for _, node := range nodes {
+				membership := ProjectMembership{
+					ProjectID:     fmt.Sprint(node.Project.ID),
+					ProjectNumber: int(node.Project.Number),
+					ProjectTitle:  string(node.Project.Title),
+					ProjectURL:    string(node.Project.URL),
+					ProjectClosed: bool(node.Project.Closed),
+					Owner:         node.Project.Owner.Login(),
+					OwnerType:     node.Project.Owner.Type(),
+					ItemID:        fmt.Sprint(node.ID),
+					Archived:      bool(node.IsArchived),
+				}
+				if node.Status != nil {
+					membership.Status = string(node.Status.SingleSelect.Name)
+				}
+				projects = append(projects, membership)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"type":       string(typename),
+				"number":     number,
+				"projects":   projects,
+				"totalCount": len(projects),
+			}), nil
+		}
+}
+
+// DraftIssue is the content of a draft issue in a project.
+type DraftIssue struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UpdateDraftIssue creates a tool to edit the title and body of a draft issue in a project.
+func UpdateDraftIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_draft_issue",
+			mcp.WithDescription(t("TOOL_UPDATE_DRAFT_ISSUE_DESCRIPTION", "Update the title and/or body of a draft issue in a GitHub Project. The draft can be identified by its own node ID or by the ID of the project item holding it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_DRAFT_ISSUE_USER_TITLE", "Update draft issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("draft_issue_id",
+				mcp.Description("Node ID of the draft issue. Either this or item_id is required"),
+			),
+			mcp.WithString("item_id",
+				mcp.Description("Node ID of the project item holding the draft issue. Either this or draft_issue_id is required"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("New body. An empty string clears the body"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			draftIssueID, err := OptionalParam[string](request, "draft_issue_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemID, err := OptionalParam[string](request, "item_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (draftIssueID == "") == (itemID == "") {
+				return mcp.NewToolResultError("exactly one of draft_issue_id or item_id must be provided"), nil
+			}
+			title, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, bodyProvided, err := OptionalParamOK[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if title == "" && !bodyProvided {
+				return mcp.NewToolResultError("at least one of title or body must be provided"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			if itemID != "" {
+				var query struct {
+					Node struct {
+						ProjectV2Item struct {
+							Content struct {
+								DraftIssue struct {
+									ID githubv4.ID
+								} `graphql:"... on DraftIssue"`
+							}
+						} `graphql:"... on ProjectV2Item"`
+					} `graphql:"node(id: $itemId)"`
+				}
+				if err := client.Query(ctx, &query, map[string]any{"itemId": githubv4.ID(itemID)}); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project item", err), nil
+				}
+				id := query.Node.ProjectV2Item.Content.DraftIssue.ID
+				if id == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("project item %s is not a draft issue", itemID)), nil
+				}
+				draftIssueID = fmt.Sprint(id)
+			}
+
+			input := githubv4.UpdateProjectV2DraftIssueInput{
+				DraftIssueID: githubv4.ID(draftIssueID),
+				Title:        newGQLStringlike[githubv4.String](title),
+			}
+			if bodyProvided {
+				b := githubv4.String(body)
+				input.Body = &b
+			}
+
+			var mutation struct {
+				UpdateProjectV2DraftIssue struct {
+					DraftIssue struct {
+						ID        githubv4.ID
+						Title     githubv4.String
+						Body      githubv4.String
+						UpdatedAt githubv4.DateTime
+					}
+				} `graphql:"updateProjectV2DraftIssue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update draft issue", err), nil
+			}
+
+			draft := mutation.UpdateProjectV2DraftIssue.DraftIssue
+			return MarshalledTextResult(DraftIssue{
+				ID:        fmt.Sprint(draft.ID),
+				Title:     string(draft.Title),
+				Body:      string(draft.Body),
+				UpdatedAt: draft.UpdatedAt.Time,
+			}), nil
+		}
+}
+
+// ProjectItemComment is a comment posted on the issue or pull request held by a project item.
+type ProjectItemComment struct {
+	ItemID      string `json:"item_id"`
+	ContentType string `json:"content_type"`
+	Repository  string `json:"repository"`
+	Number      int    `json:"number"`
+	CommentID   string `json:"comment_id"`
+	CommentURL  string `json:"comment_url"`
+}
+
+// CommentOnProjectItem creates a tool to comment on the issue or pull request held by a project item.
+func CommentOnProjectItem(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("comment_on_project_item",
+			mcp.WithDescription(t("TOOL_COMMENT_ON_PROJECT_ITEM_DESCRIPTION", "Add a comment to the issue or pull request held by a GitHub Project item, given the node ID of the item. Draft issues cannot be commented on.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMMENT_ON_PROJECT_ITEM_USER_TITLE", "Comment on project item"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("item_id",
+				mcp.Required(),
+				mcp.Description("Node ID of the project item"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment content"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			itemID, err := RequiredParam[string](request, "item_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			type commentable struct {
+				ID         githubv4.ID
+				Number     githubv4.Int
+				Repository struct {
+					NameWithOwner githubv4.String
+				}
+			}
+			var query struct {
+				Node struct {
+					ProjectV2Item struct {
+						Type    githubv4.String
+						Content struct {
+							Issue       commentable `graphql:"... on Issue"`
+							PullRequest commentable `graphql:"... on PullRequest"`
+						}
+					} `graphql:"... on ProjectV2Item"`
+				} `graphql:"node(id: $itemId)"`
+			}
+			if err := client.Query(ctx, &query, map[string]any{"itemId": githubv4.ID(itemID)}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project item", err), nil
+			}
+			item := query.Node.ProjectV2Item
+			var content commentable
+			switch item.Type {
+			case "ISSUE":
+				content = item.Content.Issue
+			case "PULL_REQUEST":
+				content = item.Content.PullRequest
+			case "DRAFT_ISSUE":
+				return mcp.NewToolResultError(fmt.Sprintf("project item %s is a draft issue, which cannot be commented on", itemID)), nil
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("project item %s does not hold an issue or pull request the current user can see", itemID)), nil
+			}
+
+			var mutation struct {
+				AddComment struct {
+					CommentEdge struct {
+						Node struct {
+							ID  githubv4.ID
+							URL githubv4.String
+						}
+					}
+				} `graphql:"addComment(input: $input)"`
+			}
+			input := githubv4.AddCommentInput{SubjectID: content.ID, Body: githubv4.String(body)}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add comment", err), nil
+			}
+
+			comment := mutation.AddComment.CommentEdge.Node
+			return MarshalledTextResult(ProjectItemComment{
+				ItemID:      itemID,
+				ContentType: strings.ToLower(string(item.Type)),
+				Repository:  string(content.Repository.NameWithOwner),
+				Number:      int(content.Number),
+				CommentID:   fmt.Sprint(comment.ID),
+				CommentURL:  string(comment.URL),
+			}), nil
+		}
+}
+
+// resolvedProject selects the identifiers of a project and of its owner.
+type resolvedProject struct {
+	ProjectFragment
+	OwnerNode struct {
+		ID githubv4.ID
+	} `graphql:"ownerNode: owner"`
+}
+
+// ResolvedProject lists all the identifiers of a project.
+type ResolvedProject struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Closed    bool   `json:"closed,omitempty"`
+	Owner     string `json:"owner"`
+	OwnerType string `json:"owner_type"`
+	OwnerID   string `json:"owner_id"`
+}
+
+// parseProjectURL extracts the owner and number of a project from its URL, such as
+// https://github.com/orgs/octo-org/projects/7 or https://github.com/users/octocat/projects/3/views/1.
+// The host is not checked, so URLs of GitHub Enterprise Server projects are accepted as well.
+func parseProjectURL(rawURL string) (owner, ownerType string, number int, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid project URL %q: %w", rawURL, err)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 4 || segments[2] != "projects" {
+		return "", "", 0, fmt.Errorf("%q is not a project URL", rawURL)
+	}
+	switch segments[0] {
+	case "orgs":
+		ownerType = "org"
+	case "users":
+		ownerType = "user"
+	default:
+		return "", "", 0, fmt.Errorf("%q is not a project URL", rawURL)
+	}
+	number, err = strconv.Atoi(segments[3])
+	if err != nil || number <= 0 {
+		return "", "", 0, fmt.Errorf("%q is not a project URL", rawURL)
+	}
+	return segments[1], ownerType, number, nil
+}
+
+// ResolveProject creates a tool to look up all the identifiers of a project from any one of them.
+func ResolveProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_project",
+			mcp.WithDescription(t("TOOL_RESOLVE_PROJECT_DESCRIPTION", "Look up a GitHub Project by its URL, by owner and number, or by node ID, and return all of its identifiers along with its owner. Use this to get the node ID other tools need when only the project URL is known.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_PROJECT_USER_TITLE", "Resolve project"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("url",
+				mcp.Description("URL of the project, such as https://github.com/orgs/octo-org/projects/7"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Login of the user or organization that owns the project. Requires project_number"),
+			),
+			mcp.WithString("owner_type",
+				mcp.Description("Whether the owner is a user or an organization. Detected from the login if omitted."),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Description("The project's number, as shown in its URL. Requires owner"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Node ID of the project"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectURL, err := OptionalParam[string](request, "url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerType, err := OptionalParam[string](request, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := OptionalIntParam(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectID, err := OptionalParam[string](request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			given := 0
+			for _, set := range []bool{projectURL != "", owner != "" || number != 0, projectID != ""} {
+				if set {
+					given++
+				}
+			}
+			switch {
+			case given == 0:
+				return mcp.NewToolResultError("one of url, owner and project_number, or project_id must be provided"), nil
+			case given > 1:
+				return mcp.NewToolResultError("only one of url, owner and project_number, or project_id may be provided"), nil
+			case projectURL != "":
+				if owner, ownerType, number, err = parseProjectURL(projectURL); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			case projectID == "" && (owner == "" || number == 0):
+				return mcp.NewToolResultError("owner and project_number must be provided together"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var project resolvedProject
+			if projectID != "" {
+				var query struct {
+					Node struct {
+						ProjectV2 resolvedProject `graphql:"... on ProjectV2"`
+					} `graphql:"node(id: $projectId)"`
+				}
+				if err := client.Query(ctx, &query, map[string]any{"projectId": githubv4.ID(projectID)}); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project", err), nil
+				}
+				project = query.Node.ProjectV2
+			} else {
+				project, err = queryOwnerProject[resolvedProject](ctx, client, owner, ownerType, number, nil)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project", err), nil
+				}
+			}
+			if project.ID == nil {
+				return mcp.NewToolResultError("project not found"), nil
+			}
+
+			return MarshalledTextResult(ResolvedProject{
+				ID:        fmt.Sprint(project.ID),
+				Number:    int(project.Number),
+				Title:     string(project.Title),
+				URL:       string(project.URL),
+				Closed:    bool(project.Closed),
+				Owner:     project.Owner.Login(),
+				OwnerType: project.Owner.Type(),
+				OwnerID:   fmt.Sprint(project.OwnerNode.ID),
+			}), nil
+		}
+}
+
+type projectFields struct {
+	ID     githubv4.ID
+	Fields struct {
+		PageInfo PageInfoFragment
+		Nodes    []ProjectFieldFragment
+	} `graphql:"fields(first: 50, after: $after)"`
+}
+
+// getProjectFields returns the project ID and all of its fields, reading every page.
+func getProjectFields(ctx context.Context, client *githubv4.Client, owner, ownerType string, number int) (githubv4.ID, []ProjectFieldFragment, error) {
+	var projectID githubv4.ID
+	var fields []ProjectFieldFragment
+	vars := map[string]any{
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		project, err := queryOwnerProject[projectFields](ctx, client, owner, ownerType, number, vars)
+		if err != nil {
+			return nil, nil, err
+		}
+		projectID = project.ID
+		fields = append(fields, project.Fields.Nodes...)
+		if !project.Fields.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = project.Fields.PageInfo.EndCursor
+	}
+	return projectID, fields, nil
+}
+
+// ClearProjectItemField creates a tool to reset a field of a project item to empty.
+func ClearProjectItemField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("clear_project_item_field",
+			mcp.WithDescription(t("TOOL_CLEAR_PROJECT_ITEM_FIELD_DESCRIPTION", "Clear the value of a field of an item in a GitHub Project, e.g. to reset its status, assignees, date or iteration to empty.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_CLEAR_PROJECT_ITEM_FIELD_USER_TITLE", "Clear project item field"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithProjectOwner(),
+			WithProjectItem(),
+			mcp.WithString("field_name",
+				mcp.Required(),
+				mcp.Description("Name of the field to clear, e.g. 'Status'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, number, err := requiredProjectOwner(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			item, err := requiredProjectItem(request, owner)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldName, err := RequiredParam[string](request, "field_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			projectID, fields, err := getProjectFields(ctx, client, owner, ownerType, number)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project fields", err), nil
+			}
+			var fieldID githubv4.ID
+			for _, node := range fields {
+				if strings.EqualFold(string(node.Common.Name), fieldName) {
+					fieldID = node.Common.ID
+					fieldName = string(node.Common.Name)
+					break
+				}
+			}
+			if fieldID == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("field %q not found in the project", fieldName)), nil
+			}
+
+			itemID, err := item.resolve(ctx, client, projectID)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project item", err), nil
+			}
+
+			var mutation struct {
+				ClearProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.ClearProjectV2ItemFieldValueInput{
+				ProjectID: projectID,
+				ItemID:    itemID,
+				FieldID:   fieldID,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to clear item field", err), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"item_id": itemID,
+				"field":   fieldName,
+				"cleared": true,
+			}), nil
+		}
+}
+
+type projectTemplateState struct {
+	ID       githubv4.ID
+	Number   githubv4.Int
+	Title    githubv4.String
+	Template githubv4.Boolean
+}
+
+// MarkProjectAsTemplate creates a tool to make an organization project available as a template.
+func MarkProjectAsTemplate(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_project_as_template",
+			mcp.WithDescription(t("TOOL_MARK_PROJECT_AS_TEMPLATE_DESCRIPTION", "Mark an organization's GitHub Project as a template, so members of the organization can create new projects from it. Only organization projects can be templates.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_PROJECT_AS_TEMPLATE_USER_TITLE", "Mark project as template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withOrgProject(),
+		),
+		setProjectTemplateHandler(getGQLClient, true)
+}
+
+// UnmarkProjectAsTemplate creates a tool to stop offering an organization project as a template.
+func UnmarkProjectAsTemplate(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unmark_project_as_template",
+			mcp.WithDescription(t("TOOL_UNMARK_PROJECT_AS_TEMPLATE_DESCRIPTION", "Unmark an organization's GitHub Project as a template. The project itself is left untouched.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNMARK_PROJECT_AS_TEMPLATE_USER_TITLE", "Unmark project as template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withOrgProject(),
+		),
+		setProjectTemplateHandler(getGQLClient, false)
+}
+
+// withOrgProject adds the parameters identifying an organization project to a tool definition.
+func withOrgProject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Login of the organization that owns the project"),
+		)(tool)
+		mcp.WithNumber("project_number",
+			mcp.Required(),
+			mcp.Description("The project's number, as shown in its URL"),
+		)(tool)
+	}
+}
+
+func setProjectTemplateHandler(getGQLClient GetGQLClientFn, template bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		number, err := RequiredInt(request, "project_number")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+
+		projectID, err := getProjectID(ctx, client, owner, "org", number)
+		if err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project", err), nil
+		}
+
+		var result projectTemplateState
+		if template {
+			var mutation struct {
+				MarkProjectV2AsTemplate struct {
+					ProjectV2 projectTemplateState
+				} `graphql:"markProjectV2AsTemplate(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.MarkProjectV2AsTemplateInput{ProjectID: projectID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to mark project as template", err), nil
+			}
+			result = mutation.MarkProjectV2AsTemplate.ProjectV2
+		} else {
+			var mutation struct {
+				UnmarkProjectV2AsTemplate struct {
+					ProjectV2 projectTemplateState
+				} `graphql:"unmarkProjectV2AsTemplate(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.UnmarkProjectV2AsTemplateInput{ProjectID: projectID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unmark project as template", err), nil
+			}
+			result = mutation.UnmarkProjectV2AsTemplate.ProjectV2
+		}
+
+		return MarshalledTextResult(map[string]any{
+			"id":       result.ID,
+			"number":   result.Number,
+			"title":    result.Title,
+			"template": result.Template,
+		}), nil
+	}
+}
+
+// maxTemplateLookups bounds how many projects of an organization list_project_templates looks through.
+const maxTemplateLookups = 1000
+
+// ProjectTemplate is an organization project offered as a template.
+type ProjectTemplate struct {
+	ID               githubv4.ID `json:"id"`
+	Number           int         `json:"number"`
+	Title            string      `json:"title"`
+	ShortDescription string      `json:"short_description,omitempty"`
+	URL              string      `json:"url"`
+	Closed           bool        `json:"closed"`
+}
+
+type projectTemplatesPage struct {
+	ProjectsV2 struct {
+		Nodes []struct {
+			ID               githubv4.ID
+			Number           githubv4.Int
+			Title            githubv4.String
+			ShortDescription githubv4.String
+			URL              githubv4.String
+			Closed           githubv4.Boolean
+			Template         githubv4.Boolean
+		}
+		PageInfo struct {
+			HasNextPage githubv4.Boolean
+			EndCursor   githubv4.String
+		}
+	} `graphql:"projectsV2(first: 100, after: $after, query: $query)"`
+}
+
+// ListProjectTemplates creates a tool to discover the projects an organization offers as templates.
+func ListProjectTemplates(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_templates",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_TEMPLATES_DESCRIPTION", "List the GitHub Projects an organization offers as templates, which members can create new projects from. Use mark_project_as_template and unmark_project_as_template to change which projects are templates.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_TEMPLATES_USER_TITLE", "List project templates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the organization"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Only list templates whose title matches this text"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// Whether a project is a template cannot be filtered on, so look through the projects page by page
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"after": (*githubv4.String)(nil),
+				"query": githubv4.String(query),
+			}
+			templates := []ProjectTemplate{}
+			scanned := 0
+			for {
+				var q struct {
+					Organization projectTemplatesPage `graphql:"organization(login: $owner)"`
+				}
+				if err := client.Query(ctx, &q, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list projects", err), nil
+				}
+				page := q.Organization.ProjectsV2
+				for _, project := range page.Nodes {
+					scanned++
+					if !project.Template {
+						continue
+					}
+					templates = append(templates, ProjectTemplate{
+						ID:               project.ID,
+						Number:           int(project.Number),
+						Title:            string(project.Title),
+						ShortDescription: string(project.ShortDescription),
+						URL:              string(project.URL),
+						Closed:           bool(project.Closed),
+					})
+				}
+				if !page.PageInfo.HasNextPage || scanned >= maxTemplateLookups {
+					break
+				}
+				vars["after"] = page.PageInfo.EndCursor
+			}
+
+			result := map[string]any{
+				"templates": templates,
+			}
+			if scanned >= maxTemplateLookups {
+				result["note"] = fmt.Sprintf("Only the first %d projects were looked through, pass a query to narrow them down.", maxTemplateLookups)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// The fragments below are the selection sets the project tools share, so that every tool reads
+// projects, fields and items the same way and a new field only has to be added once.
+
+// ProjectOwnerFragment selects the user or organization owning a project.
+type ProjectOwnerFragment struct {
+	Typename     githubv4.String `graphql:"__typename"`
+	Organization struct {
+		Login githubv4.String
+	} `graphql:"... on Organization"`
+	User struct {
+		Login githubv4.String
+	} `graphql:"... on User"`
+}
+
+// Login returns the login of the owner.
+func (o ProjectOwnerFragment) Login() string {
+	if o.Typename == "Organization" {
+		return string(o.Organization.Login)
+	}
+	return string(o.User.Login)
+}
+
+// Type returns the owner type as the owner_type parameter of the project tools takes it.
+func (o ProjectOwnerFragment) Type() string {
+	if o.Typename == "Organization" {
+		return "org"
+	}
+	return "user"
+}
+
+// ProjectFragment selects the fields identifying a project.
+type ProjectFragment struct {
+	ID     githubv4.ID
+	Number githubv4.Int
+	Title  githubv4.String
+	URL    githubv4.String
+	Closed githubv4.Boolean
+	Owner  ProjectOwnerFragment
+}
+
+// ProjectFieldFragment selects the fields every kind of project field has.
+type ProjectFieldFragment struct {
+	Common struct {
+		ID   githubv4.ID
+		Name githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// ProjectAssigneesFragment selects the assignees of the content of a project item.
+type ProjectAssigneesFragment struct {
+	Nodes []struct {
+		Login githubv4.String
+	}
+}
+
+// ProjectItemContentFragment selects the fields issues and pull requests held by project items share.
+type ProjectItemContentFragment struct {
+	Title      githubv4.String
+	Number     githubv4.Int
+	URL        githubv4.String
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+	Assignees ProjectAssigneesFragment `graphql:"assignees(first: 10)"`
+}
+
+// ProjectItemFieldValueFragment selects the value of a field of a project item. Typename tells
+// which of the value kinds is set.
+type ProjectItemFieldValueFragment struct {
+	Typename githubv4.String `graphql:"__typename"`
+	Text     struct {
+		Text  githubv4.String
+		Field ProjectFieldFragment
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	Number struct {
+		Number githubv4.Float
+		Field  ProjectFieldFragment
+	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	Date struct {
+		Date  githubv4.String
+		Field ProjectFieldFragment
+	} `graphql:"... on ProjectV2ItemFieldDateValue"`
+	SingleSelect struct {
+		Name  githubv4.String
+		Field ProjectFieldFragment
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Iteration struct {
+		Title githubv4.String
+		Field ProjectFieldFragment
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+}
+
+// FieldName returns the name of the field the value belongs to.
+func (v ProjectItemFieldValueFragment) FieldName() string {
+	switch v.Typename {
+	case "ProjectV2ItemFieldTextValue":
+		return string(v.Text.Field.Common.Name)
+	case "ProjectV2ItemFieldNumberValue":
+		return string(v.Number.Field.Common.Name)
+	case "ProjectV2ItemFieldDateValue":
+		return string(v.Date.Field.Common.Name)
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return string(v.SingleSelect.Field.Common.Name)
+	case "ProjectV2ItemFieldIterationValue":
+		return string(v.Iteration.Field.Common.Name)
+	}
+	return ""
+}
+
+// Value returns the value formatted as text, and false for value kinds that are not read.
+func (v ProjectItemFieldValueFragment) Value() (string, bool) {
+	switch v.Typename {
+	case "ProjectV2ItemFieldTextValue":
+		return string(v.Text.Text), true
+	case "ProjectV2ItemFieldNumberValue":
+		return strconv.FormatFloat(float64(v.Number.Number), 'f', -1, 64), true
+	case "ProjectV2ItemFieldDateValue":
+		return string(v.Date.Date), true
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return string(v.SingleSelect.Name), true
+	case "ProjectV2ItemFieldIterationValue":
+		return string(v.Iteration.Title), true
+	}
+	return "", false
+}
+
+// ProjectItemFragment selects a project item along with its content and field values.
+type ProjectItemFragment struct {
+	ID         githubv4.ID
+	Type       githubv4.String
+	IsArchived githubv4.Boolean
+	Content    struct {
+		Issue struct {
+			ProjectItemContentFragment
+			IssueState githubv4.String `graphql:"issueState: state"`
+		} `graphql:"... on Issue"`
+		PullRequest struct {
+			ProjectItemContentFragment
+			PullRequestState githubv4.String `graphql:"pullRequestState: state"`
+		} `graphql:"... on PullRequest"`
+		DraftIssue struct {
+			Title     githubv4.String
+			Body      githubv4.String
+			Assignees ProjectAssigneesFragment `graphql:"assignees(first: 10)"`
+		} `graphql:"... on DraftIssue"`
+	}
+	FieldValues struct {
+		Nodes []ProjectItemFieldValueFragment
+	} `graphql:"fieldValues(first: 50)"`
+}
+
+// ContentFields returns the content of the item. Draft issues only have a title and assignees,
+// their body is read from Content.DraftIssue.
+func (i ProjectItemFragment) ContentFields() ProjectItemContentFragment {
+	switch i.Type {
+	case "ISSUE":
+		return i.Content.Issue.ProjectItemContentFragment
+	case "PULL_REQUEST":
+		return i.Content.PullRequest.ProjectItemContentFragment
+	case "DRAFT_ISSUE":
+		return ProjectItemContentFragment{Title: i.Content.DraftIssue.Title, Assignees: i.Content.DraftIssue.Assignees}
+	}
+	return ProjectItemContentFragment{}
+}
+
+// State returns the lowercase state of the content of the item, draft for draft issues and
+// unknown for items the current user cannot see.
+func (i ProjectItemFragment) State() string {
+	switch i.Type {
+	case "ISSUE":
+		return strings.ToLower(string(i.Content.Issue.IssueState))
+	case "PULL_REQUEST":
+		return strings.ToLower(string(i.Content.PullRequest.PullRequestState))
+	case "DRAFT_ISSUE":
+		return "draft"
+	}
+	return "unknown"
+}
+
+// ProjectItemsFragment selects a page of the items of a project.
+type ProjectItemsFragment struct {
+	TotalCount githubv4.Int
+	PageInfo   PageInfoFragment
+	Nodes      []ProjectItemFragment
+}
+
+// projectItemsPage is a page of the items of a project, queried with queryOwnerProject.
+type projectItemsPage struct {
+	Title githubv4.String
+	Items ProjectItemsFragment `graphql:"items(first: 100, after: $after)"`
+}
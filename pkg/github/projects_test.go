@@ -0,0 +1,2257 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProvisionProject(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ProvisionProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "provision_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "owner_type")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "fields")
+	assert.Contains(t, tool.InputSchema.Properties, "statuses")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "title"})
+
+	orgIDMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ID githubv4.ID
+			} `graphql:"organization(login: $login)"`
+		}{},
+		map[string]any{
+			"login": githubv4.String("octo-org"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{"id": "O_1"},
+		}),
+	)
+
+	createProjectMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			CreateProjectV2 struct {
+				ProjectV2 ProjectFragment
+			} `graphql:"createProjectV2(input: $input)"`
+		}{},
+		githubv4.CreateProjectV2Input{
+			OwnerID: "O_1",
+			Title:   "Roadmap",
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"createProjectV2": map[string]any{
+				"projectV2": map[string]any{
+					"id":     "PVT_1",
+					"number": 7,
+					"title":  "Roadmap",
+					"url":    "https://github.com/orgs/octo-org/projects/7",
+				},
+			},
+		}),
+	)
+
+	priorityOptions := []githubv4.ProjectV2SingleSelectFieldOptionInput{
+		{Name: "P0", Color: githubv4.ProjectV2SingleSelectFieldOptionColorGray, Description: ""},
+		{Name: "P1", Color: githubv4.ProjectV2SingleSelectFieldOptionColorGray, Description: ""},
+	}
+	createFieldMatcher := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CreateProjectV2Field struct {
+					ProjectV2Field ProjectFieldFragment
+				} `graphql:"createProjectV2Field(input: $input)"`
+			}{},
+			githubv4.CreateProjectV2FieldInput{
+				ProjectID:           "PVT_1",
+				DataType:            githubv4.ProjectV2CustomFieldTypeSingleSelect,
+				Name:                "Priority",
+				SingleSelectOptions: &priorityOptions,
+			},
+			nil,
+			response,
+		)
+	}
+
+	statusFieldMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Node struct {
+				ProjectV2 struct {
+					Field struct {
+						SingleSelectField struct {
+							ID githubv4.ID
+						} `graphql:"... on ProjectV2SingleSelectField"`
+					} `graphql:"field(name: $fieldName)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $projectId)"`
+		}{},
+		map[string]any{
+			"projectId": githubv4.ID("PVT_1"),
+			"fieldName": githubv4.String("Status"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{
+				"field": map[string]any{"id": "PVTSSF_status"},
+			},
+		}),
+	)
+
+	statusOptions := []githubv4.ProjectV2SingleSelectFieldOptionInput{
+		{Name: "Backlog", Color: githubv4.ProjectV2SingleSelectFieldOptionColorGray, Description: ""},
+		{Name: "Shipped", Color: githubv4.ProjectV2SingleSelectFieldOptionColorGray, Description: ""},
+	}
+	updateStatusMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			UpdateProjectV2Field struct {
+				ProjectV2Field ProjectFieldFragment
+			} `graphql:"updateProjectV2Field(input: $input)"`
+		}{},
+		UpdateProjectV2FieldInput{
+			FieldID:             "PVTSSF_status",
+			SingleSelectOptions: &statusOptions,
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"updateProjectV2Field": map[string]any{
+				"projectV2Field": map[string]any{"id": "PVTSSF_status"},
+			},
+		}),
+	)
+
+	repoIDMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("octo-org"),
+			"repo":  githubv4.String("api"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"id": "R_1"},
+		}),
+	)
+
+	linkRepoMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			LinkProjectV2ToRepository struct {
+				Repository struct {
+					ID githubv4.ID
+				}
+			} `graphql:"linkProjectV2ToRepository(input: $input)"`
+		}{},
+		githubv4.LinkProjectV2ToRepositoryInput{
+			ProjectID:    "PVT_1",
+			RepositoryID: "R_1",
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"linkProjectV2ToRepository": map[string]any{
+				"repository": map[string]any{"id": "R_1"},
+			},
+		}),
+	)
+
+	fullArgs := map[string]any{
+		"owner":      "octo-org",
+		"owner_type": "org",
+		"title":      "Roadmap",
+		"fields": []any{
+			map[string]any{"name": "Priority", "type": "single_select", "options": []any{"P0", "P1"}},
+		},
+		"statuses":     []any{"Backlog", "Shipped"},
+		"repositories": []any{"api"},
+	}
+
+	tests := []struct {
+		name           string
+		matchers       []githubv4mock.Matcher
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg []string
+		expectedSteps  []string
+	}{
+		{
+			name: "provisions project with fields, statuses and repositories",
+			matchers: []githubv4mock.Matcher{
+				orgIDMatcher,
+				createProjectMatcher,
+				createFieldMatcher(githubv4mock.DataResponse(map[string]any{
+					"createProjectV2Field": map[string]any{
+						"projectV2Field": map[string]any{"id": "PVTSSF_priority"},
+					},
+				})),
+				statusFieldMatcher,
+				updateStatusMatcher,
+				repoIDMatcher,
+				linkRepoMatcher,
+			},
+			requestArgs: fullArgs,
+			expectedSteps: []string{
+				`created project #7 "Roadmap"`,
+				`created single_select field "Priority"`,
+				"set Status options to Backlog, Shipped",
+				"linked repository octo-org/api",
+			},
+		},
+		{
+			name: "detects the owner type when it is omitted",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(
+					struct {
+						User struct {
+							ID githubv4.ID
+						} `graphql:"user(login: $login)"`
+					}{},
+					map[string]any{
+						"login": githubv4.String("octo-org"),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a User with the login of 'octo-org'."),
+				),
+				orgIDMatcher,
+				createProjectMatcher,
+			},
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"title": "Roadmap",
+			},
+			expectedSteps: []string{
+				`created project #7 "Roadmap"`,
+			},
+		},
+		{
+			name: "fails when the owner is neither a user nor an organization",
+			requestArgs: map[string]any{
+				"owner": "ghost",
+				"title": "Roadmap",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: []string{"failed to resolve owner ghost"},
+		},
+		{
+			name: "reports completed steps when a later step fails",
+			matchers: []githubv4mock.Matcher{
+				orgIDMatcher,
+				createProjectMatcher,
+				createFieldMatcher(githubv4mock.ErrorResponse("field name already taken")),
+			},
+			requestArgs:   fullArgs,
+			expectToolErr: true,
+			expectedErrMsg: []string{
+				`failed to create field "Priority"`,
+				`created project #7 "Roadmap"`,
+			},
+		},
+		{
+			name: "rejects single select field without options",
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"owner_type": "org",
+				"title":      "Roadmap",
+				"fields": []any{
+					map[string]any{"name": "Priority", "type": "single_select"},
+				},
+			},
+			expectToolErr:  true,
+			expectedErrMsg: []string{`single_select field "Priority" must have at least one option`},
+		},
+		{
+			name: "rejects unsupported field type",
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"owner_type": "org",
+				"title":      "Roadmap",
+				"fields": []any{
+					map[string]any{"name": "Sprint", "type": "iteration"},
+				},
+			},
+			expectToolErr:  true,
+			expectedErrMsg: []string{`field "Sprint" has unsupported type "iteration"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			gqlClient := githubv4.NewClient(httpClient)
+			_, handler := ProvisionProject(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				var text string
+				for _, content := range result.Content {
+					textContent, ok := content.(mcp.TextContent)
+					require.True(t, ok)
+					text += textContent.Text
+				}
+				for _, msg := range tc.expectedErrMsg {
+					assert.Contains(t, text, msg)
+				}
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Project struct {
+					ID     string `json:"id"`
+					Number int    `json:"number"`
+					URL    string `json:"url"`
+				} `json:"project"`
+				Steps []string `json:"steps"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "PVT_1", response.Project.ID)
+			assert.Equal(t, 7, response.Project.Number)
+			assert.Equal(t, "https://github.com/orgs/octo-org/projects/7", response.Project.URL)
+			assert.Equal(t, tc.expectedSteps, response.Steps)
+		})
+	}
+}
+
+func projectIterationFieldsMatcher(fieldsResponse []any) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 projectIterationFields `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+			"after":  (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{
+					"id": "PVT_1",
+					"fields": map[string]any{
+						"pageInfo": map[string]any{"hasNextPage": false},
+						"nodes":    fieldsResponse,
+					},
+				},
+			},
+		}),
+	)
+}
+
+var sprintFieldResponse = map[string]any{
+	"id":   "PVTIF_sprint",
+	"name": "Sprint",
+	"configuration": map[string]any{
+		"duration": 14,
+		"startDay": 1,
+		"iterations": []any{
+			map[string]any{"id": "it3", "title": "Sprint 3", "startDate": "2999-01-05", "duration": 14},
+			map[string]any{"id": "it4", "title": "Sprint 4", "startDate": "2999-01-19", "duration": 14},
+		},
+		"completedIterations": []any{
+			map[string]any{"id": "it1", "title": "Sprint 1", "startDate": "2020-01-06", "duration": 14},
+		},
+	},
+}
+
+func Test_ListProjectIterations(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectIterations(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_iterations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "field_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(projectIterationFieldsMatcher([]any{
+		// Non iteration fields are returned without the inline fragment fields
+		map[string]any{},
+		sprintFieldResponse,
+	}))
+	_, handler := ListProjectIterations(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octo-org",
+		"owner_type":     "org",
+		"project_number": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var fields []IterationFieldSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fields))
+	require.Len(t, fields, 1)
+	assert.Equal(t, "PVTIF_sprint", fields[0].ID)
+	assert.Equal(t, "Sprint", fields[0].Name)
+	assert.Equal(t, 14, fields[0].Duration)
+	require.Len(t, fields[0].Iterations, 2)
+	assert.Equal(t, IterationSummary{ID: "it3", Title: "Sprint 3", StartDate: "2999-01-05", EndDate: "2999-01-18", Duration: 14, State: "upcoming"}, fields[0].Iterations[0])
+	require.Len(t, fields[0].CompletedIterations, 1)
+	assert.Equal(t, "completed", fields[0].CompletedIterations[0].State)
+	assert.Equal(t, "2020-01-19", fields[0].CompletedIterations[0].EndDate)
+
+	t.Run("detects the owner type when it is omitted", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			repositoryOwnerMatcher("octo-org", "Organization"),
+			projectIterationFieldsMatcher([]any{sprintFieldResponse}),
+		)
+		_, handler := ListProjectIterations(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "octo-org",
+			"project_number": float64(7),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var fields []IterationFieldSummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fields))
+		require.Len(t, fields, 1)
+		assert.Equal(t, "PVTIF_sprint", fields[0].ID)
+	})
+}
+
+func Test_SummarizeIteration(t *testing.T) {
+	it := projectIteration{ID: "it", Title: "Sprint", StartDate: "2024-03-04", Duration: 7}
+
+	tests := []struct {
+		name     string
+		today    string
+		expected string
+	}{
+		{name: "before start", today: "2024-03-03", expected: "upcoming"},
+		{name: "first day", today: "2024-03-04", expected: "current"},
+		{name: "last day", today: "2024-03-10", expected: "current"},
+		{name: "after end", today: "2024-03-11", expected: "completed"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			today, err := time.Parse(time.DateOnly, tc.today)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, summarizeIteration(it, false, today).State)
+		})
+	}
+}
+
+func Test_SetItemIteration(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := SetItemIteration(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_item_iteration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "item_id")
+	assert.Contains(t, tool.InputSchema.Properties, "iteration")
+	assert.Contains(t, tool.InputSchema.Properties, "field_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "iteration"})
+
+	setIterationMatcher := func(iterationID string) githubv4mock.Matcher {
+		id := githubv4.String(iterationID)
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+			}{},
+			githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: "PVT_1",
+				ItemID:    "PVTI_1",
+				FieldID:   "PVTIF_sprint",
+				Value: githubv4.ProjectV2FieldValue{
+					IterationID: &id,
+				},
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateProjectV2ItemFieldValue": map[string]any{
+					"projectV2Item": map[string]any{"id": "PVTI_1"},
+				},
+			}),
+		)
+	}
+
+	otherIterationField := map[string]any{
+		"id":            "PVTIF_release",
+		"name":          "Release",
+		"configuration": map[string]any{"duration": 28, "startDay": 1, "iterations": []any{}, "completedIterations": []any{}},
+	}
+
+	tests := []struct {
+		name              string
+		fields            []any
+		mutationMatcher   *githubv4mock.Matcher
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedIteration string
+	}{
+		{
+			name:              "next iteration",
+			fields:            []any{sprintFieldResponse},
+			mutationMatcher:   githubv4mock.Ptr(setIterationMatcher("it3")),
+			requestArgs:       map[string]any{"iteration": "next"},
+			expectedIteration: "it3",
+		},
+		{
+			name:              "iteration by title",
+			fields:            []any{sprintFieldResponse, otherIterationField},
+			mutationMatcher:   githubv4mock.Ptr(setIterationMatcher("it4")),
+			requestArgs:       map[string]any{"iteration": "sprint 4", "field_name": "Sprint"},
+			expectedIteration: "it4",
+		},
+		{
+			name:              "completed iteration by ID",
+			fields:            []any{sprintFieldResponse},
+			mutationMatcher:   githubv4mock.Ptr(setIterationMatcher("it1")),
+			requestArgs:       map[string]any{"iteration": "it1"},
+			expectedIteration: "it1",
+		},
+		{
+			name:           "no current iteration",
+			fields:         []any{sprintFieldResponse},
+			requestArgs:    map[string]any{"iteration": "current"},
+			expectError:    true,
+			expectedErrMsg: `field "Sprint" has no current iteration`,
+		},
+		{
+			name:           "ambiguous field",
+			fields:         []any{sprintFieldResponse, otherIterationField},
+			requestArgs:    map[string]any{"iteration": "next"},
+			expectError:    true,
+			expectedErrMsg: "specify field_name",
+		},
+		{
+			name:           "unknown iteration",
+			fields:         []any{sprintFieldResponse},
+			requestArgs:    map[string]any{"iteration": "Sprint 99"},
+			expectError:    true,
+			expectedErrMsg: `no iteration matching "Sprint 99"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matchers := []githubv4mock.Matcher{projectIterationFieldsMatcher(tc.fields)}
+			if tc.mutationMatcher != nil {
+				matchers = append(matchers, *tc.mutationMatcher)
+			}
+			httpClient := githubv4mock.NewMockedHTTPClient(matchers...)
+			_, handler := SetItemIteration(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"item_id":        "PVTI_1",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Iteration IterationSummary `json:"iteration"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedIteration, response.Iteration.ID)
+		})
+	}
+}
+
+func Test_GetProjectInsights(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetProjectInsights(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_project_insights", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "status_field")
+	assert.Contains(t, tool.InputSchema.Properties, "iteration_field")
+	assert.Contains(t, tool.InputSchema.Properties, "max_items")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	query := struct {
+		User struct {
+			ProjectV2 projectItemsPage `graphql:"projectV2(number: $number)"`
+		} `graphql:"user(login: $owner)"`
+	}{}
+
+	fieldValues := func(status, iteration string) map[string]any {
+		var nodes []any
+		if status != "" {
+			nodes = append(nodes, map[string]any{"__typename": "ProjectV2ItemFieldSingleSelectValue", "name": status, "field": map[string]any{"name": "Status"}})
+		}
+		if iteration != "" {
+			nodes = append(nodes, map[string]any{"__typename": "ProjectV2ItemFieldIterationValue", "title": iteration, "field": map[string]any{"name": "Sprint"}})
+		}
+		return map[string]any{"nodes": nodes}
+	}
+	assignees := func(logins ...string) map[string]any {
+		nodes := []any{}
+		for _, login := range logins {
+			nodes = append(nodes, map[string]any{"login": login})
+		}
+		return map[string]any{"nodes": nodes}
+	}
+
+	firstPage := githubv4mock.NewQueryMatcher(query,
+		map[string]any{
+			"owner":  githubv4.String("octocat"),
+			"number": githubv4.Int(3),
+			"after":  (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"user": map[string]any{
+				"projectV2": map[string]any{
+					"title": "Board",
+					"items": map[string]any{
+						"totalCount": 3,
+						"pageInfo":   map[string]any{"hasNextPage": true, "endCursor": "cursor1"},
+						"nodes": []any{
+							map[string]any{
+								"type":        "ISSUE",
+								"content":     map[string]any{"issueState": "OPEN", "assignees": assignees("alice", "bob")},
+								"fieldValues": fieldValues("In Progress", "Sprint 1"),
+							},
+							map[string]any{
+								"type":        "PULL_REQUEST",
+								"content":     map[string]any{"pullRequestState": "MERGED", "assignees": assignees("alice")},
+								"fieldValues": fieldValues("Done", "Sprint 1"),
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+	secondPage := githubv4mock.NewQueryMatcher(query,
+		map[string]any{
+			"owner":  githubv4.String("octocat"),
+			"number": githubv4.Int(3),
+			"after":  githubv4.String("cursor1"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"user": map[string]any{
+				"projectV2": map[string]any{
+					"title": "Board",
+					"items": map[string]any{
+						"totalCount": 3,
+						"pageInfo":   map[string]any{"hasNextPage": false, "endCursor": "cursor2"},
+						"nodes": []any{
+							map[string]any{
+								"type":        "DRAFT_ISSUE",
+								"content":     map[string]any{"assignees": assignees()},
+								"fieldValues": fieldValues("", ""),
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name     string
+		matchers []githubv4mock.Matcher
+		args     map[string]any
+		expected ProjectInsights
+	}{
+		{
+			name:     "aggregates all pages",
+			matchers: []githubv4mock.Matcher{firstPage, secondPage},
+			args:     map[string]any{},
+			expected: ProjectInsights{
+				Title:       "Board",
+				TotalItems:  3,
+				Analyzed:    3,
+				ByStatus:    map[string]int{"In Progress": 1, "Done": 1, "(no status)": 1},
+				ByAssignee:  map[string]int{"alice": 2, "bob": 1, "(unassigned)": 1},
+				ByIteration: map[string]int{"Sprint 1": 2, "(no iteration)": 1},
+				ByType:      map[string]int{"issue": 1, "pull_request": 1, "draft_issue": 1},
+				ByState:     map[string]int{"open": 1, "merged": 1, "draft": 1},
+			},
+		},
+		{
+			name:     "stops at max items",
+			matchers: []githubv4mock.Matcher{firstPage},
+			args:     map[string]any{"max_items": float64(1), "status_field": "status"},
+			expected: ProjectInsights{
+				Title:       "Board",
+				TotalItems:  3,
+				Analyzed:    1,
+				Truncated:   true,
+				ByStatus:    map[string]int{"In Progress": 1},
+				ByAssignee:  map[string]int{"alice": 1, "bob": 1},
+				ByIteration: map[string]int{"Sprint 1": 1},
+				ByType:      map[string]int{"issue": 1},
+				ByState:     map[string]int{"open": 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			_, handler := GetProjectInsights(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": float64(3),
+			}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var insights ProjectInsights
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &insights))
+			assert.Equal(t, tc.expected, insights)
+		})
+	}
+}
+
+func Test_ExportProjectItems(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ExportProjectItems(stubGetGQLClientFn(mockClient), "", translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "export_project_items", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.Contains(t, tool.InputSchema.Properties, "max_items")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	query := struct {
+		Organization struct {
+			ProjectV2 projectItemsPage `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}{}
+	value := func(typename, key string, v any, field string) map[string]any {
+		return map[string]any{"__typename": typename, key: v, "field": map[string]any{"name": field}}
+	}
+	page := func(after any, hasNextPage bool, nodes ...any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(query,
+			map[string]any{
+				"owner":  githubv4.String("octo-org"),
+				"number": githubv4.Int(7),
+				"after":  after,
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"organization": map[string]any{
+					"projectV2": map[string]any{
+						"title": "Roadmap",
+						"items": map[string]any{
+							"totalCount": 3,
+							"pageInfo":   map[string]any{"hasNextPage": hasNextPage, "endCursor": "cursor1"},
+							"nodes":      nodes,
+						},
+					},
+				},
+			}),
+		)
+	}
+	matchers := []githubv4mock.Matcher{
+		page((*githubv4.String)(nil), true,
+			map[string]any{
+				"id":         "PVTI_1",
+				"type":       "ISSUE",
+				"isArchived": false,
+				"content": map[string]any{
+					"title":      "Fix login, again",
+					"number":     12,
+					"url":        "https://github.com/octo-org/api/issues/12",
+					"repository": map[string]any{"nameWithOwner": "octo-org/api"},
+					"assignees":  map[string]any{"nodes": []any{map[string]any{"login": "alice"}, map[string]any{"login": "bob"}}},
+					"issueState": "OPEN",
+				},
+				"fieldValues": map[string]any{"nodes": []any{
+					value("ProjectV2ItemFieldTextValue", "text", "Fix login, again", "Title"),
+					value("ProjectV2ItemFieldSingleSelectValue", "name", "In Progress", "Status"),
+					value("ProjectV2ItemFieldNumberValue", "number", 2.5, "Estimate"),
+				}},
+			},
+		),
+		page(githubv4.String("cursor1"), false,
+			map[string]any{
+				"id":         "PVTI_2",
+				"type":       "DRAFT_ISSUE",
+				"isArchived": true,
+				"content": map[string]any{
+					"title":     "Write docs",
+					"body":      "Cover the new API",
+					"assignees": map[string]any{"nodes": []any{}},
+				},
+				"fieldValues": map[string]any{"nodes": []any{
+					value("ProjectV2ItemFieldDateValue", "date", "2024-05-01", "Due"),
+					value("ProjectV2ItemFieldSingleSelectValue", "name", "Todo", "Status"),
+					value("ProjectV2ItemFieldIterationValue", "title", "Sprint 3", "Sprint"),
+				}},
+			},
+			map[string]any{
+				"type":        "REDACTED",
+				"isArchived":  false,
+				"content":     map[string]any{},
+				"fieldValues": map[string]any{"nodes": []any{}},
+			},
+		),
+	}
+
+	args := map[string]any{
+		"owner":          "octo-org",
+		"owner_type":     "org",
+		"project_number": float64(7),
+	}
+
+	t.Run("csv in the result", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+		_, handler := ExportProjectItems(stubGetGQLClientFn(client), "", translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "Type,Title,Number,Repository,URL,State,Assignees,Archived,Item ID,Draft Body,Status,Estimate,Due,Sprint\n"+
+			"issue,\"Fix login, again\",12,octo-org/api,https://github.com/octo-org/api/issues/12,open,\"alice, bob\",false,PVTI_1,,In Progress,2.5,,\n"+
+			"draft_issue,Write docs,,,,draft,,true,PVTI_2,Cover the new API,Todo,,2024-05-01,Sprint 3\n"+
+			"redacted,,,,,,,false,,,,,,\n",
+			getTextResult(t, result).Text)
+	})
+
+	t.Run("tsv truncated", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+		_, handler := ExportProjectItems(stubGetGQLClientFn(client), "", translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(7),
+			"format":         "tsv",
+			"max_items":      float64(1),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.Equal(t, "Type\tTitle\tNumber\tRepository\tURL\tState\tAssignees\tArchived\tItem ID\tDraft Body\tStatus\tEstimate\n"+
+			"issue\tFix login, again\t12\tocto-org/api\thttps://github.com/octo-org/api/issues/12\topen\talice, bob\tfalse\tPVTI_1\t\tIn Progress\t2.5\n",
+			result.Content[0].(mcp.TextContent).Text)
+		assert.Equal(t, "Exported 1 of 3 items, raise max_items to export more.", result.Content[1].(mcp.TextContent).Text)
+	})
+
+	t.Run("stopped by the GraphQL budget", func(t *testing.T) {
+		mocked := githubv4mock.NewMockedHTTPClient(matchers...)
+		client := githubv4.NewClient(&http.Client{Transport: &budgetExceededTransport{transport: mocked.Transport, allowed: 1}})
+		_, handler := ExportProjectItems(stubGetGQLClientFn(client), "", translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.True(t, strings.HasPrefix(result.Content[0].(mcp.TextContent).Text, "Type,Title,Number"))
+		assert.Equal(t, "Exported 1 of 3 items to stay within the GraphQL budget: GraphQL queries of this call would cost more than the maximum of 100 points per call, 120 were spent already", result.Content[1].(mcp.TextContent).Text)
+	})
+
+	t.Run("stored in the export directory", func(t *testing.T) {
+		dir := t.TempDir()
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+		_, handler := ExportProjectItems(stubGetGQLClientFn(client), dir, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var stored struct {
+			Path       string `json:"path"`
+			Items      int    `json:"items"`
+			TotalItems int    `json:"total_items"`
+			Truncated  bool   `json:"truncated"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &stored))
+		assert.Equal(t, filepath.Join(dir, "octo-org-project-7.csv"), stored.Path)
+		assert.Equal(t, 3, stored.Items)
+		assert.False(t, stored.Truncated)
+
+		content, err := os.ReadFile(stored.Path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "Type,Title,Number"))
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		// A summary is returned rather than stored, even with an export directory
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+		_, handler := ExportProjectItems(stubGetGQLClientFn(client), t.TempDir(), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(7),
+			"summary":        true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var summary ListSummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+		assert.Equal(t, 3, summary.TotalCount)
+		assert.Equal(t, 3, summary.Analyzed)
+		assert.False(t, summary.Truncated)
+		assert.Equal(t, map[string]map[string]int{
+			"type":     {"issue": 1, "draft_issue": 1, "redacted": 1},
+			"state":    {"open": 1, "draft": 1, "unknown": 1},
+			"assignee": {"alice": 1, "bob": 1, "(none)": 2},
+			"Status":   {"In Progress": 1, "Todo": 1},
+			"Sprint":   {"Sprint 3": 1},
+		}, summary.Counts)
+
+		// The first item of each status, then of each state for items without one
+		require.Len(t, summary.Samples, 3)
+		first := summary.Samples[0].(map[string]any)
+		assert.Equal(t, "PVTI_1", first["item_id"])
+		assert.Equal(t, float64(12), first["number"])
+		assert.Equal(t, map[string]any{"Status": "In Progress", "Estimate": "2.5"}, first["fields"])
+		assert.Equal(t, "Todo", summary.Samples[1].(map[string]any)["fields"].(map[string]any)["Status"])
+		assert.Equal(t, "unknown", summary.Samples[2].(map[string]any)["state"])
+	})
+}
+
+func Test_FindItemInProjects(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := FindItemInProjects(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_item_in_projects", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "status_field")
+	assert.Contains(t, tool.InputSchema.Properties, "include_archived")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "number"})
+
+	projectItems := map[string]any{
+		"totalCount": 2,
+		"pageInfo":   map[string]any{"hasNextPage": false},
+		"nodes": []any{
+			map[string]any{
+				"id":         "PVTI_roadmap",
+				"isArchived": false,
+				"project": map[string]any{
+					"id":     "PVT_roadmap",
+					"number": 3,
+					"title":  "Roadmap",
+					"url":    "https://github.com/orgs/octo-org/projects/3",
+					"closed": false,
+					"owner":  map[string]any{"__typename": "Organization", "login": "octo-org"},
+				},
+				"status": map[string]any{"name": "In progress"},
+			},
+			map[string]any{
+				"id":         "PVTI_personal",
+				"isArchived": true,
+				"project": map[string]any{
+					"id":     "PVT_personal",
+					"number": 1,
+					"title":  "My work",
+					"url":    "https://github.com/users/octocat/projects/1",
+					"closed": false,
+					"owner":  map[string]any{"__typename": "User", "login": "octocat"},
+				},
+				"status": nil,
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]any
+		vars             map[string]any
+		response         githubv4mock.GQLResponse
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "issue in an org and a user project",
+			requestArgs: map[string]any{
+				"owner":            "octo-org",
+				"repo":             "octo-repo",
+				"number":           float64(42),
+				"include_archived": true,
+			},
+			vars: map[string]any{
+				"owner":           githubv4.String("octo-org"),
+				"repo":            githubv4.String("octo-repo"),
+				"number":          githubv4.Int(42),
+				"statusField":     githubv4.String("Status"),
+				"includeArchived": githubv4.Boolean(true),
+				"after":           (*githubv4.String)(nil),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issueOrPullRequest": map[string]any{
+						"__typename":   "Issue",
+						"projectItems": projectItems,
+					},
+				},
+			}),
+			expectedResponse: map[string]any{
+				"type":       "Issue",
+				"number":     float64(42),
+				"totalCount": float64(2),
+				"projects": []any{
+					map[string]any{
+						"project_id":     "PVT_roadmap",
+						"project_number": float64(3),
+						"project_title":  "Roadmap",
+						"project_url":    "https://github.com/orgs/octo-org/projects/3",
+						"owner":          "octo-org",
+						"owner_type":     "org",
+						"item_id":        "PVTI_roadmap",
+						"status":         "In progress",
+					},
+					map[string]any{
+						"project_id":     "PVT_personal",
+						"project_number": float64(1),
+						"project_title":  "My work",
+						"project_url":    "https://github.com/users/octocat/projects/1",
+						"owner":          "octocat",
+						"owner_type":     "user",
+						"item_id":        "PVTI_personal",
+						"archived":       true,
+					},
+				},
+			},
+		},
+		{
+			name: "pull request in no project with a custom status field",
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repo":         "octo-repo",
+				"number":       float64(7),
+				"status_field": "Stage",
+			},
+			vars: map[string]any{
+				"owner":           githubv4.String("octo-org"),
+				"repo":            githubv4.String("octo-repo"),
+				"number":          githubv4.Int(7),
+				"statusField":     githubv4.String("Stage"),
+				"includeArchived": githubv4.Boolean(false),
+				"after":           (*githubv4.String)(nil),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issueOrPullRequest": map[string]any{
+						"__typename":   "PullRequest",
+						"projectItems": map[string]any{"totalCount": 0, "pageInfo": map[string]any{"hasNextPage": false}, "nodes": []any{}},
+					},
+				},
+			}),
+			expectedResponse: map[string]any{
+				"type":       "PullRequest",
+				"number":     float64(7),
+				"totalCount": float64(0),
+				"projects":   []any{},
+			},
+		},
+		{
+			name: "not found",
+			requestArgs: map[string]any{
+				"owner":  "octo-org",
+				"repo":   "octo-repo",
+				"number": float64(999),
+			},
+			vars: map[string]any{
+				"owner":           githubv4.String("octo-org"),
+				"repo":            githubv4.String("octo-repo"),
+				"number":          githubv4.Int(999),
+				"statusField":     githubv4.String("Status"),
+				"includeArchived": githubv4.Boolean(false),
+				"after":           (*githubv4.String)(nil),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"issueOrPullRequest": nil},
+			}),
+			expectError:    true,
+			expectedErrMsg: "issue or pull request octo-org/octo-repo#999 not found",
+		},
+		{
+			name: "query error",
+			requestArgs: map[string]any{
+				"owner":  "octo-org",
+				"repo":   "missing",
+				"number": float64(1),
+			},
+			vars: map[string]any{
+				"owner":           githubv4.String("octo-org"),
+				"repo":            githubv4.String("missing"),
+				"number":          githubv4.Int(1),
+				"statusField":     githubv4.String("Status"),
+				"includeArchived": githubv4.Boolean(false),
+				"after":           (*githubv4.String)(nil),
+			},
+			response:       githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'octo-org/missing'."),
+			expectError:    true,
+			expectedErrMsg: "failed to find item in projects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(findItemInProjectsQuery{}, tc.vars, tc.response),
+			)
+			_, handler := FindItemInProjects(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_UpdateDraftIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UpdateDraftIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_draft_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "draft_issue_id")
+	assert.Contains(t, tool.InputSchema.Properties, "item_id")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	updateMatcher := func(input githubv4.UpdateProjectV2DraftIssueInput, title, body string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateProjectV2DraftIssue struct {
+					DraftIssue struct {
+						ID        githubv4.ID
+						Title     githubv4.String
+						Body      githubv4.String
+						UpdatedAt githubv4.DateTime
+					}
+				} `graphql:"updateProjectV2DraftIssue(input: $input)"`
+			}{},
+			input,
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateProjectV2DraftIssue": map[string]any{
+					"draftIssue": map[string]any{
+						"id":        "DI_1",
+						"title":     title,
+						"body":      body,
+						"updatedAt": "2024-05-01T10:00:00Z",
+					},
+				},
+			}),
+		)
+	}
+	itemQuery := struct {
+		Node struct {
+			ProjectV2Item struct {
+				Content struct {
+					DraftIssue struct {
+						ID githubv4.ID
+					} `graphql:"... on DraftIssue"`
+				}
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id: $itemId)"`
+	}{}
+
+	newTitle := githubv4.String("New title")
+	emptyBody := githubv4.String("")
+
+	tests := []struct {
+		name             string
+		matchers         []githubv4mock.Matcher
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse DraftIssue
+	}{
+		{
+			name: "update title by draft issue ID",
+			matchers: []githubv4mock.Matcher{
+				updateMatcher(githubv4.UpdateProjectV2DraftIssueInput{DraftIssueID: "DI_1", Title: &newTitle}, "New title", "Old body"),
+			},
+			requestArgs: map[string]any{
+				"draft_issue_id": "DI_1",
+				"title":          "New title",
+			},
+			expectedResponse: DraftIssue{ID: "DI_1", Title: "New title", Body: "Old body", UpdatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "clear body by project item ID",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(itemQuery, map[string]any{"itemId": githubv4.ID("PVTI_1")}, githubv4mock.DataResponse(map[string]any{
+					"node": map[string]any{"content": map[string]any{"id": "DI_1"}},
+				})),
+				updateMatcher(githubv4.UpdateProjectV2DraftIssueInput{DraftIssueID: "DI_1", Body: &emptyBody}, "Title", ""),
+			},
+			requestArgs: map[string]any{
+				"item_id": "PVTI_1",
+				"body":    "",
+			},
+			expectedResponse: DraftIssue{ID: "DI_1", Title: "Title", Body: "", UpdatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "item is not a draft issue",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(itemQuery, map[string]any{"itemId": githubv4.ID("PVTI_2")}, githubv4mock.DataResponse(map[string]any{
+					"node": map[string]any{"content": map[string]any{}},
+				})),
+			},
+			requestArgs: map[string]any{
+				"item_id": "PVTI_2",
+				"title":   "New title",
+			},
+			expectError:    true,
+			expectedErrMsg: "project item PVTI_2 is not a draft issue",
+		},
+		{
+			name: "both IDs given",
+			requestArgs: map[string]any{
+				"draft_issue_id": "DI_1",
+				"item_id":        "PVTI_1",
+				"title":          "New title",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of draft_issue_id or item_id must be provided",
+		},
+		{
+			name: "nothing to update",
+			requestArgs: map[string]any{
+				"draft_issue_id": "DI_1",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of title or body must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(tc.matchers...)
+			_, handler := UpdateDraftIssue(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response DraftIssue
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_CommentOnProjectItem(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CommentOnProjectItem(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "comment_on_project_item", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"item_id", "body"})
+
+	type commentable struct {
+		ID         githubv4.ID
+		Number     githubv4.Int
+		Repository struct {
+			NameWithOwner githubv4.String
+		}
+	}
+	itemQuery := struct {
+		Node struct {
+			ProjectV2Item struct {
+				Type    githubv4.String
+				Content struct {
+					Issue       commentable `graphql:"... on Issue"`
+					PullRequest commentable `graphql:"... on PullRequest"`
+				}
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id: $itemId)"`
+	}{}
+	itemMatcher := func(itemType string, content map[string]any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(itemQuery, map[string]any{"itemId": githubv4.ID("PVTI_1")}, githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{"type": itemType, "content": content},
+		}))
+	}
+	commentMatcher := func(subjectID string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				AddComment struct {
+					CommentEdge struct {
+						Node struct {
+							ID  githubv4.ID
+							URL githubv4.String
+						}
+					}
+				} `graphql:"addComment(input: $input)"`
+			}{},
+			githubv4.AddCommentInput{SubjectID: githubv4.ID(subjectID), Body: "Moved to In progress"},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addComment": map[string]any{
+					"commentEdge": map[string]any{
+						"node": map[string]any{"id": "IC_1", "url": "https://github.com/octo-org/app/issues/7#issuecomment-1"},
+					},
+				},
+			}),
+		)
+	}
+	requestArgs := map[string]any{"item_id": "PVTI_1", "body": "Moved to In progress"}
+
+	tests := []struct {
+		name             string
+		matchers         []githubv4mock.Matcher
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse ProjectItemComment
+	}{
+		{
+			name: "comment on an issue",
+			matchers: []githubv4mock.Matcher{
+				itemMatcher("ISSUE", map[string]any{"id": "I_1", "number": 7, "repository": map[string]any{"nameWithOwner": "octo-org/app"}}),
+				commentMatcher("I_1"),
+			},
+			expectedResponse: ProjectItemComment{
+				ItemID:      "PVTI_1",
+				ContentType: "issue",
+				Repository:  "octo-org/app",
+				Number:      7,
+				CommentID:   "IC_1",
+				CommentURL:  "https://github.com/octo-org/app/issues/7#issuecomment-1",
+			},
+		},
+		{
+			name: "comment on a pull request",
+			matchers: []githubv4mock.Matcher{
+				itemMatcher("PULL_REQUEST", map[string]any{"id": "PR_1", "number": 8, "repository": map[string]any{"nameWithOwner": "octo-org/app"}}),
+				commentMatcher("PR_1"),
+			},
+			expectedResponse: ProjectItemComment{
+				ItemID:      "PVTI_1",
+				ContentType: "pull_request",
+				Repository:  "octo-org/app",
+				Number:      8,
+				CommentID:   "IC_1",
+				CommentURL:  "https://github.com/octo-org/app/issues/7#issuecomment-1",
+			},
+		},
+		{
+			name: "draft issue",
+			matchers: []githubv4mock.Matcher{
+				itemMatcher("DRAFT_ISSUE", map[string]any{}),
+			},
+			expectError:    true,
+			expectedErrMsg: "project item PVTI_1 is a draft issue",
+		},
+		{
+			name: "item not found",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(itemQuery, map[string]any{"itemId": githubv4.ID("PVTI_1")}, githubv4mock.ErrorResponse("Could not resolve to a node")),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get project item",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := CommentOnProjectItem(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got ProjectItemComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, tc.expectedResponse, got)
+		})
+	}
+}
+
+func Test_ResolveProject(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ResolveProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "resolve_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	orgProject := map[string]any{
+		"id":        "PVT_org7",
+		"number":    7,
+		"title":     "Roadmap",
+		"url":       "https://github.com/orgs/octo-org/projects/7",
+		"closed":    false,
+		"owner":     map[string]any{"__typename": "Organization", "login": "octo-org"},
+		"ownerNode": map[string]any{"id": "O_1"},
+	}
+	userProject := map[string]any{
+		"id":        "PVT_user3",
+		"number":    3,
+		"title":     "Chores",
+		"url":       "https://github.com/users/octocat/projects/3",
+		"closed":    true,
+		"owner":     map[string]any{"__typename": "User", "login": "octocat"},
+		"ownerNode": map[string]any{"id": "U_1"},
+	}
+	orgMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 resolvedProject `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{"owner": githubv4.String("octo-org"), "number": githubv4.Int(7)},
+		githubv4mock.DataResponse(map[string]any{"organization": map[string]any{"projectV2": orgProject}}),
+	)
+	expectedOrgProject := ResolvedProject{
+		ID:        "PVT_org7",
+		Number:    7,
+		Title:     "Roadmap",
+		URL:       "https://github.com/orgs/octo-org/projects/7",
+		Owner:     "octo-org",
+		OwnerType: "org",
+		OwnerID:   "O_1",
+	}
+
+	tests := []struct {
+		name             string
+		matchers         []githubv4mock.Matcher
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse ResolvedProject
+	}{
+		{
+			name:             "by URL",
+			matchers:         []githubv4mock.Matcher{orgMatcher},
+			requestArgs:      map[string]any{"url": "https://github.com/orgs/octo-org/projects/7/views/2"},
+			expectedResponse: expectedOrgProject,
+		},
+		{
+			name:             "by owner and number",
+			matchers:         []githubv4mock.Matcher{orgMatcher},
+			requestArgs:      map[string]any{"owner": "octo-org", "owner_type": "org", "project_number": float64(7)},
+			expectedResponse: expectedOrgProject,
+		},
+		{
+			name: "by node ID",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Node struct {
+							ProjectV2 resolvedProject `graphql:"... on ProjectV2"`
+						} `graphql:"node(id: $projectId)"`
+					}{},
+					map[string]any{"projectId": githubv4.ID("PVT_user3")},
+					githubv4mock.DataResponse(map[string]any{"node": userProject}),
+				),
+			},
+			requestArgs: map[string]any{"project_id": "PVT_user3"},
+			expectedResponse: ResolvedProject{
+				ID:        "PVT_user3",
+				Number:    3,
+				Title:     "Chores",
+				URL:       "https://github.com/users/octocat/projects/3",
+				Closed:    true,
+				Owner:     "octocat",
+				OwnerType: "user",
+				OwnerID:   "U_1",
+			},
+		},
+		{
+			name:           "URL of something else",
+			requestArgs:    map[string]any{"url": "https://github.com/octo-org/app/issues/7"},
+			expectError:    true,
+			expectedErrMsg: "is not a project URL",
+		},
+		{
+			name:           "no identifier",
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "one of url, owner and project_number, or project_id must be provided",
+		},
+		{
+			name:           "several identifiers",
+			requestArgs:    map[string]any{"url": "https://github.com/orgs/octo-org/projects/7", "project_id": "PVT_org7"},
+			expectError:    true,
+			expectedErrMsg: "only one of url, owner and project_number, or project_id may be provided",
+		},
+		{
+			name:           "owner without number",
+			requestArgs:    map[string]any{"owner": "octo-org"},
+			expectError:    true,
+			expectedErrMsg: "owner and project_number must be provided together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := ResolveProject(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got ResolvedProject
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, tc.expectedResponse, got)
+		})
+	}
+}
+
+func Test_ClearProjectItemField(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ClearProjectItemField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "clear_project_item_field", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "item_id")
+	assert.Contains(t, tool.InputSchema.Properties, "item_repository")
+	assert.Contains(t, tool.InputSchema.Properties, "item_number")
+	assert.Contains(t, tool.InputSchema.Properties, "field_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "field_name"})
+
+	fieldsMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 projectFields `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+			"after":  (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{
+					"id": "PVT_1",
+					"fields": map[string]any{
+						"pageInfo": map[string]any{"hasNextPage": false},
+						"nodes": []any{
+							map[string]any{"id": "PVTF_title", "name": "Title"},
+							map[string]any{"id": "PVTSSF_status", "name": "Status"},
+						},
+					},
+				},
+			},
+		}),
+	)
+	clearMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			ClearProjectV2ItemFieldValue struct {
+				ProjectV2Item struct {
+					ID githubv4.ID
+				}
+			} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+		}{},
+		githubv4.ClearProjectV2ItemFieldValueInput{
+			ProjectID: "PVT_1",
+			ItemID:    "PVTI_1",
+			FieldID:   "PVTSSF_status",
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"clearProjectV2ItemFieldValue": map[string]any{
+				"projectV2Item": map[string]any{"id": "PVTI_1"},
+			},
+		}),
+	)
+
+	issueMatcher := issueOrPullRequestIDMatcher("octo-org", "octo-repo", 42, "I_42")
+	type projectItems struct {
+		Nodes []struct {
+			ID      githubv4.ID
+			Project struct {
+				ID githubv4.ID
+			}
+		}
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   githubv4.String
+		}
+	}
+	type itemsQuery struct {
+		Node struct {
+			Issue struct {
+				ProjectItems projectItems `graphql:"projectItems(first: 100, after: $after, includeArchived: true)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ProjectItems projectItems `graphql:"projectItems(first: 100, after: $after, includeArchived: true)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"node(id: $id)"`
+	}
+	// The issue is in more projects than fit a page, and in the project on the second page
+	itemsMatcher := githubv4mock.NewQueryMatcher(
+		itemsQuery{},
+		map[string]any{"id": githubv4.ID("I_42"), "after": (*githubv4.String)(nil)},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{
+				"projectItems": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "PVTI_other", "project": map[string]any{"id": "PVT_other"}},
+					},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "cursor-1"},
+				},
+			},
+		}),
+	)
+	itemsNextMatcher := githubv4mock.NewQueryMatcher(
+		itemsQuery{},
+		map[string]any{"id": githubv4.ID("I_42"), "after": githubv4.String("cursor-1")},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{
+				"projectItems": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "PVTI_1", "project": map[string]any{"id": "PVT_1"}},
+					},
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor-2"},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name:        "clear status by item ID",
+			requestArgs: map[string]any{"item_id": "PVTI_1", "field_name": "status"},
+			expectedResponse: map[string]any{
+				"item_id": "PVTI_1",
+				"field":   "Status",
+				"cleared": true,
+			},
+		},
+		{
+			name:        "clear status by issue number",
+			requestArgs: map[string]any{"item_repository": "octo-repo", "item_number": float64(42), "field_name": "Status"},
+			expectedResponse: map[string]any{
+				"item_id": "PVTI_1",
+				"field":   "Status",
+				"cleared": true,
+			},
+		},
+		{
+			name:           "unknown field",
+			requestArgs:    map[string]any{"item_id": "PVTI_1", "field_name": "Priority"},
+			expectError:    true,
+			expectedErrMsg: `field "Priority" not found in the project`,
+		},
+		{
+			name:           "item not identified",
+			requestArgs:    map[string]any{"field_name": "Status"},
+			expectError:    true,
+			expectedErrMsg: "one of item_id or item_number must be provided",
+		},
+		{
+			name:           "number without repository",
+			requestArgs:    map[string]any{"item_number": float64(42), "field_name": "Status"},
+			expectError:    true,
+			expectedErrMsg: "item_repository is required with item_number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := githubv4mock.NewMockedHTTPClient(fieldsMatcher, issueMatcher, itemsMatcher, itemsNextMatcher, clearMatcher)
+			_, handler := ClearProjectItemField(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_GetProjectFields(t *testing.T) {
+	query := struct {
+		Organization struct {
+			ProjectV2 projectFields `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}{}
+	page := func(after any, hasNextPage bool, ids ...string) githubv4mock.Matcher {
+		nodes := make([]any, len(ids))
+		for i, id := range ids {
+			nodes[i] = map[string]any{"id": id, "name": id}
+		}
+		return githubv4mock.NewQueryMatcher(query,
+			map[string]any{
+				"owner":  githubv4.String("octo-org"),
+				"number": githubv4.Int(7),
+				"after":  after,
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"organization": map[string]any{
+					"projectV2": map[string]any{
+						"id": "PVT_1",
+						"fields": map[string]any{
+							"pageInfo": map[string]any{"hasNextPage": hasNextPage, "endCursor": "Y3Vyc29yOjUw"},
+							"nodes":    nodes,
+						},
+					},
+				},
+			}),
+		)
+	}
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		page((*githubv4.String)(nil), true, "PVTF_title", "PVTSSF_status"),
+		page(githubv4.String("Y3Vyc29yOjUw"), false, "PVTF_estimate"),
+	)
+
+	projectID, fields, err := getProjectFields(context.Background(), githubv4.NewClient(httpClient), "octo-org", "org", 7)
+	require.NoError(t, err)
+	assert.Equal(t, githubv4.ID("PVT_1"), projectID)
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = string(field.Common.Name)
+	}
+	assert.Equal(t, []string{"PVTF_title", "PVTSSF_status", "PVTF_estimate"}, names)
+}
+
+func Test_MarkProjectAsTemplate(t *testing.T) {
+	// Verify tool definitions
+	mockClient := githubv4.NewClient(nil)
+	markTool, _ := MarkProjectAsTemplate(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(markTool.Name, markTool))
+	unmarkTool, _ := UnmarkProjectAsTemplate(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unmarkTool.Name, unmarkTool))
+
+	assert.Equal(t, "mark_project_as_template", markTool.Name)
+	assert.Equal(t, "unmark_project_as_template", unmarkTool.Name)
+	assert.ElementsMatch(t, markTool.InputSchema.Required, []string{"owner", "project_number"})
+	assert.ElementsMatch(t, unmarkTool.InputSchema.Required, []string{"owner", "project_number"})
+
+	projectMatcher := githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 struct{ ID githubv4.ID } `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{"id": "PVT_1"},
+			},
+		}),
+	)
+
+	t.Run("mark", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			projectMatcher,
+			githubv4mock.NewMutationMatcher(
+				struct {
+					MarkProjectV2AsTemplate struct {
+						ProjectV2 projectTemplateState
+					} `graphql:"markProjectV2AsTemplate(input: $input)"`
+				}{},
+				githubv4.MarkProjectV2AsTemplateInput{ProjectID: "PVT_1"},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"markProjectV2AsTemplate": map[string]any{
+						"projectV2": map[string]any{"id": "PVT_1", "number": 7, "title": "Roadmap", "template": true},
+					},
+				}),
+			),
+		)
+		_, handler := MarkProjectAsTemplate(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "octo-org",
+			"project_number": float64(7),
+		}))
+		require.NoError(t, err)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, map[string]any{"id": "PVT_1", "number": float64(7), "title": "Roadmap", "template": true}, response)
+	})
+
+	t.Run("unmark", func(t *testing.T) {
+		httpClient := githubv4mock.NewMockedHTTPClient(
+			projectMatcher,
+			githubv4mock.NewMutationMatcher(
+				struct {
+					UnmarkProjectV2AsTemplate struct {
+						ProjectV2 projectTemplateState
+					} `graphql:"unmarkProjectV2AsTemplate(input: $input)"`
+				}{},
+				githubv4.UnmarkProjectV2AsTemplateInput{ProjectID: "PVT_1"},
+				nil,
+				githubv4mock.ErrorResponse("Project is not a template"),
+			),
+		)
+		_, handler := UnmarkProjectAsTemplate(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "octo-org",
+			"project_number": float64(7),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to unmark project as template")
+	})
+}
+
+func Test_ListProjectTemplates(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectTemplates(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_templates", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	query := struct {
+		Organization projectTemplatesPage `graphql:"organization(login: $owner)"`
+	}{}
+	project := func(number int, title string, template bool) map[string]any {
+		return map[string]any{
+			"id":               fmt.Sprintf("PVT_%d", number),
+			"number":           number,
+			"title":            title,
+			"shortDescription": "",
+			"url":              fmt.Sprintf("https://github.com/orgs/octo-org/projects/%d", number),
+			"closed":           false,
+			"template":         template,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		matchers    []githubv4mock.Matcher
+		requestArgs map[string]any
+		expected    []ProjectTemplate
+	}{
+		{
+			name: "templates across pages",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(query,
+					map[string]any{
+						"owner": githubv4.String("octo-org"),
+						"after": (*githubv4.String)(nil),
+						"query": githubv4.String(""),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{
+							"projectsV2": map[string]any{
+								"nodes":    []any{project(1, "Roadmap", false), project(2, "Sprint template", true)},
+								"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "cursor1"},
+							},
+						},
+					}),
+				),
+				githubv4mock.NewQueryMatcher(query,
+					map[string]any{
+						"owner": githubv4.String("octo-org"),
+						"after": githubv4.String("cursor1"),
+						"query": githubv4.String(""),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{
+							"projectsV2": map[string]any{
+								"nodes":    []any{project(3, "Bug triage template", true)},
+								"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor2"},
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{"owner": "octo-org"},
+			expected: []ProjectTemplate{
+				{ID: "PVT_2", Number: 2, Title: "Sprint template", URL: "https://github.com/orgs/octo-org/projects/2"},
+				{ID: "PVT_3", Number: 3, Title: "Bug triage template", URL: "https://github.com/orgs/octo-org/projects/3"},
+			},
+		},
+		{
+			name: "filtered by title",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(query,
+					map[string]any{
+						"owner": githubv4.String("octo-org"),
+						"after": (*githubv4.String)(nil),
+						"query": githubv4.String("sprint"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{
+							"projectsV2": map[string]any{
+								"nodes":    []any{project(2, "Sprint template", true)},
+								"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor1"},
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{"owner": "octo-org", "query": "sprint"},
+			expected: []ProjectTemplate{
+				{ID: "PVT_2", Number: 2, Title: "Sprint template", URL: "https://github.com/orgs/octo-org/projects/2"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := ListProjectTemplates(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var response struct {
+				Templates []ProjectTemplate `json:"templates"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response.Templates)
+		})
+	}
+}
+
+func projectRepositoriesMatcher(first int32, total int, names ...string) githubv4mock.Matcher {
+	nodes := make([]any, len(names))
+	for i, name := range names {
+		nodes[i] = map[string]any{
+			"nameWithOwner": name,
+			"url":           "https://github.com/" + name,
+			"description":   "",
+			"isArchived":    false,
+			"isPrivate":     name == "octo-org/internal",
+		}
+	}
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 projectRepositories `graphql:"projectV2(number: $number)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+			"first":  githubv4.Int(first),
+			"after":  (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{
+					"repositories": map[string]any{
+						"totalCount": total,
+						"pageInfo": map[string]any{
+							"hasNextPage": total > len(names),
+							"endCursor":   "Y3Vyc29yOjE=",
+						},
+						"nodes": nodes,
+					},
+				},
+			},
+		}),
+	)
+}
+
+func Test_ListProjectRepos(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectRepos(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_repos", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(projectRepositoriesMatcher(30, 2, "octo-org/api", "octo-org/internal"))
+	_, handler := ListProjectRepos(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octo-org",
+		"owner_type":     "org",
+		"project_number": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		Repositories []ProjectRepository `json:"repositories"`
+		Pagination   Pagination          `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, Pagination{TotalCount: github.Ptr(2)}, returned.Pagination)
+	assert.Equal(t, []ProjectRepository{
+		{FullName: "octo-org/api", URL: "https://github.com/octo-org/api"},
+		{FullName: "octo-org/internal", URL: "https://github.com/octo-org/internal", Private: true},
+	}, returned.Repositories)
+}
+
+func Test_ListProjectWorkflows(t *testing.T) {
+	// Verify tool definition
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectWorkflows(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_workflows", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				User struct {
+					ProjectV2 projectWorkflows `graphql:"projectV2(number: $number)"`
+				} `graphql:"user(login: $owner)"`
+			}{},
+			map[string]any{
+				"owner":  githubv4.String("octocat"),
+				"number": githubv4.Int(3),
+				"first":  githubv4.Int(2),
+				"after":  githubv4.String("Y3Vyc29yOjI="),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"user": map[string]any{
+					"projectV2": map[string]any{
+						"workflows": map[string]any{
+							"totalCount": 5,
+							"pageInfo": map[string]any{
+								"hasNextPage": true,
+								"endCursor":   "Y3Vyc29yOjQ=",
+							},
+							"nodes": []any{
+								map[string]any{"id": "PWF_1", "number": 1, "name": "Item added to project", "enabled": true, "updatedAt": "2024-05-01T10:00:00Z"},
+								map[string]any{"id": "PWF_2", "number": 2, "name": "Auto-archive items", "enabled": false, "updatedAt": "2024-05-02T10:00:00Z"},
+							},
+						},
+					},
+				},
+			}),
+		),
+	)
+	_, handler := ListProjectWorkflows(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octocat",
+		"owner_type":     "user",
+		"project_number": float64(3),
+		"perPage":        float64(2),
+		"after":          "Y3Vyc29yOjI=",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		EnabledCount int               `json:"enabled_count"`
+		Workflows    []ProjectWorkflow `json:"workflows"`
+		Pagination   Pagination        `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, Pagination{NextCursor: "Y3Vyc29yOjQ=", HasMore: true, TotalCount: github.Ptr(5)}, returned.Pagination)
+	assert.Equal(t, 1, returned.EnabledCount)
+	assert.Equal(t, []ProjectWorkflow{
+		{ID: "PWF_1", Number: 1, Name: "Item added to project", Enabled: true, Description: builtInProjectWorkflows["Item added to project"], UpdatedAt: "2024-05-01T10:00:00Z"},
+		{ID: "PWF_2", Number: 2, Name: "Auto-archive items", Description: builtInProjectWorkflows["Auto-archive items"], UpdatedAt: "2024-05-02T10:00:00Z"},
+	}, returned.Workflows)
+}
+
+func Test_SearchProjectIssues(t *testing.T) {
+	// Verify tool definition
+	tool, _ := SearchProjectIssues(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_project_issues", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "query"})
+
+	searchResult := &github.IssuesSearchResult{
+		Total:  github.Ptr(1),
+		Issues: []*github.Issue{{Number: github.Ptr(42), Title: github.Ptr("Login fails")}},
+	}
+
+	tests := []struct {
+		name           string
+		gqlMatchers    []githubv4mock.Matcher
+		restClient     *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:        "scopes the query to the linked repositories",
+			gqlMatchers: []githubv4mock.Matcher{projectRepositoriesMatcher(100, 2, "octo-org/api", "octo-org/web")},
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "repo:octo-org/api repo:octo-org/web is:issue label:bug",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(mockResponse(t, http.StatusOK, searchResult)),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"query":          "label:bug",
+				"type":           "issue",
+			},
+		},
+		{
+			name: "rejects repo filters",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"query":          "repo:octo-org/api label:bug",
+			},
+			expectError:    true,
+			expectedErrMsg: "the query must not contain repo: filters",
+		},
+		{
+			name:        "project without repositories",
+			gqlMatchers: []githubv4mock.Matcher{projectRepositoriesMatcher(100, 0)},
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"query":          "label:bug",
+			},
+			expectError:    true,
+			expectedErrMsg: "the project has no linked repositories",
+		},
+		{
+			name:        "too many repositories for one query",
+			gqlMatchers: []githubv4mock.Matcher{projectRepositoriesMatcher(100, 120, "octo-org/api")},
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"query":          "label:bug",
+			},
+			expectError:    true,
+			expectedErrMsg: "the project links 120 repositories, too many to search at once",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...))
+			restClient := github.NewClient(tc.restClient)
+			_, handler := SearchProjectIssues(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.IssuesSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned.Issues, 1)
+			assert.Equal(t, 42, returned.Issues[0].GetNumber())
+		})
+	}
+}
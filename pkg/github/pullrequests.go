@@ -3,9 +3,17 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -120,6 +128,14 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			mcp.WithBoolean("maintainer_can_modify",
 				mcp.Description("Allow maintainer edits"),
 			),
+			mcp.WithArray("closes_issues",
+				mcp.Description("Numbers of issues in this repository that the PR closes. Each issue is checked to exist and a 'Closes #N' line is added to the description"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -158,6 +174,34 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			closesIssues, err := OptionalIntArrayParam(request, "closes_issues")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Validate the issues before creating anything, so a typo in an issue
+			// number doesn't leave behind a pull request with a dangling reference.
+			for _, number := range closesIssues {
+				issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get issue #%d to close", number),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if issue.IsPullRequest() {
+					return mcp.NewToolResultError(fmt.Sprintf("#%d is a pull request, not an issue", number)), nil
+				}
+			}
+			body = appendClosingKeywords(body, closesIssues)
+
 			newPR := &github.NewPullRequest{
 				Title: github.Ptr(title),
 				Head:  github.Ptr(head),
@@ -171,10 +215,6 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			newPR.Draft = github.Ptr(draft)
 			newPR.MaintainerCanModify = github.Ptr(maintainerCanModify)
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, newPR)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -208,6 +248,170 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
+// closingKeywordPattern matches a closing keyword referencing an issue, e.g. "Fixes #12".
+var closingKeywordPattern = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(\d+)\b`)
+
+// appendClosingKeywords adds a "Closes #N" line to body for each issue number
+// that the body doesn't already reference with a closing keyword.
+func appendClosingKeywords(body string, issues []int) string {
+	referenced := map[int]bool{}
+	for _, match := range closingKeywordPattern.FindAllStringSubmatch(body, -1) {
+		if number, err := strconv.Atoi(match[1]); err == nil {
+			referenced[number] = true
+		}
+	}
+	var lines []string
+	for _, number := range issues {
+		// Also skips numbers listed more than once
+		if referenced[number] {
+			continue
+		}
+		referenced[number] = true
+		lines = append(lines, fmt.Sprintf("Closes #%d", number))
+	}
+	if len(lines) == 0 {
+		return body
+	}
+	if body == "" {
+		return strings.Join(lines, "\n")
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + strings.Join(lines, "\n")
+}
+
+type pullRequestDraftQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ID      githubv4.ID
+			IsDraft githubv4.Boolean
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// setPullRequestDraft converts a pull request to a draft, or marks it ready for review.
+func setPullRequestDraft(ctx context.Context, gqlClient *githubv4.Client, pullRequestID githubv4.ID, draft bool) error {
+	if draft {
+		var mutation struct {
+			ConvertPullRequestToDraft struct {
+				PullRequest struct {
+					ID      githubv4.ID
+					IsDraft githubv4.Boolean
+				}
+			} `graphql:"convertPullRequestToDraft(input: $input)"`
+		}
+		return gqlClient.Mutate(ctx, &mutation, githubv4.ConvertPullRequestToDraftInput{
+			PullRequestID: pullRequestID,
+		}, nil)
+	}
+
+	var mutation struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				ID      githubv4.ID
+				IsDraft githubv4.Boolean
+			}
+		} `graphql:"markPullRequestReadyForReview(input: $input)"`
+	}
+	return gqlClient.Mutate(ctx, &mutation, githubv4.MarkPullRequestReadyForReviewInput{
+		PullRequestID: pullRequestID,
+	}, nil)
+}
+
+// ConvertPullRequestToDraft creates a tool to convert a pull request to a draft.
+func ConvertPullRequestToDraft(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("convert_pull_request_to_draft",
+			mcp.WithDescription(t("TOOL_CONVERT_PULL_REQUEST_TO_DRAFT_DESCRIPTION", "Convert an open pull request to a draft, signalling that it is a work in progress and not ready to be merged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CONVERT_PULL_REQUEST_TO_DRAFT_USER_TITLE", "Convert pull request to draft"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		pullRequestDraftHandler(getGQLClient, true)
+}
+
+// MarkPullRequestReadyForReview creates a tool to mark a draft pull request as ready for review.
+func MarkPullRequestReadyForReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_pull_request_ready_for_review",
+			mcp.WithDescription(t("TOOL_MARK_PULL_REQUEST_READY_FOR_REVIEW_DESCRIPTION", "Mark a draft pull request as ready for review, which notifies requested reviewers and code owners.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_PULL_REQUEST_READY_FOR_REVIEW_USER_TITLE", "Mark pull request ready for review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		pullRequestDraftHandler(getGQLClient, false)
+}
+
+func pullRequestDraftHandler(getGQLClient GetGQLClientFn, draft bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		repo, err := RequiredParam[string](request, "repo")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pullNumber, err := RequiredInt(request, "pullNumber")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		gqlClient, err := getGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+		}
+
+		var prQuery pullRequestDraftQuery
+		err = gqlClient.Query(ctx, &prQuery, map[string]interface{}{
+			"owner": githubv4.String(owner),
+			"repo":  githubv4.String(repo),
+			"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+		})
+		if err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to find pull request", err), nil
+		}
+
+		changed := bool(prQuery.Repository.PullRequest.IsDraft) != draft
+		if changed {
+			if err := setPullRequestDraft(ctx, gqlClient, prQuery.Repository.PullRequest.ID, draft); err != nil {
+				if draft {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to convert pull request to draft", err), nil
+				}
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to mark pull request ready for review", err), nil
+			}
+		}
+
+		return MarshalledTextResult(map[string]any{
+			"number":   pullNumber,
+			"is_draft": draft,
+			"changed":  changed,
+		}), nil
+	}
+}
+
 // UpdatePullRequest creates a tool to update an existing pull request.
 func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request",
@@ -361,15 +565,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 					return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
 				}
 
-				var prQuery struct {
-					Repository struct {
-						PullRequest struct {
-							ID      githubv4.ID
-							IsDraft githubv4.Boolean
-						} `graphql:"pullRequest(number: $prNum)"`
-					} `graphql:"repository(owner: $owner, name: $repo)"`
-				}
-
+				var prQuery pullRequestDraftQuery
 				err = gqlClient.Query(ctx, &prQuery, map[string]interface{}{
 					"owner": githubv4.String(owner),
 					"repo":  githubv4.String(repo),
@@ -382,40 +578,11 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 				currentIsDraft := bool(prQuery.Repository.PullRequest.IsDraft)
 
 				if currentIsDraft != draftValue {
-					if draftValue {
-						// Convert to draft
-						var mutation struct {
-							ConvertPullRequestToDraft struct {
-								PullRequest struct {
-									ID      githubv4.ID
-									IsDraft githubv4.Boolean
-								}
-							} `graphql:"convertPullRequestToDraft(input: $input)"`
-						}
-
-						err = gqlClient.Mutate(ctx, &mutation, githubv4.ConvertPullRequestToDraftInput{
-							PullRequestID: prQuery.Repository.PullRequest.ID,
-						}, nil)
-						if err != nil {
+					if err := setPullRequestDraft(ctx, gqlClient, prQuery.Repository.PullRequest.ID, draftValue); err != nil {
+						if draftValue {
 							return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to convert pull request to draft", err), nil
 						}
-					} else {
-						// Mark as ready for review
-						var mutation struct {
-							MarkPullRequestReadyForReview struct {
-								PullRequest struct {
-									ID      githubv4.ID
-									IsDraft githubv4.Boolean
-								}
-							} `graphql:"markPullRequestReadyForReview(input: $input)"`
-						}
-
-						err = gqlClient.Mutate(ctx, &mutation, githubv4.MarkPullRequestReadyForReviewInput{
-							PullRequestID: prQuery.Repository.PullRequest.ID,
-						}, nil)
-						if err != nil {
-							return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to mark pull request ready for review", err), nil
-						}
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to mark pull request ready for review", err), nil
 					}
 				}
 			}
@@ -690,6 +857,205 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+type pullRequestAutoMergeQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ID               githubv4.ID
+			AutoMergeRequest *struct {
+				MergeMethod githubv4.PullRequestMergeMethod
+			}
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// EnablePullRequestAutoMerge creates a tool to enable auto-merge on a pull request.
+func EnablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Enable auto-merge on a pull request, so that it is merged automatically once all required reviews and status checks pass. Prefer this over merge_pull_request when checks are still running. Auto-merge must be allowed in the repository settings.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Enable pull request auto-merge"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("merge_method",
+				mcp.Description("Merge method to use once the pull request is mergeable. Defaults to 'merge'. Ignored when the base branch uses a merge queue"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+			mcp.WithString("commit_title",
+				mcp.Description("Title for the merge commit"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Extra detail for the merge commit"),
+			),
+			mcp.WithString("expected_head_sha",
+				mcp.Description("Only enable auto-merge if the head of the pull request is still this commit SHA"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergeMethod, err := OptionalParam[string](request, "merge_method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitTitle, err := OptionalParam[string](request, "commit_title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitMessage, err := OptionalParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](request, "expected_head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			var prQuery pullRequestAutoMergeQuery
+			if err := client.Query(ctx, &prQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to find pull request", err), nil
+			}
+
+			var mutation struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest struct {
+						AutoMergeRequest *struct {
+							EnabledAt   githubv4.DateTime
+							MergeMethod githubv4.PullRequestMergeMethod
+							EnabledBy   struct {
+								Login githubv4.String
+							}
+						}
+					}
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}
+			input := githubv4.EnablePullRequestAutoMergeInput{
+				PullRequestID:   prQuery.Repository.PullRequest.ID,
+				MergeMethod:     newGQLStringlike[githubv4.PullRequestMergeMethod](strings.ToUpper(mergeMethod)),
+				CommitHeadline:  newGQLStringlike[githubv4.String](commitTitle),
+				CommitBody:      newGQLStringlike[githubv4.String](commitMessage),
+				ExpectedHeadOid: newGQLStringlike[githubv4.GitObjectID](expectedHeadSHA),
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to enable auto-merge", err), nil
+			}
+
+			result := map[string]any{
+				"number":             pullNumber,
+				"auto_merge_enabled": true,
+			}
+			if autoMerge := mutation.EnablePullRequestAutoMerge.PullRequest.AutoMergeRequest; autoMerge != nil {
+				result["merge_method"] = strings.ToLower(string(autoMerge.MergeMethod))
+				result["enabled_at"] = autoMerge.EnabledAt.Time
+				result["enabled_by"] = string(autoMerge.EnabledBy.Login)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// DisablePullRequestAutoMerge creates a tool to disable auto-merge on a pull request.
+func DisablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Disable auto-merge on a pull request, so that it is no longer merged automatically once its requirements are met.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Disable pull request auto-merge"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			var prQuery pullRequestAutoMergeQuery
+			if err := client.Query(ctx, &prQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to find pull request", err), nil
+			}
+
+			changed := prQuery.Repository.PullRequest.AutoMergeRequest != nil
+			if changed {
+				var mutation struct {
+					DisablePullRequestAutoMerge struct {
+						PullRequest struct {
+							ID githubv4.ID
+						}
+					} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.DisablePullRequestAutoMergeInput{
+					PullRequestID: prQuery.Repository.PullRequest.ID,
+				}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to disable auto-merge", err), nil
+				}
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"number":             pullNumber,
+				"auto_merge_enabled": false,
+				"changed":            changed,
+			}), nil
+		}
+}
+
 // SearchPullRequests creates a tool to search for pull requests.
 func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_pull_requests",
@@ -735,12 +1101,302 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
-// GetPullRequestFiles creates a tool to get the list of files changed in a pull request.
-func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("get_pull_request_files",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_FILES_DESCRIPTION", "Get the files changed in a specific pull request.")),
+// GetPullRequestFiles creates a tool to get the list of files changed in a pull request.
+func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_files",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_FILES_DESCRIPTION", "Get the files changed in a specific pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_FILES_USER_TITLE", "Get pull request files"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithPagination(),
+			WithSummary(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			summary, err := OptionalParam[bool](request, SummaryParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if summary {
+				return summarizePullRequestFiles(ctx, client, owner, repo, pullNumber)
+			}
+			opts := &github.ListOptions{
+				PerPage: pagination.PerPage,
+				Page:    pagination.Page,
+			}
+			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request files",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(files)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// summarizePullRequestFiles reads all files of a pull request and counts them by change status,
+// top-level directory and extension, along with the total lines changed. One file of each status
+// is kept as a sample.
+func summarizePullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	summary := newListSummary("status", "directory", "extension")
+	summary.TotalCount = pr.GetChangedFiles()
+	opts := &github.ListOptions{PerPage: 100, Page: 1}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		for _, file := range files {
+			if summary.Analyzed >= maxSummaryItems {
+				break
+			}
+			summary.Analyzed++
+			name := file.GetFilename()
+			directory := "/"
+			if i := strings.Index(name, "/"); i >= 0 {
+				directory = name[:i+1]
+			}
+			summary.count("status", file.GetStatus())
+			summary.count("directory", directory)
+			summary.count("extension", path.Ext(name))
+			summary.add("additions", file.GetAdditions())
+			summary.add("deletions", file.GetDeletions())
+			summary.add("changes", file.GetChanges())
+			summary.sample(file.GetStatus(), MinimalCommitFile{
+				Filename:  name,
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+			})
+		}
+
+		if resp.NextPage == 0 || summary.Analyzed >= maxSummaryItems {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	summary.Truncated = summary.Analyzed < summary.TotalCount
+
+	return MarshalledTextResult(summary), nil
+}
+
+// FileDiffstat is the size of the change to one file of a pull request.
+type FileDiffstat struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+}
+
+// DirectoryDiffstat is the size of the changes to the files within a directory.
+type DirectoryDiffstat struct {
+	Path      string `json:"path"`
+	Files     int    `json:"files"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+// PullRequestDiffstat is the size of the changes of a pull request, by file and by directory.
+type PullRequestDiffstat struct {
+	Files       []FileDiffstat      `json:"files"`
+	Directories []DirectoryDiffstat `json:"directories"`
+	TotalFiles  int                 `json:"total_files"`
+	Additions   int                 `json:"additions"`
+	Deletions   int                 `json:"deletions"`
+	Changes     int                 `json:"changes"`
+}
+
+// diffstatDirectories returns the directories of a file up to depth levels deep, "/" for files at the root.
+func diffstatDirectories(filename string, depth int) []string {
+	parts := strings.Split(filename, "/")
+	if len(parts) == 1 {
+		return []string{"/"}
+	}
+	parts = parts[:len(parts)-1]
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	directories := make([]string, 0, len(parts))
+	for i := range parts {
+		directories = append(directories, strings.Join(parts[:i+1], "/")+"/")
+	}
+	return directories
+}
+
+// GetPullRequestDiffstat creates a tool to get the lines changed per file and directory of a pull request, without the patches.
+func GetPullRequestDiffstat(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pr_diffstat",
+			mcp.WithDescription(t("TOOL_GET_PR_DIFFSTAT_DESCRIPTION", "Get the additions and deletions of every file changed in a pull request, rolled up per directory, without the patches. Use it to decide which files are worth fetching for review.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PR_DIFFSTAT_USER_TITLE", "Get pull request diff stats"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("depth",
+				mcp.Description("How many directory levels to roll changes up to. Changes of a file count toward each of its directories up to this depth"),
+				mcp.Min(1),
+				mcp.DefaultNumber(2),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			depth, err := OptionalIntParamWithDefault(request, "depth", 2)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if depth < 1 {
+				return mcp.NewToolResultError("depth must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			diffstat := PullRequestDiffstat{Files: []FileDiffstat{}, Directories: []DirectoryDiffstat{}}
+			directories := map[string]*DirectoryDiffstat{}
+			opts := &github.ListOptions{PerPage: 100, Page: 1}
+			for {
+				files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, file := range files {
+					stat := FileDiffstat{
+						Filename:         file.GetFilename(),
+						PreviousFilename: file.GetPreviousFilename(),
+						Status:           file.GetStatus(),
+						Additions:        file.GetAdditions(),
+						Deletions:        file.GetDeletions(),
+						Changes:          file.GetChanges(),
+					}
+					diffstat.Files = append(diffstat.Files, stat)
+					diffstat.Additions += stat.Additions
+					diffstat.Deletions += stat.Deletions
+					diffstat.Changes += stat.Changes
+					for _, dir := range diffstatDirectories(stat.Filename, depth) {
+						d, ok := directories[dir]
+						if !ok {
+							d = &DirectoryDiffstat{Path: dir}
+							directories[dir] = d
+						}
+						d.Files++
+						d.Additions += stat.Additions
+						d.Deletions += stat.Deletions
+						d.Changes += stat.Changes
+					}
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			diffstat.TotalFiles = len(diffstat.Files)
+
+			for _, d := range directories {
+				diffstat.Directories = append(diffstat.Directories, *d)
+			}
+			sort.Slice(diffstat.Directories, func(i, j int) bool {
+				return diffstat.Directories[i].Path < diffstat.Directories[j].Path
+			})
+
+			return MarshalledTextResult(diffstat), nil
+		}
+}
+
+// GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
+func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_status",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_STATUS_DESCRIPTION", "Get the combined check status of a pull request as a single verdict: 'passing', 'failing', 'pending', or 'none' if nothing reported on its head commit. Commit statuses and check runs are both taken into account, and the failing and pending checks are listed with links to their details.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_PULL_REQUEST_FILES_USER_TITLE", "Get pull request files"),
+				Title:        t("TOOL_GET_PULL_REQUEST_STATUS_USER_TITLE", "Get pull request status checks"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -755,7 +1411,6 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
-			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -770,52 +1425,199 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pagination, err := OptionalPaginationParams(request)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
+			// First get the PR to find the head SHA
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			opts := &github.ListOptions{
-				PerPage: pagination.PerPage,
-				Page:    pagination.Page,
-			}
-			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get pull request files",
+					"failed to get pull request",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			headSHA := pr.GetHead().GetSHA()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
+			summary, errResult := getChecksSummary(ctx, client, owner, repo, headSHA)
+			if errResult != nil {
+				return errResult, nil
 			}
+			summary.PullNumber = pullNumber
 
-			r, err := json.Marshal(files)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+			return MarshalledTextResult(summary), nil
+		}
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// CheckResult is the state of a single commit status or check run.
+type CheckResult struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	State      string `json:"state"`
+	Conclusion string `json:"conclusion,omitempty"`
+	DetailsURL string `json:"details_url,omitempty"`
+}
+
+// PullRequestChecksSummary combines the commit statuses and check runs of a pull request into one verdict.
+type PullRequestChecksSummary struct {
+	PullNumber   int           `json:"pull_number"`
+	HeadSHA      string        `json:"head_sha"`
+	State        string        `json:"state"`
+	TotalCount   int           `json:"total_count"`
+	PassingCount int           `json:"passing_count"`
+	Failing      []CheckResult `json:"failing"`
+	Pending      []CheckResult `json:"pending"`
+}
+
+func (s *PullRequestChecksSummary) add(check CheckResult) {
+	s.TotalCount++
+	switch check.State {
+	case "passing":
+		s.PassingCount++
+	case "failing":
+		s.Failing = append(s.Failing, check)
+	default:
+		s.Pending = append(s.Pending, check)
+	}
+}
+
+// getChecksSummary combines the commit statuses and check runs of ref into one verdict: passing,
+// failing, pending, or none if nothing reported on it. A non-nil result is the error to return.
+func getChecksSummary(ctx context.Context, client *github.Client, owner, repo, ref string) (PullRequestChecksSummary, *mcp.CallToolResult) {
+	summary := PullRequestChecksSummary{
+		HeadSHA: ref,
+		Failing: []CheckResult{},
+		Pending: []CheckResult{},
+	}
+
+	statuses, resp, err := listCommitStatuses(ctx, client, owner, repo, ref)
+	if err != nil {
+		return summary, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get combined status",
+			resp,
+			err,
+		)
+	}
+
+	checkRuns, resp, err := listCheckRuns(ctx, client, owner, repo, ref)
+	if err != nil {
+		return summary, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to list check runs",
+			resp,
+			err,
+		)
+	}
+
+	for _, s := range statuses {
+		summary.add(CheckResult{
+			Name:       s.GetContext(),
+			Source:     "status",
+			State:      commitStatusState(s),
+			Conclusion: s.GetState(),
+			DetailsURL: s.GetTargetURL(),
+		})
+	}
+	for _, run := range checkRuns {
+		summary.add(CheckResult{
+			Name:       run.GetName(),
+			Source:     "check_run",
+			State:      checkRunState(run),
+			Conclusion: run.GetConclusion(),
+			DetailsURL: run.GetDetailsURL(),
+		})
+	}
+	switch {
+	case summary.TotalCount == 0:
+		summary.State = "none"
+	case len(summary.Failing) > 0:
+		summary.State = "failing"
+	case len(summary.Pending) > 0:
+		summary.State = "pending"
+	default:
+		summary.State = "passing"
+	}
+	return summary, nil
+}
+
+// listCommitStatuses lists the latest status of every context of ref, across all pages of its combined status.
+func listCommitStatuses(ctx context.Context, client *github.Client, owner, repo, ref string) ([]*github.RepoStatus, *github.Response, error) {
+	var statuses []*github.RepoStatus
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		statuses = append(statuses, status.Statuses...)
+		if resp.NextPage == 0 {
+			return statuses, resp, nil
 		}
+		opts.Page = resp.NextPage
+	}
 }
 
-// GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
-func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("get_pull_request_status",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_STATUS_DESCRIPTION", "Get the status of a specific pull request.")),
+// listCheckRuns lists the latest check runs of every check suite of ref, across all pages.
+func listCheckRuns(ctx context.Context, client *github.Client, owner, repo, ref string) ([]*github.CheckRun, *github.Response, error) {
+	var checkRuns []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		runs, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		checkRuns = append(checkRuns, runs.CheckRuns...)
+		if resp.NextPage == 0 {
+			return checkRuns, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// RequiredCheckResult is the state of a single required status check on a pull request.
+type RequiredCheckResult struct {
+	Context    string `json:"context"`
+	AppID      int64  `json:"app_id,omitempty"`
+	State      string `json:"state"`
+	Source     string `json:"source,omitempty"`
+	Conclusion string `json:"conclusion,omitempty"`
+	DetailsURL string `json:"details_url,omitempty"`
+}
+
+// checkRunState maps a check run to passing, failing or pending.
+func checkRunState(run *github.CheckRun) string {
+	if run.GetStatus() != "completed" {
+		return "pending"
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return "passing"
+	default:
+		return "failing"
+	}
+}
+
+// commitStatusState maps a commit status to passing, failing or pending.
+func commitStatusState(status *github.RepoStatus) string {
+	switch status.GetState() {
+	case "success":
+		return "passing"
+	case "pending":
+		return "pending"
+	default:
+		return "failing"
+	}
+}
+
+// CompareChecksToProtection creates a tool to cross-reference the required status checks of a pull request's base branch with the checks reported on its head commit.
+func CompareChecksToProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_checks_to_protection",
+			mcp.WithDescription(t("TOOL_COMPARE_CHECKS_TO_PROTECTION_DESCRIPTION", "Explain which required status checks are blocking a pull request from being merged. Cross-references the required checks of the base branch's protection with the check runs and commit statuses reported on the head commit, and lists the ones that are missing, failing or still pending.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_PULL_REQUEST_STATUS_USER_TITLE", "Get pull request status checks"),
+				Title:        t("TOOL_COMPARE_CHECKS_TO_PROTECTION_USER_TITLE", "Compare pull request checks to branch protection"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -844,11 +1646,12 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			// First get the PR to find the head SHA
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
 			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -859,39 +1662,113 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			baseBranch := pr.GetBase().GetRef()
+			headSHA := pr.GetHead().GetSHA()
+			result := map[string]any{
+				"pull_number": pullNumber,
+				"base":        baseBranch,
+				"head_sha":    headSHA,
 			}
 
-			// Get combined status for the head SHA
-			status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, *pr.Head.SHA, nil)
+			required, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, baseBranch)
 			if err != nil {
+				if errors.Is(err, github.ErrBranchNotProtected) {
+					result["protected"] = false
+					result["required_checks"] = []RequiredCheckResult{}
+					result["checks_satisfied"] = true
+					return MarshalledTextResult(result), nil
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get combined status",
+					fmt.Sprintf("failed to get required status checks of branch %s", baseBranch),
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			var requiredChecks []RequiredCheckResult
+			if required.Checks != nil {
+				for _, check := range *required.Checks {
+					requiredChecks = append(requiredChecks, RequiredCheckResult{Context: check.Context, AppID: check.GetAppID()})
+				}
+			} else if required.Contexts != nil {
+				for _, name := range *required.Contexts {
+					requiredChecks = append(requiredChecks, RequiredCheckResult{Context: name})
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(status)
+			checkRuns, resp, err := listCheckRuns(ctx, client, owner, repo, headSHA)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list check runs",
+					resp,
+					err,
+				), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			statuses, resp, err := listCommitStatuses(ctx, client, owner, repo, headSHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get combined status",
+					resp,
+					err,
+				), nil
+			}
+
+			missing, failing, pending := []string{}, []string{}, []string{}
+			for i := range requiredChecks {
+				check := &requiredChecks[i]
+				check.State = "missing"
+				for _, run := range checkRuns {
+					// An app ID of -1 (or none) means any app may report the check
+					if run.GetName() != check.Context || (check.AppID > 0 && run.GetApp().GetID() != check.AppID) {
+						continue
+					}
+					check.State = checkRunState(run)
+					check.Source = "check_run"
+					check.Conclusion = run.GetConclusion()
+					check.DetailsURL = run.GetDetailsURL()
+					break
+				}
+				if check.State == "missing" {
+					for _, s := range statuses {
+						if s.GetContext() != check.Context {
+							continue
+						}
+						check.State = commitStatusState(s)
+						check.Source = "status"
+						check.Conclusion = s.GetState()
+						check.DetailsURL = s.GetTargetURL()
+						break
+					}
+				}
+
+				switch check.State {
+				case "missing":
+					missing = append(missing, check.Context)
+				case "failing":
+					failing = append(failing, check.Context)
+				case "pending":
+					pending = append(pending, check.Context)
+				}
+			}
+			if requiredChecks == nil {
+				requiredChecks = []RequiredCheckResult{}
+			}
+
+			result["protected"] = true
+			result["strict"] = required.Strict
+			result["required_checks"] = requiredChecks
+			result["missing"] = missing
+			result["failing"] = failing
+			result["pending"] = pending
+			result["checks_satisfied"] = len(missing) == 0 && len(failing) == 0 && len(pending) == 0
+			if required.Strict {
+				// Strict protection also requires the head branch to be up to date with the base branch
+				result["branch_behind"] = pr.GetMergeableState() == "behind"
+			}
+
+			return MarshalledTextResult(result), nil
 		}
 }
 
@@ -1614,8 +2491,9 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 	return mcp.NewTool("delete_pending_pull_request_review",
 			mcp.WithDescription(t("TOOL_DELETE_PENDING_PULL_REQUEST_REVIEW_DESCRIPTION", "Delete the requester's latest pending pull request review. Use this after the user decides not to submit a pending review, if you don't know if they already created one then check first.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_DELETE_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Delete the requester's latest pending pull request review"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:           t("TOOL_DELETE_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Delete the requester's latest pending pull request review"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
 			}),
 			// Ideally, for performance sake this would just accept the pullRequestReviewID. However, we would need to
 			// add a new tool to get that ID for clients that aren't in the same context as the original pending review
@@ -1729,9 +2607,256 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 		}
 }
 
+// ReviewThreadComment is a comment of a pull request review thread.
+type ReviewThreadComment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+// ReviewThread is a pull request review thread with its comments.
+type ReviewThread struct {
+	ID           string                `json:"id"`
+	Path         string                `json:"path"`
+	Line         int                   `json:"line,omitempty"`
+	StartLine    int                   `json:"start_line,omitempty"`
+	IsResolved   bool                  `json:"is_resolved"`
+	IsOutdated   bool                  `json:"is_outdated"`
+	ResolvedBy   string                `json:"resolved_by,omitempty"`
+	DiffHunk     string                `json:"diff_hunk,omitempty"`
+	CommentCount int                   `json:"comment_count"`
+	Comments     []ReviewThreadComment `json:"comments"`
+}
+
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				TotalCount int
+				PageInfo   PageInfoFragment
+				Nodes      []struct {
+					ID         githubv4.ID
+					Path       githubv4.String
+					Line       *githubv4.Int
+					StartLine  *githubv4.Int
+					IsResolved githubv4.Boolean
+					IsOutdated githubv4.Boolean
+					ResolvedBy *struct {
+						Login githubv4.String
+					}
+					Comments struct {
+						TotalCount int
+						Nodes      []struct {
+							Author struct {
+								Login githubv4.String
+							}
+							Body      githubv4.String
+							DiffHunk  githubv4.String
+							CreatedAt githubv4.DateTime
+							URL       githubv4.String
+						}
+					} `graphql:"comments(first: 50)"`
+				}
+			} `graphql:"reviewThreads(first: $first, after: $after)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// ListPullRequestReviewThreads creates a tool to list the review threads of a pull request.
+func ListPullRequestReviewThreads(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pull_request_review_threads",
+			mcp.WithDescription(t("TOOL_LIST_PULL_REQUEST_REVIEW_THREADS_DESCRIPTION", "List the review comment threads of a pull request with their resolved state, the diff hunk they refer to and their comments. Use the thread IDs with resolve_review_thread once the feedback has been addressed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PULL_REQUEST_REVIEW_THREADS_USER_TITLE", "List pull request review threads"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("is_resolved",
+				mcp.Description("Only return threads that are resolved (true) or unresolved (false)"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			isResolved, filterResolved, err := OptionalParamOK[bool](request, "is_resolved")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query reviewThreadsQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			paginationParams.Vars(vars)
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list review threads", err), nil
+			}
+
+			connection := query.Repository.PullRequest.ReviewThreads
+			threads := []ReviewThread{}
+			for _, node := range connection.Nodes {
+				if filterResolved && bool(node.IsResolved) != isResolved {
+					continue
+				}
+				thread := ReviewThread{
+					ID:           fmt.Sprint(node.ID),
+					Path:         string(node.Path),
+					IsResolved:   bool(node.IsResolved),
+					IsOutdated:   bool(node.IsOutdated),
+					CommentCount: node.Comments.TotalCount,
+					Comments:     []ReviewThreadComment{},
+				}
+				if node.Line != nil {
+					thread.Line = int(*node.Line)
+				}
+				if node.StartLine != nil {
+					thread.StartLine = int(*node.StartLine)
+				}
+				if node.ResolvedBy != nil {
+					thread.ResolvedBy = string(node.ResolvedBy.Login)
+				}
+				for i, comment := range node.Comments.Nodes {
+					// All comments of a thread refer to the same hunk
+					if i == 0 {
+						thread.DiffHunk = string(comment.DiffHunk)
+					}
+					thread.Comments = append(thread.Comments, ReviewThreadComment{
+						Author:    string(comment.Author.Login),
+						Body:      string(comment.Body),
+						CreatedAt: comment.CreatedAt.Time,
+						URL:       string(comment.URL),
+					})
+				}
+				threads = append(threads, thread)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"threads":    threads,
+				"pagination": connection.PageInfo.Pagination(connection.TotalCount),
+			}), nil
+		}
+}
+
+// ResolveReviewThread creates a tool to mark a pull request review thread as resolved.
+func ResolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_review_thread",
+			mcp.WithDescription(t("TOOL_RESOLVE_REVIEW_THREAD_DESCRIPTION", "Mark a pull request review thread as resolved, e.g. once the feedback in it has been addressed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_REVIEW_THREAD_USER_TITLE", "Resolve review thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("thread_id",
+				mcp.Required(),
+				mcp.Description("The node ID of the review thread, as returned by list_pull_request_review_threads"),
+			),
+		),
+		reviewThreadResolutionHandler(getGQLClient, true)
+}
+
+// UnresolveReviewThread creates a tool to mark a resolved pull request review thread as unresolved.
+func UnresolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("unresolve_review_thread",
+			mcp.WithDescription(t("TOOL_UNRESOLVE_REVIEW_THREAD_DESCRIPTION", "Mark a resolved pull request review thread as unresolved, reopening the discussion in it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNRESOLVE_REVIEW_THREAD_USER_TITLE", "Unresolve review thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("thread_id",
+				mcp.Required(),
+				mcp.Description("The node ID of the review thread, as returned by list_pull_request_review_threads"),
+			),
+		),
+		reviewThreadResolutionHandler(getGQLClient, false)
+}
+
+type reviewThreadState struct {
+	ID         githubv4.ID
+	IsResolved githubv4.Boolean
+}
+
+func reviewThreadResolutionHandler(getGQLClient GetGQLClientFn, resolve bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		threadID, err := RequiredParam[string](request, "thread_id")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+
+		var thread reviewThreadState
+		if resolve {
+			var mutation struct {
+				ResolveReviewThread struct {
+					Thread reviewThreadState
+				} `graphql:"resolveReviewThread(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID(threadID)}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to resolve review thread", err), nil
+			}
+			thread = mutation.ResolveReviewThread.Thread
+		} else {
+			var mutation struct {
+				UnresolveReviewThread struct {
+					Thread reviewThreadState
+				} `graphql:"unresolveReviewThread(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID(threadID)}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unresolve review thread", err), nil
+			}
+			thread = mutation.UnresolveReviewThread.Thread
+		}
+
+		return MarshalledTextResult(map[string]any{
+			"thread_id":   fmt.Sprint(thread.ID),
+			"is_resolved": bool(thread.IsResolved),
+		}), nil
+	}
+}
+
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request. For large pull requests, use files to limit the diff to specific paths, and max_bytes with file_offset to page through the diff one chunk of files at a time.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1748,12 +2873,31 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithArray("files",
+				mcp.Description("Only include the diff for these file paths"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description("Maximum size of the returned diff in bytes. The diff is cut at a file boundary and the response explains how to fetch the next chunk"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("file_offset",
+				mcp.Description("Index of the first file to include, used to fetch subsequent chunks of a diff limited by max_bytes"),
+				mcp.Min(0),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
 				Owner      string
 				Repo       string
 				PullNumber int32
+				Files      []string
+				MaxBytes   int `mapstructure:"max_bytes"`
+				FileOffset int `mapstructure:"file_offset"`
 			}
 			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -1789,9 +2933,97 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 
 			defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+			if len(params.Files) == 0 && params.MaxBytes == 0 && params.FileOffset == 0 {
+				// Return the raw response
+				return mcp.NewToolResultText(string(raw)), nil
+			}
+
+			return paginateDiff(splitDiffByFile(string(raw)), params.Files, params.MaxBytes, params.FileOffset), nil
+		}
+}
+
+// fileDiff is the portion of a unified diff that applies to a single file.
+type fileDiff struct {
+	oldPath string
+	newPath string
+	text    string
+}
+
+// splitDiffByFile splits a unified diff into one section per "diff --git" header.
+func splitDiffByFile(diff string) []fileDiff {
+	var files []fileDiff
+	for _, section := range strings.SplitAfter(diff, "\n") {
+		if section == "" {
+			continue
+		}
+		if strings.HasPrefix(section, "diff --git ") || len(files) == 0 {
+			f := fileDiff{}
+			header := strings.TrimSuffix(strings.TrimPrefix(section, "diff --git "), "\n")
+			if a, b, ok := strings.Cut(header, " b/"); ok {
+				f.oldPath = strings.TrimPrefix(a, "a/")
+				f.newPath = b
+			}
+			files = append(files, f)
+		}
+		files[len(files)-1].text += section
+	}
+	return files
+}
+
+// paginateDiff filters a split diff down to the requested files and returns as
+// many whole files as fit in maxBytes, starting at offset. A single file larger
+// than maxBytes is truncated so that every chunk makes progress.
+func paginateDiff(files []fileDiff, paths []string, maxBytes, offset int) *mcp.CallToolResult {
+	if len(paths) > 0 {
+		wanted := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			wanted[p] = true
+		}
+		filtered := make([]fileDiff, 0, len(paths))
+		for _, f := range files {
+			if wanted[f.oldPath] || wanted[f.newPath] {
+				filtered = append(filtered, f)
+			}
+		}
+		files = filtered
+	}
+
+	if offset > len(files) {
+		return mcp.NewToolResultError(fmt.Sprintf("file_offset %d is out of range, the diff contains %d files", offset, len(files)))
+	}
+
+	var sb strings.Builder
+	next := offset
+	truncated := false
+	for ; next < len(files); next++ {
+		text := files[next].text
+		if maxBytes > 0 && sb.Len()+len(text) > maxBytes {
+			if sb.Len() > 0 {
+				break
+			}
+			// Cut at a rune boundary so the result stays valid UTF-8
+			cut := maxBytes
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+			text = text[:cut]
+			truncated = true
+		}
+		sb.WriteString(text)
+	}
+
+	result := mcp.NewToolResultText(sb.String())
+	if next < len(files) || truncated {
+		note := fmt.Sprintf("Diff truncated: showing files %d-%d of %d.", offset+1, next, len(files))
+		if truncated {
+			note = fmt.Sprintf("Diff truncated: the diff for %s is larger than max_bytes and was cut off.", files[offset].newPath)
 		}
+		if next < len(files) {
+			note += fmt.Sprintf(" Call again with file_offset=%d to fetch the next chunk.", next)
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(note))
+	}
+	return result
 }
 
 // RequestCopilotReview creates a tool to request a Copilot review for a pull request.
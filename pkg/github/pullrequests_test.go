@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
 
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -572,6 +575,179 @@ func Test_UpdatePullRequest_Draft(t *testing.T) {
 	}
 }
 
+func Test_ConvertPullRequestToDraftAndMarkReady(t *testing.T) {
+	// Verify tool definitions
+	convertTool, _ := ConvertPullRequestToDraft(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(convertTool.Name, convertTool))
+
+	assert.Equal(t, "convert_pull_request_to_draft", convertTool.Name)
+	assert.NotEmpty(t, convertTool.Description)
+	assert.ElementsMatch(t, convertTool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	readyTool, _ := MarkPullRequestReadyForReview(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(readyTool.Name, readyTool))
+
+	assert.Equal(t, "mark_pull_request_ready_for_review", readyTool.Name)
+	assert.NotEmpty(t, readyTool.Description)
+	assert.ElementsMatch(t, readyTool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	prQueryMatcher := func(isDraft bool) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			pullRequestDraftQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"id":      "PR_kwDOA0xdyM50BPaO",
+						"isDraft": isDraft,
+					},
+				},
+			}),
+		)
+	}
+
+	convertMutationMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			ConvertPullRequestToDraft struct {
+				PullRequest struct {
+					ID      githubv4.ID
+					IsDraft githubv4.Boolean
+				}
+			} `graphql:"convertPullRequestToDraft(input: $input)"`
+		}{},
+		githubv4.ConvertPullRequestToDraftInput{
+			PullRequestID: "PR_kwDOA0xdyM50BPaO",
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"convertPullRequestToDraft": map[string]any{
+				"pullRequest": map[string]any{
+					"id":      "PR_kwDOA0xdyM50BPaO",
+					"isDraft": true,
+				},
+			},
+		}),
+	)
+
+	readyMutationMatcher := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				MarkPullRequestReadyForReview struct {
+					PullRequest struct {
+						ID      githubv4.ID
+						IsDraft githubv4.Boolean
+					}
+				} `graphql:"markPullRequestReadyForReview(input: $input)"`
+			}{},
+			githubv4.MarkPullRequestReadyForReviewInput{
+				PullRequestID: "PR_kwDOA0xdyM50BPaO",
+			},
+			nil,
+			response,
+		)
+	}
+
+	tests := []struct {
+		name            string
+		tool            func(GetGQLClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedDraft   bool
+		expectedChanged bool
+	}{
+		{
+			name:            "convert ready pull request to draft",
+			tool:            ConvertPullRequestToDraft,
+			mockedClient:    githubv4mock.NewMockedHTTPClient(prQueryMatcher(false), convertMutationMatcher),
+			requestArgs:     map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedDraft:   true,
+			expectedChanged: true,
+		},
+		{
+			name:            "convert pull request that is already a draft",
+			tool:            ConvertPullRequestToDraft,
+			mockedClient:    githubv4mock.NewMockedHTTPClient(prQueryMatcher(true)),
+			requestArgs:     map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedDraft:   true,
+			expectedChanged: false,
+		},
+		{
+			name: "mark draft pull request ready for review",
+			tool: MarkPullRequestReadyForReview,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				prQueryMatcher(true),
+				readyMutationMatcher(githubv4mock.DataResponse(map[string]any{
+					"markPullRequestReadyForReview": map[string]any{
+						"pullRequest": map[string]any{
+							"id":      "PR_kwDOA0xdyM50BPaO",
+							"isDraft": false,
+						},
+					},
+				})),
+			),
+			requestArgs:     map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedDraft:   false,
+			expectedChanged: true,
+		},
+		{
+			name: "mark ready for review fails",
+			tool: MarkPullRequestReadyForReview,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				prQueryMatcher(true),
+				readyMutationMatcher(githubv4mock.ErrorResponse("pull request is closed")),
+			),
+			requestArgs:    map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "Failed to mark pull request ready for review",
+		},
+		{
+			name:           "missing pullNumber",
+			tool:           MarkPullRequestReadyForReview,
+			mockedClient:   githubv4mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"owner": "owner", "repo": "repo"},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: pullNumber",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := tc.tool(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Number  int  `json:"number"`
+				IsDraft bool `json:"is_draft"`
+				Changed bool `json:"changed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, 42, response.Number)
+			assert.Equal(t, tc.expectedDraft, response.IsDraft)
+			assert.Equal(t, tc.expectedChanged, response.Changed)
+		})
+	}
+}
+
 func Test_ListPullRequests(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -821,6 +997,244 @@ func Test_MergePullRequest(t *testing.T) {
 	}
 }
 
+func Test_EnablePullRequestAutoMerge(t *testing.T) {
+	// Verify tool definition
+	tool, _ := EnablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "enable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_head_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	prQueryMatcher := githubv4mock.NewQueryMatcher(
+		pullRequestAutoMergeQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"id":               "PR_kwDOA0xdyM50BPaO",
+					"autoMergeRequest": nil,
+				},
+			},
+		}),
+	)
+
+	enableMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				AutoMergeRequest *struct {
+					EnabledAt   githubv4.DateTime
+					MergeMethod githubv4.PullRequestMergeMethod
+					EnabledBy   struct {
+						Login githubv4.String
+					}
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedMethod string
+	}{
+		{
+			name: "enable auto-merge with squash",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:   "PR_kwDOA0xdyM50BPaO",
+						MergeMethod:     newGQLStringlike[githubv4.PullRequestMergeMethod]("SQUASH"),
+						CommitHeadline:  githubv4.NewString("Add feature (#42)"),
+						ExpectedHeadOid: newGQLStringlike[githubv4.GitObjectID]("abc123"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"autoMergeRequest": map[string]any{
+									"enabledAt":   "2024-01-01T00:00:00Z",
+									"mergeMethod": "SQUASH",
+									"enabledBy": map[string]any{
+										"login": "octocat",
+									},
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"merge_method":      "squash",
+				"commit_title":      "Add feature (#42)",
+				"expected_head_sha": "abc123",
+			},
+			expectedMethod: "squash",
+		},
+		{
+			name: "auto-merge not allowed in repository",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID: "PR_kwDOA0xdyM50BPaO",
+					},
+					nil,
+					githubv4mock.ErrorResponse("Auto merge is not allowed for this repository"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "Auto merge is not allowed for this repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := EnablePullRequestAutoMerge(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Number           int    `json:"number"`
+				AutoMergeEnabled bool   `json:"auto_merge_enabled"`
+				MergeMethod      string `json:"merge_method"`
+				EnabledBy        string `json:"enabled_by"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, 42, response.Number)
+			assert.True(t, response.AutoMergeEnabled)
+			assert.Equal(t, tc.expectedMethod, response.MergeMethod)
+			assert.Equal(t, "octocat", response.EnabledBy)
+		})
+	}
+}
+
+func Test_DisablePullRequestAutoMerge(t *testing.T) {
+	// Verify tool definition
+	tool, _ := DisablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "disable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	prQueryMatcher := func(autoMergeRequest any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			pullRequestAutoMergeQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"id":               "PR_kwDOA0xdyM50BPaO",
+						"autoMergeRequest": autoMergeRequest,
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectedChanged bool
+	}{
+		{
+			name: "disable enabled auto-merge",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				prQueryMatcher(map[string]any{"mergeMethod": "MERGE"}),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						DisablePullRequestAutoMerge struct {
+							PullRequest struct {
+								ID githubv4.ID
+							}
+						} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+					}{},
+					githubv4.DisablePullRequestAutoMergeInput{
+						PullRequestID: "PR_kwDOA0xdyM50BPaO",
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"disablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"id": "PR_kwDOA0xdyM50BPaO",
+							},
+						},
+					}),
+				),
+			),
+			expectedChanged: true,
+		},
+		{
+			name:            "auto-merge already disabled",
+			mockedClient:    githubv4mock.NewMockedHTTPClient(prQueryMatcher(nil)),
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := DisablePullRequestAutoMerge(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				AutoMergeEnabled bool `json:"auto_merge_enabled"`
+				Changed          bool `json:"changed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.False(t, response.AutoMergeEnabled)
+			assert.Equal(t, tc.expectedChanged, response.Changed)
+		})
+	}
+}
+
 func Test_SearchPullRequests(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := SearchPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
@@ -1267,6 +1681,160 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestFiles_Summary(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			&github.PullRequest{Number: github.Ptr(42), ChangedFiles: github.Ptr(4)},
+		),
+		mock.WithRequestMatchPages(
+			mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+			[]*github.CommitFile{
+				{Filename: github.Ptr("pkg/a.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12)},
+				{Filename: github.Ptr("pkg/b.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Changes: github.Ptr(2)},
+			},
+			[]*github.CommitFile{
+				{Filename: github.Ptr("docs/new.md"), Status: github.Ptr("added"), Additions: github.Ptr(30), Changes: github.Ptr(30)},
+				{Filename: github.Ptr("Makefile"), Status: github.Ptr("removed"), Deletions: github.Ptr(5), Changes: github.Ptr(5)},
+			},
+		),
+	)
+	_, handler := GetPullRequestFiles(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+		"summary":    true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var summary ListSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+	assert.Equal(t, 4, summary.TotalCount)
+	assert.Equal(t, 4, summary.Analyzed)
+	assert.False(t, summary.Truncated)
+	assert.Equal(t, map[string]map[string]int{
+		"status":    {"modified": 2, "added": 1, "removed": 1},
+		"directory": {"pkg/": 2, "docs/": 1, "/": 1},
+		"extension": {".go": 2, ".md": 1, "(none)": 1},
+	}, summary.Counts)
+	assert.Equal(t, map[string]int{"additions": 41, "deletions": 8, "changes": 49}, summary.Totals)
+	// One sample per status
+	assert.Len(t, summary.Samples, 3)
+}
+
+func Test_GetPullRequestDiffstat(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestDiffstat(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pr_diffstat", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       PullRequestDiffstat
+	}{
+		{
+			name: "rolls changes up per directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{
+						{Filename: github.Ptr("pkg/github/a.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12), Patch: github.Ptr("@@ -1,2 +1,10 @@")},
+						{Filename: github.Ptr("pkg/github/deep/b.go"), PreviousFilename: github.Ptr("pkg/b.go"), Status: github.Ptr("renamed"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Changes: github.Ptr(2)},
+					},
+					[]*github.CommitFile{
+						{Filename: github.Ptr("README.md"), Status: github.Ptr("added"), Additions: github.Ptr(30), Changes: github.Ptr(30)},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expected: PullRequestDiffstat{
+				Files: []FileDiffstat{
+					{Filename: "pkg/github/a.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+					{Filename: "pkg/github/deep/b.go", PreviousFilename: "pkg/b.go", Status: "renamed", Additions: 1, Deletions: 1, Changes: 2},
+					{Filename: "README.md", Status: "added", Additions: 30, Changes: 30},
+				},
+				Directories: []DirectoryDiffstat{
+					{Path: "/", Files: 1, Additions: 30, Changes: 30},
+					{Path: "pkg/", Files: 2, Additions: 11, Deletions: 3, Changes: 14},
+					{Path: "pkg/github/", Files: 2, Additions: 11, Deletions: 3, Changes: 14},
+				},
+				TotalFiles: 3,
+				Additions:  41,
+				Deletions:  3,
+				Changes:    44,
+			},
+		},
+		{
+			name: "depth limits the rollup",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{
+						{Filename: github.Ptr("pkg/github/a.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12)},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42), "depth": float64(1)},
+			expected: PullRequestDiffstat{
+				Files: []FileDiffstat{
+					{Filename: "pkg/github/a.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+				},
+				Directories: []DirectoryDiffstat{
+					{Path: "pkg/", Files: 1, Additions: 10, Deletions: 2, Changes: 12},
+				},
+				TotalFiles: 1,
+				Additions:  10,
+				Deletions:  2,
+				Changes:    12,
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(999)},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request files",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestDiffstat(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var diffstat PullRequestDiffstat
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &diffstat))
+			assert.Equal(t, tc.expected, diffstat)
+		})
+	}
+}
+
 func Test_GetPullRequestStatus(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1317,116 +1885,394 @@ func Test_GetPullRequestStatus(t *testing.T) {
 		},
 	}
 
+	pendingStatus := &github.CombinedStatus{
+		State: github.Ptr("pending"),
+		Statuses: []*github.RepoStatus{
+			{
+				State:     github.Ptr("pending"),
+				Context:   github.Ptr("deploy/preview"),
+				TargetURL: github.Ptr("https://example.com/preview"),
+			},
+		},
+	}
+	checkRuns := func(runs ...*github.CheckRun) *github.ListCheckRunsResults {
+		return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}
+	}
+	passingRun := &github.CheckRun{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}
+	failingRun := &github.CheckRun{
+		Name:       github.Ptr("test"),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("failure"),
+		DetailsURL: github.Ptr("https://github.com/owner/repo/runs/1"),
+	}
+	requestArgs := map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}
+
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedStatus *github.CombinedStatus
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedState   string
+		expectedTotal   int
+		expectedFailing []CheckResult
+		expectedPending []string
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful status fetch",
+			name: "all checks passing",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposPullsByOwnerByRepoByPullNumber,
-					mockPR,
-				),
-				mock.WithRequestMatch(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, mockStatus),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(passingRun)),
+			),
+			requestArgs:     requestArgs,
+			expectedState:   "passing",
+			expectedTotal:   4,
+			expectedFailing: []CheckResult{},
+			expectedPending: []string{},
+		},
+		{
+			name: "failing check run wins over pending status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, pendingStatus),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(passingRun, failingRun)),
+			),
+			requestArgs:   requestArgs,
+			expectedState: "failing",
+			expectedTotal: 3,
+			expectedFailing: []CheckResult{
+				{Name: "test", Source: "check_run", State: "failing", Conclusion: "failure", DetailsURL: "https://github.com/owner/repo/runs/1"},
+			},
+			expectedPending: []string{"deploy/preview"},
+		},
+		{
+			name: "failing status beyond the first page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatchPages(
 					mock.GetReposCommitsStatusByOwnerByRepoByRef,
 					mockStatus,
+					&github.CombinedStatus{
+						State: github.Ptr("failure"),
+						Statuses: []*github.RepoStatus{
+							{State: github.Ptr("failure"), Context: github.Ptr("security/scan"), TargetURL: github.Ptr("https://example.com/scan")},
+						},
+					},
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(passingRun)),
+			),
+			requestArgs:   requestArgs,
+			expectedState: "failing",
+			expectedTotal: 5,
+			expectedFailing: []CheckResult{
+				{Name: "security/scan", Source: "status", State: "failing", Conclusion: "failure", DetailsURL: "https://example.com/scan"},
+			},
+			expectedPending: []string{},
+		},
+		{
+			name: "pending status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, pendingStatus),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(passingRun)),
+			),
+			requestArgs:     requestArgs,
+			expectedState:   "pending",
+			expectedTotal:   2,
+			expectedFailing: []CheckResult{},
+			expectedPending: []string{"deploy/preview"},
+		},
+		{
+			name: "no checks reported",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{State: github.Ptr("pending")}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns()),
+			),
+			requestArgs:     requestArgs,
+			expectedState:   "none",
+			expectedFailing: []CheckResult{},
+			expectedPending: []string{},
+		},
+		{
+			name: "PR fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
-				"pullNumber": float64(42),
+				"pullNumber": float64(999),
 			},
-			expectError:    false,
-			expectedStatus: mockStatus,
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
 		},
 		{
-			name: "PR fetch fails",
+			name: "status fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
+				mock.WithRequestMatch(
 					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
 						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":      "owner",
-				"repo":       "repo",
-				"pullNumber": float64(999),
-			},
-			expectError:    true,
-			expectedErrMsg: "failed to get pull request",
+			requestArgs:    requestArgs,
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status",
+		},
+		{
+			name: "check runs fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, mockStatus),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs:    requestArgs,
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var summary PullRequestChecksSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+			assert.Equal(t, 42, summary.PullNumber)
+			assert.Equal(t, "abcd1234", summary.HeadSHA)
+			assert.Equal(t, tc.expectedState, summary.State)
+			assert.Equal(t, tc.expectedTotal, summary.TotalCount)
+			assert.Equal(t, tc.expectedFailing, summary.Failing)
+			pending := []string{}
+			for _, check := range summary.Pending {
+				pending = append(pending, check.Name)
+			}
+			assert.Equal(t, tc.expectedPending, pending)
+		})
+	}
+}
+
+func Test_CompareChecksToProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareChecksToProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_checks_to_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number:         github.Ptr(42),
+		MergeableState: github.Ptr("behind"),
+		Base: &github.PullRequestBranch{
+			Ref: github.Ptr("main"),
+		},
+		Head: &github.PullRequestBranch{
+			SHA: github.Ptr("abcd1234"),
+			Ref: github.Ptr("feature-branch"),
+		},
+	}
+
+	mockRequiredChecks := &github.RequiredStatusChecks{
+		Strict: true,
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "build"},
+			{Context: "test", AppID: github.Ptr(int64(15368))},
+			{Context: "lint"},
+			{Context: "deploy/preview"},
+			{Context: "security-scan"},
+		},
+	}
+
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(4),
+		CheckRuns: []*github.CheckRun{
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(15368))}},
+			{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), DetailsURL: github.Ptr("https://github.com/owner/repo/runs/2"), App: &github.App{ID: github.Ptr(int64(15368))}},
+			{Name: github.Ptr("lint"), Status: github.Ptr("in_progress"), App: &github.App{ID: github.Ptr(int64(15368))}},
+			// Reported by a different app than the one required, so it doesn't count
+			{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(1))}},
+		},
+	}
+
+	mockStatus := &github.CombinedStatus{
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("deploy/preview"), State: github.Ptr("success")},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectProtected  bool
+		expectSatisfied  bool
+		expectedMissing  []string
+		expectedFailing  []string
+		expectedPending  []string
+		expectedBehind   bool
+		expectedRequired int
+	}{
+		{
+			name: "required checks missing, failing and pending",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/branches/main/protection/required_status_checks").andThen(
+						mockResponse(t, http.StatusOK, mockRequiredChecks),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/abcd1234/check-runs").andThen(
+						mockResponse(t, http.StatusOK, mockCheckRuns),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+			),
+			expectProtected:  true,
+			expectSatisfied:  false,
+			expectedMissing:  []string{"security-scan"},
+			expectedFailing:  []string{"test"},
+			expectedPending:  []string{"lint"},
+			expectedBehind:   true,
+			expectedRequired: 5,
 		},
 		{
-			name: "status fetch fails",
+			name: "base branch not protected",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
 					mock.GetReposPullsByOwnerByRepoByPullNumber,
 					mockPR,
 				),
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			expectProtected:  false,
+			expectSatisfied:  true,
+			expectedRequired: 0,
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":      "owner",
-				"repo":       "repo",
-				"pullNumber": float64(42),
-			},
 			expectError:    true,
-			expectedErrMsg: "failed to get combined status",
+			expectedErrMsg: "failed to get pull request",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequestStatus(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := CompareChecksToProtection(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
+			request := createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedStatus github.CombinedStatus
-			err = json.Unmarshal([]byte(textContent.Text), &returnedStatus)
-			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedStatus.State, *returnedStatus.State)
-			assert.Equal(t, *tc.expectedStatus.TotalCount, *returnedStatus.TotalCount)
-			assert.Len(t, returnedStatus.Statuses, len(tc.expectedStatus.Statuses))
-			for i, status := range returnedStatus.Statuses {
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].State, *status.State)
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].Context, *status.Context)
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].Description, *status.Description)
+			var response struct {
+				Protected       bool                  `json:"protected"`
+				ChecksSatisfied bool                  `json:"checks_satisfied"`
+				RequiredChecks  []RequiredCheckResult `json:"required_checks"`
+				Missing         []string              `json:"missing"`
+				Failing         []string              `json:"failing"`
+				Pending         []string              `json:"pending"`
+				BranchBehind    bool                  `json:"branch_behind"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectProtected, response.Protected)
+			assert.Equal(t, tc.expectSatisfied, response.ChecksSatisfied)
+			assert.Len(t, response.RequiredChecks, tc.expectedRequired)
+			assert.Equal(t, tc.expectedMissing, response.Missing)
+			assert.Equal(t, tc.expectedFailing, response.Failing)
+			assert.Equal(t, tc.expectedPending, response.Pending)
+			assert.Equal(t, tc.expectedBehind, response.BranchBehind)
+
+			for _, check := range response.RequiredChecks {
+				if check.Context == "deploy/preview" {
+					assert.Equal(t, "status", check.Source)
+					assert.Equal(t, "passing", check.State)
+				}
+				if check.Context == "test" {
+					assert.Equal(t, "https://github.com/owner/repo/runs/2", check.DetailsURL)
+				}
 			}
 		})
 	}
@@ -1826,6 +2672,7 @@ func Test_CreatePullRequest(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "base")
 	assert.Contains(t, tool.InputSchema.Properties, "draft")
 	assert.Contains(t, tool.InputSchema.Properties, "maintainer_can_modify")
+	assert.Contains(t, tool.InputSchema.Properties, "closes_issues")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title", "head", "base"})
 
 	// Setup mock PR for success case
@@ -1888,6 +2735,84 @@ func Test_CreatePullRequest(t *testing.T) {
 			expectError: false,
 			expectedPR:  mockPR,
 		},
+		{
+			name: "PR creation closing issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{Number: github.Ptr(7)},
+					&github.Issue{Number: github.Ptr(8)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"title":                 "Test PR",
+						"body":                  "This is a test PR\n\nFixes #8\n\nCloses #7",
+						"head":                  "feature-branch",
+						"base":                  "main",
+						"draft":                 false,
+						"maintainer_can_modify": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockPR),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"title":         "Test PR",
+				"body":          "This is a test PR\n\nFixes #8\n",
+				"head":          "feature-branch",
+				"base":          "main",
+				"closes_issues": []any{float64(7), float64(8)},
+			},
+			expectError: false,
+			expectedPR:  mockPR,
+		},
+		{
+			name: "closes_issues references a missing issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"title":         "Test PR",
+				"head":          "feature-branch",
+				"base":          "main",
+				"closes_issues": []any{float64(999)},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue #999 to close",
+		},
+		{
+			name: "closes_issues references a pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{
+						Number:           github.Ptr(5),
+						PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/5")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"title":         "Test PR",
+				"head":          "feature-branch",
+				"base":          "main",
+				"closes_issues": []any{float64(5)},
+			},
+			expectError:    true,
+			expectedErrMsg: "#5 is a pull request, not an issue",
+		},
 		{
 			name:         "missing required parameter",
 			mockedClient: mock.NewMockedHTTPClient(),
@@ -2755,6 +3680,50 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 	}
 }
 
+func Test_AppendClosingKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		issues   []int
+		expected string
+	}{
+		{
+			name:     "no issues",
+			body:     "Description",
+			expected: "Description",
+		},
+		{
+			name:     "empty body",
+			issues:   []int{1, 2},
+			expected: "Closes #1\nCloses #2",
+		},
+		{
+			name:     "appends after description",
+			body:     "Description\n",
+			issues:   []int{3},
+			expected: "Description\n\nCloses #3",
+		},
+		{
+			name:     "skips issues already referenced",
+			body:     "Resolves #3 and fixes #4",
+			issues:   []int{3, 4, 34},
+			expected: "Resolves #3 and fixes #4\n\nCloses #34",
+		},
+		{
+			name:     "skips duplicate issues",
+			body:     "Fixes #2",
+			issues:   []int{5, 5, 2, 6, 5},
+			expected: "Fixes #2\n\nCloses #5\nCloses #6",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, appendClosingKeywords(tc.body, tc.issues))
+		})
+	}
+}
+
 func TestGetPullRequestDiff(t *testing.T) {
 	t.Parallel()
 
@@ -2783,12 +3752,47 @@ index 5d6e7b2..8a4f5c3 100644
 +
 +This is a new section added in the pull request.`
 
+	goDiff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-func old() {}
++func updated() {}
+`
+	docsDiff := `diff --git a/docs/old.md b/docs/new.md
+similarity index 90%
+rename from docs/old.md
+rename to docs/new.md
+`
+	multiFileDiff := stubbedDiff + "\n" + goDiff + docsDiff
+	unicodeDiff := `diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-Grüße
++Grüße, wörld
+`
+
+	multiFileClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				mockResponse(t, http.StatusOK, multiFileDiff),
+			),
+		)
+	}
+
 	tests := []struct {
 		name               string
 		requestArgs        map[string]any
 		mockedClient       *http.Client
 		expectToolError    bool
 		expectedToolErrMsg string
+		expectedDiff       string
+		expectedNote       string
 	}{
 		{
 			name: "successful diff retrieval",
@@ -2807,6 +3811,84 @@ index 5d6e7b2..8a4f5c3 100644
 				),
 			),
 			expectToolError: false,
+			expectedDiff:    stubbedDiff,
+		},
+		{
+			name: "filter by file path",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"files":      []any{"main.go", "docs/old.md"},
+			},
+			mockedClient: multiFileClient(),
+			expectedDiff: goDiff + docsDiff,
+		},
+		{
+			name: "first chunk limited by max_bytes",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"max_bytes":  float64(len(stubbedDiff) + 1 + len(goDiff)),
+			},
+			mockedClient: multiFileClient(),
+			expectedDiff: stubbedDiff + "\n" + goDiff,
+			expectedNote: "Diff truncated: showing files 1-2 of 3. Call again with file_offset=2 to fetch the next chunk.",
+		},
+		{
+			name: "subsequent chunk",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"max_bytes":   float64(len(stubbedDiff) + 1 + len(goDiff)),
+				"file_offset": float64(2),
+			},
+			mockedClient: multiFileClient(),
+			expectedDiff: docsDiff,
+		},
+		{
+			name: "single file larger than max_bytes is cut off",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"files":      []any{"main.go"},
+				"max_bytes":  float64(20),
+			},
+			mockedClient: multiFileClient(),
+			expectedDiff: goDiff[:20],
+			expectedNote: "Diff truncated: the diff for main.go is larger than max_bytes and was cut off.",
+		},
+		{
+			name: "cut off at a rune boundary",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"max_bytes":  float64(strings.Index(unicodeDiff, "ü") + 1),
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, unicodeDiff),
+				),
+			),
+			expectedDiff: unicodeDiff[:strings.Index(unicodeDiff, "ü")],
+			expectedNote: "Diff truncated: the diff for README.md is larger than max_bytes and was cut off.",
+		},
+		{
+			name: "file_offset out of range",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"file_offset": float64(5),
+			},
+			mockedClient:       multiFileClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "file_offset 5 is out of range, the diff contains 3 files",
 		},
 	}
 
@@ -2825,16 +3907,27 @@ index 5d6e7b2..8a4f5c3 100644
 			result, err := handler(context.Background(), request)
 			require.NoError(t, err)
 
-			textContent := getTextResult(t, result)
-
 			if tc.expectToolError {
 				require.True(t, result.IsError)
-				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedToolErrMsg)
 				return
 			}
 
-			// Parse the result and get the text content if no error
-			require.Equal(t, stubbedDiff, textContent.Text)
+			if tc.expectedNote == "" {
+				textContent := getTextResult(t, result)
+				require.Equal(t, tc.expectedDiff, textContent.Text)
+				return
+			}
+
+			// Chunked responses carry the diff followed by a note on how to fetch the rest
+			require.Len(t, result.Content, 2)
+			diff, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedDiff, diff.Text)
+			note, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedNote, note.Text)
 		})
 	}
 }
@@ -2910,3 +4003,218 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func Test_ListPullRequestReviewThreads(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListPullRequestReviewThreads(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_pull_request_review_threads", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	matcher := githubv4mock.NewQueryMatcher(
+		reviewThreadsQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+			"first": githubv4.Int(30),
+			"after": (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"reviewThreads": map[string]any{
+						"totalCount": 2,
+						"pageInfo":   map[string]any{"hasNextPage": false, "endCursor": "c2"},
+						"nodes": []any{
+							map[string]any{
+								"id":         "PRRT_1",
+								"path":       "main.go",
+								"line":       12,
+								"isResolved": false,
+								"isOutdated": false,
+								"comments": map[string]any{
+									"totalCount": 2,
+									"nodes": []any{
+										map[string]any{
+											"author":    map[string]any{"login": "reviewer"},
+											"body":      "Please handle the error",
+											"diffHunk":  "@@ -10,3 +10,4 @@",
+											"createdAt": "2024-01-01T00:00:00Z",
+											"url":       "https://github.com/owner/repo/pull/42#discussion_r1",
+										},
+										map[string]any{
+											"author":    map[string]any{"login": "author"},
+											"body":      "Done",
+											"diffHunk":  "@@ -10,3 +10,4 @@",
+											"createdAt": "2024-01-02T00:00:00Z",
+											"url":       "https://github.com/owner/repo/pull/42#discussion_r2",
+										},
+									},
+								},
+							},
+							map[string]any{
+								"id":         "PRRT_2",
+								"path":       "README.md",
+								"isResolved": true,
+								"isOutdated": true,
+								"resolvedBy": map[string]any{"login": "reviewer"},
+								"comments": map[string]any{
+									"totalCount": 1,
+									"nodes": []any{
+										map[string]any{
+											"author":    map[string]any{"login": "reviewer"},
+											"body":      "Typo",
+											"diffHunk":  "@@ -1 +1 @@",
+											"createdAt": "2024-01-01T00:00:00Z",
+											"url":       "https://github.com/owner/repo/pull/42#discussion_r3",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]interface{}
+		expectedIDs []string
+	}{
+		{
+			name:        "all threads",
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedIDs: []string{"PRRT_1", "PRRT_2"},
+		},
+		{
+			name:        "unresolved threads",
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42), "is_resolved": false},
+			expectedIDs: []string{"PRRT_1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+			_, handler := ListPullRequestReviewThreads(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Threads    []ReviewThread `json:"threads"`
+				Pagination Pagination     `json:"pagination"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			ids := make([]string, 0, len(response.Threads))
+			for _, thread := range response.Threads {
+				ids = append(ids, thread.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.False(t, response.Pagination.HasMore)
+
+			first := response.Threads[0]
+			assert.Equal(t, "main.go", first.Path)
+			assert.Equal(t, 12, first.Line)
+			assert.Equal(t, "@@ -10,3 +10,4 @@", first.DiffHunk)
+			assert.Equal(t, 2, first.CommentCount)
+			require.Len(t, first.Comments, 2)
+			assert.Equal(t, "reviewer", first.Comments[0].Author)
+		})
+	}
+}
+
+func Test_ResolveAndUnresolveReviewThread(t *testing.T) {
+	// Verify tool definitions
+	resolveTool, _ := ResolveReviewThread(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(resolveTool.Name, resolveTool))
+	assert.ElementsMatch(t, resolveTool.InputSchema.Required, []string{"thread_id"})
+
+	unresolveTool, _ := UnresolveReviewThread(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unresolveTool.Name, unresolveTool))
+	assert.ElementsMatch(t, unresolveTool.InputSchema.Required, []string{"thread_id"})
+
+	resolveMatcher := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				ResolveReviewThread struct {
+					Thread reviewThreadState
+				} `graphql:"resolveReviewThread(input: $input)"`
+			}{},
+			githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+			nil,
+			response,
+		)
+	}
+	unresolveMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			UnresolveReviewThread struct {
+				Thread reviewThreadState
+			} `graphql:"unresolveReviewThread(input: $input)"`
+		}{},
+		githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"unresolveReviewThread": map[string]any{
+				"thread": map[string]any{"id": "PRRT_1", "isResolved": false},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name             string
+		tool             func(GetGQLClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		matcher          githubv4mock.Matcher
+		expectError      bool
+		expectedErrMsg   string
+		expectedResolved bool
+	}{
+		{
+			name: "resolve thread",
+			tool: ResolveReviewThread,
+			matcher: resolveMatcher(githubv4mock.DataResponse(map[string]any{
+				"resolveReviewThread": map[string]any{
+					"thread": map[string]any{"id": "PRRT_1", "isResolved": true},
+				},
+			})),
+			expectedResolved: true,
+		},
+		{
+			name:             "unresolve thread",
+			tool:             UnresolveReviewThread,
+			matcher:          unresolveMatcher,
+			expectedResolved: false,
+		},
+		{
+			name:           "resolve fails",
+			tool:           ResolveReviewThread,
+			matcher:        resolveMatcher(githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'PRRT_1'")),
+			expectError:    true,
+			expectedErrMsg: "failed to resolve review thread",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matcher))
+			_, handler := tc.tool(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"thread_id": "PRRT_1"}))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, map[string]any{"thread_id": "PRRT_1", "is_resolved": tc.expectedResolved}, response)
+		})
+	}
+}
@@ -0,0 +1,460 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Operations check_push_allowed evaluates.
+const (
+	pushOperationPush      = "push"
+	pushOperationForcePush = "force_push"
+	pushOperationCreate    = "create"
+	pushOperationDelete    = "delete"
+)
+
+// Sources of the rules check_push_allowed reports.
+const (
+	pushRuleSourceRepository       = "repository"
+	pushRuleSourceBranchProtection = "branch_protection"
+	pushRuleSourceRuleset          = "ruleset"
+)
+
+// repositoryRoleIDs are the actor IDs rulesets use for the bypass actors of type RepositoryRole.
+var repositoryRoleIDs = map[string]int64{
+	"maintain": 2,
+	"write":    4,
+	"admin":    5,
+}
+
+// PushRule is a rule that applies to a push.
+type PushRule struct {
+	Source  string `json:"source"`
+	Ruleset string `json:"ruleset,omitempty"`
+	Rule    string `json:"rule"`
+	Reason  string `json:"reason"`
+}
+
+// PushCheck explains whether an actor can push to a branch.
+type PushCheck struct {
+	Branch     string `json:"branch"`
+	Actor      string `json:"actor"`
+	Permission string `json:"permission"`
+	Operation  string `json:"operation"`
+	Allowed    bool   `json:"allowed"`
+	// Blocking are the rules that reject the push.
+	Blocking []PushRule `json:"blocking"`
+	// Bypassed are the rules that would reject the push but that the actor may bypass.
+	Bypassed []PushRule `json:"bypassed"`
+	// Conditional are the rules whose outcome depends on the pushed commits, or on facts that cannot be checked.
+	Conditional []PushRule `json:"conditional"`
+	Notes       []string   `json:"notes,omitempty"`
+}
+
+func (c *PushCheck) block(rule PushRule) {
+	c.Blocking = append(c.Blocking, rule)
+}
+
+func (c *PushCheck) condition(rule PushRule) {
+	c.Conditional = append(c.Conditional, rule)
+}
+
+// updatesBranch reports whether an operation adds commits to the branch.
+func updatesBranch(operation string) bool {
+	return operation == pushOperationPush || operation == pushOperationForcePush
+}
+
+// matchesRefPattern evaluates the pattern of a branch name rule against a branch.
+func matchesRefPattern(parameters github.PatternRuleParameters, branch string) (bool, error) {
+	var matches bool
+	switch parameters.Operator {
+	case github.PatternRuleOperatorStartsWith:
+		matches = strings.HasPrefix(branch, parameters.Pattern)
+	case github.PatternRuleOperatorEndsWith:
+		matches = strings.HasSuffix(branch, parameters.Pattern)
+	case github.PatternRuleOperatorContains:
+		matches = strings.Contains(branch, parameters.Pattern)
+	case github.PatternRuleOperatorRegex:
+		re, err := regexp.Compile(parameters.Pattern)
+		if err != nil {
+			return false, err
+		}
+		matches = re.MatchString(branch)
+	default:
+		return false, fmt.Errorf("unknown operator %q", parameters.Operator)
+	}
+	if parameters.Negate != nil && *parameters.Negate {
+		matches = !matches
+	}
+	return matches, nil
+}
+
+// evaluateBranchProtection adds the rules of the protection of a branch that apply to the operation.
+func evaluateBranchProtection(check *PushCheck, protection *github.Protection) {
+	var blocking []PushRule
+	rule := func(name, reason string) PushRule {
+		return PushRule{Source: pushRuleSourceBranchProtection, Rule: name, Reason: reason}
+	}
+
+	if protection.GetLockBranch().GetEnabled() && check.Operation != pushOperationCreate {
+		blocking = append(blocking, rule("lock_branch", "the branch is locked and read-only"))
+	}
+	if updatesBranch(check.Operation) {
+		if protection.RequiredPullRequestReviews != nil {
+			blocking = append(blocking, rule("required_pull_request_reviews", "changes must be made through a pull request"))
+		}
+		if checks := protection.GetRequiredStatusChecks(); checks != nil && (len(checks.GetChecks()) > 0 || len(checks.GetContexts()) > 0) {
+			blocking = append(blocking, rule("required_status_checks", "required status checks must pass before commits reach the branch, push to another branch and open a pull request"))
+		}
+		if protection.GetRequiredSignatures().GetEnabled() {
+			check.condition(rule("required_signatures", "every commit must have a verified signature"))
+		}
+		if protection.RequireLinearHistory != nil && protection.RequireLinearHistory.Enabled {
+			check.condition(rule("required_linear_history", "merge commits are rejected"))
+		}
+	}
+	if check.Operation == pushOperationForcePush && (protection.AllowForcePushes == nil || !protection.AllowForcePushes.Enabled) {
+		blocking = append(blocking, rule("allow_force_pushes", "force pushes are not allowed"))
+	}
+	if check.Operation == pushOperationDelete && (protection.AllowDeletions == nil || !protection.AllowDeletions.Enabled) {
+		blocking = append(blocking, rule("allow_deletions", "the branch cannot be deleted"))
+	}
+	if restrictions := protection.Restrictions; restrictions != nil {
+		listed := slices.ContainsFunc(restrictions.Users, func(user *github.User) bool {
+			return strings.EqualFold(user.GetLogin(), check.Actor)
+		})
+		switch {
+		case listed:
+		case len(restrictions.Teams) > 0:
+			teams := make([]string, 0, len(restrictions.Teams))
+			for _, team := range restrictions.Teams {
+				teams = append(teams, team.GetSlug())
+			}
+			check.condition(rule("restrictions", fmt.Sprintf("only listed users, apps and members of the teams %s can push", strings.Join(teams, ", "))))
+		default:
+			blocking = append(blocking, rule("restrictions", "only listed users and apps can push"))
+		}
+	}
+
+	// Branch protection does not apply to admins unless it is enforced for them
+	if check.Permission == "admin" && (protection.EnforceAdmins == nil || !protection.EnforceAdmins.Enabled) {
+		for _, r := range blocking {
+			r.Reason += ", admins are exempt because the protection is not enforced for administrators"
+			check.Bypassed = append(check.Bypassed, r)
+		}
+		return
+	}
+	check.Blocking = append(check.Blocking, blocking...)
+}
+
+// rulesetRule is a rule of a ruleset that applies to the branch.
+type rulesetRule struct {
+	metadata github.BranchRuleMetadata
+	rule     string
+	reason   string
+	// conditional marks rules whose outcome depends on the pushed commits.
+	conditional bool
+}
+
+// applicableRulesetRules returns the active ruleset rules that apply to the operation on branch.
+func applicableRulesetRules(rules *github.BranchRules, operation, branch string) []rulesetRule {
+	var applicable []rulesetRule
+	add := func(metadata github.BranchRuleMetadata, rule, reason string, conditional bool) {
+		applicable = append(applicable, rulesetRule{metadata: metadata, rule: rule, reason: reason, conditional: conditional})
+	}
+	addAll := func(metadata []*github.BranchRuleMetadata, rule, reason string, conditional bool) {
+		for _, m := range metadata {
+			add(*m, rule, reason, conditional)
+		}
+	}
+
+	switch operation {
+	case pushOperationCreate:
+		addAll(rules.Creation, "creation", "branches matching the ruleset cannot be created", false)
+	case pushOperationDelete:
+		addAll(rules.Deletion, "deletion", "the branch cannot be deleted", false)
+	case pushOperationForcePush:
+		addAll(rules.NonFastForward, "non_fast_forward", "force pushes are not allowed", false)
+	}
+	if updatesBranch(operation) {
+		for _, r := range rules.Update {
+			add(r.BranchRuleMetadata, "update", "the branch cannot be updated", false)
+		}
+		for _, r := range rules.PullRequest {
+			add(r.BranchRuleMetadata, "pull_request", "changes must be made through a pull request", false)
+		}
+		for _, r := range rules.RequiredStatusChecks {
+			add(r.BranchRuleMetadata, "required_status_checks", "required status checks must pass before commits reach the branch, push to another branch and open a pull request", false)
+		}
+		for _, r := range rules.RequiredDeployments {
+			add(r.BranchRuleMetadata, "required_deployments", "deployments must succeed before commits reach the branch, push to another branch and open a pull request", false)
+		}
+		for _, r := range rules.MergeQueue {
+			add(r.BranchRuleMetadata, "merge_queue", "changes must be merged through the merge queue", false)
+		}
+	}
+	if operation != pushOperationDelete {
+		addAll(rules.RequiredSignatures, "required_signatures", "every commit must have a verified signature", true)
+		addAll(rules.RequiredLinearHistory, "required_linear_history", "merge commits are rejected", true)
+		for _, r := range rules.CommitMessagePattern {
+			add(r.BranchRuleMetadata, "commit_message_pattern", fmt.Sprintf("commit messages must match %s %q", r.Parameters.Operator, r.Parameters.Pattern), true)
+		}
+		for _, r := range rules.CommitAuthorEmailPattern {
+			add(r.BranchRuleMetadata, "commit_author_email_pattern", fmt.Sprintf("commit author emails must match %s %q", r.Parameters.Operator, r.Parameters.Pattern), true)
+		}
+		for _, r := range rules.CommitterEmailPattern {
+			add(r.BranchRuleMetadata, "committer_email_pattern", fmt.Sprintf("committer emails must match %s %q", r.Parameters.Operator, r.Parameters.Pattern), true)
+		}
+		for _, r := range rules.FilePathRestriction {
+			add(r.BranchRuleMetadata, "file_path_restriction", "commits cannot change restricted file paths", true)
+		}
+		for _, r := range rules.MaxFilePathLength {
+			add(r.BranchRuleMetadata, "max_file_path_length", "file paths cannot exceed the maximum length", true)
+		}
+		for _, r := range rules.FileExtensionRestriction {
+			add(r.BranchRuleMetadata, "file_extension_restriction", "commits cannot add files with restricted extensions", true)
+		}
+		for _, r := range rules.MaxFileSize {
+			add(r.BranchRuleMetadata, "max_file_size", "files cannot exceed the maximum size", true)
+		}
+		for _, r := range rules.Workflows {
+			add(r.BranchRuleMetadata, "workflows", "required workflows must pass", true)
+		}
+		for _, r := range rules.CodeScanning {
+			add(r.BranchRuleMetadata, "code_scanning", "code scanning results must meet the required thresholds", true)
+		}
+	}
+	for _, r := range rules.BranchNamePattern {
+		matches, err := matchesRefPattern(r.Parameters, branch)
+		switch {
+		case err != nil:
+			add(r.BranchRuleMetadata, "branch_name_pattern", fmt.Sprintf("branch names must match %s %q, which could not be evaluated: %s", r.Parameters.Operator, r.Parameters.Pattern, err), true)
+		case !matches:
+			add(r.BranchRuleMetadata, "branch_name_pattern", fmt.Sprintf("branch names must match %s %q", r.Parameters.Operator, r.Parameters.Pattern), false)
+		}
+	}
+	return applicable
+}
+
+// rulesetBypass returns whether the actor can bypass a ruleset for a direct push, and if not certain, why.
+func rulesetBypass(ruleset *github.RepositoryRuleset, permission string, authenticatedActor bool) (bypass bool, note string) {
+	if authenticatedActor && ruleset.CurrentUserCanBypass != nil {
+		return *ruleset.CurrentUserCanBypass == github.BypassModeAlways, ""
+	}
+	var unknown []string
+	for _, actor := range ruleset.BypassActors {
+		if actor.BypassMode != nil && *actor.BypassMode != github.BypassModeAlways {
+			// Bypassing through a pull request does not allow direct pushes
+			continue
+		}
+		if actor.ActorType == nil {
+			continue
+		}
+		switch *actor.ActorType {
+		case github.BypassActorTypeRepositoryRole:
+			if id, ok := repositoryRoleIDs[permission]; ok && id == actor.GetActorID() {
+				return true, ""
+			}
+		case github.BypassActorTypeOrganizationAdmin:
+			unknown = append(unknown, "organization owners")
+		case github.BypassActorTypeTeam:
+			unknown = append(unknown, fmt.Sprintf("members of team %d", actor.GetActorID()))
+		}
+	}
+	if len(unknown) > 0 {
+		return false, fmt.Sprintf("ruleset %s can be bypassed by %s, which was not checked for the actor", ruleset.Name, strings.Join(unknown, ", "))
+	}
+	return false, ""
+}
+
+// CheckPushAllowed creates a tool that explains whether a push to a branch would be accepted.
+func CheckPushAllowed(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_push_allowed",
+			mcp.WithDescription(t("TOOL_CHECK_PUSH_ALLOWED_DESCRIPTION", "Check whether a push to a branch would be accepted before attempting it. Evaluates the actor's repository permission, the branch protection and the active rulesets that apply to the branch, including who can bypass them, and explains which rules would block the push and which depend on the pushed commits.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_PUSH_ALLOWED_USER_TITLE", "Check if push is allowed"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to push to"),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Login of the user who would push. Defaults to the authenticated user"),
+			),
+			mcp.WithString("operation",
+				mcp.Description("The kind of push to evaluate"),
+				mcp.Enum(pushOperationPush, pushOperationForcePush, pushOperationCreate, pushOperationDelete),
+				mcp.DefaultString(pushOperationPush),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			actor, err := OptionalParam[string](request, "actor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			operation, err := OptionalParam[string](request, "operation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if operation == "" {
+				operation = pushOperationPush
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			user, resp, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get authenticated user",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if actor == "" {
+				actor = user.GetLogin()
+			}
+			authenticatedActor := strings.EqualFold(actor, user.GetLogin())
+
+			check := PushCheck{
+				Branch:      branch,
+				Actor:       actor,
+				Operation:   operation,
+				Blocking:    []PushRule{},
+				Bypassed:    []PushRule{},
+				Conditional: []PushRule{},
+			}
+
+			level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, actor)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the permission of %s", actor),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			// The permission is one of admin, write, read or none, the role name also tells maintainers apart
+			check.Permission = level.GetPermission()
+			if level.GetRoleName() == "maintain" {
+				check.Permission = "maintain"
+			}
+			if _, ok := repositoryRoleIDs[check.Permission]; !ok {
+				check.block(PushRule{
+					Source: pushRuleSourceRepository,
+					Rule:   "write_access",
+					Reason: fmt.Sprintf("%s has %s access to the repository, pushing requires write access", actor, check.Permission),
+				})
+			}
+
+			if operation == pushOperationCreate {
+				check.Notes = append(check.Notes, "branch protection rules are only evaluated for existing branches, rulesets are evaluated for the new branch name")
+			} else {
+				protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+				switch {
+				case err == nil:
+					_ = resp.Body.Close()
+					evaluateBranchProtection(&check, protection)
+				case errors.Is(err, github.ErrBranchNotProtected) || (resp != nil && resp.StatusCode == http.StatusNotFound):
+				case resp != nil && resp.StatusCode == http.StatusForbidden:
+					check.Notes = append(check.Notes, "the branch protection could not be read, which requires admin access to the repository, so it was not evaluated")
+				default:
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get branch protection",
+						resp,
+						err,
+					), nil
+				}
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get rules for branch",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			rulesets := map[int64]*github.RepositoryRuleset{}
+			for _, r := range applicableRulesetRules(rules, operation, branch) {
+				ruleset, ok := rulesets[r.metadata.RulesetID]
+				if !ok {
+					ruleset, resp, err = client.Repositories.GetRuleset(ctx, owner, repo, r.metadata.RulesetID, true)
+					if err != nil {
+						if resp == nil || (resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusForbidden) {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx,
+								fmt.Sprintf("failed to get ruleset %d", r.metadata.RulesetID),
+								resp,
+								err,
+							), nil
+						}
+						ruleset = &github.RepositoryRuleset{ID: github.Ptr(r.metadata.RulesetID), Name: fmt.Sprintf("%d", r.metadata.RulesetID)}
+						check.Notes = append(check.Notes, fmt.Sprintf("ruleset %d from %s could not be read, so who can bypass it was not checked", r.metadata.RulesetID, r.metadata.RulesetSource))
+					} else {
+						_ = resp.Body.Close()
+					}
+					rulesets[r.metadata.RulesetID] = ruleset
+					if _, note := rulesetBypass(ruleset, check.Permission, authenticatedActor); note != "" {
+						check.Notes = append(check.Notes, note)
+					}
+				}
+
+				rule := PushRule{
+					Source:  pushRuleSourceRuleset,
+					Ruleset: fmt.Sprintf("%s (%s)", ruleset.Name, r.metadata.RulesetSource),
+					Rule:    r.rule,
+					Reason:  r.reason,
+				}
+				bypass, _ := rulesetBypass(ruleset, check.Permission, authenticatedActor)
+				switch {
+				case bypass:
+					rule.Reason += ", the actor can bypass this ruleset"
+					check.Bypassed = append(check.Bypassed, rule)
+				case r.conditional:
+					check.condition(rule)
+				default:
+					check.block(rule)
+				}
+			}
+
+			check.Allowed = len(check.Blocking) == 0
+			return MarshalledTextResult(check), nil
+		}
+}
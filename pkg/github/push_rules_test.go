@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckPushAllowed(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckPushAllowed(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_push_allowed", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	authenticatedUser := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetUser, github.User{Login: github.Ptr("octocat")})
+	}
+	permission := func(permission, roleName string) mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+			github.RepositoryPermissionLevel{Permission: github.Ptr(permission), RoleName: github.Ptr(roleName)},
+		)
+	}
+	notProtected := func() mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+		)
+	}
+	branchRules := func(rules string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(rules))
+			}),
+		)
+	}
+	ruleset := func(ruleset github.RepositoryRuleset) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectQueryParams(t, map[string]string{"includes_parents": "true"}).andThen(
+				mockResponse(t, http.StatusOK, ruleset),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       PushCheck
+	}{
+		{
+			name: "ruleset requiring pull requests blocks a writer",
+			mockedClient: mock.NewMockedHTTPClient(
+				authenticatedUser(),
+				permission("write", "write"),
+				notProtected(),
+				branchRules(`[
+					{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1, "parameters": {"required_approving_review_count": 1, "dismiss_stale_reviews_on_push": false, "require_code_owner_review": false, "require_last_push_approval": false, "required_review_thread_resolution": false}},
+					{"type": "required_signatures", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1}
+				]`),
+				ruleset(github.RepositoryRuleset{ID: github.Ptr(int64(1)), Name: "main", CurrentUserCanBypass: github.Ptr(github.BypassModeNever)}),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "branch": "main"},
+			expected: PushCheck{
+				Branch:     "main",
+				Actor:      "octocat",
+				Permission: "write",
+				Operation:  "push",
+				Allowed:    false,
+				Blocking: []PushRule{
+					{Source: "ruleset", Ruleset: "main (owner/repo)", Rule: "pull_request", Reason: "changes must be made through a pull request"},
+				},
+				Bypassed: []PushRule{},
+				Conditional: []PushRule{
+					{Source: "ruleset", Ruleset: "main (owner/repo)", Rule: "required_signatures", Reason: "every commit must have a verified signature"},
+				},
+			},
+		},
+		{
+			name: "maintainer bypasses ruleset through repository role",
+			mockedClient: mock.NewMockedHTTPClient(
+				authenticatedUser(),
+				permission("write", "maintain"),
+				notProtected(),
+				branchRules(`[{"type": "non_fast_forward", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 2}]`),
+				ruleset(github.RepositoryRuleset{
+					ID:   github.Ptr(int64(2)),
+					Name: "no force pushes",
+					BypassActors: []*github.BypassActor{
+						{ActorID: github.Ptr(int64(2)), ActorType: github.Ptr(github.BypassActorTypeRepositoryRole), BypassMode: github.Ptr(github.BypassModeAlways)},
+						{ActorID: github.Ptr(int64(7)), ActorType: github.Ptr(github.BypassActorTypeTeam), BypassMode: github.Ptr(github.BypassModeAlways)},
+					},
+				}),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "branch": "main", "actor": "hubot", "operation": "force_push"},
+			expected: PushCheck{
+				Branch:     "main",
+				Actor:      "hubot",
+				Permission: "maintain",
+				Operation:  "force_push",
+				Allowed:    true,
+				Blocking:   []PushRule{},
+				Bypassed: []PushRule{
+					{Source: "ruleset", Ruleset: "no force pushes (owner)", Rule: "non_fast_forward", Reason: "force pushes are not allowed, the actor can bypass this ruleset"},
+				},
+				Conditional: []PushRule{},
+			},
+		},
+		{
+			name: "branch protection not enforced for admins",
+			mockedClient: mock.NewMockedHTTPClient(
+				authenticatedUser(),
+				permission("admin", "admin"),
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					github.Protection{
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+						EnforceAdmins:              &github.AdminEnforcement{Enabled: false},
+						RequireLinearHistory:       &github.RequireLinearHistory{Enabled: true},
+					},
+				),
+				branchRules(`[]`),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "branch": "main"},
+			expected: PushCheck{
+				Branch:     "main",
+				Actor:      "octocat",
+				Permission: "admin",
+				Operation:  "push",
+				Allowed:    true,
+				Blocking:   []PushRule{},
+				Bypassed: []PushRule{
+					{Source: "branch_protection", Rule: "required_pull_request_reviews", Reason: "changes must be made through a pull request, admins are exempt because the protection is not enforced for administrators"},
+				},
+				Conditional: []PushRule{
+					{Source: "branch_protection", Rule: "required_linear_history", Reason: "merge commits are rejected"},
+				},
+			},
+		},
+		{
+			name: "read access and branch name pattern block branch creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				authenticatedUser(),
+				permission("read", "triage"),
+				branchRules(`[{"type": "branch_name_pattern", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 3, "parameters": {"operator": "starts_with", "pattern": "feature/"}}]`),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "branch": "fix", "actor": "hubot", "operation": "create"},
+			expected: PushCheck{
+				Branch:     "fix",
+				Actor:      "hubot",
+				Permission: "read",
+				Operation:  "create",
+				Allowed:    false,
+				Blocking: []PushRule{
+					{Source: "repository", Rule: "write_access", Reason: "hubot has read access to the repository, pushing requires write access"},
+					{Source: "ruleset", Ruleset: "3 (owner/repo)", Rule: "branch_name_pattern", Reason: "branch names must match starts_with \"feature/\""},
+				},
+				Bypassed:    []PushRule{},
+				Conditional: []PushRule{},
+				Notes: []string{
+					"branch protection rules are only evaluated for existing branches, rulesets are evaluated for the new branch name",
+					"ruleset 3 from owner/repo could not be read, so who can bypass it was not checked",
+				},
+			},
+		},
+		{
+			name: "permission lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				authenticatedUser(),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "branch": "main", "actor": "nobody"},
+			expectError:    true,
+			expectedErrMsg: "failed to get the permission of nobody",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CheckPushAllowed(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var check PushCheck
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &check))
+			assert.Equal(t, tc.expected, check)
+		})
+	}
+}
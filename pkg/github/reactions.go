@@ -0,0 +1,462 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// reactionContents are the reactions supported by GitHub, as named by the REST API.
+var reactionContents = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// MinimalReaction is the trimmed output type for reactions.
+type MinimalReaction struct {
+	ID        int64  `json:"id,omitempty"`
+	Content   string `json:"content"`
+	User      string `json:"user,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// toGraphQLReaction converts a REST reaction name to its GraphQL enum value.
+func toGraphQLReaction(content string) githubv4.ReactionContent {
+	switch content {
+	case "+1":
+		return githubv4.ReactionContentThumbsUp
+	case "-1":
+		return githubv4.ReactionContentThumbsDown
+	default:
+		return githubv4.ReactionContent(strings.ToUpper(content))
+	}
+}
+
+// fromGraphQLReaction converts a GraphQL reaction enum value to its REST name.
+func fromGraphQLReaction(content githubv4.ReactionContent) string {
+	switch content {
+	case githubv4.ReactionContentThumbsUp:
+		return "+1"
+	case githubv4.ReactionContentThumbsDown:
+		return "-1"
+	default:
+		return strings.ToLower(string(content))
+	}
+}
+
+// reactionSubject identifies what a reaction is attached to.
+type reactionSubject struct {
+	Type          string
+	Owner         string
+	Repo          string
+	Number        int
+	CommentID     int64
+	CommentNodeID string
+}
+
+// isDiscussion reports whether the subject is only reachable through the GraphQL API.
+func (s reactionSubject) isDiscussion() bool {
+	return s.Type == "discussion" || s.Type == "discussion_comment"
+}
+
+// WithReactionSubject adds the parameters identifying the subject of a reaction to a tool definition.
+func WithReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("What the reaction is attached to. Use 'issue' for pull requests as well."),
+			mcp.Enum("issue", "issue_comment", "pull_request_review_comment", "discussion", "discussion_comment"),
+		)(tool)
+		mcp.WithNumber("number",
+			mcp.Description("Issue, pull request or discussion number. Required for 'issue' and 'discussion'."),
+		)(tool)
+		mcp.WithNumber("comment_id",
+			mcp.Description("Comment ID. Required for 'issue_comment' and 'pull_request_review_comment'."),
+		)(tool)
+		mcp.WithString("comment_node_id",
+			mcp.Description("Comment node ID. Required for 'discussion_comment'."),
+		)(tool)
+	}
+}
+
+// requiredReactionSubject reads and validates the parameters added by WithReactionSubject.
+func requiredReactionSubject(request mcp.CallToolRequest) (reactionSubject, error) {
+	var subject reactionSubject
+	var err error
+	if subject.Owner, err = RequiredParam[string](request, "owner"); err != nil {
+		return subject, err
+	}
+	if subject.Repo, err = RequiredParam[string](request, "repo"); err != nil {
+		return subject, err
+	}
+	if subject.Type, err = RequiredParam[string](request, "subject_type"); err != nil {
+		return subject, err
+	}
+
+	switch subject.Type {
+	case "issue", "discussion":
+		if subject.Number, err = RequiredInt(request, "number"); err != nil {
+			return subject, err
+		}
+	case "issue_comment", "pull_request_review_comment":
+		commentID, err := RequiredInt(request, "comment_id")
+		if err != nil {
+			return subject, err
+		}
+		subject.CommentID = int64(commentID)
+	case "discussion_comment":
+		if subject.CommentNodeID, err = RequiredParam[string](request, "comment_node_id"); err != nil {
+			return subject, err
+		}
+	default:
+		return subject, fmt.Errorf("unsupported subject_type: %s", subject.Type)
+	}
+	return subject, nil
+}
+
+// reactableID resolves the node ID of a discussion or discussion comment.
+func reactableID(ctx context.Context, client *githubv4.Client, subject reactionSubject) (githubv4.ID, error) {
+	if subject.Type == "discussion_comment" {
+		return githubv4.ID(subject.CommentNodeID), nil
+	}
+
+	var query struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(subject.Owner),
+		"repo":             githubv4.String(subject.Repo),
+		"discussionNumber": githubv4.Int(subject.Number), // #nosec G115 - discussion numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+	return query.Repository.Discussion.ID, nil
+}
+
+func minimalReaction(r *github.Reaction) MinimalReaction {
+	reaction := MinimalReaction{
+		ID:      r.GetID(),
+		Content: r.GetContent(),
+		User:    r.GetUser().GetLogin(),
+	}
+	if r.CreatedAt != nil {
+		reaction.CreatedAt = r.GetCreatedAt().Format("2006-01-02T15:04:05Z")
+	}
+	return reaction
+}
+
+// AddReaction creates a tool to react to an issue, pull request, comment or discussion.
+func AddReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_reaction",
+			mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "Add a reaction to an issue, pull request, comment, or discussion. Adding a reaction that already exists for the current user is a no-op.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction to add"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := requiredReactionSubject(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if subject.isDiscussion() {
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := reactableID(ctx, client, subject)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find discussion", err), nil
+				}
+
+				var mutation struct {
+					AddReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"addReaction(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.AddReactionInput{
+					SubjectID: subjectID,
+					Content:   toGraphQLReaction(content),
+				}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add reaction", err), nil
+				}
+				return MarshalledTextResult(MinimalReaction{
+					Content: fromGraphQLReaction(mutation.AddReaction.Reaction.Content),
+				}), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reaction *github.Reaction
+			var resp *github.Response
+			switch subject.Type {
+			case "issue":
+				reaction, resp, err = client.Reactions.CreateIssueReaction(ctx, subject.Owner, subject.Repo, subject.Number, content)
+			case "issue_comment":
+				reaction, resp, err = client.Reactions.CreateIssueCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, content)
+			case "pull_request_review_comment":
+				reaction, resp, err = client.Reactions.CreatePullRequestCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, content)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add reaction", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(minimalReaction(reaction)), nil
+		}
+}
+
+// ListReactions creates a tool to list the reactions on an issue, pull request, comment or discussion.
+func ListReactions(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the reactions on an issue, pull request, comment, or discussion, along with a count per reaction. Discussions and discussion comments are paginated with after, using the nextCursor of the previous page, the other subjects with page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithReactionSubject(),
+			mcp.WithString("content",
+				mcp.Description("Only list reactions of this type"),
+				mcp.Enum(reactionContents...),
+			),
+			WithUnifiedPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := requiredReactionSubject(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			reactions := []MinimalReaction{}
+			var pageInfo *Pagination
+			if subject.isDiscussion() {
+				gqlParams, err := pagination.ToGraphQLParams()
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := reactableID(ctx, client, subject)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find discussion", err), nil
+				}
+
+				var query struct {
+					Node struct {
+						Reactable struct {
+							Reactions struct {
+								Nodes []struct {
+									DatabaseID githubv4.Int
+									Content    githubv4.ReactionContent
+									User       struct {
+										Login githubv4.String
+									}
+									CreatedAt githubv4.DateTime
+								}
+								PageInfo   PageInfoFragment
+								TotalCount githubv4.Int
+							} `graphql:"reactions(first: $first, after: $after, content: $content)"`
+						} `graphql:"... on Reactable"`
+					} `graphql:"node(id: $id)"`
+				}
+				vars := map[string]any{
+					"id":      subjectID,
+					"content": (*githubv4.ReactionContent)(nil),
+				}
+				if content != "" {
+					vars["content"] = toGraphQLReaction(content)
+				}
+				gqlParams.Vars(vars)
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list reactions", err), nil
+				}
+				for _, r := range query.Node.Reactable.Reactions.Nodes {
+					reactions = append(reactions, MinimalReaction{
+						ID:        int64(r.DatabaseID),
+						Content:   fromGraphQLReaction(r.Content),
+						User:      string(r.User.Login),
+						CreatedAt: r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+					})
+				}
+				reactionsPage := query.Node.Reactable.Reactions
+				p := reactionsPage.PageInfo.Pagination(int(reactionsPage.TotalCount))
+				pageInfo = &p
+			} else {
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+
+				opts := &github.ListReactionOptions{
+					Content: content,
+					ListOptions: github.ListOptions{
+						Page:    pagination.Page,
+						PerPage: pagination.PerPage,
+					},
+				}
+				var result []*github.Reaction
+				var resp *github.Response
+				switch subject.Type {
+				case "issue":
+					result, resp, err = client.Reactions.ListIssueReactions(ctx, subject.Owner, subject.Repo, subject.Number, opts)
+				case "issue_comment":
+					result, resp, err = client.Reactions.ListIssueCommentReactions(ctx, subject.Owner, subject.Repo, subject.CommentID, opts)
+				case "pull_request_review_comment":
+					result, resp, err = client.Reactions.ListPullRequestCommentReactions(ctx, subject.Owner, subject.Repo, subject.CommentID, opts)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list reactions", resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				for _, r := range result {
+					reactions = append(reactions, minimalReaction(r))
+				}
+			}
+
+			counts := map[string]int{}
+			for _, r := range reactions {
+				counts[r.Content]++
+			}
+
+			out := map[string]any{
+				"reactions": reactions,
+				"counts":    counts,
+			}
+			if pageInfo != nil {
+				out["pagination"] = pageInfo
+			}
+			return MarshalledTextResult(out), nil
+		}
+}
+
+// DeleteReaction creates a tool to remove a reaction from an issue, pull request, comment or discussion.
+func DeleteReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_reaction",
+			mcp.WithDescription(t("TOOL_DELETE_REACTION_DESCRIPTION", "Remove a reaction from an issue, pull request, comment, or discussion. Issues and comments require the reaction ID from list_reactions; discussions require the reaction content, and remove the current user's reaction.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REACTION_USER_TITLE", "Delete reaction"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithReactionSubject(),
+			mcp.WithNumber("reaction_id",
+				mcp.Description("ID of the reaction to delete. Required for all subjects except discussions."),
+			),
+			mcp.WithString("content",
+				mcp.Description("The reaction to remove. Required for 'discussion' and 'discussion_comment'."),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := requiredReactionSubject(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if subject.isDiscussion() {
+				content, err := RequiredParam[string](request, "content")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := reactableID(ctx, client, subject)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find discussion", err), nil
+				}
+
+				var mutation struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.RemoveReactionInput{
+					SubjectID: subjectID,
+					Content:   toGraphQLReaction(content),
+				}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to delete reaction", err), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("removed %s reaction", content)), nil
+			}
+
+			reactionIDInt, err := RequiredInt(request, "reaction_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reactionID := int64(reactionIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			switch subject.Type {
+			case "issue":
+				resp, err = client.Reactions.DeleteIssueReaction(ctx, subject.Owner, subject.Repo, subject.Number, reactionID)
+			case "issue_comment":
+				resp, err = client.Reactions.DeleteIssueCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, reactionID)
+			case "pull_request_review_comment":
+				resp, err = client.Reactions.DeletePullRequestCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, reactionID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete reaction", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete reaction: unexpected status %d", resp.StatusCode)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("deleted reaction %d", reactionID)), nil
+		}
+}
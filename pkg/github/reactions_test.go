@@ -0,0 +1,431 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discussionIDMatcher = githubv4mock.NewQueryMatcher(
+	struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{},
+	map[string]any{
+		"owner":            githubv4.String("owner"),
+		"repo":             githubv4.String("repo"),
+		"discussionNumber": githubv4.Int(5),
+	},
+	githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{"id": "D_1"},
+		},
+	}),
+)
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := AddReaction(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "subject_type")
+	assert.Contains(t, tool.InputSchema.Properties, "number")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_node_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	mockReaction := &github.Reaction{
+		ID:      github.Ptr(int64(42)),
+		Content: github.Ptr("+1"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		mockedGQLClient  *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedReaction MinimalReaction
+	}{
+		{
+			name: "react to issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"content": "+1"}).andThen(
+						mockResponse(t, http.StatusCreated, mockReaction),
+					),
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(1),
+				"content":      "+1",
+			},
+			expectedReaction: MinimalReaction{ID: 42, Content: "+1", User: "octocat"},
+		},
+		{
+			name: "react to pull request review comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.PostReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					mockReaction,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(99),
+				"content":      "+1",
+			},
+			expectedReaction: MinimalReaction{ID: 42, Content: "+1", User: "octocat"},
+		},
+		{
+			name:         "react to discussion",
+			mockedClient: mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				discussionIDMatcher,
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddReaction struct {
+							Reaction struct {
+								Content githubv4.ReactionContent
+							}
+						} `graphql:"addReaction(input: $input)"`
+					}{},
+					githubv4.AddReactionInput{
+						SubjectID: "D_1",
+						Content:   githubv4.ReactionContentRocket,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addReaction": map[string]any{
+							"reaction": map[string]any{"content": "ROCKET"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(5),
+				"content":      "rocket",
+			},
+			expectedReaction: MinimalReaction{Content: "rocket"},
+		},
+		{
+			name:            "missing comment id",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"content":      "heart",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: comment_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := AddReaction(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var reaction MinimalReaction
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reaction))
+			assert.Equal(t, tc.expectedReaction, reaction)
+		})
+	}
+}
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReactions(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		mockedGQLClient    *http.Client
+		requestArgs        map[string]any
+		expectedCounts     map[string]int
+		expectedUsers      []string
+		expectedPagination *Pagination
+	}{
+		{
+			name: "issue comment reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Reaction{
+							{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("alice")}},
+							{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("bob")}},
+							{ID: github.Ptr(int64(3)), Content: github.Ptr("eyes"), User: &github.User{Login: github.Ptr("carol")}},
+						}),
+					),
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"comment_id":   float64(10),
+			},
+			expectedCounts: map[string]int{"+1": 2, "eyes": 1},
+			expectedUsers:  []string{"alice", "bob", "carol"},
+		},
+		{
+			name:         "discussion comment reactions filtered by content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Node struct {
+							Reactable struct {
+								Reactions struct {
+									Nodes []struct {
+										DatabaseID githubv4.Int
+										Content    githubv4.ReactionContent
+										User       struct {
+											Login githubv4.String
+										}
+										CreatedAt githubv4.DateTime
+									}
+									PageInfo   PageInfoFragment
+									TotalCount githubv4.Int
+								} `graphql:"reactions(first: $first, after: $after, content: $content)"`
+							} `graphql:"... on Reactable"`
+						} `graphql:"node(id: $id)"`
+					}{},
+					map[string]any{
+						"id":      githubv4.ID("DC_1"),
+						"content": githubv4.ReactionContentThumbsUp,
+						"first":   githubv4.Int(30),
+						"after":   githubv4.String("cursor-1"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"node": map[string]any{
+							"reactions": map[string]any{
+								"nodes": []any{
+									map[string]any{"databaseId": 1, "content": "THUMBS_UP", "user": map[string]any{"login": "alice"}, "createdAt": "2024-01-01T00:00:00Z"},
+								},
+								"pageInfo": map[string]any{
+									"hasNextPage":     true,
+									"hasPreviousPage": true,
+									"startCursor":     "cursor-2",
+									"endCursor":       "cursor-2",
+								},
+								"totalCount": 150,
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"subject_type":    "discussion_comment",
+				"comment_node_id": "DC_1",
+				"content":         "+1",
+				"after":           "cursor-1",
+			},
+			expectedCounts: map[string]int{"+1": 1},
+			expectedUsers:  []string{"alice"},
+			expectedPagination: &Pagination{
+				NextCursor: "cursor-2",
+				HasMore:    true,
+				TotalCount: github.Ptr(150),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := ListReactions(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Reactions  []MinimalReaction `json:"reactions"`
+				Counts     map[string]int    `json:"counts"`
+				Pagination *Pagination       `json:"pagination"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedCounts, response.Counts)
+			assert.Equal(t, tc.expectedPagination, response.Pagination)
+
+			var users []string
+			for _, r := range response.Reactions {
+				users = append(users, r.User)
+			}
+			assert.Equal(t, tc.expectedUsers, users)
+		})
+	}
+}
+
+func Test_DeleteReaction(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteReaction(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "reaction_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedText    string
+	}{
+		{
+			name: "delete issue reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(1),
+				"reaction_id":  float64(42),
+			},
+			expectedText: "deleted reaction 42",
+		},
+		{
+			name:         "delete discussion reaction",
+			mockedClient: mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				discussionIDMatcher,
+				githubv4mock.NewMutationMatcher(
+					struct {
+						RemoveReaction struct {
+							Reaction struct {
+								Content githubv4.ReactionContent
+							}
+						} `graphql:"removeReaction(input: $input)"`
+					}{},
+					githubv4.RemoveReactionInput{
+						SubjectID: "D_1",
+						Content:   githubv4.ReactionContentHeart,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"removeReaction": map[string]any{
+							"reaction": map[string]any{"content": "HEART"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(5),
+				"content":      "heart",
+			},
+			expectedText: "removed heart reaction",
+		},
+		{
+			name:            "discussion requires content",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(5),
+				"reaction_id":  float64(42),
+			},
+			expectError:  true,
+			expectedText: "missing required parameter: content",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := DeleteReaction(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedText)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
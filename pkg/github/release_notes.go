@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// releaseNotesConcurrency bounds how many commits get_release_notes_data looks up the pull requests of at the same time.
+const releaseNotesConcurrency = 5
+
+// ReleaseNotesPullRequest is a merged pull request that brought commits into a release.
+type ReleaseNotesPullRequest struct {
+	Number   int      `json:"number"`
+	Title    string   `json:"title"`
+	URL      string   `json:"url"`
+	Author   string   `json:"author"`
+	Labels   []string `json:"labels"`
+	MergedAt string   `json:"merged_at"`
+}
+
+// ReleaseNotesCommit is a commit of a release that no merged pull request is associated with.
+type ReleaseNotesCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author"`
+}
+
+// ReleaseNotesData is what release notes between two refs are written from.
+type ReleaseNotesData struct {
+	Base            string                    `json:"base"`
+	Head            string                    `json:"head"`
+	TotalCommits    int                       `json:"total_commits"`
+	AnalyzedCommits int                       `json:"analyzed_commits"`
+	Truncated       bool                      `json:"truncated"`
+	PullRequests    []ReleaseNotesPullRequest `json:"pull_requests"`
+	DirectCommits   []ReleaseNotesCommit      `json:"direct_commits"`
+	LabelCounts     map[string]int            `json:"label_counts"`
+	Contributors    []string                  `json:"contributors"`
+}
+
+// commitAuthorLogin returns the login of the author of a commit, or its git author name if it is not linked to an account.
+func commitAuthorLogin(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
+}
+
+// GetReleaseNotesData creates a tool that collects the merged pull requests and direct commits between two refs.
+func GetReleaseNotesData(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_notes_data",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_NOTES_DATA_DESCRIPTION", "Collect the merged pull requests (title, labels, author) and the commits not associated with any pull request between two tags or refs, with label counts and the list of contributors. Use this to draft release notes or a changelog.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_NOTES_DATA_USER_TITLE", "Get release notes data"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Tag, branch or commit SHA of the previous release"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Tag, branch or commit SHA of the new release"),
+			),
+			mcp.WithNumber("max_commits",
+				mcp.Description("Maximum number of commits to analyze, oldest first (default 250, max 1000). The result is flagged as truncated if there are more"),
+				mcp.Min(1),
+				mcp.Max(1000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "max_commits", 250)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			data := ReleaseNotesData{
+				Base:          base,
+				Head:          head,
+				PullRequests:  []ReleaseNotesPullRequest{},
+				DirectCommits: []ReleaseNotesCommit{},
+				LabelCounts:   map[string]int{},
+			}
+			var commits []*github.RepositoryCommit
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to compare %s...%s", base, head),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				data.TotalCommits = comparison.GetTotalCommits()
+				commits = append(commits, comparison.Commits...)
+				if len(commits) >= maxCommits || resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if len(commits) > maxCommits {
+				commits = commits[:maxCommits]
+			}
+			data.AnalyzedCommits = len(commits)
+			data.Truncated = data.TotalCommits > len(commits)
+
+			pullRequests := make([][]*github.PullRequest, len(commits))
+			errs := make([]error, len(commits))
+			responses := make([]*github.Response, len(commits))
+			semaphore := make(chan struct{}, releaseNotesConcurrency)
+			var wg sync.WaitGroup
+			for i, commit := range commits {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }()
+
+					prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, commit.GetSHA(), &github.ListOptions{PerPage: 100})
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					pullRequests[i], responses[i], errs[i] = prs, resp, err
+				}()
+			}
+			wg.Wait()
+
+			seen := map[int]bool{}
+			contributors := map[string]bool{}
+			for i, commit := range commits {
+				if errs[i] != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list pull requests of commit %s", commit.GetSHA()),
+						responses[i],
+						errs[i],
+					), nil
+				}
+				merged := false
+				for _, pr := range pullRequests[i] {
+					if pr.MergedAt == nil {
+						continue
+					}
+					merged = true
+					if seen[pr.GetNumber()] {
+						continue
+					}
+					seen[pr.GetNumber()] = true
+
+					labels := make([]string, 0, len(pr.Labels))
+					for _, label := range pr.Labels {
+						labels = append(labels, label.GetName())
+						data.LabelCounts[label.GetName()]++
+					}
+					data.PullRequests = append(data.PullRequests, ReleaseNotesPullRequest{
+						Number:   pr.GetNumber(),
+						Title:    pr.GetTitle(),
+						URL:      pr.GetHTMLURL(),
+						Author:   pr.GetUser().GetLogin(),
+						Labels:   labels,
+						MergedAt: pr.GetMergedAt().Format("2006-01-02T15:04:05Z"),
+					})
+					contributors[pr.GetUser().GetLogin()] = true
+				}
+				if !merged {
+					message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+					data.DirectCommits = append(data.DirectCommits, ReleaseNotesCommit{
+						SHA:     commit.GetSHA(),
+						Message: message,
+						Author:  commitAuthorLogin(commit),
+					})
+					contributors[commitAuthorLogin(commit)] = true
+				}
+			}
+			sort.SliceStable(data.PullRequests, func(i, j int) bool {
+				return data.PullRequests[i].MergedAt < data.PullRequests[j].MergedAt
+			})
+			delete(contributors, "")
+			data.Contributors = make([]string, 0, len(contributors))
+			for login := range contributors {
+				data.Contributors = append(data.Contributors, login)
+			}
+			sort.Strings(data.Contributors)
+
+			return MarshalledTextResult(data), nil
+		}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetReleaseNotesData(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReleaseNotesData(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release_notes_data", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	commit := func(sha, message, login, name string) *github.RepositoryCommit {
+		c := &github.RepositoryCommit{
+			SHA:    github.Ptr(sha),
+			Commit: &github.Commit{Message: github.Ptr(message), Author: &github.CommitAuthor{Name: github.Ptr(name)}},
+		}
+		if login != "" {
+			c.Author = &github.User{Login: github.Ptr(login)}
+		}
+		return c
+	}
+	pr := func(number int, title, author string, mergedAt time.Time, labels ...string) *github.PullRequest {
+		p := &github.PullRequest{
+			Number:  github.Ptr(number),
+			Title:   github.Ptr(title),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/" + title),
+			User:    &github.User{Login: github.Ptr(author)},
+		}
+		if !mergedAt.IsZero() {
+			p.MergedAt = &github.Timestamp{Time: mergedAt}
+		}
+		for _, label := range labels {
+			p.Labels = append(p.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+		return p
+	}
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 12, 0, 0, 0, time.UTC) }
+
+	comparison := &github.CommitsComparison{
+		TotalCommits: github.Ptr(4),
+		Commits: []*github.RepositoryCommit{
+			commit("aaa", "Add feature\n\nDetails", "alice", "Alice"),
+			commit("bbb", "Fix feature", "alice", "Alice"),
+			commit("ccc", "Fix typo", "", "Carol"),
+			commit("ddd", "Bump version", "bob", "Bob"),
+		},
+	}
+	pullsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sha := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/"), "/pulls")
+		var prs []*github.PullRequest
+		switch sha {
+		case "aaa", "bbb":
+			prs = []*github.PullRequest{pr(10, "Add feature", "alice", day(3), "enhancement")}
+		case "ccc":
+			// A closed pull request that was not merged.
+			prs = []*github.PullRequest{pr(11, "Typo", "carol", time.Time{})}
+		case "ddd":
+			prs = []*github.PullRequest{pr(9, "Bump deps", "dependabot[bot]", day(1), "dependencies", "enhancement")}
+		}
+		mockResponse(t, http.StatusOK, prs)(w, r)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       ReleaseNotesData
+	}{
+		{
+			name: "pull requests and direct commits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/compare/v1.0.0...v1.1.0", r.URL.Path)
+						mockResponse(t, http.StatusOK, comparison)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+					pullsHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "v1.1.0",
+			},
+			expected: ReleaseNotesData{
+				Base:            "v1.0.0",
+				Head:            "v1.1.0",
+				TotalCommits:    4,
+				AnalyzedCommits: 4,
+				PullRequests: []ReleaseNotesPullRequest{
+					{Number: 9, Title: "Bump deps", URL: "https://github.com/owner/repo/pull/Bump deps", Author: "dependabot[bot]", Labels: []string{"dependencies", "enhancement"}, MergedAt: "2024-05-01T12:00:00Z"},
+					{Number: 10, Title: "Add feature", URL: "https://github.com/owner/repo/pull/Add feature", Author: "alice", Labels: []string{"enhancement"}, MergedAt: "2024-05-03T12:00:00Z"},
+				},
+				DirectCommits: []ReleaseNotesCommit{
+					{SHA: "ccc", Message: "Fix typo", Author: "Carol"},
+				},
+				LabelCounts:  map[string]int{"enhancement": 2, "dependencies": 1},
+				Contributors: []string{"Carol", "alice", "dependabot[bot]"},
+			},
+		},
+		{
+			name: "truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					comparison,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+					pullsHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"base":        "v1.0.0",
+				"head":        "main",
+				"max_commits": float64(1),
+			},
+			expected: ReleaseNotesData{
+				Base:            "v1.0.0",
+				Head:            "main",
+				TotalCommits:    4,
+				AnalyzedCommits: 1,
+				Truncated:       true,
+				PullRequests: []ReleaseNotesPullRequest{
+					{Number: 10, Title: "Add feature", URL: "https://github.com/owner/repo/pull/Add feature", Author: "alice", Labels: []string{"enhancement"}, MergedAt: "2024-05-03T12:00:00Z"},
+				},
+				DirectCommits: []ReleaseNotesCommit{},
+				LabelCounts:   map[string]int{"enhancement": 1},
+				Contributors:  []string{"alice"},
+			},
+		},
+		{
+			name: "unknown tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v0.0.0",
+				"head":  "v1.1.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v0.0.0...v1.1.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReleaseNotesData(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var data ReleaseNotesData
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &data))
+			assert.Equal(t, tc.expected, data)
+		})
+	}
+}
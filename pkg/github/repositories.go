@@ -2,13 +2,16 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -16,6 +19,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -200,6 +204,82 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// CompareCommits creates a tool to compare two commits, branches or tags, including across forks.
+func CompareCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_commits",
+			mcp.WithDescription(t("TOOL_COMPARE_COMMITS_DESCRIPTION", "Compare two commits, branches or tags: how many commits head is ahead of and behind base, their merge base, and the commits only in head. To compare across forks of the repository, give refs as 'owner:ref', e.g. base 'main' and head 'octocat:main' to see how far octocat's fork has diverged from upstream.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_COMMITS_USER_TITLE", "Compare commits"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base commit SHA, branch or tag. Use 'owner:ref' for a ref of a fork in the same network, or 'owner:repo:ref' if the fork has a different name"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head commit SHA, branch or tag. Use 'owner:ref' for a ref of a fork in the same network, or 'owner:repo:ref' if the fork has a different name"),
+			),
+			mcp.WithBoolean("include_files",
+				mcp.Description("Also return the files changed between base and head. Defaults to false"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeFiles, err := OptionalParam[bool](request, "include_files")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalComparison(base, head, comparison, includeFiles)), nil
+		}
+}
+
 // ListBranches creates a tool to list branches in a GitHub repository.
 func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_branches",
@@ -562,6 +642,30 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				}
 				fileSHA = *fileContent.SHA
 
+				var resourceURI string
+				switch {
+				case sha != "":
+					resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				case ref != "":
+					resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				default:
+					resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				}
+
+				// Large files are not downloaded, the caller can read them in parts with fetch_resource.
+				if fileContent.GetSize() > maxInlineFileSize {
+					return fileResourceLinkResult(resourceURI, path, "", fileSHA, fileContent.GetSize(), false), nil
+				}
+
 				rawClient, err := getRawClient(ctx)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
@@ -575,56 +679,29 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				}()
 
 				if resp.StatusCode == http.StatusOK {
-					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
-					}
 					contentType := resp.Header.Get("Content-Type")
 
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
+					// Binary files are returned as a link rather than base64 encoded, which
+					// is rarely useful to a model and inflates the context.
+					if !isTextMIMEType(contentType) {
+						return fileResourceLinkResult(resourceURI, path, contentType, fileSHA, fileContent.GetSize(), true), nil
 					}
 
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						result := mcp.TextResourceContents{
-							URI:      resourceURI,
-							Text:     string(body),
-							MIMEType: contentType,
-						}
-						// Include SHA in the result metadata
-						if fileSHA != "" {
-							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
-						}
-						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
+					// If the raw content is found, return it directly
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return mcp.NewToolResultError("failed to read response body"), nil
 					}
-
-					result := mcp.BlobResourceContents{
+					result := mcp.TextResourceContents{
 						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
+						Text:     string(body),
 						MIMEType: contentType,
 					}
 					// Include SHA in the result metadata
 					if fileSHA != "" {
-						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
+						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
 					}
-					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
-
+					return mcp.NewToolResultResource("successfully downloaded text file", result), nil
 				}
 			}
 
@@ -758,6 +835,90 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// SyncForkWithUpstream creates a tool to sync a branch of a fork with its upstream repository.
+func SyncForkWithUpstream(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sync_fork_with_upstream",
+			mcp.WithDescription(t("TOOL_SYNC_FORK_WITH_UPSTREAM_DESCRIPTION", "Sync a branch of a fork with the same branch of its upstream repository, by fast-forwarding or merging. If the changes conflict, nothing is changed and the conflict is reported; use compare_commits to inspect how the branches diverged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SYNC_FORK_WITH_UPSTREAM_USER_TITLE", "Sync fork with upstream"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the fork"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the fork"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch of the fork to sync. Defaults to the fork's default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if branch == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				branch = repository.GetDefaultBranch()
+			}
+
+			result, resp, err := client.Repositories.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{
+				Branch: github.Ptr(branch),
+			})
+			if err != nil {
+				// A conflict is an expected outcome rather than a failure of the tool
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return MarshalledTextResult(map[string]any{
+						"branch":   branch,
+						"synced":   false,
+						"conflict": true,
+						"message":  fmt.Sprintf("branch %s could not be synced with upstream because of merge conflicts, they have to be resolved manually", branch),
+					}), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to sync fork with upstream",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"branch":      branch,
+				"synced":      true,
+				"conflict":    false,
+				"merge_type":  result.GetMergeType(),
+				"base_branch": result.GetBaseBranch(),
+				"message":     result.GetMessage(),
+			}), nil
+		}
+}
+
 // DeleteFile creates a tool to delete a file in a GitHub repository.
 // This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
 // This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
@@ -1035,6 +1196,127 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// RenameBranch creates a tool to rename a branch, including the default branch.
+func RenameBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rename_branch",
+			mcp.WithDescription(t("TOOL_RENAME_BRANCH_DESCRIPTION", "Rename a branch in a GitHub repository, including the default branch. GitHub retargets open pull requests based on the branch and moves branch protection rules that name it; the response lists the retargeted pull requests and whether the branch is still protected under its new name. Renaming the default branch requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RENAME_BRANCH_USER_TITLE", "Rename branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Current name of the branch"),
+			),
+			mcp.WithString("new_name",
+				mcp.Required(),
+				mcp.Description("New name of the branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := RequiredParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			before, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 0)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// The pull requests GitHub retargets are the open ones based on the branch
+			var pullRequests []*github.PullRequest
+			opts := &github.PullRequestListOptions{State: "open", Base: branch, ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list pull requests",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				pullRequests = append(pullRequests, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			renamed, resp, err := client.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to rename branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			retargeted := make([]map[string]any, 0, len(pullRequests))
+			for _, pr := range pullRequests {
+				retargeted = append(retargeted, map[string]any{
+					"number":   pr.GetNumber(),
+					"title":    pr.GetTitle(),
+					"html_url": pr.GetHTMLURL(),
+				})
+			}
+			result := map[string]any{
+				"old_name":                 branch,
+				"new_name":                 renamed.GetName(),
+				"default_branch":           repository.GetDefaultBranch() == branch,
+				"retargeted_pull_requests": retargeted,
+				"protected_before":         before.GetProtected(),
+				"protected_after":          renamed.GetProtected(),
+			}
+			if before.GetProtected() && !renamed.GetProtected() {
+				result["warning"] = fmt.Sprintf("%s was protected by a rule that does not match %s, add protection for the new name", branch, newName)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
@@ -1911,3 +2193,523 @@ func UnstarRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			return mcp.NewToolResultText(fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)), nil
 		}
 }
+
+// BlameRange is a contiguous range of lines last changed by the same commit.
+type BlameRange struct {
+	StartLine     int    `json:"start_line"`
+	EndLine       int    `json:"end_line"`
+	Age           int    `json:"age"`
+	CommitSHA     string `json:"commit_sha"`
+	CommitURL     string `json:"commit_url"`
+	CommittedDate string `json:"committed_date"`
+	Message       string `json:"message"`
+	AuthorName    string `json:"author_name,omitempty"`
+	AuthorEmail   string `json:"author_email,omitempty"`
+	AuthorLogin   string `json:"author_login,omitempty"`
+}
+
+// blameCommit selects the blame ranges of a file in a commit.
+type blameCommit struct {
+	Blame struct {
+		Ranges []struct {
+			StartingLine githubv4.Int
+			EndingLine   githubv4.Int
+			Age          githubv4.Int
+			Commit       struct {
+				OID             githubv4.String `graphql:"oid"`
+				URL             githubv4.String
+				CommittedDate   githubv4.DateTime
+				MessageHeadline githubv4.String
+				Author          struct {
+					Name  githubv4.String
+					Email githubv4.String
+					User  struct {
+						Login githubv4.String
+					}
+				}
+			}
+		}
+	} `graphql:"blame(path: $path)"`
+}
+
+// BlameQuery fetches the blame ranges for a file at a given revision.
+type BlameQuery struct {
+	Repository struct {
+		Object *struct {
+			Typename githubv4.String `graphql:"__typename"`
+			Commit   blameCommit     `graphql:"... on Commit"`
+			// Annotated tags are peeled to the commit they point to
+			Tag struct {
+				Target struct {
+					Typename githubv4.String `graphql:"__typename"`
+					Commit   blameCommit     `graphql:"... on Commit"`
+				}
+			} `graphql:"... on Tag"`
+		} `graphql:"object(expression: $ref)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// commit returns the commit ref resolved to, failing if it does not exist or is not a commit.
+func (q *BlameQuery) commit(ref string) (*blameCommit, error) {
+	object := q.Repository.Object
+	if object == nil {
+		return nil, fmt.Errorf("ref %q not found", ref)
+	}
+	switch object.Typename {
+	case "Commit":
+		return &object.Commit, nil
+	case "Tag":
+		if object.Tag.Target.Typename != "Commit" {
+			return nil, fmt.Errorf("tag %q points to a %s, not a commit", ref, object.Tag.Target.Typename)
+		}
+		return &object.Tag.Target.Commit, nil
+	default:
+		return nil, fmt.Errorf("ref %q points to a %s, not a commit", ref, object.Typename)
+	}
+}
+
+// GetBlame creates a tool to get the blame information for a file.
+func GetBlame(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_blame",
+			mcp.WithDescription(t("TOOL_GET_BLAME_DESCRIPTION", "Get the blame for a file in a GitHub repository: for each range of lines, the commit that last changed it, its author and its age. Use start_line and end_line to find who last touched a specific function or block.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to blame. Defaults to the repository's default branch"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("Only return ranges that include lines at or after this line"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Only return ranges that include lines at or before this line"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine > 0 && endLine > 0 && endLine < startLine {
+				return mcp.NewToolResultError("end_line must be greater than or equal to start_line"), nil
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query BlameQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"ref":   githubv4.String(ref),
+				"path":  githubv4.String(path),
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to get blame for %s at %s", path, ref), err), nil
+			}
+			commit, err := query.commit(ref)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(commit.Blame.Ranges) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no blame found for %s at %s, check that the file exists at this ref", path, ref)), nil
+			}
+
+			ranges := []BlameRange{}
+			for _, r := range commit.Blame.Ranges {
+				if startLine > 0 && int(r.EndingLine) < startLine {
+					continue
+				}
+				if endLine > 0 && int(r.StartingLine) > endLine {
+					continue
+				}
+				ranges = append(ranges, BlameRange{
+					StartLine:     int(r.StartingLine),
+					EndLine:       int(r.EndingLine),
+					Age:           int(r.Age),
+					CommitSHA:     string(r.Commit.OID),
+					CommitURL:     string(r.Commit.URL),
+					CommittedDate: r.Commit.CommittedDate.Format(time.RFC3339),
+					Message:       string(r.Commit.MessageHeadline),
+					AuthorName:    string(r.Commit.Author.Name),
+					AuthorEmail:   string(r.Commit.Author.Email),
+					AuthorLogin:   string(r.Commit.Author.User.Login),
+				})
+			}
+
+			return MarshalledTextResult(ranges), nil
+		}
+}
+
+// SetRepoTopics creates a tool to replace the topics of a repository.
+func SetRepoTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repo_topics",
+			mcp.WithDescription(t("TOOL_SET_REPO_TOPICS_DESCRIPTION", "Replace all topics of a GitHub repository. Topics are lowercased; pass an empty list to remove all topics.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPO_TOPICS_USER_TITLE", "Set repository topics"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("topics",
+				mcp.Required(),
+				mcp.Description("The complete list of topics for the repository"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["topics"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: topics"), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for i, topic := range topics {
+				topics[i] = strings.ToLower(strings.TrimSpace(topic))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set topics of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{"topics": updated}), nil
+		}
+}
+
+// GetCustomPropertyValues creates a tool to get the organization custom property values of a repository.
+func GetCustomPropertyValues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_custom_property_values",
+			mcp.WithDescription(t("TOOL_GET_CUSTOM_PROPERTY_VALUES_DESCRIPTION", "Get the values of the organization's custom properties (such as team ownership or data classification) set on a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CUSTOM_PROPERTY_VALUES_USER_TITLE", "Get repository custom property values"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization that owns the repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get custom property values of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			properties := make(map[string]any, len(values))
+			for _, v := range values {
+				properties[v.PropertyName] = v.Value
+			}
+
+			return MarshalledTextResult(properties), nil
+		}
+}
+
+// SetCustomPropertyValues creates a tool to set organization custom property values on a repository.
+func SetCustomPropertyValues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_custom_property_values",
+			mcp.WithDescription(t("TOOL_SET_CUSTOM_PROPERTY_VALUES_DESCRIPTION", "Set values of the organization's custom properties on a repository. Only the given properties are changed; set a property to null to remove its value.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_CUSTOM_PROPERTY_VALUES_USER_TITLE", "Set repository custom property values"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization that owns the repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithObject("properties",
+				mcp.Required(),
+				mcp.Description("Map of custom property names to values. Values are strings, arrays of strings for multi-select properties, or null to unset"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			properties, err := OptionalParam[map[string]any](request, "properties")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(properties) == 0 {
+				return mcp.NewToolResultError("missing required parameter: properties"), nil
+			}
+
+			values := make([]*github.CustomPropertyValue, 0, len(properties))
+			for name, value := range properties {
+				switch v := value.(type) {
+				case nil, string:
+					values = append(values, &github.CustomPropertyValue{PropertyName: name, Value: v})
+				case []any:
+					strValues := make([]string, len(v))
+					for i, item := range v {
+						s, ok := item.(string)
+						if !ok {
+							return mcp.NewToolResultError(fmt.Sprintf("custom property %s must be a string, an array of strings or null", name)), nil
+						}
+						strValues[i] = s
+					}
+					values = append(values, &github.CustomPropertyValue{PropertyName: name, Value: strValues})
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("custom property %s must be a string, an array of strings or null", name)), nil
+				}
+			}
+			// Sort for a deterministic request body
+			sort.Slice(values, func(i, j int) bool { return values[i].PropertyName < values[j].PropertyName })
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repo, values)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set custom property values of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully updated %d custom properties of repository %s/%s", len(values), owner, repo)), nil
+		}
+}
+
+// archiveFileNameReplacer makes owner, repository and ref names safe to use in a file name.
+var archiveFileNameReplacer = strings.NewReplacer("/", "-", "\\", "-", "..", "-", ":", "-")
+
+// DownloadRepoArchive creates a tool to download a tarball or zipball of a repository at a ref.
+// When archiveDir is empty, the tool returns a short-lived download URL instead of storing the archive.
+func DownloadRepoArchive(getClient GetClientFn, archiveDir string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Get a short-lived URL to download a tarball or zipball of a repository at a branch, tag or commit. For private repositories the URL expires after five minutes."
+	if archiveDir != "" {
+		description = "Download a tarball or zipball of a repository at a branch, tag or commit, and store it in the server's archive directory. Returns the path of the stored archive."
+	}
+	return mcp.NewTool("download_repo_archive",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_REPO_ARCHIVE_DESCRIPTION", description)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_REPO_ARCHIVE_USER_TITLE", "Download repository archive"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA. Defaults to the repository's default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Archive format"),
+				mcp.Enum("tarball", "zipball"),
+				mcp.DefaultString("tarball"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = string(github.Tarball)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			archiveURL, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, github.ArchiveFormat(format), &github.RepositoryContentGetOptions{Ref: ref}, 0)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get archive link", resp, err), nil
+			}
+
+			if archiveDir == "" {
+				return MarshalledTextResult(map[string]any{
+					"url":    archiveURL.String(),
+					"format": format,
+				}), nil
+			}
+
+			extension := ".tar.gz"
+			if format == string(github.Zipball) {
+				extension = ".zip"
+			}
+			refName := ref
+			if refName == "" {
+				refName = "default"
+			}
+			name := archiveFileNameReplacer.Replace(fmt.Sprintf("%s-%s-%s", owner, repo, refName)) + extension
+
+			path, size, err := downloadFile(ctx, archiveURL.String(), archiveDir, name)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download archive: %v", err)), nil
+			}
+			return MarshalledTextResult(map[string]any{
+				"path":       path,
+				"size_bytes": size,
+				"format":     format,
+			}), nil
+		}
+}
+
+// downloadFile stores the content at fileURL as name in dir.
+func downloadFile(ctx context.Context, fileURL, dir, name string) (string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return storeFile(dir, name, resp.Body)
+}
+
+// storeFile writes the content of r as name in dir, replacing any existing file of that name.
+// The file only appears once it is complete.
+func storeFile(dir, name string, r io.Reader) (string, int64, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", 0, err
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	size, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, err
+	}
+	return path, size, nil
+}
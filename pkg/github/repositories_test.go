@@ -2,20 +2,24 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -131,6 +135,7 @@ func Test_GetFileContents(t *testing.T) {
 							Path: github.Ptr("test.png"),
 							SHA:  github.Ptr("def456"),
 							Type: github.Ptr("file"),
+							Size: github.Ptr(1234),
 						}
 						contentBytes, _ := json.Marshal(fileContent)
 						_, _ = w.Write(contentBytes)
@@ -150,12 +155,122 @@ func Test_GetFileContents(t *testing.T) {
 				"path":  "test.png",
 				"ref":   "refs/heads/main",
 			},
-			expectError: false,
-			expectedResult: mcp.BlobResourceContents{
-				URI:      "repo://owner/repo/refs/heads/main/contents/test.png",
-				Blob:     base64.StdEncoding.EncodeToString(mockRawContent),
-				MIMEType: "image/png",
+			expectError:    false,
+			expectedResult: mcp.NewResourceLink("repo://owner/repo/refs/heads/main/contents/test.png", "test.png", "image/png, 1234 bytes", "image/png"),
+		},
+		{
+			name: "octet-stream blob returned as a link",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("build.bin"),
+							Path: github.Ptr("build.bin"),
+							SHA:  github.Ptr("def456"),
+							Type: github.Ptr("file"),
+							Size: github.Ptr(1234),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/octet-stream")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "build.bin",
+				"ref":   "refs/heads/main",
+			},
+			expectError:    false,
+			expectedResult: mcp.NewResourceLink("repo://owner/repo/refs/heads/main/contents/build.bin", "build.bin", "application/octet-stream, 1234 bytes", "application/octet-stream"),
+		},
+		{
+			name: "pdf returned as a link",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("manual.pdf"),
+							Path: github.Ptr("docs/manual.pdf"),
+							SHA:  github.Ptr("def456"),
+							Type: github.Ptr("file"),
+							Size: github.Ptr(1234),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/pdf")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "docs/manual.pdf",
+				"ref":   "refs/heads/main",
+			},
+			expectError:    false,
+			expectedResult: mcp.NewResourceLink("repo://owner/repo/refs/heads/main/contents/docs/manual.pdf", "manual.pdf", "application/pdf, 1234 bytes", "application/pdf"),
+		},
+		{
+			name: "large file returned as a link without download",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Name: github.Ptr("data.json"),
+						Path: github.Ptr("testdata/data.json"),
+						SHA:  github.Ptr("fed987"),
+						Type: github.Ptr("file"),
+						Size: github.Ptr(5 * 1024 * 1024),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "testdata/data.json",
+				"ref":   "refs/heads/main",
 			},
+			expectError:    false,
+			expectedResult: mcp.NewResourceLink("repo://owner/repo/refs/heads/main/contents/testdata/data.json", "data.json", "application/json, 5242880 bytes", "application/json"),
 		},
 		{
 			name: "successful directory content fetch",
@@ -259,9 +374,10 @@ func Test_GetFileContents(t *testing.T) {
 			case mcp.TextResourceContents:
 				textResource := getTextResourceResult(t, result)
 				assert.Equal(t, expected, textResource)
-			case mcp.BlobResourceContents:
-				blobResource := getBlobResourceResult(t, result)
-				assert.Equal(t, expected, blobResource)
+			case mcp.ResourceLink:
+				require.Len(t, result.Content, 2)
+				assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "read it in byte ranges with fetch_resource")
+				assert.Equal(t, expected, result.Content[1])
 			case []*github.RepositoryContent:
 				// Directory content fetch returns a text result (JSON array)
 				textContent := getTextResult(t, result)
@@ -3192,3 +3308,925 @@ func Test_UnstarRepository(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetBlame(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetBlame(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	blameRange := func(start, end, age int, oid, login string) map[string]any {
+		return map[string]any{
+			"startingLine": start,
+			"endingLine":   end,
+			"age":          age,
+			"commit": map[string]any{
+				"oid":             oid,
+				"url":             "https://github.com/owner/repo/commit/" + oid,
+				"committedDate":   "2024-05-01T10:00:00Z",
+				"messageHeadline": "Change " + oid,
+				"author": map[string]any{
+					"name":  login,
+					"email": login + "@example.com",
+					"user":  map[string]any{"login": login},
+				},
+			},
+		}
+	}
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"__typename": "Commit",
+				"blame": map[string]any{
+					"ranges": []any{
+						blameRange(1, 10, 8, "abc", "alice"),
+						blameRange(11, 20, 2, "def", "bob"),
+						blameRange(21, 30, 5, "abc", "alice"),
+					},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		ref            string
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedErrMsg string
+		expectedShas   []string
+	}{
+		{
+			name: "defaults to HEAD",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+			},
+			ref:          "HEAD",
+			response:     blameResponse,
+			expectedShas: []string{"abc", "def", "abc"},
+		},
+		{
+			name: "filters ranges by line",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"ref":        "main",
+				"start_line": float64(12),
+				"end_line":   float64(15),
+			},
+			ref:          "main",
+			response:     blameResponse,
+			expectedShas: []string{"def"},
+		},
+		{
+			name: "invalid line range",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(15),
+				"end_line":   float64(12),
+			},
+			ref:            "HEAD",
+			response:       blameResponse,
+			expectError:    true,
+			expectedErrMsg: "end_line must be greater than or equal to start_line",
+		},
+		{
+			name: "peels annotated tags",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "v1.0.0",
+			},
+			ref: "v1.0.0",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"object": map[string]any{
+						"__typename": "Tag",
+						"target": map[string]any{
+							"__typename": "Commit",
+							"blame": map[string]any{
+								"ranges": []any{blameRange(1, 30, 8, "abc", "alice")},
+							},
+						},
+					},
+				},
+			}),
+			expectedShas: []string{"abc"},
+		},
+		{
+			name: "ref is not a commit",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "HEAD:main.go",
+			},
+			ref: "HEAD:main.go",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"object": map[string]any{"__typename": "Blob"},
+				},
+			}),
+			expectError:    true,
+			expectedErrMsg: `ref "HEAD:main.go" points to a Blob, not a commit`,
+		},
+		{
+			name: "unknown ref",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "missing",
+			},
+			ref: "missing",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"object": nil},
+			}),
+			expectError:    true,
+			expectedErrMsg: `ref "missing" not found`,
+		},
+		{
+			name: "path without blame",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "missing.go",
+			},
+			ref: "HEAD",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"object": map[string]any{
+						"__typename": "Commit",
+						"blame":      map[string]any{"ranges": []any{}},
+					},
+				},
+			}),
+			expectError:    true,
+			expectedErrMsg: "no blame found for missing.go at HEAD",
+		},
+		{
+			name: "query fails",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "missing.go",
+			},
+			ref:            "HEAD",
+			response:       githubv4mock.ErrorResponse("Could not resolve file"),
+			expectError:    true,
+			expectedErrMsg: "failed to get blame for missing.go at HEAD",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, _ := tc.requestArgs["path"].(string)
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(BlameQuery{}, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"ref":   githubv4.String(tc.ref),
+					"path":  githubv4.String(path),
+				}, tc.response),
+			)
+			_, handler := GetBlame(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var ranges []BlameRange
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ranges))
+			authors := map[string]string{"abc": "alice", "def": "bob"}
+			shas := make([]string, 0, len(ranges))
+			for _, r := range ranges {
+				shas = append(shas, r.CommitSHA)
+				assert.Equal(t, authors[r.CommitSHA], r.AuthorLogin)
+				assert.Equal(t, "2024-05-01T10:00:00Z", r.CommittedDate)
+			}
+			assert.Equal(t, tc.expectedShas, shas)
+		})
+	}
+}
+
+func Test_SetRepoTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepoTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repo_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "topics"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedTopics []string
+	}{
+		{
+			name: "replace topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"go", "mcp"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{
+							"names": []string{"go", "mcp"},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"Go", " mcp "},
+			},
+			expectedTopics: []string{"go", "mcp"},
+		},
+		{
+			name: "clear topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{
+							"names": []string{},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{},
+			},
+			expectedTopics: []string{},
+		},
+		{
+			name:         "missing topics",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: topics",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepoTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Topics []string `json:"topics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedTopics, response.Topics)
+		})
+	}
+}
+
+func Test_GetCustomPropertyValues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCustomPropertyValues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_custom_property_values", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPropertiesValuesByOwnerByRepo,
+			expectPath(t, "/repos/acme/repo/properties/values").andThen(
+				mockResponse(t, http.StatusOK, []map[string]interface{}{
+					{"property_name": "team", "value": "platform"},
+					{"property_name": "data_classes", "value": []string{"pii", "financial"}},
+					{"property_name": "tier", "value": nil},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetCustomPropertyValues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "acme",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var properties map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &properties))
+	assert.Equal(t, map[string]interface{}{
+		"team":         "platform",
+		"data_classes": []interface{}{"pii", "financial"},
+		"tier":         nil,
+	}, properties)
+}
+
+func Test_SetCustomPropertyValues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetCustomPropertyValues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_custom_property_values", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "properties"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "set string, multi-select and null values",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"properties": []interface{}{
+							map[string]interface{}{"property_name": "data_classes", "value": []interface{}{"pii"}},
+							map[string]interface{}{"property_name": "team", "value": "platform"},
+							map[string]interface{}{"property_name": "tier", "value": nil},
+						},
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "acme",
+				"repo":  "repo",
+				"properties": map[string]interface{}{
+					"team":         "platform",
+					"data_classes": []interface{}{"pii"},
+					"tier":         nil,
+				},
+			},
+		},
+		{
+			name:         "invalid value type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "acme",
+				"repo":  "repo",
+				"properties": map[string]interface{}{
+					"tier": float64(1),
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "custom property tier must be a string, an array of strings or null",
+		},
+		{
+			name:         "missing properties",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "acme",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: properties",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetCustomPropertyValues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Successfully updated 3 custom properties of repository acme/repo", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_DownloadRepoArchive(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadRepoArchive(stubGetClientFn(mockClient), "", translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_repo_archive", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	archiveServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("archive content"))
+	}))
+	defer archiveServer.Close()
+	archiveURL := archiveServer.URL + "/owner/repo/legacy.tar.gz/refs/heads/main?token=abc"
+
+	redirectTo := func(location string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", location)
+			w.WriteHeader(http.StatusFound)
+		}
+	}
+
+	t.Run("returns the download URL", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposTarballByOwnerByRepoByRef, redirectTo(archiveURL)),
+		))
+		_, handler := DownloadRepoArchive(stubGetClientFn(client), "", translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"ref":   "main",
+		}))
+		require.NoError(t, err)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, map[string]any{"url": archiveURL, "format": "tarball"}, response)
+	})
+
+	t.Run("stores the archive", func(t *testing.T) {
+		dir := t.TempDir()
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposZipballByOwnerByRepoByRef, redirectTo(archiveURL)),
+		))
+		_, handler := DownloadRepoArchive(stubGetClientFn(client), dir, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"ref":    "v1.0",
+			"format": "zipball",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		expectedPath := filepath.Join(dir, "owner-repo-v1.0.zip")
+		assert.Equal(t, map[string]any{"path": expectedPath, "size_bytes": float64(15), "format": "zipball"}, response)
+
+		content, err := os.ReadFile(expectedPath)
+		require.NoError(t, err)
+		assert.Equal(t, "archive content", string(content))
+
+		// No temporary files are left behind
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposTarballByOwnerByRepoByRef,
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+			),
+		))
+		_, handler := DownloadRepoArchive(stubGetClientFn(client), "", translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "missing",
+			"ref":   "main",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get archive link")
+	})
+}
+
+func Test_CompareCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_commits", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(5),
+		TotalCommits:    github.Ptr(2),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("merge-base")},
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/main...octocat:main"),
+		Commits: []*github.RepositoryCommit{
+			{SHA: github.Ptr("fork-1"), Commit: &github.Commit{Message: github.Ptr("Fork change")}},
+			{SHA: github.Ptr("fork-2"), Commit: &github.Commit{Message: github.Ptr("Another fork change")}},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Deletions: github.Ptr(1), Changes: github.Ptr(4)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectFiles    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "compare fork to upstream",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/main...octocat:main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "octocat:main",
+			},
+		},
+		{
+			name: "include files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, mockComparison),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"base":          "main",
+				"head":          "octocat:main",
+				"include_files": true,
+			},
+			expectFiles: true,
+		},
+		{
+			name: "unrelated histories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "No common ancestor between main and stranger:main."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "stranger:main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare main...stranger:main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var comparison MinimalComparison
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comparison))
+			assert.Equal(t, "main", comparison.Base)
+			assert.Equal(t, "octocat:main", comparison.Head)
+			assert.Equal(t, "diverged", comparison.Status)
+			assert.Equal(t, 2, comparison.AheadBy)
+			assert.Equal(t, 5, comparison.BehindBy)
+			assert.Equal(t, "merge-base", comparison.MergeBaseSHA)
+			require.Len(t, comparison.Commits, 2)
+			assert.Equal(t, "Fork change", comparison.Commits[0].Commit.Message)
+			if tc.expectFiles {
+				assert.Equal(t, []MinimalCommitFile{{Filename: "main.go", Status: "modified", Additions: 3, Deletions: 1, Changes: 4}}, comparison.Files)
+			} else {
+				assert.Empty(t, comparison.Files)
+			}
+		})
+	}
+}
+
+func Test_SyncForkWithUpstream(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SyncForkWithUpstream(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_fork_with_upstream", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockResult := &github.RepoMergeUpstreamResult{
+		Message:    github.Ptr("Successfully fetched and fast-forwarded from upstream octocat:main."),
+		MergeType:  github.Ptr("fast-forward"),
+		BaseBranch: github.Ptr("octocat:main"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "sync given branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"branch": "release",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "fork-owner",
+				"repo":   "repo",
+				"branch": "release",
+			},
+			expectedResult: map[string]interface{}{
+				"branch":      "release",
+				"synced":      true,
+				"conflict":    false,
+				"merge_type":  "fast-forward",
+				"base_branch": "octocat:main",
+				"message":     "Successfully fetched and fast-forwarded from upstream octocat:main.",
+			},
+		},
+		{
+			name: "sync default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"branch": "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "fork-owner",
+				"repo":  "repo",
+			},
+			expectedResult: map[string]interface{}{
+				"branch":      "main",
+				"synced":      true,
+				"conflict":    false,
+				"merge_type":  "fast-forward",
+				"base_branch": "octocat:main",
+				"message":     "Successfully fetched and fast-forwarded from upstream octocat:main.",
+			},
+		},
+		{
+			name: "merge conflict",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, `{"message": "There are merge conflicts"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "fork-owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedResult: map[string]interface{}{
+				"branch":   "main",
+				"synced":   false,
+				"conflict": true,
+				"message":  "branch main could not be synced with upstream because of merge conflicts, they have to be resolved manually",
+			},
+		},
+		{
+			name: "branch does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Branch not found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "fork-owner",
+				"repo":   "repo",
+				"branch": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to sync fork with upstream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SyncForkWithUpstream(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_RenameBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RenameBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "rename_branch", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "new_name"})
+
+	repository := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{DefaultBranch: github.Ptr("master")})
+	}
+	protectedBranch := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, github.Branch{Name: github.Ptr("master"), Protected: github.Ptr(true)})
+	}
+	pullRequests := func() mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"state": "open", "base": "master", "per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.PullRequest{
+					{Number: github.Ptr(12), Title: github.Ptr("Add feature"), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/12")},
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name: "rename the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				repository(),
+				protectedBranch(),
+				pullRequests(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{"new_name": "main"}).andThen(
+						mockResponse(t, http.StatusCreated, github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(true)}),
+					),
+				),
+			),
+			expected: map[string]any{
+				"old_name":       "master",
+				"new_name":       "main",
+				"default_branch": true,
+				"retargeted_pull_requests": []any{
+					map[string]any{"number": float64(12), "title": "Add feature", "html_url": "https://github.com/owner/repo/pull/12"},
+				},
+				"protected_before": true,
+				"protected_after":  true,
+			},
+		},
+		{
+			name: "protection does not follow the branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				repository(),
+				protectedBranch(),
+				pullRequests(),
+				mock.WithRequestMatch(mock.PostReposBranchesRenameByOwnerByRepoByBranch, github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(false)}),
+			),
+			expected: map[string]any{
+				"old_name":       "master",
+				"new_name":       "main",
+				"default_branch": true,
+				"retargeted_pull_requests": []any{
+					map[string]any{"number": float64(12), "title": "Add feature", "html_url": "https://github.com/owner/repo/pull/12"},
+				},
+				"protected_before": true,
+				"protected_after":  false,
+				"warning":          "master was protected by a rule that does not match main, add protection for the new name",
+			},
+		},
+		{
+			name: "rename refused",
+			mockedClient: mock.NewMockedHTTPClient(
+				repository(),
+				protectedBranch(),
+				pullRequests(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, `{"message": "You must be an admin to rename the default branch"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to rename branch master",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				repository(),
+				mock.WithRequestMatchHandler(mock.GetReposBranchesByOwnerByRepoByBranch, mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`)),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get branch master",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RenameBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branch":   "master",
+				"new_name": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
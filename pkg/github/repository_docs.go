@@ -0,0 +1,320 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultDocsBudget is the number of bytes of documentation get_repo_file_tree_docs returns by default.
+	defaultDocsBudget = 100_000
+	// maxDocsBudget bounds the number of bytes of documentation get_repo_file_tree_docs returns.
+	maxDocsBudget = 1_000_000
+)
+
+// RepoDocFile is a documentation file returned with its content.
+type RepoDocFile struct {
+	Path    string `json:"path"`
+	Size    int    `json:"size"`
+	Content string `json:"content"`
+}
+
+// SkippedRepoDocFile is a documentation file left out because it did not fit in the size budget.
+type SkippedRepoDocFile struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+// readmeURL returns the API path of the README of a repository, or of a directory of it when dir is set.
+func readmeURL(owner, repo, dir, ref string) string {
+	u := fmt.Sprintf("repos/%s/%s/readme", url.PathEscape(owner), url.PathEscape(repo))
+	if dir = strings.Trim(dir, "/"); dir != "" {
+		u += "/" + dir
+	}
+	if ref != "" {
+		u += "?ref=" + url.QueryEscape(ref)
+	}
+	return u
+}
+
+// isMarkdownPath reports whether p has a Markdown file extension.
+func isMarkdownPath(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// GetRepoReadme creates a tool to get the README of a repository or of one of its directories.
+func GetRepoReadme(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_readme",
+			mcp.WithDescription(t("TOOL_GET_REPO_README_DESCRIPTION", "Get the README of a GitHub repository, or of a directory in it, as raw Markdown or rendered HTML. GitHub picks the README file the same way it does on the repository page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_README_USER_TITLE", "Get repository README"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Directory to get the README of. Defaults to the repository root"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the README from. Defaults to the default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Return the README as raw Markdown or as HTML rendered by GitHub"),
+				mcp.Enum("raw", "rendered"),
+				mcp.DefaultString("raw"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = "raw"
+			}
+			if format != "raw" && format != "rendered" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid format %q, must be raw or rendered", format)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest("GET", readmeURL(owner, repo, dir, ref), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			readme := new(github.RepositoryContent)
+			resp, err := client.Do(ctx, req, readme)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get README",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			var content string
+			if format == "raw" {
+				if content, err = readme.GetContent(); err != nil {
+					return nil, fmt.Errorf("failed to decode README: %w", err)
+				}
+			} else {
+				req, err := client.NewRequest("GET", readmeURL(owner, repo, dir, ref), nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				req.Header.Set("Accept", "application/vnd.github.html")
+				var buf bytes.Buffer
+				resp, err := client.Do(ctx, req, &buf)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get rendered README",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				content = buf.String()
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"path":     readme.GetPath(),
+				"name":     readme.GetName(),
+				"sha":      readme.GetSHA(),
+				"size":     readme.GetSize(),
+				"html_url": readme.GetHTMLURL(),
+				"format":   format,
+				"content":  content,
+			}), nil
+		}
+}
+
+// GetRepoFileTreeDocs creates a tool to get the Markdown documentation of a repository in one call.
+func GetRepoFileTreeDocs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_file_tree_docs",
+			mcp.WithDescription(t("TOOL_GET_REPO_FILE_TREE_DOCS_DESCRIPTION", "Get the Markdown files under a directory of a GitHub repository (docs/ by default) and the root README, up to a size budget. Files are returned in path order, files that do not fit in the budget are listed as skipped. Use this to summarize or answer questions about a repository in one call.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_FILE_TREE_DOCS_USER_TITLE", "Get repository documentation"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Directory to collect Markdown files from, recursively. Use '/' for the whole repository"),
+				mcp.DefaultString("docs"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the files from. Defaults to the default branch"),
+			),
+			mcp.WithBoolean("include_readme",
+				mcp.Description("Include the README of the repository root first, even when it is outside path"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Maximum total size of the returned file contents, in bytes (max %d)", maxDocsBudget)),
+				mcp.DefaultNumber(defaultDocsBudget),
+				mcp.Min(1),
+				mcp.Max(maxDocsBudget),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir, ok, err := OptionalParamOK[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				dir = "docs"
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeReadme, err := OptionalBoolParamWithDefault(request, "include_readme", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := OptionalIntParamWithDefault(request, "max_bytes", defaultDocsBudget)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxBytes < 1 || maxBytes > maxDocsBudget {
+				return mcp.NewToolResultError(fmt.Sprintf("max_bytes must be between 1 and %d", maxDocsBudget)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			prefix := strings.Trim(dir, "/")
+			var readme *github.TreeEntry
+			var docs []*github.TreeEntry
+			for _, entry := range tree.Entries {
+				p := entry.GetPath()
+				if entry.GetType() != "blob" || !isMarkdownPath(p) {
+					continue
+				}
+				if includeReadme && readme == nil && strings.EqualFold(p, "README.md") {
+					readme = entry
+					continue
+				}
+				if prefix == "" || strings.HasPrefix(p, prefix+"/") {
+					docs = append(docs, entry)
+				}
+			}
+			sort.Slice(docs, func(i, j int) bool {
+				return docs[i].GetPath() < docs[j].GetPath()
+			})
+			if readme != nil {
+				docs = append([]*github.TreeEntry{readme}, docs...)
+			}
+
+			files := []RepoDocFile{}
+			skipped := []SkippedRepoDocFile{}
+			totalBytes := 0
+			for _, entry := range docs {
+				if totalBytes+entry.GetSize() > maxBytes {
+					skipped = append(skipped, SkippedRepoDocFile{Path: entry.GetPath(), Size: entry.GetSize()})
+					continue
+				}
+				content, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, entry.GetSHA())
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get %s", entry.GetPath()),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				totalBytes += len(content)
+				files = append(files, RepoDocFile{Path: entry.GetPath(), Size: len(content), Content: string(content)})
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"ref":            ref,
+				"path":           prefix,
+				"max_bytes":      maxBytes,
+				"total_bytes":    totalBytes,
+				"files":          files,
+				"skipped":        skipped,
+				"tree_truncated": tree.GetTruncated(),
+			}), nil
+		}
+}
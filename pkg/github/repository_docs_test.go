@@ -0,0 +1,298 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepoReadme(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoReadme(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_readme", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	readme := func(path string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Name:     github.Ptr("README.md"),
+			Path:     github.Ptr(path),
+			SHA:      github.Ptr("abc123"),
+			Size:     github.Ptr(20),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# Hello\n\nWorld\n"))),
+			HTMLURL:  github.Ptr("https://github.com/owner/repo/blob/main/" + path),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedPath    string
+		expectedContent string
+	}{
+		{
+			name: "raw README of the repository root",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReadmeByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"ref": "v1.0"}).andThen(
+						mockResponse(t, http.StatusOK, readme("README.md")),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.0",
+			},
+			expectedPath:    "README.md",
+			expectedContent: "# Hello\n\nWorld\n",
+		},
+		{
+			name: "rendered README of a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReadmeByOwnerByRepoByDir,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/readme/docs", r.URL.Path)
+						if r.Header.Get("Accept") == "application/vnd.github.html" {
+							w.Header().Set("Content-Type", "text/html")
+							_, _ = w.Write([]byte("<h1>Hello</h1>"))
+							return
+						}
+						w.Header().Set("Content-Type", "application/json")
+						_ = json.NewEncoder(w).Encode(readme("docs/README.md"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "/docs/",
+				"format": "rendered",
+			},
+			expectedPath:    "docs/README.md",
+			expectedContent: "<h1>Hello</h1>",
+		},
+		{
+			name: "README not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReadmeByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get README",
+		},
+		{
+			name:         "invalid format",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"format": "pdf",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoReadme(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedPath, response["path"])
+			assert.Equal(t, tc.expectedContent, response["content"])
+		})
+	}
+}
+
+func Test_GetRepoFileTreeDocs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoFileTreeDocs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_file_tree_docs", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tree := &github.Tree{
+		SHA: github.Ptr("tree-sha"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), SHA: github.Ptr("readme"), Size: github.Ptr(8)},
+			{Path: github.Ptr("main.go"), Type: github.Ptr("blob"), SHA: github.Ptr("main"), Size: github.Ptr(100)},
+			{Path: github.Ptr("docs"), Type: github.Ptr("tree"), SHA: github.Ptr("docs")},
+			{Path: github.Ptr("docs/usage.md"), Type: github.Ptr("blob"), SHA: github.Ptr("usage"), Size: github.Ptr(7)},
+			{Path: github.Ptr("docs/big.md"), Type: github.Ptr("blob"), SHA: github.Ptr("big"), Size: github.Ptr(200_000)},
+			{Path: github.Ptr("docs/logo.png"), Type: github.Ptr("blob"), SHA: github.Ptr("logo"), Size: github.Ptr(10)},
+			{Path: github.Ptr("docs/api/INDEX.markdown"), Type: github.Ptr("blob"), SHA: github.Ptr("index"), Size: github.Ptr(7)},
+			{Path: github.Ptr("guides/intro.md"), Type: github.Ptr("blob"), SHA: github.Ptr("intro"), Size: github.Ptr(7)},
+		},
+	}
+	blobs := map[string]string{
+		"readme": "# Repo\n\n",
+		"usage":  "# Usage",
+		"index":  "# Index",
+		"intro":  "# Intro",
+	}
+	blobHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sha := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		content, ok := blobs[sha]
+		if !ok {
+			t.Errorf("unexpected blob %s", sha)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedFiles   []string
+		expectedSkipped []string
+	}{
+		{
+			name: "docs directory of the default branch with the README",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/git/trees/main", r.URL.Path)
+						assert.Equal(t, "1", r.URL.Query().Get("recursive"))
+						w.Header().Set("Content-Type", "application/json")
+						_ = json.NewEncoder(w).Encode(tree)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					blobHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedFiles:   []string{"README.md", "docs/api/INDEX.markdown", "docs/usage.md"},
+			expectedSkipped: []string{"docs/big.md"},
+		},
+		{
+			name: "whole repository within a budget",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					tree,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					blobHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"ref":            "main",
+				"path":           "/",
+				"include_readme": false,
+				"max_bytes":      float64(16),
+			},
+			expectedFiles:   []string{"README.md", "docs/api/INDEX.markdown"},
+			expectedSkipped: []string{"docs/big.md", "docs/usage.md", "guides/intro.md"},
+		},
+		{
+			name: "tree not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get git tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoFileTreeDocs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Files   []RepoDocFile        `json:"files"`
+				Skipped []SkippedRepoDocFile `json:"skipped"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			files := make([]string, 0, len(response.Files))
+			for _, file := range response.Files {
+				files = append(files, file.Path)
+				assert.NotEmpty(t, file.Content)
+			}
+			skipped := make([]string, 0, len(response.Skipped))
+			for _, file := range response.Skipped {
+				skipped = append(skipped, file.Path)
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+			assert.Equal(t, tc.expectedSkipped, skipped)
+		})
+	}
+}
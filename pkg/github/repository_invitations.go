@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListRepositoryInvitations creates a tool to list repository invitations, either those received by the
+// authenticated user or the pending invitations of a repository the user administers.
+func ListRepositoryInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_invitations",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_INVITATIONS_DESCRIPTION", "List pending repository invitations. Without owner and repo, lists the invitations the authenticated user has received to collaborate on other repositories. With owner and repo, lists the invitations sent for that repository, which requires admin access to it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner. Must be given together with repo"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. Must be given together with owner"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (owner == "") != (repo == "") {
+				return mcp.NewToolResultError("owner and repo must be provided together"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+
+			var invitations []*github.RepositoryInvitation
+			var resp *github.Response
+			if owner != "" {
+				invitations, resp, err = client.Repositories.ListInvitations(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list invitations of repository %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+			} else {
+				invitations, resp, err = client.Users.ListInvitations(ctx, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list repository invitations",
+						resp,
+						err,
+					), nil
+				}
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalRepositoryInvitations(invitations)), nil
+		}
+}
+
+// AcceptRepositoryInvitation creates a tool to accept a repository invitation received by the authenticated user.
+func AcceptRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("accept_repository_invitation",
+			mcp.WithDescription(t("TOOL_ACCEPT_REPOSITORY_INVITATION_DESCRIPTION", "Accept an invitation the authenticated user has received to collaborate on a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ACCEPT_REPOSITORY_INVITATION_USER_TITLE", "Accept repository invitation"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("ID of the invitation, as returned by list_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Users.AcceptInvitation(ctx, int64(invitationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to accept repository invitation %d", invitationID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully accepted repository invitation %d", invitationID)), nil
+		}
+}
+
+// DeclineRepositoryInvitation creates a tool to decline a repository invitation received by the authenticated user.
+func DeclineRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("decline_repository_invitation",
+			mcp.WithDescription(t("TOOL_DECLINE_REPOSITORY_INVITATION_DESCRIPTION", "Decline an invitation the authenticated user has received to collaborate on a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DECLINE_REPOSITORY_INVITATION_USER_TITLE", "Decline repository invitation"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("ID of the invitation, as returned by list_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Users.DeclineInvitation(ctx, int64(invitationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to decline repository invitation %d", invitationID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully declined repository invitation %d", invitationID)), nil
+		}
+}
+
+// DeleteRepositoryInvitation creates a tool to revoke a pending invitation of a repository the authenticated user administers.
+func DeleteRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository_invitation",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_INVITATION_DESCRIPTION", "Revoke a pending invitation to collaborate on a repository. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_INVITATION_USER_TITLE", "Delete repository invitation"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("ID of the invitation, as returned by list_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteInvitation(ctx, owner, repo, int64(invitationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete invitation %d of repository %s/%s", invitationID, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted invitation %d of repository %s/%s", invitationID, owner, repo)), nil
+		}
+}
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockInvitations := []*github.RepositoryInvitation{
+		{
+			ID:          github.Ptr(int64(1)),
+			Repo:        &github.Repository{FullName: github.Ptr("octo-org/hello-world")},
+			Invitee:     &github.User{Login: github.Ptr("octocat")},
+			Inviter:     &github.User{Login: github.Ptr("hubot")},
+			Permissions: github.Ptr("write"),
+			HTMLURL:     github.Ptr("https://github.com/octo-org/hello-world/invitations"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list invitations of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserRepositoryInvitations,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockInvitations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "list invitations of a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInvitationsByOwnerByRepo,
+					expectPath(t, "/repos/octo-org/hello-world/invitations").andThen(
+						mockResponse(t, http.StatusOK, mockInvitations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "hello-world",
+			},
+		},
+		{
+			name:         "owner without repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner and repo must be provided together",
+		},
+		{
+			name: "repository invitations require admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInvitationsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "hello-world",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list invitations of repository octo-org/hello-world",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var invitations []MinimalRepositoryInvitation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &invitations))
+			require.Len(t, invitations, 1)
+			assert.Equal(t, int64(1), invitations[0].ID)
+			assert.Equal(t, "octo-org/hello-world", invitations[0].Repository)
+			assert.Equal(t, "octocat", invitations[0].Invitee)
+			assert.Equal(t, "hubot", invitations[0].Inviter)
+			assert.Equal(t, "write", invitations[0].Permissions)
+		})
+	}
+}
+
+func Test_AcceptAndDeclineRepositoryInvitation(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := github.NewClient(nil)
+	acceptTool, _ := AcceptRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(acceptTool.Name, acceptTool))
+	assert.Equal(t, "accept_repository_invitation", acceptTool.Name)
+	assert.ElementsMatch(t, acceptTool.InputSchema.Required, []string{"invitation_id"})
+
+	declineTool, _ := DeclineRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(declineTool.Name, declineTool))
+	assert.Equal(t, "decline_repository_invitation", declineTool.Name)
+	assert.ElementsMatch(t, declineTool.InputSchema.Required, []string{"invitation_id"})
+
+	tests := []struct {
+		name           string
+		accept         bool
+		mockedClient   *http.Client
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:   "accept invitation",
+			accept: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchUserRepositoryInvitationsByInvitationId,
+					expectPath(t, "/user/repository_invitations/7").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			expectedText: "Successfully accepted repository invitation 7",
+		},
+		{
+			name:   "decline invitation",
+			accept: false,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserRepositoryInvitationsByInvitationId,
+					expectPath(t, "/user/repository_invitations/7").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			expectedText: "Successfully declined repository invitation 7",
+		},
+		{
+			name:   "accept expired invitation",
+			accept: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchUserRepositoryInvitationsByInvitationId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to accept repository invitation 7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeclineRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+			if tc.accept {
+				_, handler = AcceptRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+			}
+
+			request := createMCPRequest(map[string]interface{}{
+				"invitation_id": float64(7),
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_DeleteRepositoryInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "invitation_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposInvitationsByOwnerByRepoByInvitationId,
+			expectPath(t, "/repos/octo-org/hello-world/invitations/7").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner":         "octo-org",
+		"repo":          "hello-world",
+		"invitation_id": float64(7),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "Successfully deleted invitation 7 of repository octo-org/hello-world", textContent.Text)
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// setRepositoryArchived archives or unarchives a repository.
+func setRepositoryArchived(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, archived bool) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	action := "archive"
+	if !archived {
+		action = "unarchive"
+	}
+	repository, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{Archived: github.Ptr(archived)})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to %s repository", action),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return MarshalledTextResult(map[string]any{
+		"repository": repository.GetFullName(),
+		"archived":   repository.GetArchived(),
+		"html_url":   repository.GetHTMLURL(),
+	}), nil
+}
+
+// ArchiveRepository creates a tool to archive a repository, making it read-only.
+func ArchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("archive_repository",
+			mcp.WithDescription(t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive a GitHub repository. Archived repositories are read-only: issues, pull requests, pushes and workflows are disabled until the repository is unarchived. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepositoryArchived(ctx, getClient, request, true)
+		}
+}
+
+// UnarchiveRepository creates a tool to unarchive a repository.
+func UnarchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unarchive_repository",
+			mcp.WithDescription(t("TOOL_UNARCHIVE_REPOSITORY_DESCRIPTION", "Unarchive a GitHub repository, making it writable again. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNARCHIVE_REPOSITORY_USER_TITLE", "Unarchive repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepositoryArchived(ctx, getClient, request, false)
+		}
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_repository",
+			mcp.WithDescription(t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a GitHub repository to another user or organization, optionally renaming it and giving teams of the new organization access. Transfers to a user must be accepted by that user. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_owner",
+				mcp.Required(),
+				mcp.Description("User or organization to transfer the repository to"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the repository. Defaults to the current name"),
+			),
+			mcp.WithArray("teams",
+				mcp.Description("Slugs of teams of the new owner organization to give access to the repository"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := RequiredParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teams, err := OptionalStringArrayParam(request, "teams")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			transfer := github.TransferRequest{NewOwner: newOwner}
+			if newName != "" {
+				transfer.NewName = github.Ptr(newName)
+			} else {
+				newName = repo
+			}
+			// The API takes team IDs, which are looked up before anything is transferred
+			for _, slug := range teams {
+				team, resp, err := client.Teams.GetTeamBySlug(ctx, newOwner, slug)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("team %s not found in organization %s", slug, newOwner)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get team %s", slug),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				transfer.TeamID = append(transfer.TeamID, team.GetID())
+			}
+
+			repository, resp, err := client.Repositories.Transfer(ctx, owner, repo, transfer)
+			status := "transferred"
+			if err != nil {
+				if !isAcceptedError(err) {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to transfer repository",
+						resp,
+						err,
+					), nil
+				}
+				// GitHub transfers the repository in the background
+				status = "scheduled"
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			result := map[string]any{
+				"status":     status,
+				"repository": fmt.Sprintf("%s/%s", newOwner, newName),
+				"teams":      teams,
+			}
+			if repository.GetFullName() != "" {
+				result["repository"] = repository.GetFullName()
+				result["html_url"] = repository.GetHTMLURL()
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ArchiveRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ArchiveRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "archive_repository", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	unarchiveTool, _ := UnarchiveRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unarchiveTool.Name, unarchiveTool))
+	assert.Nil(t, unarchiveTool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		newTool        func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name:    "archive",
+			newTool: ArchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"archived": true}).andThen(
+						mockResponse(t, http.StatusOK, github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(true), HTMLURL: github.Ptr("https://github.com/owner/repo")}),
+					),
+				),
+			),
+			expected: map[string]any{"repository": "owner/repo", "archived": true, "html_url": "https://github.com/owner/repo"},
+		},
+		{
+			name:    "unarchive",
+			newTool: UnarchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"archived": false}).andThen(
+						mockResponse(t, http.StatusOK, github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(false), HTMLURL: github.Ptr("https://github.com/owner/repo")}),
+					),
+				),
+			),
+			expected: map[string]any{"repository": "owner/repo", "archived": false, "html_url": "https://github.com/owner/repo"},
+		},
+		{
+			name:    "not an admin",
+			newTool: ArchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to archive repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := tc.newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := TransferRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_repository", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_owner"})
+
+	teams := mock.WithRequestMatchHandler(
+		mock.GetOrgsTeamsByOrgByTeamSlug,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/orgs/new-org/teams/platform" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(github.Team{ID: github.Ptr(int64(42)), Slug: github.Ptr("platform")})
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name: "transfer with teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				teams,
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"new_owner": "new-org", "new_name": "service", "team_ids": []any{float64(42)}}).andThen(
+						mockResponse(t, http.StatusAccepted, github.Repository{FullName: github.Ptr("new-org/service")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "new_owner": "new-org", "new_name": "service", "teams": []any{"platform"}},
+			expected:    map[string]any{"status": "scheduled", "repository": "new-org/service", "teams": []any{"platform"}},
+		},
+		{
+			name: "transfer completed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"new_owner": "someone"}).andThen(
+						mockResponse(t, http.StatusOK, github.Repository{FullName: github.Ptr("someone/repo"), HTMLURL: github.Ptr("https://github.com/someone/repo")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "new_owner": "someone"},
+			expected:    map[string]any{"status": "transferred", "repository": "someone/repo", "html_url": "https://github.com/someone/repo", "teams": []any{}},
+		},
+		{
+			name:           "unknown team",
+			mockedClient:   mock.NewMockedHTTPClient(teams),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "new_owner": "new-org", "teams": []any{"nope"}},
+			expectError:    true,
+			expectedErrMsg: "team nope not found in organization new-org",
+		},
+		{
+			name: "transfer refused",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Repository has already been taken"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "new_owner": "someone"},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := TransferRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
@@ -19,6 +21,234 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// maxInlineFileSize is the size above which the contents of a file are not returned by content tools,
+// but linked to, to be read in ranges with fetch_resource.
+const maxInlineFileSize = 1024 * 1024
+
+// fileResourceLinkResult returns a link to a file that was not downloaded because it is binary or
+// larger than maxInlineFileSize, with its size and MIME type. The MIME type is guessed from the
+// extension if it is not known.
+func fileResourceLinkResult(uri, path, mimeType, sha string, size int, binary bool) *mcp.CallToolResult {
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(path))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	reason := fmt.Sprintf("file is larger than %d bytes", maxInlineFileSize)
+	if binary {
+		reason = "file is binary"
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(fmt.Sprintf("%s and was not downloaded (SHA: %s, size: %d bytes), read it in byte ranges with fetch_resource", reason, sha, size)),
+			mcp.NewResourceLink(uri, filepath.Base(path), fmt.Sprintf("%s, %d bytes", mimeType, size), mimeType),
+		},
+	}
+}
+
+// textApplicationTypes are the application/* MIME types whose content is text.
+var textApplicationTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/javascript": true,
+}
+
+// isTextMIMEType reports whether content of the given MIME type is returned as text rather than
+// as a link or base64 encoded: text/* and a few application types, including +json and +xml ones.
+func isTextMIMEType(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		textApplicationTypes[mediaType] ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml")
+}
+
+// parseRepoResourceURI splits a repo:// URI as returned by content tools into the repository, the
+// git reference and the path of the file. The reference is empty for the default branch, "sha/{sha}"
+// for a commit, or a branch, tag or pull request reference otherwise.
+func parseRepoResourceURI(uri string) (owner, repo, ref, path string, err error) {
+	rest, ok := strings.CutPrefix(uri, "repo://")
+	if !ok {
+		return "", "", "", "", fmt.Errorf("unsupported resource URI %q, expected a repo:// URI", uri)
+	}
+	head, path, ok := strings.Cut(rest, "/contents/")
+	if !ok || path == "" {
+		return "", "", "", "", fmt.Errorf("resource URI %q does not point to a file", uri)
+	}
+	if path, err = url.PathUnescape(path); err != nil {
+		return "", "", "", "", fmt.Errorf("invalid path in resource URI %q: %w", uri, err)
+	}
+	parts := strings.SplitN(head, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", "", fmt.Errorf("resource URI %q does not name a repository", uri)
+	}
+	if len(parts) == 3 {
+		ref = parts[2]
+	}
+	return parts[0], parts[1], ref, path, nil
+}
+
+// contentRangeTotal returns the total size from a Content-Range header such as "bytes 0-99/1234" or
+// "bytes */1234", or -1 if it is unknown.
+func contentRangeTotal(header string) int {
+	_, total, ok := strings.Cut(header, "/")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.Atoi(total)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// FetchResource creates a tool to read a byte range of a file linked to by a content tool.
+func FetchResource(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fetch_resource",
+			mcp.WithDescription(t("TOOL_FETCH_RESOURCE_DESCRIPTION", fmt.Sprintf("Read a byte range of a repository file from its repo:// resource URI, such as the resource links content tools return for binary files and files larger than %d bytes. Text is returned as is, binary data as a base64 encoded blob. Text ranges may start or end in the middle of a multi-byte character.", maxInlineFileSize))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FETCH_RESOURCE_USER_TITLE", "Fetch resource byte range"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("uri",
+				mcp.Required(),
+				mcp.Description("repo:// URI of the file, e.g. repo://owner/repo/refs/heads/main/contents/path/to/file"),
+			),
+			mcp.WithNumber("offset",
+				mcp.Description("Byte offset to start reading at"),
+				mcp.DefaultNumber(0),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("length",
+				mcp.Description(fmt.Sprintf("Number of bytes to read (max %d)", maxInlineFileSize)),
+				mcp.DefaultNumber(maxInlineFileSize),
+				mcp.Min(1),
+				mcp.Max(maxInlineFileSize),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			uri, err := RequiredParam[string](request, "uri")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			offset, err := OptionalIntParam(request, "offset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			length, err := OptionalIntParamWithDefault(request, "length", maxInlineFileSize)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if offset < 0 {
+				return mcp.NewToolResultError("offset must not be negative"), nil
+			}
+			if length < 1 || length > maxInlineFileSize {
+				return mcp.NewToolResultError(fmt.Sprintf("length must be between 1 and %d", maxInlineFileSize)), nil
+			}
+
+			owner, repo, ref, path, err := parseRepoResourceURI(uri)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			rawOpts := &raw.ContentOpts{}
+			switch {
+			case strings.HasPrefix(ref, "sha/"):
+				rawOpts.SHA = strings.TrimPrefix(ref, "sha/")
+			case strings.HasPrefix(ref, "refs/pull/"):
+				prNumber, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(ref, "refs/pull/"), "/head"))
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid pull request reference %q", ref)), nil
+				}
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, prNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				rawOpts.SHA = pr.GetHead().GetSHA()
+			default:
+				rawOpts.Ref = ref
+			}
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+			}
+			resp, err := rawClient.GetRawContentRange(ctx, owner, repo, path, rawOpts, int64(offset), int64(length))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get raw content: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var content []byte
+			total := -1
+			switch resp.StatusCode {
+			case http.StatusPartialContent:
+				if content, err = io.ReadAll(resp.Body); err != nil {
+					return nil, fmt.Errorf("failed to read file content: %w", err)
+				}
+				total = contentRangeTotal(resp.Header.Get("Content-Range"))
+			case http.StatusOK:
+				// The server ignored the range and returned the whole file.
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read file content: %w", err)
+				}
+				total = len(body)
+				if offset >= total {
+					return mcp.NewToolResultError(fmt.Sprintf("offset %d is past the end of the file (%d bytes)", offset, total)), nil
+				}
+				content = body[offset:min(offset+length, total)]
+			case http.StatusRequestedRangeNotSatisfiable:
+				return mcp.NewToolResultError(fmt.Sprintf("offset %d is past the end of the file (%d bytes)", offset, contentRangeTotal(resp.Header.Get("Content-Range")))), nil
+			case http.StatusNotFound:
+				return mcp.NewToolResultError(fmt.Sprintf("resource not found: %s", uri)), nil
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fetch raw content: %s", string(body))), nil
+			}
+
+			mimeType := resp.Header.Get("Content-Type")
+			if mimeType == "" {
+				mimeType = mime.TypeByExtension(filepath.Ext(path))
+			}
+
+			end := offset + len(content)
+			message := fmt.Sprintf("bytes %d-%d of %d", offset, end-1, total)
+			if total < 0 {
+				message = fmt.Sprintf("bytes %d-%d", offset, end-1)
+			}
+			if total >= 0 && end < total {
+				message += fmt.Sprintf(", continue at offset %d", end)
+			}
+
+			if isTextMIMEType(mimeType) {
+				return mcp.NewToolResultResource(message, mcp.TextResourceContents{
+					URI:      uri,
+					MIMEType: mimeType,
+					Text:     string(content),
+				}), nil
+			}
+			return mcp.NewToolResultResource(message, mcp.BlobResourceContents{
+				URI:      uri,
+				MIMEType: mimeType,
+				Blob:     base64.StdEncoding.EncodeToString(content),
+			}), nil
+		}
+}
+
 // GetRepositoryResourceContent defines the resource template and handler for getting repository content.
 func GetRepositoryResourceContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
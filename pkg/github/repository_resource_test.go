@@ -2,15 +2,19 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -278,3 +282,213 @@ func Test_GetRepositoryResourceTagContent(t *testing.T) {
 	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", tmpl.URITemplate.Raw())
 }
+
+func Test_ParseRepoResourceURI(t *testing.T) {
+	tests := []struct {
+		uri         string
+		owner, repo string
+		ref, path   string
+		expectError string
+	}{
+		{uri: "repo://owner/repo/contents/README.md", owner: "owner", repo: "repo", path: "README.md"},
+		{uri: "repo://owner/repo/refs/heads/main/contents/docs/logo%20dark.png", owner: "owner", repo: "repo", ref: "refs/heads/main", path: "docs/logo dark.png"},
+		{uri: "repo://owner/repo/sha/abc123/contents/bin/tool", owner: "owner", repo: "repo", ref: "sha/abc123", path: "bin/tool"},
+		{uri: "repo://owner/repo/refs/pull/42/head/contents/a.zip", owner: "owner", repo: "repo", ref: "refs/pull/42/head", path: "a.zip"},
+		{uri: "https://github.com/owner/repo/blob/main/README.md", expectError: "expected a repo:// URI"},
+		{uri: "repo://owner/repo/contents/", expectError: "does not point to a file"},
+		{uri: "repo://owner/contents/README.md", expectError: "does not name a repository"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			owner, repo, ref, path, err := parseRepoResourceURI(tc.uri)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tc.owner, tc.repo, tc.ref, tc.path}, []string{owner, repo, ref, path})
+		})
+	}
+}
+
+func Test_IsTextMIMEType(t *testing.T) {
+	for mimeType, expected := range map[string]bool{
+		"text/plain; charset=utf-8": true,
+		"text/markdown":             true,
+		"application/json":          true,
+		"application/xml":           true,
+		"application/javascript":    true,
+		"application/vnd.api+json":  true,
+		"image/svg+xml":             true,
+		"application/octet-stream":  false,
+		"application/pdf":           false,
+		"application/zip":           false,
+		"image/png":                 false,
+		"":                          false,
+	} {
+		assert.Equal(t, expected, isTextMIMEType(mimeType), mimeType)
+	}
+}
+
+func Test_FetchResource(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+	tool, _ := FetchResource(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "fetch_resource", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"uri"})
+
+	png := []byte("\x89PNG\r\n\x1a\nIHDR0123456789")
+	rangeHandler := func(contentType string, data []byte) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var start, end int
+			_, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+			require.NoError(t, err)
+			w.Header().Set("Content-Type", contentType)
+			if start >= len(data) {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(data)))
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			end = min(end, len(data)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(data[start : end+1])
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     string
+		expectedMessage string
+		expectedText    string
+		expectedBlob    []byte
+	}{
+		{
+			name: "binary range of a commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					rangeHandler("image/png", png),
+				),
+			),
+			requestArgs: map[string]any{
+				"uri":    "repo://owner/repo/sha/abc123/contents/logo.png",
+				"offset": float64(8),
+				"length": float64(4),
+			},
+			expectedMessage: "bytes 8-11 of 22, continue at offset 12",
+			expectedBlob:    []byte("IHDR"),
+		},
+		{
+			name: "text range at the end of a branch file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					rangeHandler("text/plain; charset=utf-8", []byte("line 1\nline 2\n")),
+				),
+			),
+			requestArgs: map[string]any{
+				"uri":    "repo://owner/repo/refs/heads/main/contents/log.txt",
+				"offset": float64(7),
+			},
+			expectedMessage: "bytes 7-13 of 14",
+			expectedText:    "line 2\n",
+		},
+		{
+			name: "range ignored by the server",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("0123456789"))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"uri":    "repo://owner/repo/contents/digits.txt",
+				"offset": float64(2),
+				"length": float64(3),
+			},
+			expectedMessage: "bytes 2-4 of 10, continue at offset 5",
+			expectedText:    "234",
+		},
+		{
+			name: "pull request head",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Head: &github.PullRequestBranch{SHA: github.Ptr("abc123")}},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/owner/repo/abc123/logo.png", r.URL.Path)
+						rangeHandler("image/png", png)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"uri":    "repo://owner/repo/refs/pull/42/head/contents/logo.png",
+				"length": float64(4),
+			},
+			expectedMessage: "bytes 0-3 of 22, continue at offset 4",
+			expectedBlob:    png[:4],
+		},
+		{
+			name: "offset past the end",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					rangeHandler("image/png", png),
+				),
+			),
+			requestArgs: map[string]any{
+				"uri":    "repo://owner/repo/sha/abc123/contents/logo.png",
+				"offset": float64(100),
+			},
+			expectError: "offset 100 is past the end of the file (22 bytes)",
+		},
+		{
+			name:         "not a repo URI",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"uri": "https://example.com/logo.png",
+			},
+			expectError: "expected a repo:// URI",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := FetchResource(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectError)
+				return
+			}
+
+			require.Len(t, result.Content, 2)
+			assert.Equal(t, tc.expectedMessage, result.Content[0].(mcp.TextContent).Text)
+			if tc.expectedBlob != nil {
+				blob := getBlobResourceResult(t, result)
+				assert.Equal(t, base64.StdEncoding.EncodeToString(tc.expectedBlob), blob.Blob)
+				return
+			}
+			text := getTextResourceResult(t, result)
+			assert.Equal(t, tc.expectedText, text.Text)
+		})
+	}
+}
@@ -0,0 +1,731 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// repoStatsAttempts is how often the statistics endpoints are asked before reporting that
+// GitHub is still computing them.
+const repoStatsAttempts = 3
+
+// repoStatsRetryDelay is the time to wait before asking again for statistics that are being computed.
+var repoStatsRetryDelay = 2 * time.Second
+
+// statsComputingResult tells the caller that GitHub is computing the statistics in the background.
+func statsComputingResult(owner, repo string) *mcp.CallToolResult {
+	return MarshalledTextResult(map[string]any{
+		"computing": true,
+		"message":   fmt.Sprintf("GitHub is computing the statistics of %s/%s, retry in a few seconds", owner, repo),
+	})
+}
+
+// fetchRepoStats calls one of the statistics endpoints. These answer 202 Accepted while the statistics
+// are computed in the background, in which case the request is retried a few times. computing is
+// true if the statistics were still not available after that.
+func fetchRepoStats[T any](ctx context.Context, fetch func() (T, *github.Response, error)) (stats T, computing bool, resp *github.Response, err error) {
+	for attempt := 1; ; attempt++ {
+		stats, resp, err = fetch()
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err == nil || !isAcceptedError(err) {
+			return stats, false, resp, err
+		}
+		if attempt == repoStatsAttempts {
+			return stats, true, resp, nil
+		}
+		select {
+		case <-ctx.Done():
+			return stats, false, resp, ctx.Err()
+		case <-time.After(repoStatsRetryDelay):
+		}
+	}
+}
+
+// ContributorStats summarizes the commits of a contributor.
+type ContributorStats struct {
+	Login       string     `json:"login"`
+	Commits     int        `json:"commits"`
+	Additions   int        `json:"additions"`
+	Deletions   int        `json:"deletions"`
+	ActiveWeeks int        `json:"active_weeks"`
+	FirstWeek   *time.Time `json:"first_week,omitempty"`
+	LastWeek    *time.Time `json:"last_week,omitempty"`
+}
+
+// ContributorsStats summarizes who contributes to a repository.
+type ContributorsStats struct {
+	TotalCommits int `json:"total_commits"`
+	Contributors int `json:"contributors"`
+	// BusFactor is the smallest number of contributors that authored at least half of the commits.
+	BusFactor           int                `json:"bus_factor"`
+	TopContributorShare float64            `json:"top_contributor_share"`
+	Churn               int                `json:"churn"`
+	TopContributors     []ContributorStats `json:"top_contributors"`
+}
+
+// summarizeContributorsStats aggregates the weekly statistics of the contributors, counting only
+// weeks starting at since if it is not zero.
+func summarizeContributorsStats(stats []*github.ContributorStats, since time.Time, maxContributors int) ContributorsStats {
+	contributors := make([]ContributorStats, 0, len(stats))
+	for _, s := range stats {
+		c := ContributorStats{Login: s.GetAuthor().GetLogin()}
+		for _, week := range s.Weeks {
+			start := week.GetWeek().Time
+			if start.Before(since) || week.GetCommits() == 0 {
+				continue
+			}
+			c.Commits += week.GetCommits()
+			c.Additions += week.GetAdditions()
+			c.Deletions += week.GetDeletions()
+			c.ActiveWeeks++
+			if c.FirstWeek == nil || start.Before(*c.FirstWeek) {
+				c.FirstWeek = &start
+			}
+			if c.LastWeek == nil || start.After(*c.LastWeek) {
+				c.LastWeek = &start
+			}
+		}
+		if c.Commits > 0 {
+			contributors = append(contributors, c)
+		}
+	}
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Commits > contributors[j].Commits
+	})
+
+	summary := ContributorsStats{Contributors: len(contributors)}
+	for _, c := range contributors {
+		summary.TotalCommits += c.Commits
+		summary.Churn += c.Additions + c.Deletions
+	}
+	covered := 0
+	for _, c := range contributors {
+		if 2*covered >= summary.TotalCommits {
+			break
+		}
+		covered += c.Commits
+		summary.BusFactor++
+	}
+	if summary.TotalCommits > 0 {
+		share := float64(contributors[0].Commits) / float64(summary.TotalCommits)
+		summary.TopContributorShare = math.Round(share*1000) / 1000
+	}
+	if len(contributors) > maxContributors {
+		contributors = contributors[:maxContributors]
+	}
+	summary.TopContributors = contributors
+	return summary
+}
+
+// GetContributorsStats creates a tool to get the commit statistics of the contributors of a repository.
+func GetContributorsStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contributors_stats",
+			mcp.WithDescription(t("TOOL_GET_CONTRIBUTORS_STATS_DESCRIPTION", "Get commit statistics of the top 100 contributors of a repository: commits, additions, deletions and active weeks per contributor, along with the bus factor (the fewest contributors authoring half of the commits) and churn. GitHub computes these statistics in the background; if they are not ready yet, the result says so and the call should be retried shortly.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTRIBUTORS_STATS_USER_TITLE", "Get contributors statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("weeks",
+				mcp.Description("Only count the commits of the last number of weeks. Defaults to the whole history"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_contributors",
+				mcp.Description("Maximum number of contributors to list, ordered by commits (default 25, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParam(request, "weeks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxContributors, err := OptionalIntParamWithDefault(request, "max_contributors", 25)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stats, computing, resp, err := fetchRepoStats(ctx, func() ([]*github.ContributorStats, *github.Response, error) {
+				return client.Repositories.ListContributorsStats(ctx, owner, repo)
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get contributors statistics",
+					resp,
+					err,
+				), nil
+			}
+			if computing {
+				return statsComputingResult(owner, repo), nil
+			}
+
+			var since time.Time
+			if weeks > 0 {
+				since = time.Now().AddDate(0, 0, -7*weeks)
+			}
+			return MarshalledTextResult(summarizeContributorsStats(stats, since, maxContributors)), nil
+		}
+}
+
+// WeekActivity is the number of commits in a week, per day starting on Sunday.
+type WeekActivity struct {
+	Week  string `json:"week"`
+	Total int    `json:"total"`
+	Days  []int  `json:"days"`
+}
+
+// CommitActivity summarizes the weekly commit activity of a repository.
+type CommitActivity struct {
+	TotalCommits   int            `json:"total_commits"`
+	AveragePerWeek float64        `json:"average_per_week"`
+	ActiveWeeks    int            `json:"active_weeks"`
+	BusiestWeek    *WeekActivity  `json:"busiest_week,omitempty"`
+	CommitsPerDay  map[string]int `json:"commits_per_weekday"`
+	Weeks          []WeekActivity `json:"weeks"`
+}
+
+// summarizeCommitActivity aggregates the last number of weeks of commit activity.
+func summarizeCommitActivity(activity []*github.WeeklyCommitActivity, weeks int) CommitActivity {
+	if len(activity) > weeks {
+		activity = activity[len(activity)-weeks:]
+	}
+	summary := CommitActivity{
+		CommitsPerDay: map[string]int{},
+		Weeks:         make([]WeekActivity, 0, len(activity)),
+	}
+	for _, a := range activity {
+		week := WeekActivity{
+			Week:  a.GetWeek().UTC().Format(time.DateOnly),
+			Total: a.GetTotal(),
+			Days:  a.Days,
+		}
+		summary.Weeks = append(summary.Weeks, week)
+		summary.TotalCommits += week.Total
+		if week.Total > 0 {
+			summary.ActiveWeeks++
+		}
+		if week.Total > 0 && (summary.BusiestWeek == nil || week.Total > summary.BusiestWeek.Total) {
+			busiest := week
+			summary.BusiestWeek = &busiest
+		}
+		for day, commits := range a.Days {
+			summary.CommitsPerDay[time.Weekday(day%7).String()] += commits
+		}
+	}
+	if len(activity) > 0 {
+		summary.AveragePerWeek = math.Round(float64(summary.TotalCommits)/float64(len(activity))*10) / 10
+	}
+	return summary
+}
+
+// GetCommitActivity creates a tool to get the weekly commit activity of a repository over the last year.
+func GetCommitActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_activity",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_ACTIVITY_DESCRIPTION", "Get the weekly commit activity of a repository over up to the last year, with commits per day of the week, the busiest week and the average number of commits per week. GitHub computes these statistics in the background; if they are not ready yet, the result says so and the call should be retried shortly.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_ACTIVITY_USER_TITLE", "Get commit activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("weeks",
+				mcp.Description("Number of most recent weeks to return (default 52, max 52)"),
+				mcp.Min(1),
+				mcp.Max(52),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParamWithDefault(request, "weeks", 52)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			activity, computing, resp, err := fetchRepoStats(ctx, func() ([]*github.WeeklyCommitActivity, *github.Response, error) {
+				return client.Repositories.ListCommitActivity(ctx, owner, repo)
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get commit activity",
+					resp,
+					err,
+				), nil
+			}
+			if computing {
+				return statsComputingResult(owner, repo), nil
+			}
+
+			return MarshalledTextResult(summarizeCommitActivity(activity, weeks)), nil
+		}
+}
+
+// StarBucket is the number of stars a repository received in a week or month.
+type StarBucket struct {
+	Start string `json:"start"`
+	Stars int    `json:"stars"`
+	// Cumulative is the number of stars at the end of the bucket.
+	Cumulative int `json:"cumulative"`
+}
+
+// StargazerTimeline is the number of stars of a repository over time.
+type StargazerTimeline struct {
+	TotalStars int          `json:"total_stars"`
+	Interval   string       `json:"interval"`
+	Analyzed   int          `json:"analyzed_stars"`
+	Truncated  bool         `json:"truncated"`
+	Buckets    []StarBucket `json:"buckets"`
+}
+
+type stargazersQuery struct {
+	Repository struct {
+		Stargazers struct {
+			TotalCount githubv4.Int
+			Edges      []struct {
+				StarredAt githubv4.DateTime
+			}
+			PageInfo PageInfoFragment
+		} `graphql:"stargazers(first: 100, after: $after, orderBy: {field: STARRED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// bucketStart returns the start of the week (starting on Monday) or month t falls in.
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	if interval == "week" {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextBucketStart returns the start of the bucket following the one starting at start.
+func nextBucketStart(start time.Time, interval string) time.Time {
+	if interval == "week" {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// bucketStars counts the stars starred at the given times, newest first, per bucket. Buckets without
+// stars between the oldest and the newest star are included. As the oldest stars may not have been
+// analyzed, the cumulative counts are computed backwards from the total.
+func bucketStars(starredAt []time.Time, interval string, totalStars int) []StarBucket {
+	buckets := []StarBucket{}
+	if len(starredAt) == 0 {
+		return buckets
+	}
+	counts := make(map[time.Time]int)
+	for _, t := range starredAt {
+		counts[bucketStart(t, interval)]++
+	}
+	first := bucketStart(starredAt[len(starredAt)-1], interval)
+	last := bucketStart(starredAt[0], interval)
+	for start := first; !start.After(last); start = nextBucketStart(start, interval) {
+		buckets = append(buckets, StarBucket{Start: start.Format(time.DateOnly), Stars: counts[start]})
+	}
+	cumulative := totalStars
+	for i := len(buckets) - 1; i >= 0; i-- {
+		buckets[i].Cumulative = cumulative
+		cumulative -= buckets[i].Stars
+	}
+	return buckets
+}
+
+// GetStargazerTimeline creates a tool that counts the stars of a repository per week or month.
+func GetStargazerTimeline(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_stargazer_timeline",
+			mcp.WithDescription(t("TOOL_GET_STARGAZER_TIMELINE_DESCRIPTION", "Get the number of stars a repository received per week or month, with the cumulative star count at the end of each period. Stargazers are paged and aggregated server-side, starting from the most recent, so growth charts can be produced without listing every stargazer.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_STARGAZER_TIMELINE_USER_TITLE", "Get stargazer timeline"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("interval",
+				mcp.Description("Period to count stars per, weeks start on Monday (default month)"),
+				mcp.Enum("week", "month"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only count stars from this ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD) on"),
+			),
+			mcp.WithNumber("max_stars",
+				mcp.Description("Maximum number of most recent stars to analyze (default 10000, max 50000). The result is flagged as truncated if there are more"),
+				mcp.Min(1),
+				mcp.Max(50000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			interval, err := OptionalParam[string](request, "interval")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if interval == "" {
+				interval = "month"
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var sinceTime time.Time
+			if since != "" {
+				if sinceTime, err = parseISOTimestamp(since); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err)), nil
+				}
+			}
+			maxStars, err := OptionalIntParamWithDefault(request, "max_stars", 10000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"after": (*githubv4.String)(nil),
+			}
+			timeline := StargazerTimeline{Interval: interval}
+			var starredAt []time.Time
+			for done := false; !done; {
+				var query stargazersQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list stargazers", err), nil
+				}
+				stargazers := query.Repository.Stargazers
+				timeline.TotalStars = int(stargazers.TotalCount)
+
+				for _, edge := range stargazers.Edges {
+					if edge.StarredAt.Before(sinceTime) {
+						done = true
+						break
+					}
+					if len(starredAt) == maxStars {
+						timeline.Truncated = true
+						done = true
+						break
+					}
+					starredAt = append(starredAt, edge.StarredAt.Time)
+				}
+				if !stargazers.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = githubv4.NewString(stargazers.PageInfo.EndCursor)
+			}
+
+			timeline.Analyzed = len(starredAt)
+			timeline.Buckets = bucketStars(starredAt, interval, timeline.TotalStars)
+			return MarshalledTextResult(timeline), nil
+		}
+}
+
+// LanguageShare is the amount of code written in a language.
+type LanguageShare struct {
+	Name    string  `json:"name"`
+	Bytes   int     `json:"bytes"`
+	Percent float64 `json:"percent"`
+	// Repos is the number of repositories using the language, set when aggregating repositories.
+	Repos int `json:"repos,omitempty"`
+}
+
+// languageShares converts byte counts per language to shares of the total, largest first. repos
+// optionally counts the repositories per language.
+func languageShares(bytes map[string]int, repos map[string]int) (shares []LanguageShare, total int) {
+	for _, n := range bytes {
+		total += n
+	}
+	shares = make([]LanguageShare, 0, len(bytes))
+	for name, n := range bytes {
+		share := LanguageShare{Name: name, Bytes: n, Repos: repos[name]}
+		if total > 0 {
+			share.Percent = math.Round(float64(n)*1000/float64(total)) / 10
+		}
+		shares = append(shares, share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Bytes != shares[j].Bytes {
+			return shares[i].Bytes > shares[j].Bytes
+		}
+		return shares[i].Name < shares[j].Name
+	})
+	return shares, total
+}
+
+// GetRepoLanguages creates a tool that gets the number of bytes of code per language of a repository.
+func GetRepoLanguages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_languages",
+			mcp.WithDescription(t("TOOL_GET_REPO_LANGUAGES_DESCRIPTION", "Get the languages of a repository as detected by GitHub, with the number of bytes of code and the share of each, largest first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_LANGUAGES_USER_TITLE", "Get repository languages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list repository languages",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			shares, total := languageShares(languages, nil)
+			return MarshalledTextResult(map[string]any{
+				"total_bytes": total,
+				"languages":   shares,
+			}), nil
+		}
+}
+
+// TopicCount is the number of repositories tagged with a topic.
+type TopicCount struct {
+	Name  string `json:"name"`
+	Repos int    `json:"repos"`
+}
+
+// OrgLanguages is the languages and topics of the repositories of an organization.
+type OrgLanguages struct {
+	Org              string          `json:"org"`
+	TotalRepos       int             `json:"total_repos"`
+	AnalyzedRepos    int             `json:"analyzed_repos"`
+	Truncated        bool            `json:"truncated"`
+	TotalBytes       int             `json:"total_bytes"`
+	Languages        []LanguageShare `json:"languages"`
+	PrimaryLanguages map[string]int  `json:"primary_languages"`
+	Topics           []TopicCount    `json:"topics"`
+}
+
+// maxOrgTopics bounds the number of topics aggregate_org_languages returns.
+const maxOrgTopics = 50
+
+type orgLanguagesQuery struct {
+	Organization struct {
+		Repositories struct {
+			TotalCount githubv4.Int
+			Nodes      []struct {
+				IsFork          bool
+				IsArchived      bool
+				PrimaryLanguage *struct {
+					Name githubv4.String
+				}
+				Languages struct {
+					Edges []struct {
+						Size githubv4.Int
+						Node struct {
+							Name githubv4.String
+						}
+					}
+				} `graphql:"languages(first: 100)"`
+				RepositoryTopics struct {
+					Nodes []struct {
+						Topic struct {
+							Name githubv4.String
+						}
+					}
+				} `graphql:"repositoryTopics(first: 20)"`
+			}
+			PageInfo PageInfoFragment
+		} `graphql:"repositories(first: 50, after: $after, orderBy: {field: NAME, direction: ASC})"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// AggregateOrgLanguages creates a tool that sums the languages and counts the topics of the repositories of an organization.
+func AggregateOrgLanguages(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("aggregate_org_languages",
+			mcp.WithDescription(t("TOOL_AGGREGATE_ORG_LANGUAGES_DESCRIPTION", "Sum the bytes of code per language across the repositories of an organization, with the number of repositories using each language, the primary language counts and the most used topics. Repositories are paged and aggregated server-side, use this for tech-stack inventories instead of getting the languages of each repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_AGGREGATE_ORG_LANGUAGES_USER_TITLE", "Aggregate organization languages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithBoolean("include_forks",
+				mcp.Description("Include forked repositories (default false)"),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Include archived repositories (default false)"),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description("Maximum number of repositories to analyze (default 1000, max 5000). The result is flagged as truncated if there are more"),
+				mcp.Min(1),
+				mcp.Max(5000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeForks, err := OptionalParam[bool](request, "include_forks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeArchived, err := OptionalParam[bool](request, "include_archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", 1000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"org":   githubv4.String(org),
+				"after": (*githubv4.String)(nil),
+			}
+			result := OrgLanguages{Org: org, PrimaryLanguages: map[string]int{}}
+			bytes := map[string]int{}
+			repos := map[string]int{}
+			topics := map[string]int{}
+			seen := 0
+			for done := false; !done; {
+				var query orgLanguagesQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list organization repositories", err), nil
+				}
+				repositories := query.Organization.Repositories
+				result.TotalRepos = int(repositories.TotalCount)
+
+				for _, repo := range repositories.Nodes {
+					if seen == maxRepos {
+						result.Truncated = true
+						done = true
+						break
+					}
+					seen++
+					if (repo.IsFork && !includeForks) || (repo.IsArchived && !includeArchived) {
+						continue
+					}
+					result.AnalyzedRepos++
+					if repo.PrimaryLanguage != nil {
+						result.PrimaryLanguages[string(repo.PrimaryLanguage.Name)]++
+					}
+					for _, edge := range repo.Languages.Edges {
+						bytes[string(edge.Node.Name)] += int(edge.Size)
+						repos[string(edge.Node.Name)]++
+					}
+					for _, node := range repo.RepositoryTopics.Nodes {
+						topics[string(node.Topic.Name)]++
+					}
+				}
+				if !repositories.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = githubv4.NewString(repositories.PageInfo.EndCursor)
+			}
+
+			result.Languages, result.TotalBytes = languageShares(bytes, repos)
+			result.Topics = make([]TopicCount, 0, len(topics))
+			for name, n := range topics {
+				result.Topics = append(result.Topics, TopicCount{Name: name, Repos: n})
+			}
+			sort.Slice(result.Topics, func(i, j int) bool {
+				if result.Topics[i].Repos != result.Topics[j].Repos {
+					return result.Topics[i].Repos > result.Topics[j].Repos
+				}
+				return result.Topics[i].Name < result.Topics[j].Name
+			})
+			if len(result.Topics) > maxOrgTopics {
+				result.Topics = result.Topics[:maxOrgTopics]
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,566 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statsHandler answers 202 Accepted for the first accepted requests and then returns body.
+func statsHandler(t *testing.T, accepted int, body any) http.HandlerFunc {
+	requests := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= accepted {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, body)(w, r)
+	}
+}
+
+func Test_GetContributorsStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetContributorsStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contributors_stats", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	defer func(delay time.Duration) { repoStatsRetryDelay = delay }(repoStatsRetryDelay)
+	repoStatsRetryDelay = 0
+
+	recent := time.Now().AddDate(0, 0, -7).Truncate(24 * time.Hour)
+	old := time.Now().AddDate(-1, 0, 0).Truncate(24 * time.Hour)
+	week := func(start time.Time, additions, deletions, commits int) *github.WeeklyStats {
+		return &github.WeeklyStats{
+			Week:      &github.Timestamp{Time: start},
+			Additions: github.Ptr(additions),
+			Deletions: github.Ptr(deletions),
+			Commits:   github.Ptr(commits),
+		}
+	}
+	stats := []*github.ContributorStats{
+		{Author: &github.Contributor{Login: github.Ptr("alice")}, Weeks: []*github.WeeklyStats{week(old, 100, 10, 6), week(recent, 10, 5, 1)}},
+		{Author: &github.Contributor{Login: github.Ptr("bob")}, Weeks: []*github.WeeklyStats{week(recent, 20, 2, 3)}},
+		{Author: &github.Contributor{Login: github.Ptr("carol")}, Weeks: []*github.WeeklyStats{week(recent, 1, 1, 2)}},
+		{Author: &github.Contributor{Login: github.Ptr("dave")}, Weeks: []*github.WeeklyStats{week(old, 0, 0, 0)}},
+	}
+
+	tests := []struct {
+		name            string
+		accepted        int
+		requestArgs     map[string]interface{}
+		expectComputing bool
+		expectedStats   ContributorsStats
+		expectedLogins  []string
+	}{
+		{
+			name:     "whole history",
+			accepted: 0,
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedStats: ContributorsStats{
+				TotalCommits:        12,
+				Contributors:        3,
+				BusFactor:           1,
+				TopContributorShare: 0.583,
+				Churn:               149,
+			},
+			expectedLogins: []string{"alice", "bob", "carol"},
+		},
+		{
+			name:     "recent weeks after computing",
+			accepted: 2,
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"weeks":            float64(4),
+				"max_contributors": float64(2),
+			},
+			expectedStats: ContributorsStats{
+				TotalCommits:        6,
+				Contributors:        3,
+				BusFactor:           1,
+				TopContributorShare: 0.5,
+				Churn:               39,
+			},
+			expectedLogins: []string{"bob", "carol"},
+		},
+		{
+			name:     "still computing",
+			accepted: repoStatsAttempts,
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectComputing: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposStatsContributorsByOwnerByRepo, statsHandler(t, tc.accepted, stats)),
+			))
+			_, handler := GetContributorsStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			if tc.expectComputing {
+				assert.Contains(t, getTextResult(t, result).Text, `"computing":true`)
+				return
+			}
+
+			var returned ContributorsStats
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedStats.TotalCommits, returned.TotalCommits)
+			assert.Equal(t, tc.expectedStats.Contributors, returned.Contributors)
+			assert.Equal(t, tc.expectedStats.BusFactor, returned.BusFactor)
+			assert.Equal(t, tc.expectedStats.TopContributorShare, returned.TopContributorShare)
+			assert.Equal(t, tc.expectedStats.Churn, returned.Churn)
+			logins := make([]string, 0, len(returned.TopContributors))
+			for _, c := range returned.TopContributors {
+				logins = append(logins, c.Login)
+			}
+			// Ties keep the order of the API
+			assert.Equal(t, tc.expectedLogins, logins)
+		})
+	}
+
+	t.Run("repository not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposStatsContributorsByOwnerByRepo,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := GetContributorsStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get contributors statistics")
+	})
+}
+
+func Test_GetCommitActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_activity", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	defer func(delay time.Duration) { repoStatsRetryDelay = delay }(repoStatsRetryDelay)
+	repoStatsRetryDelay = 0
+
+	activity := []*github.WeeklyCommitActivity{
+		{Week: &github.Timestamp{Time: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)}, Total: github.Ptr(0), Days: []int{0, 0, 0, 0, 0, 0, 0}},
+		{Week: &github.Timestamp{Time: time.Date(2024, 5, 26, 0, 0, 0, 0, time.UTC)}, Total: github.Ptr(9), Days: []int{0, 4, 2, 0, 3, 0, 0}},
+		{Week: &github.Timestamp{Time: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)}, Total: github.Ptr(3), Days: []int{1, 1, 0, 0, 0, 1, 0}},
+	}
+
+	t.Run("summarizes recent weeks", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposStatsCommitActivityByOwnerByRepo, statsHandler(t, 1, activity)),
+		))
+		_, handler := GetCommitActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+			"weeks": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned CommitActivity
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.Equal(t, 12, returned.TotalCommits)
+		assert.Equal(t, 6.0, returned.AveragePerWeek)
+		assert.Equal(t, 2, returned.ActiveWeeks)
+		require.NotNil(t, returned.BusiestWeek)
+		assert.Equal(t, "2024-05-26", returned.BusiestWeek.Week)
+		assert.Equal(t, 5, returned.CommitsPerDay["Monday"])
+		assert.Equal(t, 1, returned.CommitsPerDay["Sunday"])
+		require.Len(t, returned.Weeks, 2)
+		assert.Equal(t, "2024-06-02", returned.Weeks[1].Week)
+	})
+
+	t.Run("still computing", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposStatsCommitActivityByOwnerByRepo, statsHandler(t, repoStatsAttempts, activity)),
+		))
+		_, handler := GetCommitActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "GitHub is computing the statistics of owner/repo")
+	})
+}
+
+func Test_BucketStars(t *testing.T) {
+	starredAt := []time.Time{
+		time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, []StarBucket{
+		{Start: "2024-01-01", Stars: 2, Cumulative: 12},
+		{Start: "2024-02-01", Stars: 0, Cumulative: 12},
+		{Start: "2024-03-01", Stars: 2, Cumulative: 14},
+	}, bucketStars(starredAt, "month", 14))
+
+	weeks := bucketStars(starredAt[:2], "week", 2)
+	require.Len(t, weeks, 4)
+	// 2024-03-02 is a Saturday, its week starts on Monday 2024-02-26
+	assert.Equal(t, StarBucket{Start: "2024-02-26", Stars: 1, Cumulative: 1}, weeks[0])
+	assert.Equal(t, StarBucket{Start: "2024-03-18", Stars: 1, Cumulative: 2}, weeks[3])
+
+	assert.Empty(t, bucketStars(nil, "month", 0))
+}
+
+func Test_GetStargazerTimeline(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetStargazerTimeline(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_stargazer_timeline", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	page := func(hasNextPage bool, endCursor string, starredAt ...string) githubv4mock.GQLResponse {
+		edges := make([]any, 0, len(starredAt))
+		for _, s := range starredAt {
+			edges = append(edges, map[string]any{"starredAt": s})
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"stargazers": map[string]any{
+					"totalCount": 10,
+					"edges":      edges,
+					"pageInfo":   map[string]any{"hasNextPage": hasNextPage, "endCursor": endCursor},
+				},
+			},
+		})
+	}
+	pageMatcher := func(after string, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		matcher := githubv4mock.NewQueryMatcher(stargazersQuery{}, map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"after": githubv4.NewString(githubv4.String(after)),
+		}, response)
+		// The cursor is nullable, but arrives as a plain value.
+		matcher.Variables["after"] = nil
+		if after != "" {
+			matcher.Variables["after"] = after
+		}
+		return matcher
+	}
+	matchers := func() *http.Client {
+		return githubv4mock.NewMockedHTTPClient(
+			pageMatcher("", page(true, "cursor1", "2024-03-20T10:00:00Z", "2024-03-02T10:00:00Z")),
+			pageMatcher("cursor1", page(true, "cursor2", "2024-01-31T10:00:00Z", "2023-12-01T10:00:00Z")),
+		)
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedTimeline StargazerTimeline
+	}{
+		{
+			name: "stars since",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-01-01",
+			},
+			expectedTimeline: StargazerTimeline{
+				TotalStars: 10,
+				Interval:   "month",
+				Analyzed:   3,
+				Buckets: []StarBucket{
+					{Start: "2024-01-01", Stars: 1, Cumulative: 8},
+					{Start: "2024-02-01", Stars: 0, Cumulative: 8},
+					{Start: "2024-03-01", Stars: 2, Cumulative: 10},
+				},
+			},
+		},
+		{
+			name: "truncated",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"interval":  "week",
+				"max_stars": float64(2),
+			},
+			expectedTimeline: StargazerTimeline{
+				TotalStars: 10,
+				Interval:   "week",
+				Analyzed:   2,
+				Truncated:  true,
+				Buckets: []StarBucket{
+					{Start: "2024-02-26", Stars: 1, Cumulative: 9},
+					{Start: "2024-03-04", Stars: 0, Cumulative: 9},
+					{Start: "2024-03-11", Stars: 0, Cumulative: 9},
+					{Start: "2024-03-18", Stars: 1, Cumulative: 10},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetStargazerTimeline(stubGetGQLClientFn(githubv4.NewClient(matchers())), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var timeline StargazerTimeline
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &timeline))
+			assert.Equal(t, tc.expectedTimeline, timeline)
+		})
+	}
+}
+
+func Test_GetRepoLanguages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoLanguages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_languages", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		expectError       bool
+		expectedErrMsg    string
+		expectedLanguages []LanguageShare
+	}{
+		{
+			name: "languages largest first",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLanguagesByOwnerByRepo,
+					map[string]int{"Shell": 250, "Go": 9000, "Makefile": 250, "Dockerfile": 500},
+				),
+			),
+			expectedLanguages: []LanguageShare{
+				{Name: "Go", Bytes: 9000, Percent: 90},
+				{Name: "Dockerfile", Bytes: 500, Percent: 5},
+				{Name: "Makefile", Bytes: 250, Percent: 2.5},
+				{Name: "Shell", Bytes: 250, Percent: 2.5},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLanguagesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository languages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoLanguages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				TotalBytes int             `json:"total_bytes"`
+				Languages  []LanguageShare `json:"languages"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, 10000, response.TotalBytes)
+			assert.Equal(t, tc.expectedLanguages, response.Languages)
+		})
+	}
+}
+
+func Test_AggregateOrgLanguages(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := AggregateOrgLanguages(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "aggregate_org_languages", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	repository := func(fork, archived bool, primary string, languages map[string]int, topics ...string) map[string]any {
+		edges := []any{}
+		for name, size := range languages {
+			edges = append(edges, map[string]any{"size": size, "node": map[string]any{"name": name}})
+		}
+		topicNodes := []any{}
+		for _, topic := range topics {
+			topicNodes = append(topicNodes, map[string]any{"topic": map[string]any{"name": topic}})
+		}
+		var primaryLanguage any
+		if primary != "" {
+			primaryLanguage = map[string]any{"name": primary}
+		}
+		return map[string]any{
+			"isFork":           fork,
+			"isArchived":       archived,
+			"primaryLanguage":  primaryLanguage,
+			"languages":        map[string]any{"edges": edges},
+			"repositoryTopics": map[string]any{"nodes": topicNodes},
+		}
+	}
+	page := func(hasNextPage bool, endCursor string, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"repositories": map[string]any{
+					"totalCount": 4,
+					"nodes":      nodes,
+					"pageInfo":   map[string]any{"hasNextPage": hasNextPage, "endCursor": endCursor},
+				},
+			},
+		})
+	}
+	secondPage := githubv4mock.NewQueryMatcher(orgLanguagesQuery{}, map[string]any{
+		"org":   githubv4.String("octo-org"),
+		"after": githubv4.NewString("cursor1"),
+	}, page(false, "cursor2",
+		repository(false, true, "Python", map[string]int{"Python": 3000}),
+		repository(false, false, "TypeScript", map[string]int{"TypeScript": 1000}, "mcp"),
+	))
+	// The cursor is nullable, but arrives as a plain value.
+	secondPage.Variables["after"] = "cursor1"
+	mockedClient := func() *http.Client {
+		return githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(orgLanguagesQuery{}, map[string]any{
+				"org":   githubv4.String("octo-org"),
+				"after": (*githubv4.String)(nil),
+			}, page(true, "cursor1",
+				repository(false, false, "Go", map[string]int{"Go": 800, "Shell": 200}, "cli", "mcp"),
+				repository(true, false, "Go", map[string]int{"Go": 5000}, "cli"),
+			)),
+			secondPage,
+		)
+	}
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]interface{}
+		expected    OrgLanguages
+	}{
+		{
+			name:        "sources only",
+			requestArgs: map[string]interface{}{"org": "octo-org"},
+			expected: OrgLanguages{
+				Org:           "octo-org",
+				TotalRepos:    4,
+				AnalyzedRepos: 2,
+				TotalBytes:    2000,
+				Languages: []LanguageShare{
+					{Name: "TypeScript", Bytes: 1000, Percent: 50, Repos: 1},
+					{Name: "Go", Bytes: 800, Percent: 40, Repos: 1},
+					{Name: "Shell", Bytes: 200, Percent: 10, Repos: 1},
+				},
+				PrimaryLanguages: map[string]int{"Go": 1, "TypeScript": 1},
+				Topics:           []TopicCount{{Name: "mcp", Repos: 2}, {Name: "cli", Repos: 1}},
+			},
+		},
+		{
+			name: "forks included and truncated",
+			requestArgs: map[string]interface{}{
+				"org":           "octo-org",
+				"include_forks": true,
+				"max_repos":     float64(2),
+			},
+			expected: OrgLanguages{
+				Org:           "octo-org",
+				TotalRepos:    4,
+				AnalyzedRepos: 2,
+				Truncated:     true,
+				TotalBytes:    6000,
+				Languages: []LanguageShare{
+					{Name: "Go", Bytes: 5800, Percent: 96.7, Repos: 2},
+					{Name: "Shell", Bytes: 200, Percent: 3.3, Repos: 1},
+				},
+				PrimaryLanguages: map[string]int{"Go": 2},
+				Topics:           []TopicCount{{Name: "cli", Repos: 2}, {Name: "mcp", Repos: 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := AggregateOrgLanguages(stubGetGQLClientFn(githubv4.NewClient(mockedClient())), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			var response OrgLanguages
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+
+	t.Run("organization not found", func(t *testing.T) {
+		client := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(orgLanguagesQuery{}, map[string]any{
+				"org":   githubv4.String("missing"),
+				"after": (*githubv4.String)(nil),
+			}, githubv4mock.ErrorResponse("Could not resolve to an Organization with the login of 'missing'.")),
+		)
+		_, handler := AggregateOrgLanguages(stubGetGQLClientFn(githubv4.NewClient(client)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"org": "missing"}))
+		require.NoError(t, err)
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "failed to list organization repositories")
+	})
+}
@@ -0,0 +1,268 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// maxCachedNodeIDs bounds the number of node IDs kept by nodeIDs. The cache is simply emptied when
+// it is full, as lookups are cheap to repeat.
+const maxCachedNodeIDs = 10000
+
+// nodeIDCache remembers the GraphQL node IDs of owners, repositories, issues, pull requests and
+// projects, so that tools can accept the human-readable identifiers users know, such as a login or
+// a repository name and issue number, without resolving them on every call. Entries are keyed by
+// client, since node IDs are only meaningful for the host a client talks to. It is safe for
+// concurrent use.
+type nodeIDCache struct {
+	mu  sync.Mutex
+	ids map[nodeIDKey]githubv4.ID
+}
+
+type nodeIDKey struct {
+	client *githubv4.Client
+	key    string
+}
+
+var nodeIDs = &nodeIDCache{ids: make(map[nodeIDKey]githubv4.ID)}
+
+// resolve returns the cached ID for key, or looks it up and caches it.
+func (c *nodeIDCache) resolve(client *githubv4.Client, key string, lookup func() (githubv4.ID, error)) (githubv4.ID, error) {
+	k := nodeIDKey{client: client, key: key}
+
+	c.mu.Lock()
+	id, ok := c.ids[k]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	id, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	if id == nil || id == "" {
+		return nil, fmt.Errorf("could not resolve %s", key)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.ids) >= maxCachedNodeIDs {
+		clear(c.ids)
+	}
+	c.ids[k] = id
+	return id, nil
+}
+
+// getOwnerID resolves the node ID of a user or organization, as required by the Projects GraphQL API.
+func getOwnerID(ctx context.Context, client *githubv4.Client, owner, ownerType string) (githubv4.ID, error) {
+	return nodeIDs.resolve(client, fmt.Sprintf("%s %s", ownerType, strings.ToLower(owner)), func() (githubv4.ID, error) {
+		vars := map[string]any{
+			"login": githubv4.String(owner),
+		}
+
+		if ownerType == "org" {
+			var query struct {
+				Organization struct {
+					ID githubv4.ID
+				} `graphql:"organization(login: $login)"`
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return nil, err
+			}
+			return query.Organization.ID, nil
+		}
+
+		var query struct {
+			User struct {
+				ID githubv4.ID
+			} `graphql:"user(login: $login)"`
+		}
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+		return query.User.ID, nil
+	})
+}
+
+// ownerTypes caches whether a login belongs to a user ("user") or an organization ("org"). It reuses
+// the node ID cache, whose values are only compared against the empty string.
+var ownerTypes = &nodeIDCache{ids: make(map[nodeIDKey]githubv4.ID)}
+
+// getOwnerType reports whether owner is a user or an organization, for tools that accept either
+// without being told which one.
+func getOwnerType(ctx context.Context, client *githubv4.Client, owner string) (string, error) {
+	ownerType, err := ownerTypes.resolve(client, fmt.Sprintf("owner %s", strings.ToLower(owner)), func() (githubv4.ID, error) {
+		var query struct {
+			RepositoryOwner struct {
+				Typename githubv4.String `graphql:"__typename"`
+			} `graphql:"repositoryOwner(login: $login)"`
+		}
+		vars := map[string]any{
+			"login": githubv4.String(owner),
+		}
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+		switch query.RepositoryOwner.Typename {
+		case "Organization":
+			return "org", nil
+		case "User":
+			return "user", nil
+		default:
+			return "", nil
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	return ownerType.(string), nil
+}
+
+// detectOwner resolves the node ID of a login that may belong to a user or an organization, and
+// reports which of the two it is. Both lookups are issued concurrently, as at most one of them can
+// succeed.
+func detectOwner(ctx context.Context, client *githubv4.Client, owner string) (ownerType string, id githubv4.ID, err error) {
+	ownerTypes := []string{"user", "org"}
+	ids := make([]githubv4.ID, len(ownerTypes))
+	errs := make([]error, len(ownerTypes))
+
+	var wg sync.WaitGroup
+	for i, ownerType := range ownerTypes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = getOwnerID(ctx, client, owner, ownerType)
+		}()
+	}
+	wg.Wait()
+
+	for i, ownerType := range ownerTypes {
+		if errs[i] == nil {
+			return ownerType, ids[i], nil
+		}
+	}
+	return "", nil, fmt.Errorf("could not resolve %s to a user or organization: %w", owner, errors.Join(errs...))
+}
+
+// getRepositoryID resolves the node ID of a repository.
+func getRepositoryID(ctx context.Context, client *githubv4.Client, owner, repo string) (githubv4.ID, error) {
+	return nodeIDs.resolve(client, fmt.Sprintf("repository %s/%s", strings.ToLower(owner), strings.ToLower(repo)), func() (githubv4.ID, error) {
+		var query struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		vars := map[string]any{
+			"owner": githubv4.String(owner),
+			"repo":  githubv4.String(repo),
+		}
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+		return query.Repository.ID, nil
+	})
+}
+
+// getIssueOrPullRequestID resolves the node ID of an issue or pull request by its number.
+func getIssueOrPullRequestID(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (githubv4.ID, error) {
+	return nodeIDs.resolve(client, fmt.Sprintf("issue %s/%s#%d", strings.ToLower(owner), strings.ToLower(repo), number), func() (githubv4.ID, error) {
+		var query struct {
+			Repository struct {
+				IssueOrPullRequest struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"... on Issue"`
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"... on PullRequest"`
+				} `graphql:"issueOrPullRequest(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		vars := map[string]any{
+			"owner":  githubv4.String(owner),
+			"repo":   githubv4.String(repo),
+			"number": githubv4.Int(number), // #nosec G115 - issue numbers are always small positive integers
+		}
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+		if id := query.Repository.IssueOrPullRequest.Issue.ID; id != nil {
+			return id, nil
+		}
+		return query.Repository.IssueOrPullRequest.PullRequest.ID, nil
+	})
+}
+
+// getProjectID resolves the node ID of a project owned by a user or organization.
+func getProjectID(ctx context.Context, client *githubv4.Client, owner, ownerType string, number int) (githubv4.ID, error) {
+	return nodeIDs.resolve(client, fmt.Sprintf("project %s %s/%d", ownerType, strings.ToLower(owner), number), func() (githubv4.ID, error) {
+		project, err := queryOwnerProject[struct{ ID githubv4.ID }](ctx, client, owner, ownerType, number, nil)
+		if err != nil {
+			return nil, err
+		}
+		return project.ID, nil
+	})
+}
+
+// getProjectItemID finds the item of an issue or pull request in a project. Item IDs are not
+// cached, as an item gets a new ID when it is removed from a project and added again.
+func getProjectItemID(ctx context.Context, client *githubv4.Client, projectID githubv4.ID, owner, repo string, number int) (githubv4.ID, error) {
+	contentID, err := getIssueOrPullRequestID(ctx, client, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	type projectItems struct {
+		Nodes []struct {
+			ID      githubv4.ID
+			Project struct {
+				ID githubv4.ID
+			}
+		}
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   githubv4.String
+		}
+	}
+	var query struct {
+		Node struct {
+			Issue struct {
+				ProjectItems projectItems `graphql:"projectItems(first: 100, after: $after, includeArchived: true)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ProjectItems projectItems `graphql:"projectItems(first: 100, after: $after, includeArchived: true)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"node(id: $id)"`
+	}
+	vars := map[string]any{
+		"id":    contentID,
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+
+		// Only the fragment matching the type of the content is filled in
+		page := query.Node.Issue.ProjectItems
+		if len(page.Nodes) == 0 {
+			page = query.Node.PullRequest.ProjectItems
+		}
+		for _, item := range page.Nodes {
+			if fmt.Sprint(item.Project.ID) == fmt.Sprint(projectID) {
+				return item.ID, nil
+			}
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = page.PageInfo.EndCursor
+	}
+	return nil, fmt.Errorf("%s/%s#%d is not in the project", owner, repo, number)
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	transport http.RoundTripper
+	requests  int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests++
+	return c.transport.RoundTrip(req)
+}
+
+func Test_NodeIDCache(t *testing.T) {
+	repositoryMatcher := func(id string) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("octo-org"),
+				"repo":  githubv4.String("octo-repo"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"id": id},
+			}),
+		)
+	}
+
+	httpClient := githubv4mock.NewMockedHTTPClient(repositoryMatcher("R_1"))
+	counter := &countingTransport{transport: httpClient.Transport}
+	client := githubv4.NewClient(&http.Client{Transport: counter})
+
+	id, err := getRepositoryID(context.Background(), client, "octo-org", "octo-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "R_1", id)
+
+	// Lookups are cached, case-insensitively
+	id, err = getRepositoryID(context.Background(), client, "Octo-Org", "Octo-Repo")
+	require.NoError(t, err)
+	assert.Equal(t, "R_1", id)
+	assert.Equal(t, 1, counter.requests)
+
+	// Another client has a cache of its own
+	other := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(repositoryMatcher("R_2")))
+	id, err = getRepositoryID(context.Background(), other, "octo-org", "octo-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "R_2", id)
+
+	t.Run("failed lookups are not cached", func(t *testing.T) {
+		failing := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("octo-org"),
+				"repo":  githubv4.String("missing"),
+			},
+			githubv4mock.DataResponse(map[string]any{"repository": nil}),
+		)))
+		_, err := getRepositoryID(context.Background(), failing, "octo-org", "missing")
+		assert.ErrorContains(t, err, "could not resolve repository octo-org/missing")
+		assert.NotContains(t, nodeIDs.ids, nodeIDKey{client: failing, key: "repository octo-org/missing"})
+	})
+}
+
+func repositoryOwnerMatcher(login, typename string) githubv4mock.Matcher {
+	owner := map[string]any{"__typename": typename}
+	if typename == "" {
+		owner = nil
+	}
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			RepositoryOwner struct {
+				Typename githubv4.String `graphql:"__typename"`
+			} `graphql:"repositoryOwner(login: $login)"`
+		}{},
+		map[string]any{
+			"login": githubv4.String(login),
+		},
+		githubv4mock.DataResponse(map[string]any{"repositoryOwner": owner}),
+	)
+}
+
+func Test_GetOwnerType(t *testing.T) {
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		repositoryOwnerMatcher("octo-org", "Organization"),
+		repositoryOwnerMatcher("octocat", "User"),
+		repositoryOwnerMatcher("ghost", ""),
+	)
+	counter := &countingTransport{transport: httpClient.Transport}
+	client := githubv4.NewClient(&http.Client{Transport: counter})
+
+	ownerType, err := getOwnerType(context.Background(), client, "octo-org")
+	require.NoError(t, err)
+	assert.Equal(t, "org", ownerType)
+
+	ownerType, err = getOwnerType(context.Background(), client, "octocat")
+	require.NoError(t, err)
+	assert.Equal(t, "user", ownerType)
+
+	// Owner types are cached
+	ownerType, err = getOwnerType(context.Background(), client, "Octo-Org")
+	require.NoError(t, err)
+	assert.Equal(t, "org", ownerType)
+	assert.Equal(t, 2, counter.requests)
+
+	_, err = getOwnerType(context.Background(), client, "ghost")
+	assert.ErrorContains(t, err, "could not resolve owner ghost")
+}
@@ -0,0 +1,446 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// maxSuggestReviewersFiles is the number of changed files of a pull request that are considered at most.
+	maxSuggestReviewersFiles = 300
+	// maxSuggestReviewersBlameFiles is the number of most changed files whose blame is read.
+	maxSuggestReviewersBlameFiles = 5
+	// maxSuggestReviewersLoadChecks is the number of best candidates whose review load is looked up.
+	maxSuggestReviewersLoadChecks = 10
+)
+
+// ReviewerSuggestion is a proposed reviewer of a pull request and why they are proposed.
+type ReviewerSuggestion struct {
+	Reviewer         string   `json:"reviewer"`
+	Type             string   `json:"type"`
+	Score            int      `json:"score"`
+	Reasons          []string `json:"reasons"`
+	OwnedFiles       int      `json:"owned_files,omitempty"`
+	BlamedLines      int      `json:"blamed_lines,omitempty"`
+	PendingReviews   *int     `json:"pending_reviews,omitempty"`
+	AlreadyRequested bool     `json:"already_requested,omitempty"`
+
+	blamedFiles int
+}
+
+// score ranks candidates: code ownership weighs most, then having written the changed code, and
+// every review already waiting on the candidate counts against them.
+func (s *ReviewerSuggestion) score() int {
+	score := 3*s.OwnedFiles + 2*s.blamedFiles
+	if s.PendingReviews != nil {
+		score -= *s.PendingReviews
+	}
+	return score
+}
+
+// SuggestReviewers creates a tool to propose reviewers for a pull request from CODEOWNERS, the
+// blame of the changed files and the review load of the candidates.
+func SuggestReviewers(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_reviewers",
+			mcp.WithDescription(t("TOOL_SUGGEST_REVIEWERS_DESCRIPTION", "Propose reviewers for a pull request, with the reasons for each: code owners of the changed files, the authors of the code the pull request changes, according to the blame of its most changed files, and the number of open pull requests already waiting on their review. The pull request author and bots are never proposed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUGGEST_REVIEWERS_USER_TITLE", "Suggest pull request reviewers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of reviewers to propose"),
+				mcp.Min(1),
+				mcp.Max(20),
+				mcp.DefaultNumber(5),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 5)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			author := pr.GetUser().GetLogin()
+
+			var files []*github.CommitFile
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list pull request files", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				files = append(files, page...)
+				if resp.NextPage == 0 || len(files) >= maxSuggestReviewersFiles {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			candidates := map[string]*ReviewerSuggestion{}
+			candidate := func(reviewer, kind string) *ReviewerSuggestion {
+				key := strings.ToLower(reviewer)
+				if candidates[key] == nil {
+					candidates[key] = &ReviewerSuggestion{Reviewer: reviewer, Type: kind}
+				}
+				return candidates[key]
+			}
+			eligible := func(login string) bool {
+				return login != "" && !strings.EqualFold(login, author) && !strings.HasSuffix(login, "[bot]")
+			}
+
+			// The base branch decides who owns the files and holds the code the pull request changes
+			codeownersPath, content, resp, err := getCodeownersFile(ctx, client, owner, repo, pr.GetBase().GetRef())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS file", resp, err), nil
+			}
+			if codeownersPath != "" {
+				rules := parseCodeowners(content)
+				for _, file := range files {
+					for _, o := range codeownersFor(rules, file.GetFilename()).Owners {
+						// Owners given by email cannot be requested as reviewers
+						if !strings.HasPrefix(o, "@") {
+							continue
+						}
+						name := strings.TrimPrefix(o, "@")
+						kind := "user"
+						if strings.Contains(name, "/") {
+							kind = "team"
+						} else if !eligible(name) {
+							continue
+						}
+						candidate(name, kind).OwnedFiles++
+					}
+				}
+			}
+
+			blamed := make([]*github.CommitFile, 0, len(files))
+			for _, file := range files {
+				// Added files have no history to blame
+				if file.GetStatus() != "added" {
+					blamed = append(blamed, file)
+				}
+			}
+			sort.SliceStable(blamed, func(i, j int) bool { return blamed[i].GetChanges() > blamed[j].GetChanges() })
+			if len(blamed) > maxSuggestReviewersBlameFiles {
+				blamed = blamed[:maxSuggestReviewersBlameFiles]
+			}
+			blamedPaths := make([]string, 0, len(blamed))
+			for _, file := range blamed {
+				path := file.GetFilename()
+				if file.GetPreviousFilename() != "" {
+					path = file.GetPreviousFilename()
+				}
+				var query BlameQuery
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"ref":   githubv4.String(pr.GetBase().GetSHA()),
+					"path":  githubv4.String(path),
+				}
+				if err := gqlClient.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to get blame for %s", path), err), nil
+				}
+				commit, err := query.commit(pr.GetBase().GetSHA())
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				blamedPaths = append(blamedPaths, path)
+				lines := map[string]int{}
+				for _, r := range commit.Blame.Ranges {
+					login := string(r.Commit.Author.User.Login)
+					if eligible(login) {
+						lines[login] += int(r.EndingLine-r.StartingLine) + 1
+					}
+				}
+				for login, n := range lines {
+					c := candidate(login, "user")
+					c.BlamedLines += n
+					c.blamedFiles++
+				}
+			}
+
+			suggestions := make([]*ReviewerSuggestion, 0, len(candidates))
+			for _, c := range candidates {
+				suggestions = append(suggestions, c)
+			}
+			sortSuggestions := func() {
+				sort.Slice(suggestions, func(i, j int) bool {
+					if suggestions[i].score() != suggestions[j].score() {
+						return suggestions[i].score() > suggestions[j].score()
+					}
+					return strings.ToLower(suggestions[i].Reviewer) < strings.ToLower(suggestions[j].Reviewer)
+				})
+			}
+			sortSuggestions()
+
+			checked := 0
+			for _, s := range suggestions {
+				if checked == maxSuggestReviewersLoadChecks {
+					break
+				}
+				if s.Type != "user" {
+					continue
+				}
+				checked++
+				query := fmt.Sprintf("is:pr is:open review-requested:%s user:%s", s.Reviewer, owner)
+				result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get review load of %s", s.Reviewer), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				pending := result.GetTotal()
+				s.PendingReviews = &pending
+			}
+			sortSuggestions()
+
+			requested := map[string]bool{}
+			for _, u := range pr.RequestedReviewers {
+				requested[strings.ToLower(u.GetLogin())] = true
+			}
+			for _, team := range pr.RequestedTeams {
+				requested[strings.ToLower(owner+"/"+team.GetSlug())] = true
+			}
+			if len(suggestions) > limit {
+				suggestions = suggestions[:limit]
+			}
+			for _, s := range suggestions {
+				s.Score = s.score()
+				s.AlreadyRequested = requested[strings.ToLower(s.Reviewer)]
+				s.Reasons = []string{}
+				if s.OwnedFiles > 0 {
+					s.Reasons = append(s.Reasons, fmt.Sprintf("code owner of %d changed files", s.OwnedFiles))
+				}
+				if s.BlamedLines > 0 {
+					s.Reasons = append(s.Reasons, fmt.Sprintf("last changed %d lines of %d of the most changed files", s.BlamedLines, s.blamedFiles))
+				}
+				if s.PendingReviews != nil && *s.PendingReviews > 0 {
+					s.Reasons = append(s.Reasons, fmt.Sprintf("already has %d open pull requests awaiting their review", *s.PendingReviews))
+				}
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"suggestions":     suggestions,
+				"codeowners_path": codeownersPath,
+				"files_analyzed":  len(files),
+				"blamed_files":    blamedPaths,
+			}), nil
+		}
+}
+
+// ReviewRequestDigestEntry is a pull request awaiting review, as listed by get_review_requests_digest.
+type ReviewRequestDigestEntry struct {
+	Repository   string    `json:"repository"`
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	Author       string    `json:"author,omitempty"`
+	IsDraft      bool      `json:"is_draft,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	AgeDays      int       `json:"age_days"`
+	Additions    int       `json:"additions"`
+	Deletions    int       `json:"deletions"`
+	ChangedFiles int       `json:"changed_files"`
+	Size         string    `json:"size"`
+}
+
+// ReviewRequestsDigestQuery searches for open pull requests and selects their age and size.
+type ReviewRequestsDigestQuery struct {
+	Search struct {
+		IssueCount githubv4.Int
+		Nodes      []struct {
+			PullRequest struct {
+				Number       githubv4.Int
+				Title        githubv4.String
+				URL          githubv4.String
+				IsDraft      githubv4.Boolean
+				CreatedAt    githubv4.DateTime
+				UpdatedAt    githubv4.DateTime
+				Additions    githubv4.Int
+				Deletions    githubv4.Int
+				ChangedFiles githubv4.Int
+				Author       struct {
+					Login githubv4.String
+				}
+				Repository struct {
+					NameWithOwner githubv4.String
+				}
+			} `graphql:"... on PullRequest"`
+		}
+	} `graphql:"search(query: $query, type: ISSUE, first: $first)"`
+}
+
+// pullRequestSize buckets a pull request by the number of changed lines, like common size labels.
+func pullRequestSize(additions, deletions int) string {
+	switch lines := additions + deletions; {
+	case lines < 10:
+		return "XS"
+	case lines < 100:
+		return "S"
+	case lines < 500:
+		return "M"
+	case lines < 1000:
+		return "L"
+	default:
+		return "XL"
+	}
+}
+
+// GetReviewRequestsDigest creates a tool to list the open pull requests awaiting the review of
+// the current user or of a team, across repositories.
+func GetReviewRequestsDigest(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_review_requests_digest",
+			mcp.WithDescription(t("TOOL_GET_REVIEW_REQUESTS_DIGEST_DESCRIPTION", "Get a digest of the open pull requests awaiting the review of the authenticated user, or of a team, across all repositories: oldest first, with their age and size, and counts per repository and per size. Use it to write a daily summary of pending reviews.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REVIEW_REQUESTS_DIGEST_USER_TITLE", "Get review requests digest"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("team",
+				mcp.Description("Team whose review requests to list, as org/team-slug. Defaults to the review requests of the authenticated user"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Only list pull requests in repositories of this user or organization"),
+			),
+			mcp.WithBoolean("include_drafts",
+				mcp.Description("Include draft pull requests"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of pull requests to list"),
+				mcp.Min(1),
+				mcp.Max(100),
+				mcp.DefaultNumber(50),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			team, err := OptionalParam[string](request, "team")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeDrafts, err := OptionalParam[bool](request, "include_drafts")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 50)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := "is:pr is:open archived:false sort:created-asc"
+			if team != "" {
+				if org, slug, ok := strings.Cut(team, "/"); !ok || org == "" || slug == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid team %q, expected org/team-slug", team)), nil
+				}
+				query += " team-review-requested:" + team
+			} else {
+				query += " review-requested:@me"
+			}
+			if owner != "" {
+				query += " user:" + owner
+			}
+			if !includeDrafts {
+				query += " draft:false"
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q ReviewRequestsDigestQuery
+			vars := map[string]any{
+				"query": githubv4.String(query),
+				"first": githubv4.Int(limit), // #nosec G115 - limit is at most 100
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to search review requests", err), nil
+			}
+
+			now := time.Now()
+			entries := make([]ReviewRequestDigestEntry, 0, len(q.Search.Nodes))
+			perRepository := map[string]int{}
+			perSize := map[string]int{}
+			for _, node := range q.Search.Nodes {
+				pr := node.PullRequest
+				entry := ReviewRequestDigestEntry{
+					Repository:   string(pr.Repository.NameWithOwner),
+					Number:       int(pr.Number),
+					Title:        string(pr.Title),
+					URL:          string(pr.URL),
+					Author:       string(pr.Author.Login),
+					IsDraft:      bool(pr.IsDraft),
+					CreatedAt:    pr.CreatedAt.Time,
+					UpdatedAt:    pr.UpdatedAt.Time,
+					AgeDays:      int(now.Sub(pr.CreatedAt.Time).Hours() / 24),
+					Additions:    int(pr.Additions),
+					Deletions:    int(pr.Deletions),
+					ChangedFiles: int(pr.ChangedFiles),
+					Size:         pullRequestSize(int(pr.Additions), int(pr.Deletions)),
+				}
+				entries = append(entries, entry)
+				perRepository[entry.Repository]++
+				perSize[entry.Size]++
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"total_count":    int(q.Search.IssueCount),
+				"pull_requests":  entries,
+				"per_repository": perRepository,
+				"per_size":       perSize,
+				"query":          query,
+			}), nil
+		}
+}
@@ -0,0 +1,346 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SuggestReviewers(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := SuggestReviewers(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "suggest_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pr := &github.PullRequest{
+		Number:             github.Ptr(42),
+		User:               &github.User{Login: github.Ptr("author")},
+		Base:               &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("base123")},
+		RequestedReviewers: []*github.User{{Login: github.Ptr("bob")}},
+	}
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("src/app.js"), Status: github.Ptr("modified"), Changes: github.Ptr(50)},
+		{Filename: github.Ptr("docs/intro.md"), Status: github.Ptr("modified"), Changes: github.Ptr(10)},
+		{Filename: github.Ptr("new.go"), Status: github.Ptr("added"), Changes: github.Ptr(100)},
+	}
+	codeowners := "*.js @js-owner @author\n/docs/ @octo-org/docs docs@example.com\n"
+	pendingReviews := map[string]int{"js-owner": 4, "alice": 0, "bob": 1}
+
+	restClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+			mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, files),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "main", r.URL.Query().Get("ref"))
+					if !strings.HasSuffix(r.URL.Path, "/contents/.github/CODEOWNERS") {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(codeowners))),
+					})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var login string
+					for _, term := range strings.Fields(r.URL.Query().Get("q")) {
+						if v, ok := strings.CutPrefix(term, "review-requested:"); ok {
+							login = v
+						}
+					}
+					assert.Contains(t, r.URL.Query().Get("q"), "user:owner")
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(pendingReviews[login])})(w, r)
+				}),
+			),
+		)
+	}
+
+	blameRange := func(start, end int, login string) map[string]any {
+		return map[string]any{
+			"startingLine": start,
+			"endingLine":   end,
+			"age":          1,
+			"commit": map[string]any{
+				"oid":             "abc",
+				"url":             "https://github.com/owner/repo/commit/abc",
+				"committedDate":   "2024-05-01T10:00:00Z",
+				"messageHeadline": "Change",
+				"author": map[string]any{
+					"name":  login,
+					"email": login + "@example.com",
+					"user":  map[string]any{"login": login},
+				},
+			},
+		}
+	}
+	blameMatcher := func(path string, ranges ...any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(BlameQuery{}, map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"ref":   githubv4.String("base123"),
+			"path":  githubv4.String(path),
+		}, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"object": map[string]any{
+					"__typename": "Commit",
+					"blame":      map[string]any{"ranges": ranges},
+				},
+			},
+		}))
+	}
+	gqlClient := func() *http.Client {
+		return githubv4mock.NewMockedHTTPClient(
+			blameMatcher("src/app.js", blameRange(1, 30, "alice"), blameRange(31, 40, "author"), blameRange(41, 45, "dependabot[bot]")),
+			blameMatcher("docs/intro.md", blameRange(1, 5, "alice"), blameRange(6, 10, "bob")),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		restClient     *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       []string
+	}{
+		{
+			name:       "ranks code owners and authors by score",
+			restClient: restClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expected: []string{"alice", "octo-org/docs", "bob", "js-owner"},
+		},
+		{
+			name:       "limit",
+			restClient: restClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"limit":      float64(2),
+			},
+			expected: []string{"alice", "octo-org/docs"},
+		},
+		{
+			name: "pull request not found",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SuggestReviewers(
+				stubGetClientFn(github.NewClient(tc.restClient)),
+				stubGetGQLClientFn(githubv4.NewClient(gqlClient())),
+				translations.NullTranslationHelper,
+			)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				Suggestions    []ReviewerSuggestion `json:"suggestions"`
+				CodeownersPath string               `json:"codeowners_path"`
+				FilesAnalyzed  int                  `json:"files_analyzed"`
+				BlamedFiles    []string             `json:"blamed_files"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, ".github/CODEOWNERS", got.CodeownersPath)
+			assert.Equal(t, 3, got.FilesAnalyzed)
+			assert.Equal(t, []string{"src/app.js", "docs/intro.md"}, got.BlamedFiles)
+
+			reviewers := make([]string, 0, len(got.Suggestions))
+			for _, s := range got.Suggestions {
+				reviewers = append(reviewers, s.Reviewer)
+			}
+			assert.Equal(t, tc.expected, reviewers)
+
+			alice := got.Suggestions[0]
+			assert.Equal(t, "user", alice.Type)
+			assert.Equal(t, 4, alice.Score)
+			assert.Equal(t, 35, alice.BlamedLines)
+			assert.Equal(t, []string{"last changed 35 lines of 2 of the most changed files"}, alice.Reasons)
+			team := got.Suggestions[1]
+			assert.Equal(t, "team", team.Type)
+			assert.Nil(t, team.PendingReviews)
+			assert.Equal(t, []string{"code owner of 1 changed files"}, team.Reasons)
+			if len(got.Suggestions) == 4 {
+				assert.True(t, got.Suggestions[2].AlreadyRequested)
+				jsOwner := got.Suggestions[3]
+				assert.Equal(t, -1, jsOwner.Score)
+				assert.Equal(t, []string{"code owner of 1 changed files", "already has 4 open pull requests awaiting their review"}, jsOwner.Reasons)
+			}
+		})
+	}
+}
+
+func Test_GetReviewRequestsDigest(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetReviewRequestsDigest(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_review_requests_digest", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	pullRequest := func(repo string, number int, age time.Duration, additions, deletions int) map[string]any {
+		created := time.Now().Add(-age).UTC().Format(time.RFC3339)
+		return map[string]any{
+			"number":       number,
+			"title":        "Change " + repo,
+			"url":          "https://github.com/" + repo + "/pull/1",
+			"isDraft":      false,
+			"createdAt":    created,
+			"updatedAt":    created,
+			"additions":    additions,
+			"deletions":    deletions,
+			"changedFiles": 2,
+			"author":       map[string]any{"login": "octocat"},
+			"repository":   map[string]any{"nameWithOwner": repo},
+		}
+	}
+	searchResponse := githubv4mock.DataResponse(map[string]any{
+		"search": map[string]any{
+			"issueCount": 3,
+			"nodes": []any{
+				pullRequest("acme/api", 12, 72*time.Hour, 400, 50),
+				pullRequest("acme/web", 3, 26*time.Hour, 5, 2),
+				pullRequest("acme/api", 15, time.Hour, 1200, 0),
+			},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		query          string
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:        "review requests of the current user",
+			requestArgs: map[string]any{},
+			query:       "is:pr is:open archived:false sort:created-asc review-requested:@me draft:false",
+			response:    searchResponse,
+		},
+		{
+			name: "review requests of a team in an organization, with drafts",
+			requestArgs: map[string]any{
+				"team":           "acme/backend",
+				"owner":          "acme",
+				"include_drafts": true,
+				"limit":          float64(10),
+			},
+			query:    "is:pr is:open archived:false sort:created-asc team-review-requested:acme/backend user:acme",
+			response: searchResponse,
+		},
+		{
+			name: "invalid team",
+			requestArgs: map[string]any{
+				"team": "backend",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid team \"backend\", expected org/team-slug",
+		},
+		{
+			name:           "search fails",
+			requestArgs:    map[string]any{},
+			query:          "is:pr is:open archived:false sort:created-asc review-requested:@me draft:false",
+			response:       githubv4mock.ErrorResponse("rate limited"),
+			expectError:    true,
+			expectedErrMsg: "failed to search review requests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, ok := tc.requestArgs["limit"].(float64)
+			if !ok {
+				limit = 50
+			}
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(ReviewRequestsDigestQuery{}, map[string]any{
+					"query": githubv4.String(tc.query),
+					"first": githubv4.Int(int(limit)),
+				}, tc.response),
+			)
+			_, handler := GetReviewRequestsDigest(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				TotalCount    int                        `json:"total_count"`
+				PullRequests  []ReviewRequestDigestEntry `json:"pull_requests"`
+				PerRepository map[string]int             `json:"per_repository"`
+				PerSize       map[string]int             `json:"per_size"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, 3, got.TotalCount)
+			require.Len(t, got.PullRequests, 3)
+			assert.Equal(t, 3, got.PullRequests[0].AgeDays)
+			assert.Equal(t, "M", got.PullRequests[0].Size)
+			assert.Equal(t, 1, got.PullRequests[1].AgeDays)
+			assert.Equal(t, "XS", got.PullRequests[1].Size)
+			assert.Equal(t, 0, got.PullRequests[2].AgeDays)
+			assert.Equal(t, "XL", got.PullRequests[2].Size)
+			assert.Equal(t, map[string]int{"acme/api": 2, "acme/web": 1}, got.PerRepository)
+			assert.Equal(t, map[string]int{"M": 1, "XS": 1, "XL": 1}, got.PerSize)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// impliedScopes lists the OAuth scopes that are granted implicitly by a broader classic token scope.
+// See https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org", "manage_runners:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"project":          {"read:project"},
+	"write:packages":   {"read:packages"},
+	"write:discussion": {"read:discussion"},
+}
+
+// scopeRequirement lists the classic token scopes the tools of a toolset need. Any one of them is sufficient.
+type scopeRequirement struct {
+	read  []string
+	write []string
+}
+
+var (
+	repoWriteScopes     = []string{"repo", "public_repo"}
+	securityAlertScopes = []string{"repo", "security_events"}
+	notificationScopes  = []string{"notifications", "repo"}
+	actionsPolicyScopes = []string{"repo", "public_repo", "admin:org"}
+)
+
+// toolsetScopes are the scopes the tools of a toolset need, split by whether the tool is read-only.
+// Toolsets whose tools work on public data without any scope are not listed.
+var toolsetScopes = map[string]scopeRequirement{
+	"repos":             {write: repoWriteScopes},
+	"issues":            {write: repoWriteScopes},
+	"pull_requests":     {write: repoWriteScopes},
+	"actions":           {write: repoWriteScopes},
+	"discussions":       {write: repoWriteScopes},
+	"code_security":     {read: securityAlertScopes, write: securityAlertScopes},
+	"secret_protection": {read: securityAlertScopes, write: securityAlertScopes},
+	"dependabot":        {read: securityAlertScopes, write: securityAlertScopes},
+	"notifications":     {read: notificationScopes, write: notificationScopes},
+	"gists":             {write: []string{"gist"}},
+	"projects":          {read: []string{"read:project"}, write: []string{"project"}},
+	"orgs":              {write: []string{"admin:org"}},
+}
+
+// toolScopes override toolsetScopes for tools that need something other than the rest of their toolset.
+// Every group must be satisfied by any one of its scopes.
+var toolScopes = map[string][][]string{
+	"get_teams":                  {{"read:org"}},
+	"get_team_members":           {{"read:org"}},
+	"list_public_ssh_keys":       {{"read:public_key"}},
+	"add_ssh_key":                {{"write:public_key"}},
+	"delete_ssh_key":             {{"admin:public_key"}},
+	"list_gpg_keys":              {{"read:gpg_key"}},
+	"list_org_hooks":             {{"admin:org_hook"}},
+	"list_org_installations":     {{"read:org"}},
+	"list_outside_collaborators": {{"read:org"}},
+	// Repository settings can only be read by admins, even for public repositories
+	"list_deploy_keys":            {repoWriteScopes},
+	"list_repository_invitations": {repoWriteScopes},
+	"list_autolinks":              {repoWriteScopes},
+	// Workflow files are pushed like any other file, which the workflow scope alone does not allow
+	"create_workflow": {repoWriteScopes, {"workflow"}},
+	// The policy of a repository needs repository access, the policy of an organization admin:org
+	"get_actions_permissions": {actionsPolicyScopes},
+	"set_actions_permissions": {actionsPolicyScopes},
+}
+
+// TokenInfo describes the token the server authenticates with.
+type TokenInfo struct {
+	Login string `json:"login"`
+	// Scopes is nil when the token does not report OAuth scopes, as is the case for fine-grained
+	// personal access tokens and GitHub App tokens, whose permissions cannot be inspected.
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ScopesKnown reports whether the scopes of the token could be determined.
+func (i *TokenInfo) ScopesKnown() bool {
+	return i.Scopes != nil
+}
+
+// HasScopes reports whether the token was granted one scope of every group of required.
+func (i *TokenInfo) HasScopes(required [][]string) bool {
+	for _, group := range required {
+		if !slices.ContainsFunc(group, i.HasScope) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasScope reports whether the token was granted scope, directly or through a broader scope.
+func (i *TokenInfo) HasScope(scope string) bool {
+	for _, granted := range i.Scopes {
+		if granted == scope {
+			return true
+		}
+		for _, implied := range impliedScopes[granted] {
+			if implied == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetTokenInfo fetches the authenticated user together with the scopes and expiry GitHub reports for the token.
+func GetTokenInfo(ctx context.Context, client *github.Client) (*TokenInfo, *github.Response, error) {
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	info := &TokenInfo{Login: user.GetLogin()}
+	if header, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		info.Scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				info.Scopes = append(info.Scopes, scope)
+			}
+		}
+	}
+	if expiration := resp.Header.Get("Github-Authentication-Token-Expiration"); expiration != "" {
+		// GitHub formats the expiry as "2006-01-02 15:04:05 -0700", older servers as "2006-01-02 15:04:05 MST"
+		for _, layout := range []string{"2006-01-02 15:04:05 -0700", "2006-01-02 15:04:05 MST"} {
+			if expiresAt, err := time.Parse(layout, expiration); err == nil {
+				info.ExpiresAt = &expiresAt
+				break
+			}
+		}
+	}
+	return info, resp, nil
+}
+
+// RequiredScopes returns the classic token scopes a tool needs, as groups that must each be satisfied
+// by any one of their scopes. It returns nil when the tool works without any particular scope.
+func RequiredScopes(toolset string, tool mcp.Tool) [][]string {
+	if scopes, ok := toolScopes[tool.Name]; ok {
+		return scopes
+	}
+	requirement := toolsetScopes[toolset]
+	scopes := requirement.write
+	if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+		scopes = requirement.read
+	}
+	if len(scopes) == 0 {
+		return nil
+	}
+	return [][]string{scopes}
+}
+
+// UnusableTool is a tool the token lacks the scopes for.
+type UnusableTool struct {
+	Tool           string     `json:"tool"`
+	Toolset        string     `json:"toolset"`
+	RequiredScopes [][]string `json:"required_scopes"`
+}
+
+// UnusableTools returns the active tools of tsg that will fail because the token lacks the scopes they need,
+// sorted by toolset and tool name. It returns nil when the scopes of the token are unknown.
+func UnusableTools(tsg *toolsets.ToolsetGroup, info *TokenInfo) []UnusableTool {
+	if !info.ScopesKnown() {
+		return nil
+	}
+
+	unusable := []UnusableTool{}
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			required := RequiredScopes(name, tool.Tool)
+			if len(required) == 0 {
+				continue
+			}
+			if !info.HasScopes(required) {
+				unusable = append(unusable, UnusableTool{
+					Tool:           tool.Tool.Name,
+					Toolset:        name,
+					RequiredScopes: required,
+				})
+			}
+		}
+	}
+	sort.Slice(unusable, func(i, j int) bool {
+		if unusable[i].Toolset != unusable[j].Toolset {
+			return unusable[i].Toolset < unusable[j].Toolset
+		}
+		return unusable[i].Tool < unusable[j].Tool
+	})
+	return unusable
+}
+
+// CheckAuth creates a tool to inspect the token the server authenticates with.
+func CheckAuth(getClient GetClientFn, tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_auth",
+			mcp.WithDescription(t("TOOL_CHECK_AUTH_DESCRIPTION", "Check the GitHub token the server authenticates with: the user it belongs to, its OAuth scopes, when it expires, and which of the enabled tools will fail because the token lacks a required scope. Use this to diagnose permission errors. Scopes can only be inspected for classic personal access tokens and OAuth tokens.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_AUTH_USER_TITLE", "Check authentication"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			info, resp, err := GetTokenInfo(ctx, client)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to authenticate with the GitHub API",
+					resp,
+					err,
+				), nil
+			}
+
+			result := map[string]any{
+				"login":        info.Login,
+				"scopes_known": info.ScopesKnown(),
+			}
+			if info.ScopesKnown() {
+				result["scopes"] = info.Scopes
+				result["unusable_tools"] = UnusableTools(tsg, info)
+			}
+			if info.ExpiresAt != nil {
+				result["expires_at"] = info.ExpiresAt
+				result["expired"] = info.ExpiresAt.Before(time.Now())
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockUserWithHeaders(headers map[string]string) *http.Client {
+	return mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"login": "octocat"}`))
+			}),
+		),
+	)
+}
+
+func Test_TokenInfoHasScope(t *testing.T) {
+	info := &TokenInfo{Scopes: []string{"repo", "admin:org", "project"}}
+	assert.True(t, info.HasScope("repo"))
+	assert.True(t, info.HasScope("public_repo"))
+	assert.True(t, info.HasScope("security_events"))
+	assert.True(t, info.HasScope("read:org"))
+	assert.True(t, info.HasScope("read:project"))
+	assert.False(t, info.HasScope("gist"))
+	assert.False(t, info.HasScope("notifications"))
+
+	// Every group of a requirement must be satisfied
+	createWorkflow := RequiredScopes("actions", mcp.Tool{Name: "create_workflow"})
+	assert.False(t, (&TokenInfo{Scopes: []string{"workflow"}}).HasScopes(createWorkflow))
+	assert.False(t, info.HasScopes(createWorkflow))
+	assert.True(t, (&TokenInfo{Scopes: []string{"public_repo", "workflow"}}).HasScopes(createWorkflow))
+
+	listSSHKeys := RequiredScopes("users", mcp.Tool{Name: "list_public_ssh_keys"})
+	assert.False(t, info.HasScopes(listSSHKeys))
+	assert.True(t, (&TokenInfo{Scopes: []string{"admin:public_key"}}).HasScopes(listSSHKeys))
+}
+
+func Test_GetTokenInfo(t *testing.T) {
+	t.Run("classic token", func(t *testing.T) {
+		client := github.NewClient(mockUserWithHeaders(map[string]string{
+			"X-OAuth-Scopes":                         "repo, read:org",
+			"GitHub-Authentication-Token-Expiration": "2030-01-02 15:04:05 +0000",
+		}))
+		info, _, err := GetTokenInfo(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", info.Login)
+		assert.True(t, info.ScopesKnown())
+		assert.Equal(t, []string{"repo", "read:org"}, info.Scopes)
+		require.NotNil(t, info.ExpiresAt)
+		assert.Equal(t, 2030, info.ExpiresAt.Year())
+	})
+
+	t.Run("classic token without scopes", func(t *testing.T) {
+		client := github.NewClient(mockUserWithHeaders(map[string]string{
+			"X-OAuth-Scopes": "",
+		}))
+		info, _, err := GetTokenInfo(context.Background(), client)
+		require.NoError(t, err)
+		assert.True(t, info.ScopesKnown())
+		assert.Empty(t, info.Scopes)
+		assert.Nil(t, info.ExpiresAt)
+	})
+
+	t.Run("fine-grained token", func(t *testing.T) {
+		client := github.NewClient(mockUserWithHeaders(nil))
+		info, _, err := GetTokenInfo(context.Background(), client)
+		require.NoError(t, err)
+		assert.False(t, info.ScopesKnown())
+	})
+}
+
+func Test_UnusableTools(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	require.NoError(t, tsg.EnableToolsets([]string{"projects", "gists", "repos"}))
+
+	unusable := UnusableTools(tsg, &TokenInfo{Scopes: []string{"read:project", "gist"}})
+	tools := map[string]UnusableTool{}
+	for _, tool := range unusable {
+		tools[tool.Tool] = tool
+	}
+
+	// Project writes need the project scope, reads are covered by read:project
+	require.Contains(t, tools, "provision_project")
+	assert.Equal(t, [][]string{{"project"}}, tools["provision_project"].RequiredScopes)
+	assert.NotContains(t, tools, "list_project_iterations")
+	// Gists are fully covered
+	assert.NotContains(t, tools, "create_gist")
+	// Repository writes need repo or public_repo, reads need nothing
+	require.Contains(t, tools, "create_branch")
+	assert.Equal(t, "repos", tools["create_branch"].Toolset)
+	assert.NotContains(t, tools, "get_file_contents")
+	// Tools of toolsets that are not enabled are not reported
+	assert.NotContains(t, tools, "create_issue")
+
+	assert.Nil(t, UnusableTools(tsg, &TokenInfo{}))
+}
+
+// publicTools work on public data without any token scope. Every other tool must have its scopes
+// listed in toolsetScopes or toolScopes, so that the scope check does not pass it wrongly.
+var publicTools = []string{
+	// actions
+	"download_workflow_run_artifact", "get_check_annotations", "get_deployment_diff",
+	"get_dora_metrics", "get_job_logs", "get_workflow_run", "get_workflow_run_logs",
+	"get_workflow_run_usage", "list_pending_deployments", "list_workflow_jobs",
+	"list_workflow_run_artifacts", "list_workflow_runs", "list_workflows", "validate_workflow_file",
+	"wait_for_workflow_run",
+	// context
+	"check_auth", "get_me", "plan_token_permissions",
+	// dependency_graph
+	"export_sbom", "get_dependency_graph",
+	// discussions
+	"get_discussion", "get_discussion_comments", "list_discussion_categories", "list_discussions",
+	// gists
+	"list_gists",
+	// issues
+	"find_similar_issues", "get_issue", "get_issue_comments", "get_issue_templates",
+	"get_issue_timeline", "get_linked_items", "get_milestone_progress", "list_assignable_users",
+	"list_issue_types", "list_issues", "list_pinned_issues", "list_reactions", "list_sub_issues",
+	"search_issues",
+	// orgs
+	"aggregate_org_languages", "get_org_membership", "list_org_events", "list_org_members",
+	"search_org_members", "search_orgs",
+	// pull_requests
+	"compare_checks_to_protection", "get_merge_queue", "get_pr_diffstat", "get_pull_request",
+	"get_pull_request_diff", "get_pull_request_files", "get_pull_request_review_comments",
+	"get_pull_request_reviews", "get_pull_request_status", "get_review_requests_digest",
+	"list_merge_queue_entries", "list_pull_request_review_threads", "list_pull_requests",
+	"search_pull_requests", "suggest_reviewers", "wait_for_checks",
+	// repos
+	"check_push_allowed", "compare_commits", "download_repo_archive", "fetch_resource", "get_blame",
+	"get_codeowners_for_path", "get_commit", "get_commit_activity", "get_contributors_stats",
+	"get_custom_property_values", "get_file_contents", "get_latest_release", "get_ref",
+	"get_release_by_tag", "get_release_notes_data", "get_repo_file_tree_docs", "get_repo_languages",
+	"get_repo_readme", "get_stargazer_timeline", "get_tag", "list_branches", "list_commits",
+	"list_releases", "list_repo_events", "list_starred_repositories", "list_tags", "search_code",
+	"search_repositories",
+	// security_advisories
+	"get_global_security_advisory", "list_global_security_advisories",
+	"list_org_repository_security_advisories", "list_repository_security_advisories",
+	// users
+	"get_user_activity", "search_users",
+}
+
+func Test_RequiredScopesCoverAllTools(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+	public := map[string]bool{}
+	for _, name := range publicTools {
+		public[name] = true
+	}
+
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			scopes := RequiredScopes(name, tool.Tool)
+			if public[tool.Tool.Name] {
+				assert.Empty(t, scopes, "%s is listed as public but requires scopes", tool.Tool.Name)
+				continue
+			}
+			assert.NotEmpty(t, scopes, "%s has no required scopes, add them to toolsetScopes or toolScopes, or list it in publicTools", tool.Tool.Name)
+		}
+	}
+}
+
+func Test_CheckAuth(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("gists", "gists").
+		AddWriteTools(toolsets.NewServerTool(CreateGist(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper))))
+	require.NoError(t, tsg.EnableToolsets([]string{"gists"}))
+
+	tool, _ := CheckAuth(stubGetClientFn(github.NewClient(nil)), tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_auth", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name             string
+		headers          map[string]string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "classic token missing a scope",
+			headers: map[string]string{
+				"X-OAuth-Scopes":                         "repo",
+				"GitHub-Authentication-Token-Expiration": "2001-01-02 15:04:05 +0000",
+			},
+			expectedResponse: map[string]any{
+				"login":        "octocat",
+				"scopes_known": true,
+				"scopes":       []any{"repo"},
+				"unusable_tools": []any{
+					map[string]any{"tool": "create_gist", "toolset": "gists", "required_scopes": []any{[]any{"gist"}}},
+				},
+				"expires_at": "2001-01-02T15:04:05Z",
+				"expired":    true,
+			},
+		},
+		{
+			name:    "token without inspectable scopes",
+			headers: nil,
+			expectedResponse: map[string]any{
+				"login":        "octocat",
+				"scopes_known": false,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockUserWithHeaders(tc.headers))
+			_, handler := CheckAuth(stubGetClientFn(client), tsg, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+
+	t.Run("bad credentials", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetUser,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+				}),
+			),
+		))
+		_, handler := CheckAuth(stubGetClientFn(client), tsg, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "failed to authenticate with the GitHub API")
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -161,3 +163,168 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// maxPushProtectionBypassAlerts bounds how many secret scanning alerts list_push_protection_bypasses reads.
+const maxPushProtectionBypassAlerts = 1000
+
+// PushProtectionBypass is a secret that was pushed by bypassing push protection. The secret itself is not included.
+type PushProtectionBypass struct {
+	Repository            string `json:"repository"`
+	AlertNumber           int    `json:"alert_number"`
+	HTMLURL               string `json:"html_url"`
+	SecretType            string `json:"secret_type"`
+	SecretTypeDisplayName string `json:"secret_type_display_name,omitempty"`
+	State                 string `json:"state"`
+	Resolution            string `json:"resolution,omitempty"`
+	Validity              string `json:"validity,omitempty"`
+	BypassedBy            string `json:"bypassed_by"`
+	BypassedAt            string `json:"bypassed_at"`
+	RequestComment        string `json:"request_comment,omitempty"`
+	RequestURL            string `json:"request_url,omitempty"`
+	Reviewer              string `json:"reviewer,omitempty"`
+	ReviewerComment       string `json:"reviewer_comment,omitempty"`
+}
+
+// PushProtectionBypasses lists the push protection bypasses since a point in time.
+type PushProtectionBypasses struct {
+	Since        string                 `json:"since"`
+	TotalCount   int                    `json:"total_count"`
+	BySecretType map[string]int         `json:"by_secret_type"`
+	ByActor      map[string]int         `json:"by_actor"`
+	Truncated    bool                   `json:"truncated"`
+	Bypasses     []PushProtectionBypass `json:"bypasses"`
+}
+
+func ListPushProtectionBypasses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_push_protection_bypasses",
+			mcp.WithDescription(t("TOOL_LIST_PUSH_PROTECTION_BYPASSES_DESCRIPTION", "List secrets that were pushed by bypassing secret scanning push protection in a GitHub repository, or across an organization when repo is omitted. Reports who bypassed push protection, when, for which secret types, and any bypass request and review, most recent first. The secrets themselves are not returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PUSH_PROTECTION_BYPASSES_USER_TITLE", "List push protection bypasses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository, or the organization when repo is omitted."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("The name of the repository. Omit to list bypasses across the organization."),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list bypasses after this ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to 30 days ago."),
+			),
+			mcp.WithString("secret_type",
+				mcp.Description("A comma-separated list of secret types to return. All secret types are returned by default."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secretType, err := OptionalParam[string](request, "secret_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since := time.Now().UTC().AddDate(0, 0, -30)
+			if sinceParam != "" {
+				if since, err = parseISOTimestamp(sinceParam); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err)), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := PushProtectionBypasses{
+				Since:        since.Format("2006-01-02T15:04:05Z"),
+				BySecretType: map[string]int{},
+				ByActor:      map[string]int{},
+				Bypasses:     []PushProtectionBypass{},
+			}
+			// A bypassed secret raises its alert when it is pushed, so alerts are read newest first
+			// until they were created before since.
+			opts := &github.SecretScanningAlertListOptions{
+				SecretType:        secretType,
+				Sort:              "created",
+				Direction:         "desc",
+				ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+			}
+			read := 0
+		pages:
+			for {
+				var alerts []*github.SecretScanningAlert
+				var resp *github.Response
+				if repo == "" {
+					alerts, resp, err = client.SecretScanning.ListAlertsForOrg(ctx, owner, opts)
+				} else {
+					alerts, resp, err = client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, opts)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list secret scanning alerts",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, alert := range alerts {
+					if alert.GetCreatedAt().Before(since) {
+						break pages
+					}
+					if read == maxPushProtectionBypassAlerts {
+						result.Truncated = true
+						break pages
+					}
+					read++
+					if !alert.GetPushProtectionBypassed() || alert.GetPushProtectionBypassedAt().Before(since) {
+						continue
+					}
+					repository := alert.GetRepository().GetFullName()
+					if repository == "" {
+						repository = fmt.Sprintf("%s/%s", owner, repo)
+					}
+					bypass := PushProtectionBypass{
+						Repository:            repository,
+						AlertNumber:           alert.GetNumber(),
+						HTMLURL:               alert.GetHTMLURL(),
+						SecretType:            alert.GetSecretType(),
+						SecretTypeDisplayName: alert.GetSecretTypeDisplayName(),
+						State:                 alert.GetState(),
+						Resolution:            alert.GetResolution(),
+						Validity:              alert.GetValidity(),
+						BypassedBy:            alert.GetPushProtectionBypassedBy().GetLogin(),
+						BypassedAt:            alert.GetPushProtectionBypassedAt().Format("2006-01-02T15:04:05Z"),
+						RequestComment:        alert.GetPushProtectionBypassRequestComment(),
+						RequestURL:            alert.GetPushProtectionBypassRequestHTMLURL(),
+						Reviewer:              alert.GetPushProtectionBypassRequestReviewer().GetLogin(),
+						ReviewerComment:       alert.GetPushProtectionBypassRequestReviewerComment(),
+					}
+					result.BySecretType[bypass.SecretType]++
+					result.ByActor[bypass.BypassedBy]++
+					result.Bypasses = append(result.Bypasses, bypass)
+				}
+				if resp.After == "" {
+					break
+				}
+				opts.After = resp.After
+			}
+
+			sort.SliceStable(result.Bypasses, func(i, j int) bool {
+				return result.Bypasses[i].BypassedAt > result.Bypasses[j].BypassedAt
+			})
+			result.TotalCount = len(result.Bypasses)
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -247,3 +249,179 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListPushProtectionBypasses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPushProtectionBypasses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_push_protection_bypasses", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	at := func(s string) *github.Timestamp {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &github.Timestamp{Time: ts}
+	}
+	repo := &github.Repository{FullName: github.Ptr("org/api")}
+	alerts := []*github.SecretScanningAlert{
+		{
+			Number:                             github.Ptr(3),
+			HTMLURL:                            github.Ptr("https://github.com/org/api/security/secret-scanning/3"),
+			Repository:                         repo,
+			CreatedAt:                          at("2025-03-10T12:00:00Z"),
+			State:                              github.Ptr("open"),
+			SecretType:                         github.Ptr("github_personal_access_token"),
+			SecretTypeDisplayName:              github.Ptr("GitHub Personal Access Token"),
+			Secret:                             github.Ptr("ghp_secret"),
+			Validity:                           github.Ptr("active"),
+			PushProtectionBypassed:             github.Ptr(true),
+			PushProtectionBypassedBy:           &github.User{Login: github.Ptr("alice")},
+			PushProtectionBypassedAt:           at("2025-03-10T12:00:00Z"),
+			PushProtectionBypassRequestComment: github.Ptr("test fixture"),
+		},
+		{
+			Number:     github.Ptr(2),
+			Repository: repo,
+			CreatedAt:  at("2025-03-05T12:00:00Z"),
+			State:      github.Ptr("open"),
+			SecretType: github.Ptr("aws_access_key_id"),
+		},
+		{
+			Number:                   github.Ptr(1),
+			Repository:               repo,
+			CreatedAt:                at("2025-03-02T12:00:00Z"),
+			State:                    github.Ptr("resolved"),
+			Resolution:               github.Ptr("revoked"),
+			SecretType:               github.Ptr("aws_access_key_id"),
+			PushProtectionBypassed:   github.Ptr(true),
+			PushProtectionBypassedBy: &github.User{Login: github.Ptr("bob")},
+			PushProtectionBypassedAt: at("2025-03-02T12:00:00Z"),
+		},
+		{
+			Number:                   github.Ptr(0),
+			Repository:               repo,
+			CreatedAt:                at("2025-01-01T12:00:00Z"),
+			SecretType:               github.Ptr("aws_access_key_id"),
+			PushProtectionBypassed:   github.Ptr(true),
+			PushProtectionBypassedBy: &github.User{Login: github.Ptr("bob")},
+			PushProtectionBypassedAt: at("2025-01-01T12:00:00Z"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       PushProtectionBypasses
+	}{
+		{
+			name: "bypasses across an organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{"sort": "created", "direction": "desc", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, alerts),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "org", "since": "2025-03-01"},
+			expected: PushProtectionBypasses{
+				Since:        "2025-03-01T00:00:00Z",
+				TotalCount:   2,
+				BySecretType: map[string]int{"github_personal_access_token": 1, "aws_access_key_id": 1},
+				ByActor:      map[string]int{"alice": 1, "bob": 1},
+				Bypasses: []PushProtectionBypass{
+					{
+						Repository:            "org/api",
+						AlertNumber:           3,
+						HTMLURL:               "https://github.com/org/api/security/secret-scanning/3",
+						SecretType:            "github_personal_access_token",
+						SecretTypeDisplayName: "GitHub Personal Access Token",
+						State:                 "open",
+						Validity:              "active",
+						BypassedBy:            "alice",
+						BypassedAt:            "2025-03-10T12:00:00Z",
+						RequestComment:        "test fixture",
+					},
+					{
+						Repository:  "org/api",
+						AlertNumber: 1,
+						SecretType:  "aws_access_key_id",
+						State:       "resolved",
+						Resolution:  "revoked",
+						BypassedBy:  "bob",
+						BypassedAt:  "2025-03-02T12:00:00Z",
+					},
+				},
+			},
+		},
+		{
+			name: "bypasses in a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"sort": "created", "direction": "desc", "per_page": "100", "secret_type": "aws_access_key_id"}).andThen(
+						mockResponse(t, http.StatusOK, alerts[1:]),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "org", "repo": "api", "since": "2024-12-01", "secret_type": "aws_access_key_id"},
+			expected: PushProtectionBypasses{
+				Since:        "2024-12-01T00:00:00Z",
+				TotalCount:   2,
+				BySecretType: map[string]int{"aws_access_key_id": 2},
+				ByActor:      map[string]int{"bob": 2},
+				Bypasses: []PushProtectionBypass{
+					{Repository: "org/api", AlertNumber: 1, SecretType: "aws_access_key_id", State: "resolved", Resolution: "revoked", BypassedBy: "bob", BypassedAt: "2025-03-02T12:00:00Z"},
+					{Repository: "org/api", AlertNumber: 0, SecretType: "aws_access_key_id", BypassedBy: "bob", BypassedAt: "2025-01-01T12:00:00Z"},
+				},
+			},
+		},
+		{
+			name: "secret scanning disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Secret scanning is disabled on this repository."}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "org", "repo": "api"},
+			expectError:    true,
+			expectedErrMsg: "failed to list secret scanning alerts",
+		},
+		{
+			name:           "invalid since",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "org", "since": "last week"},
+			expectError:    true,
+			expectedErrMsg: "failed to parse since",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPushProtectionBypasses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, "ghp_secret")
+			var bypasses PushProtectionBypasses
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &bypasses))
+			assert.Equal(t, tc.expected, bypasses)
+		})
+	}
+}
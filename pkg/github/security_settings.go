@@ -0,0 +1,566 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Values of a security setting. A setting is unknown when the token cannot read it.
+const (
+	settingEnabled  = "enabled"
+	settingDisabled = "disabled"
+	settingUnknown  = "unknown"
+)
+
+// RepoSecuritySettings is the state of the security features of a repository.
+type RepoSecuritySettings struct {
+	Repository                    string   `json:"repository"`
+	Visibility                    string   `json:"visibility"`
+	AdvancedSecurity              string   `json:"advanced_security"`
+	DependabotAlerts              string   `json:"dependabot_alerts"`
+	DependabotSecurityUpdates     string   `json:"dependabot_security_updates"`
+	SecretScanning                string   `json:"secret_scanning"`
+	SecretScanningPushProtection  string   `json:"secret_scanning_push_protection"`
+	SecretScanningValidityChecks  string   `json:"secret_scanning_validity_checks"`
+	CodeScanningDefaultSetup      string   `json:"code_scanning_default_setup"`
+	CodeScanningLanguages         []string `json:"code_scanning_languages,omitempty"`
+	CodeScanningQuerySuite        string   `json:"code_scanning_query_suite,omitempty"`
+	PrivateVulnerabilityReporting string   `json:"private_vulnerability_reporting"`
+	// Unavailable explains why settings are unknown, by setting
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// enabledSetting converts a boolean setting.
+func enabledSetting(enabled bool) string {
+	if enabled {
+		return settingEnabled
+	}
+	return settingDisabled
+}
+
+// statusSetting converts the status of a security_and_analysis feature, which is "enabled" or "disabled".
+func statusSetting(status string) string {
+	if status == "" {
+		return settingUnknown
+	}
+	return status
+}
+
+// settingUnavailable returns why a setting could not be read when the API refused to return it, as
+// opposed to failing. It reports false for errors that should fail the tool call.
+func settingUnavailable(resp *github.Response) (string, bool) {
+	if resp == nil {
+		return "", false
+	}
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		return "forbidden, requires admin access to the repository or GitHub Advanced Security", true
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		return "not available for this repository", true
+	}
+	return "", false
+}
+
+// newRepoSecuritySettings returns the settings reported by the repository itself. The
+// security_and_analysis settings are only returned to repository admins.
+func newRepoSecuritySettings(repository *github.Repository) RepoSecuritySettings {
+	analysis := repository.GetSecurityAndAnalysis()
+	settings := RepoSecuritySettings{
+		Repository:                    repository.GetFullName(),
+		Visibility:                    repository.GetVisibility(),
+		AdvancedSecurity:              statusSetting(analysis.GetAdvancedSecurity().GetStatus()),
+		DependabotAlerts:              settingUnknown,
+		DependabotSecurityUpdates:     statusSetting(analysis.GetDependabotSecurityUpdates().GetStatus()),
+		SecretScanning:                statusSetting(analysis.GetSecretScanning().GetStatus()),
+		SecretScanningPushProtection:  statusSetting(analysis.GetSecretScanningPushProtection().GetStatus()),
+		SecretScanningValidityChecks:  statusSetting(analysis.GetSecretScanningValidityChecks().GetStatus()),
+		CodeScanningDefaultSetup:      settingUnknown,
+		PrivateVulnerabilityReporting: settingUnknown,
+		Unavailable:                   map[string]string{},
+	}
+	if analysis == nil {
+		settings.Unavailable["security_and_analysis"] = "only returned to repository admins"
+	}
+	return settings
+}
+
+// GetRepoSecuritySettings creates a tool that reports which security features of a repository are enabled.
+func GetRepoSecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_security_settings",
+			mcp.WithDescription(t("TOOL_GET_REPO_SECURITY_SETTINGS_DESCRIPTION", "Get an overview of the security features of a GitHub repository in one call: Dependabot alerts and security updates, secret scanning, push protection and validity checks, code scanning default setup, private vulnerability reporting and GitHub Advanced Security. Settings the token cannot read are reported as unknown with the reason. Use this for compliance checks.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_SECURITY_SETTINGS_USER_TITLE", "Get repository security settings"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			settings := newRepoSecuritySettings(repository)
+
+			// Dependabot alerts are reported as disabled with a 404, which go-github turns into false.
+			alerts, resp, err := client.Repositories.GetVulnerabilityAlerts(ctx, owner, repo)
+			if result, done := recordSetting(ctx, &settings, "dependabot_alerts", resp, err, "failed to get Dependabot alerts setting"); done {
+				return result, nil
+			}
+			if err == nil {
+				settings.DependabotAlerts = enabledSetting(alerts)
+			}
+
+			setup, resp, err := client.CodeScanning.GetDefaultSetupConfiguration(ctx, owner, repo)
+			if result, done := recordSetting(ctx, &settings, "code_scanning_default_setup", resp, err, "failed to get code scanning default setup"); done {
+				return result, nil
+			}
+			if err == nil {
+				settings.CodeScanningDefaultSetup = setup.GetState()
+				settings.CodeScanningLanguages = setup.Languages
+				settings.CodeScanningQuerySuite = setup.GetQuerySuite()
+			}
+
+			reporting, resp, err := client.Repositories.IsPrivateReportingEnabled(ctx, owner, repo)
+			if result, done := recordSetting(ctx, &settings, "private_vulnerability_reporting", resp, err, "failed to get private vulnerability reporting setting"); done {
+				return result, nil
+			}
+			if err == nil {
+				settings.PrivateVulnerabilityReporting = enabledSetting(reporting)
+			}
+
+			return MarshalledTextResult(settings), nil
+		}
+}
+
+// recordSetting handles the outcome of reading an optional setting. A setting the API refuses to
+// return is recorded as unavailable. Any other error is returned as the result of the tool call, in
+// which case done is true.
+func recordSetting(ctx context.Context, settings *RepoSecuritySettings, name string, resp *github.Response, err error, message string) (result *mcp.CallToolResult, done bool) {
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err == nil {
+		return nil, false
+	}
+	if reason, ok := settingUnavailable(resp); ok {
+		settings.Unavailable[name] = reason
+		return nil, false
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err), true
+}
+
+const (
+	// orgSecurityConcurrency bounds how many repositories get_org_security_overview looks up at the same time.
+	orgSecurityConcurrency = 5
+	// maxOrgSecurityAlerts bounds how many open alerts of each kind get_org_security_overview reads.
+	maxOrgSecurityAlerts = 10000
+)
+
+// AlertCounts counts the open alerts of one kind.
+type AlertCounts struct {
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"by_severity,omitempty"`
+	ByValidity map[string]int `json:"by_validity,omitempty"`
+	Truncated  bool           `json:"truncated,omitempty"`
+	// Unavailable explains why the alerts could not be listed
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// FeatureCoverage counts the repositories by the state of a security feature.
+type FeatureCoverage struct {
+	Enabled  int `json:"enabled"`
+	Disabled int `json:"disabled"`
+	Unknown  int `json:"unknown"`
+}
+
+// RepoSecurityPosture is the security posture of a repository in an organization.
+type RepoSecurityPosture struct {
+	Repository                   string `json:"repository"`
+	Visibility                   string `json:"visibility"`
+	AdvancedSecurity             string `json:"advanced_security"`
+	DependabotAlerts             string `json:"dependabot_alerts"`
+	DependabotSecurityUpdates    string `json:"dependabot_security_updates"`
+	SecretScanning               string `json:"secret_scanning"`
+	SecretScanningPushProtection string `json:"secret_scanning_push_protection"`
+	OpenDependabotAlerts         int    `json:"open_dependabot_alerts"`
+	OpenCodeScanningAlerts       int    `json:"open_code_scanning_alerts"`
+	OpenSecretScanningAlerts     int    `json:"open_secret_scanning_alerts"`
+	// CriticalOrHigh counts the open Dependabot and code scanning alerts of critical or high severity
+	CriticalOrHigh int `json:"critical_or_high"`
+}
+
+// OrgSecurityOverview summarizes the security posture of an organization.
+type OrgSecurityOverview struct {
+	Organization         string                     `json:"organization"`
+	Repositories         int                        `json:"repositories"`
+	Truncated            bool                       `json:"truncated"`
+	DependabotAlerts     AlertCounts                `json:"dependabot_alerts"`
+	CodeScanningAlerts   AlertCounts                `json:"code_scanning_alerts"`
+	SecretScanningAlerts AlertCounts                `json:"secret_scanning_alerts"`
+	Features             map[string]FeatureCoverage `json:"features"`
+	Repos                []RepoSecurityPosture      `json:"repos"`
+}
+
+// orgAlert is an open alert of an organization, reduced to what the overview counts.
+type orgAlert struct {
+	repository string
+	severity   string
+	validity   string
+}
+
+// orgAlertsUnavailable returns why the alerts of an organization could not be listed when the API
+// refused to return them, as opposed to failing.
+func orgAlertsUnavailable(resp *github.Response) (string, bool) {
+	if resp == nil {
+		return "", false
+	}
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		return "forbidden, requires an organization owner or security manager, or the feature is not enabled", true
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		return "not available for this organization", true
+	}
+	return "", false
+}
+
+// isCriticalOrHigh reports whether an alert severity is critical or high. Code scanning alerts
+// without a security severity report error as their highest severity.
+func isCriticalOrHigh(severity string) bool {
+	return severity == "critical" || severity == "high" || severity == "error"
+}
+
+// listOrgAlerts pages the open alerts of one kind with list, which returns the alerts of the page
+// after the given cursor. It stops after maxOrgSecurityAlerts alerts.
+func listOrgAlerts(list func(after string) ([]orgAlert, *github.Response, error)) (alerts []orgAlert, truncated bool, resp *github.Response, err error) {
+	after := ""
+	for {
+		page, resp, err := list(after)
+		if err != nil {
+			return nil, false, resp, err
+		}
+		_ = resp.Body.Close()
+		alerts = append(alerts, page...)
+		if len(alerts) >= maxOrgSecurityAlerts {
+			return alerts[:maxOrgSecurityAlerts], true, resp, nil
+		}
+		if resp.After == "" {
+			return alerts, false, resp, nil
+		}
+		after = resp.After
+	}
+}
+
+// repoAlertCounts counts the open alerts of one kind in a repository.
+type repoAlertCounts struct {
+	total          int
+	criticalOrHigh int
+}
+
+// countAlerts counts alerts by severity and validity, overall and per repository.
+func countAlerts(alerts []orgAlert, truncated bool) (AlertCounts, map[string]repoAlertCounts) {
+	counts := AlertCounts{Total: len(alerts), Truncated: truncated}
+	perRepo := map[string]repoAlertCounts{}
+	for _, alert := range alerts {
+		repoCounts := perRepo[alert.repository]
+		repoCounts.total++
+		if alert.severity != "" {
+			if counts.BySeverity == nil {
+				counts.BySeverity = map[string]int{}
+			}
+			counts.BySeverity[alert.severity]++
+			if isCriticalOrHigh(alert.severity) {
+				repoCounts.criticalOrHigh++
+			}
+		}
+		if alert.validity != "" {
+			if counts.ByValidity == nil {
+				counts.ByValidity = map[string]int{}
+			}
+			counts.ByValidity[alert.validity]++
+		}
+		perRepo[alert.repository] = repoCounts
+	}
+	return counts, perRepo
+}
+
+// GetOrgSecurityOverview creates a tool that summarizes the security posture of an organization.
+func GetOrgSecurityOverview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_security_overview",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECURITY_OVERVIEW_DESCRIPTION", "Summarize the security posture of a GitHub organization: open Dependabot, code scanning and secret scanning alerts by severity, how many repositories have each security feature enabled, and per-repository enablement and open alert counts, riskiest first. Use this to answer organization-wide questions such as which repositories lack secret scanning or have the most critical alerts.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_SECURITY_OVERVIEW_USER_TITLE", "Get organization security overview"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Include archived repositories. Defaults to false."),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description("Maximum number of repositories to report on. Defaults to 100, at most 1000."),
+				mcp.Min(1),
+				mcp.Max(1000),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeArchived, err := OptionalBoolParamWithDefault(request, "include_archived", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxRepos < 1 || maxRepos > 1000 {
+				return mcp.NewToolResultError("max_repos must be between 1 and 1000"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			overview := OrgSecurityOverview{Organization: org, Features: map[string]FeatureCoverage{}}
+			var repositories []*github.Repository
+			opts := &github.RepositoryListByOrgOptions{Sort: "full_name", ListOptions: github.ListOptions{PerPage: 100}}
+		pages:
+			for {
+				page, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list repositories",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, repository := range page {
+					if repository.GetArchived() && !includeArchived {
+						continue
+					}
+					if len(repositories) == maxRepos {
+						overview.Truncated = true
+						break pages
+					}
+					repositories = append(repositories, repository)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// The alerts of the whole organization are listed while the repositories are looked up.
+			alertKinds := []struct {
+				counts  *AlertCounts
+				message string
+				list    func(after string) ([]orgAlert, *github.Response, error)
+			}{
+				{
+					counts:  &overview.DependabotAlerts,
+					message: "failed to list Dependabot alerts",
+					list: func(after string) ([]orgAlert, *github.Response, error) {
+						alerts, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, &github.ListAlertsOptions{
+							State:             github.Ptr("open"),
+							ListCursorOptions: github.ListCursorOptions{PerPage: 100, After: after},
+						})
+						page := make([]orgAlert, 0, len(alerts))
+						for _, alert := range alerts {
+							page = append(page, orgAlert{repository: alert.GetRepository().GetFullName(), severity: alert.GetSecurityAdvisory().GetSeverity()})
+						}
+						return page, resp, err
+					},
+				},
+				{
+					counts:  &overview.CodeScanningAlerts,
+					message: "failed to list code scanning alerts",
+					list: func(after string) ([]orgAlert, *github.Response, error) {
+						alerts, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, &github.AlertListOptions{
+							State:             "open",
+							ListCursorOptions: github.ListCursorOptions{PerPage: 100, After: after},
+						})
+						page := make([]orgAlert, 0, len(alerts))
+						for _, alert := range alerts {
+							severity := alert.GetRule().GetSecuritySeverityLevel()
+							if severity == "" {
+								severity = alert.GetRule().GetSeverity()
+							}
+							page = append(page, orgAlert{repository: alert.GetRepository().GetFullName(), severity: severity})
+						}
+						return page, resp, err
+					},
+				},
+				{
+					counts:  &overview.SecretScanningAlerts,
+					message: "failed to list secret scanning alerts",
+					list: func(after string) ([]orgAlert, *github.Response, error) {
+						alerts, resp, err := client.SecretScanning.ListAlertsForOrg(ctx, org, &github.SecretScanningAlertListOptions{
+							State:             "open",
+							ListCursorOptions: github.ListCursorOptions{PerPage: 100, After: after},
+						})
+						page := make([]orgAlert, 0, len(alerts))
+						for _, alert := range alerts {
+							page = append(page, orgAlert{repository: alert.GetRepository().GetFullName(), validity: alert.GetValidity()})
+						}
+						return page, resp, err
+					},
+				},
+			}
+			alertErrs := make([]error, len(alertKinds))
+			alertResponses := make([]*github.Response, len(alertKinds))
+			perRepoAlerts := make([]map[string]repoAlertCounts, len(alertKinds))
+			var wg sync.WaitGroup
+			for i, kind := range alertKinds {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					alerts, truncated, resp, err := listOrgAlerts(kind.list)
+					if err != nil {
+						if resp != nil {
+							_ = resp.Body.Close()
+						}
+						if reason, ok := orgAlertsUnavailable(resp); ok {
+							kind.counts.Unavailable = reason
+							return
+						}
+						alertResponses[i], alertErrs[i] = resp, err
+						return
+					}
+					*kind.counts, perRepoAlerts[i] = countAlerts(alerts, truncated)
+				}()
+			}
+
+			// Dependabot alerts are the only feature missing from the repository itself.
+			postures := make([]RepoSecuritySettings, len(repositories))
+			repoErrs := make([]error, len(repositories))
+			repoResponses := make([]*github.Response, len(repositories))
+			semaphore := make(chan struct{}, orgSecurityConcurrency)
+			for i, repository := range repositories {
+				postures[i] = newRepoSecuritySettings(repository)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }()
+
+					enabled, resp, err := client.Repositories.GetVulnerabilityAlerts(ctx, org, repository.GetName())
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					if err != nil {
+						if _, ok := settingUnavailable(resp); !ok {
+							repoResponses[i], repoErrs[i] = resp, err
+						}
+						return
+					}
+					postures[i].DependabotAlerts = enabledSetting(enabled)
+				}()
+			}
+			wg.Wait()
+
+			for i, err := range alertErrs {
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, alertKinds[i].message, alertResponses[i], err), nil
+				}
+			}
+			for i, err := range repoErrs {
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get Dependabot alerts setting of %s", repositories[i].GetFullName()),
+						repoResponses[i],
+						err,
+					), nil
+				}
+			}
+
+			overview.Repositories = len(repositories)
+			overview.Repos = make([]RepoSecurityPosture, 0, len(repositories))
+			for _, settings := range postures {
+				posture := RepoSecurityPosture{
+					Repository:                   settings.Repository,
+					Visibility:                   settings.Visibility,
+					AdvancedSecurity:             settings.AdvancedSecurity,
+					DependabotAlerts:             settings.DependabotAlerts,
+					DependabotSecurityUpdates:    settings.DependabotSecurityUpdates,
+					SecretScanning:               settings.SecretScanning,
+					SecretScanningPushProtection: settings.SecretScanningPushProtection,
+					OpenDependabotAlerts:         perRepoAlerts[0][settings.Repository].total,
+					OpenCodeScanningAlerts:       perRepoAlerts[1][settings.Repository].total,
+					OpenSecretScanningAlerts:     perRepoAlerts[2][settings.Repository].total,
+					CriticalOrHigh:               perRepoAlerts[0][settings.Repository].criticalOrHigh + perRepoAlerts[1][settings.Repository].criticalOrHigh,
+				}
+				for feature, state := range map[string]string{
+					"advanced_security":               posture.AdvancedSecurity,
+					"dependabot_alerts":               posture.DependabotAlerts,
+					"dependabot_security_updates":     posture.DependabotSecurityUpdates,
+					"secret_scanning":                 posture.SecretScanning,
+					"secret_scanning_push_protection": posture.SecretScanningPushProtection,
+				} {
+					coverage := overview.Features[feature]
+					switch state {
+					case settingEnabled:
+						coverage.Enabled++
+					case settingDisabled:
+						coverage.Disabled++
+					default:
+						coverage.Unknown++
+					}
+					overview.Features[feature] = coverage
+				}
+				overview.Repos = append(overview.Repos, posture)
+			}
+
+			// Riskiest repositories first
+			sort.SliceStable(overview.Repos, func(i, j int) bool {
+				a, b := overview.Repos[i], overview.Repos[j]
+				if a.CriticalOrHigh != b.CriticalOrHigh {
+					return a.CriticalOrHigh > b.CriticalOrHigh
+				}
+				return a.OpenDependabotAlerts+a.OpenCodeScanningAlerts+a.OpenSecretScanningAlerts >
+					b.OpenDependabotAlerts+b.OpenCodeScanningAlerts+b.OpenSecretScanningAlerts
+			})
+
+			return MarshalledTextResult(overview), nil
+		}
+}
@@ -0,0 +1,349 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepoSecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_security_settings", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	status := func(s string) *string { return github.Ptr(s) }
+	adminRepo := &github.Repository{
+		FullName:   github.Ptr("owner/repo"),
+		Visibility: github.Ptr("private"),
+		SecurityAndAnalysis: &github.SecurityAndAnalysis{
+			AdvancedSecurity:             &github.AdvancedSecurity{Status: status("enabled")},
+			SecretScanning:               &github.SecretScanning{Status: status("enabled")},
+			SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: status("disabled")},
+			DependabotSecurityUpdates:    &github.DependabotSecurityUpdates{Status: status("enabled")},
+			SecretScanningValidityChecks: &github.SecretScanningValidityChecks{Status: status("disabled")},
+		},
+	}
+	notFound := mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       RepoSecuritySettings
+	}{
+		{
+			name: "all settings readable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, adminRepo),
+				mock.WithRequestMatchHandler(
+					mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+					github.DefaultSetupConfiguration{State: github.Ptr("configured"), Languages: []string{"go"}, QuerySuite: github.Ptr("default")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+					map[string]bool{"enabled": true},
+				),
+			),
+			expected: RepoSecuritySettings{
+				Repository:                    "owner/repo",
+				Visibility:                    "private",
+				AdvancedSecurity:              "enabled",
+				DependabotAlerts:              "enabled",
+				DependabotSecurityUpdates:     "enabled",
+				SecretScanning:                "enabled",
+				SecretScanningPushProtection:  "disabled",
+				SecretScanningValidityChecks:  "disabled",
+				CodeScanningDefaultSetup:      "configured",
+				CodeScanningLanguages:         []string{"go"},
+				CodeScanningQuerySuite:        "default",
+				PrivateVulnerabilityReporting: "enabled",
+			},
+		},
+		{
+			name: "without admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, github.Repository{FullName: github.Ptr("owner/repo"), Visibility: github.Ptr("public")}),
+				mock.WithRequestMatchHandler(mock.GetReposVulnerabilityAlertsByOwnerByRepo, notFound),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+					map[string]bool{"enabled": false},
+				),
+			),
+			expected: RepoSecuritySettings{
+				Repository:                    "owner/repo",
+				Visibility:                    "public",
+				AdvancedSecurity:              "unknown",
+				DependabotAlerts:              "disabled",
+				DependabotSecurityUpdates:     "unknown",
+				SecretScanning:                "unknown",
+				SecretScanningPushProtection:  "unknown",
+				SecretScanningValidityChecks:  "unknown",
+				CodeScanningDefaultSetup:      "unknown",
+				PrivateVulnerabilityReporting: "disabled",
+				Unavailable: map[string]string{
+					"security_and_analysis":       "only returned to repository admins",
+					"code_scanning_default_setup": "forbidden, requires admin access to the repository or GitHub Advanced Security",
+				},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, notFound),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoSecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var settings RepoSecuritySettings
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &settings))
+			assert.Equal(t, tc.expected, settings)
+		})
+	}
+}
+
+func Test_GetOrgSecurityOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecurityOverview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_security_overview", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	status := func(s string) *string { return github.Ptr(s) }
+	repos := []*github.Repository{
+		{
+			Name:       github.Ptr("api"),
+			FullName:   github.Ptr("org/api"),
+			Visibility: github.Ptr("private"),
+			SecurityAndAnalysis: &github.SecurityAndAnalysis{
+				AdvancedSecurity:             &github.AdvancedSecurity{Status: status("enabled")},
+				SecretScanning:               &github.SecretScanning{Status: status("enabled")},
+				SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: status("enabled")},
+				DependabotSecurityUpdates:    &github.DependabotSecurityUpdates{Status: status("disabled")},
+			},
+		},
+		{Name: github.Ptr("docs"), FullName: github.Ptr("org/docs"), Visibility: github.Ptr("public")},
+		{Name: github.Ptr("legacy"), FullName: github.Ptr("org/legacy"), Visibility: github.Ptr("private"), Archived: github.Ptr(true)},
+	}
+	vulnerabilityAlerts := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/org/docs/vulnerability-alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	dependabotAlerts := []*github.DependabotAlert{
+		{Repository: repos[1], SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("critical")}},
+		{Repository: repos[1], SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("low")}},
+		{Repository: repos[0], SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("medium")}},
+	}
+	codeScanningAlerts := []*github.Alert{
+		{Repository: repos[0], Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("high"), Severity: github.Ptr("error")}},
+		{Repository: repos[0], Rule: &github.Rule{Severity: github.Ptr("warning")}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       OrgSecurityOverview
+	}{
+		{
+			name: "repositories and alerts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{"sort": "full_name", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, repos),
+					),
+				),
+				mock.WithRequestMatchHandler(mock.GetReposVulnerabilityAlertsByOwnerByRepo, vulnerabilityAlerts),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{"state": "open", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, dependabotAlerts),
+					),
+				),
+				mock.WithRequestMatch(mock.GetOrgsCodeScanningAlertsByOrg, codeScanningAlerts),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Secret scanning is disabled on this organization"}`),
+				),
+			),
+			requestArgs: map[string]any{"org": "org"},
+			expected: OrgSecurityOverview{
+				Organization: "org",
+				Repositories: 2,
+				DependabotAlerts: AlertCounts{
+					Total:      3,
+					BySeverity: map[string]int{"critical": 1, "medium": 1, "low": 1},
+				},
+				CodeScanningAlerts: AlertCounts{
+					Total:      2,
+					BySeverity: map[string]int{"high": 1, "warning": 1},
+				},
+				SecretScanningAlerts: AlertCounts{Unavailable: "not available for this organization"},
+				Features: map[string]FeatureCoverage{
+					"advanced_security":               {Enabled: 1, Unknown: 1},
+					"dependabot_alerts":               {Enabled: 1, Disabled: 1},
+					"dependabot_security_updates":     {Disabled: 1, Unknown: 1},
+					"secret_scanning":                 {Enabled: 1, Unknown: 1},
+					"secret_scanning_push_protection": {Enabled: 1, Unknown: 1},
+				},
+				Repos: []RepoSecurityPosture{
+					{
+						Repository:                   "org/api",
+						Visibility:                   "private",
+						AdvancedSecurity:             "enabled",
+						DependabotAlerts:             "enabled",
+						DependabotSecurityUpdates:    "disabled",
+						SecretScanning:               "enabled",
+						SecretScanningPushProtection: "enabled",
+						OpenDependabotAlerts:         1,
+						OpenCodeScanningAlerts:       2,
+						CriticalOrHigh:               1,
+					},
+					{
+						Repository:                   "org/docs",
+						Visibility:                   "public",
+						AdvancedSecurity:             "unknown",
+						DependabotAlerts:             "disabled",
+						DependabotSecurityUpdates:    "unknown",
+						SecretScanning:               "unknown",
+						SecretScanningPushProtection: "unknown",
+						OpenDependabotAlerts:         2,
+						CriticalOrHigh:               1,
+					},
+				},
+			},
+		},
+		{
+			name: "truncated to max_repos",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, repos),
+				mock.WithRequestMatchHandler(mock.GetReposVulnerabilityAlertsByOwnerByRepo, vulnerabilityAlerts),
+				mock.WithRequestMatch(mock.GetOrgsDependabotAlertsByOrg, []*github.DependabotAlert{}),
+				mock.WithRequestMatch(mock.GetOrgsCodeScanningAlertsByOrg, []*github.Alert{}),
+				mock.WithRequestMatch(mock.GetOrgsSecretScanningAlertsByOrg, []*github.SecretScanningAlert{
+					{Repository: repos[1], Validity: github.Ptr("active")},
+				}),
+			),
+			requestArgs: map[string]any{"org": "org", "max_repos": float64(1)},
+			expected: OrgSecurityOverview{
+				Organization:         "org",
+				Repositories:         1,
+				Truncated:            true,
+				SecretScanningAlerts: AlertCounts{Total: 1, ByValidity: map[string]int{"active": 1}},
+				Features: map[string]FeatureCoverage{
+					"advanced_security":               {Enabled: 1},
+					"dependabot_alerts":               {Enabled: 1},
+					"dependabot_security_updates":     {Disabled: 1},
+					"secret_scanning":                 {Enabled: 1},
+					"secret_scanning_push_protection": {Enabled: 1},
+				},
+				Repos: []RepoSecurityPosture{
+					{
+						Repository:                   "org/api",
+						Visibility:                   "private",
+						AdvancedSecurity:             "enabled",
+						DependabotAlerts:             "enabled",
+						DependabotSecurityUpdates:    "disabled",
+						SecretScanning:               "enabled",
+						SecretScanningPushProtection: "enabled",
+					},
+				},
+			},
+		},
+		{
+			name: "alert listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, []*github.Repository{}),
+				mock.WithRequestMatch(mock.GetOrgsDependabotAlertsByOrg, []*github.DependabotAlert{}),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+				mock.WithRequestMatch(mock.GetOrgsSecretScanningAlertsByOrg, []*github.SecretScanningAlert{}),
+			),
+			requestArgs:    map[string]any{"org": "org"},
+			expectError:    true,
+			expectedErrMsg: "failed to list code scanning alerts",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetOrgsReposByOrg, mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)),
+			),
+			requestArgs:    map[string]any{"org": "nope"},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgSecurityOverview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var overview OrgSecurityOverview
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &overview))
+			assert.Equal(t, tc.expected, overview)
+		})
+	}
+}
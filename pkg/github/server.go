@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 // NewServer creates a new GitHub MCP server with the specified GH client and logger.
@@ -189,6 +190,36 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a whole number
+func OptionalIntArrayParam(r mcp.CallToolRequest, p string) ([]int, error) {
+	// Check if the parameter is present in the request
+	if _, ok := r.GetArguments()[p]; !ok {
+		return []int{}, nil
+	}
+
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []int:
+		return v, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok || f != float64(int(f)) {
+				return []int{}, fmt.Errorf("parameter %s is not of type integer, is %T", p, v)
+			}
+			intSlice[i] = int(f)
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, r.GetArguments()[p])
+	}
+}
+
 // WithPagination adds REST API pagination parameters to a tool.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination() mcp.ToolOption {
@@ -222,7 +253,7 @@ func WithUnifiedPagination() mcp.ToolOption {
 		)(tool)
 
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Cursor for pagination. Use the nextCursor from the previous page's pagination envelope."),
 		)(tool)
 	}
 }
@@ -237,7 +268,7 @@ func WithCursorPagination() mcp.ToolOption {
 		)(tool)
 
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Cursor for pagination. Use the nextCursor from the previous page's pagination envelope."),
 		)(tool)
 	}
 }
@@ -321,6 +352,34 @@ type GraphQLPaginationParams struct {
 	After *string
 }
 
+// Vars sets the "first" and "after" variables of a paginated GraphQL query.
+func (p *GraphQLPaginationParams) Vars(vars map[string]any) {
+	vars["first"] = githubv4.Int(*p.First)
+	if p.After != nil {
+		vars["after"] = githubv4.String(*p.After)
+	} else {
+		vars["after"] = (*githubv4.String)(nil)
+	}
+}
+
+// Pagination is the envelope list tools report their pagination state in. NextCursor is passed as
+// the "after" parameter to fetch the next page. TotalCount is only set when the API reports it
+// without additional requests.
+type Pagination struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+	TotalCount *int   `json:"totalCount,omitempty"`
+}
+
+// NewCursorPagination creates the pagination envelope of a GraphQL connection.
+func NewCursorPagination(hasNextPage bool, endCursor string, totalCount int) Pagination {
+	p := Pagination{HasMore: hasNextPage, TotalCount: &totalCount}
+	if hasNextPage {
+		p.NextCursor = endCursor
+	}
+	return p
+}
+
 // ToGraphQLParams converts REST API pagination parameters to GraphQL-specific parameters.
 // This converts page/perPage to first parameter for GraphQL queries.
 // If After is provided, it takes precedence over page-based pagination.
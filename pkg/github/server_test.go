@@ -12,6 +12,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubGetClientFn(client *github.Client) GetClientFn {
@@ -478,6 +479,65 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: false,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"numbers": []any{float64(1), float64(42)},
+			},
+			paramName:   "numbers",
+			expected:    []int{1, 42},
+			expectError: false,
+		},
+		{
+			name: "fractional number",
+			params: map[string]any{
+				"numbers": []any{float64(1.5)},
+			},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"numbers": []any{float64(1), "2"},
+			},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalIntArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -560,3 +620,13 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCursorPagination(t *testing.T) {
+	// The cursor is only reported when there is a next page to fetch with it
+	assert.Equal(t, Pagination{NextCursor: "c2", HasMore: true, TotalCount: github.Ptr(5)}, NewCursorPagination(true, "c2", 5))
+	assert.Equal(t, Pagination{HasMore: false, TotalCount: github.Ptr(5)}, NewCursorPagination(false, "c2", 5))
+
+	data, err := json.Marshal(NewCursorPagination(false, "", 0))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hasMore": false, "totalCount": 0}`, string(data))
+}
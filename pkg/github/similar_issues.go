@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxSimilarIssueKeywords is the number of keywords of the title a search query uses at most.
+	maxSimilarIssueKeywords = 6
+	// maxSimilarIssueErrorStrings is the number of error strings of the body searched for at most.
+	maxSimilarIssueErrorStrings = 2
+	// maxSimilarIssueErrorLength is the length error strings are cut to, on a word boundary.
+	maxSimilarIssueErrorLength = 80
+)
+
+// similarIssueStopWords are words too common in issue titles to tell issues apart.
+var similarIssueStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "when": true, "not": true, "does": true,
+	"doesn": true, "don": true, "can": true, "cannot": true, "can't": true, "from": true, "into": true,
+	"this": true, "that": true, "are": true, "was": true, "were": true, "has": true, "have": true,
+	"after": true, "before": true, "should": true, "would": true, "using": true, "use": true,
+	"bug": true, "issue": true, "error": true, "problem": true, "feature": true, "request": true,
+	"work": true, "working": true, "works": true, "how": true, "why": true, "what": true,
+}
+
+var (
+	similarIssueWordRegexp  = regexp.MustCompile(`[\p{L}\p{N}_][\p{L}\p{N}_.\-]*[\p{L}\p{N}_]|[\p{L}\p{N}]`)
+	similarIssueErrorRegexp = regexp.MustCompile(`(?i)\b(error|exception|panic|fatal|failed|failure|traceback)\b`)
+)
+
+// SimilarIssue is a candidate duplicate found by find_similar_issues.
+type SimilarIssue struct {
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	HTMLURL   string   `json:"html_url"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+	Score     float64  `json:"score"`
+	MatchedBy []string `json:"matched_by"`
+}
+
+// issueKeywords returns the distinct, lowercased words of text that are not stop words, in order.
+func issueKeywords(text string) []string {
+	var keywords []string
+	seen := map[string]bool{}
+	for _, word := range similarIssueWordRegexp.FindAllString(strings.ToLower(text), -1) {
+		if len(word) < 3 || similarIssueStopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+// issueErrorStrings returns the lines of body that look like error messages, cleaned up to be
+// searched for as phrases.
+func issueErrorStrings(body string) []string {
+	var errs []string
+	for _, line := range strings.Split(body, "\n") {
+		if len(errs) == maxSimilarIssueErrorStrings {
+			break
+		}
+		if !similarIssueErrorRegexp.MatchString(line) {
+			continue
+		}
+		// Quotes would end the phrase early
+		line = strings.Join(strings.Fields(strings.NewReplacer(`"`, " ", "`", " ").Replace(line)), " ")
+		if len(line) > maxSimilarIssueErrorLength {
+			line = line[:maxSimilarIssueErrorLength]
+			if i := strings.LastIndex(line, " "); i > 0 {
+				line = line[:i]
+			}
+		}
+		if line != "" {
+			errs = append(errs, line)
+		}
+	}
+	return errs
+}
+
+// keywordOverlap returns the share of keywords that occur in the keywords of title.
+func keywordOverlap(keywords []string, title string) float64 {
+	if len(keywords) == 0 {
+		return 0
+	}
+	titleKeywords := map[string]bool{}
+	for _, k := range issueKeywords(title) {
+		titleKeywords[k] = true
+	}
+	matches := 0
+	for _, k := range keywords {
+		if titleKeywords[k] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(keywords))
+}
+
+// FindSimilarIssues creates a tool to find issues that may be duplicates of a new issue.
+func FindSimilarIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_similar_issues",
+			mcp.WithDescription(t("TOOL_FIND_SIMILAR_ISSUES_DESCRIPTION", "Find existing issues in a repository that may be duplicates of an issue, given its title and body. Searches for the keywords of the title and for error messages quoted in the body, and ranks the candidates by how many searches found them and how much their titles overlap. Use it to triage new issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_SIMILAR_ISSUES_USER_TITLE", "Find similar issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the issue to find duplicates of"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Body of the issue to find duplicates of. Error messages in it are searched for"),
+			),
+			mcp.WithNumber("exclude_issue_number",
+				mcp.Description("Number of the issue itself, when it already exists, so it is not returned"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of candidates to return"),
+				mcp.Min(1),
+				mcp.Max(50),
+				mcp.DefaultNumber(10),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			exclude, err := OptionalIntParam(request, "exclude_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			keywords := issueKeywords(title)
+			if len(keywords) > maxSimilarIssueKeywords {
+				keywords = keywords[:maxSimilarIssueKeywords]
+			}
+			scope := fmt.Sprintf("repo:%s/%s is:issue", owner, repo)
+			type search struct {
+				name  string
+				query string
+			}
+			var searches []search
+			if len(keywords) > 0 {
+				searches = append(searches, search{"title keywords", fmt.Sprintf("%s in:title %s", scope, strings.Join(keywords, " "))})
+			}
+			// Search terms must all match, so a looser search with the most specific keywords finds
+			// duplicates worded differently
+			if len(keywords) > 3 {
+				specific := append([]string(nil), keywords...)
+				sort.SliceStable(specific, func(i, j int) bool { return len(specific[i]) > len(specific[j]) })
+				searches = append(searches, search{"specific keywords", fmt.Sprintf("%s in:title,body %s", scope, strings.Join(specific[:3], " "))})
+			}
+			for _, e := range issueErrorStrings(body) {
+				searches = append(searches, search{"error string", fmt.Sprintf(`%s in:body "%s"`, scope, e)})
+			}
+			if len(searches) == 0 {
+				return mcp.NewToolResultError("the title has no keywords to search for and the body has no error messages"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			candidates := map[int]*SimilarIssue{}
+			var order []int
+			for _, s := range searches {
+				result, resp, err := client.Search.Issues(ctx, s.query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 20}})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to search issues with %q", s.query), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				for _, issue := range result.Issues {
+					number := issue.GetNumber()
+					if number == exclude {
+						continue
+					}
+					c, ok := candidates[number]
+					if !ok {
+						c = &SimilarIssue{
+							Number:  number,
+							Title:   issue.GetTitle(),
+							State:   issue.GetState(),
+							HTMLURL: issue.GetHTMLURL(),
+						}
+						if issue.UpdatedAt != nil {
+							c.UpdatedAt = issue.GetUpdatedAt().Format(time.RFC3339)
+						}
+						candidates[number] = c
+						order = append(order, number)
+					}
+					if !slices.Contains(c.MatchedBy, s.name) {
+						c.MatchedBy = append(c.MatchedBy, s.name)
+					}
+				}
+			}
+
+			similar := make([]*SimilarIssue, 0, len(order))
+			for _, number := range order {
+				c := candidates[number]
+				// Every search that found the issue counts, error strings most as they rarely match by chance
+				score := keywordOverlap(keywords, c.Title)
+				for _, m := range c.MatchedBy {
+					if m == "error string" {
+						score += 1
+					} else {
+						score += 0.5
+					}
+				}
+				c.Score = math.Round(score*100) / 100
+				similar = append(similar, c)
+			}
+			sort.SliceStable(similar, func(i, j int) bool { return similar[i].Score > similar[j].Score })
+			if len(similar) > limit {
+				similar = similar[:limit]
+			}
+
+			queries := make([]string, 0, len(searches))
+			for _, s := range searches {
+				queries = append(queries, s.query)
+			}
+			return MarshalledTextResult(map[string]any{
+				"candidates": similar,
+				"queries":    queries,
+			}), nil
+		}
+}
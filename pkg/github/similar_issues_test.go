@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueKeywordsAndErrorStrings(t *testing.T) {
+	assert.Equal(t, []string{"login", "page", "crashes", "safari", "v1.2.3"}, issueKeywords("Login page crashes on Safari with v1.2.3 - the login BUG"))
+
+	body := "Steps:\n1. open the app\n\n```\nError: \"connection refused\" while dialing tcp 127.0.0.1:5432\n```\npanic: runtime error: invalid memory address or nil pointer dereference in the handler of the request that was sent\nfatal: third"
+	assert.Equal(t, []string{
+		"Error: connection refused while dialing tcp 127.0.0.1:5432",
+		"panic: runtime error: invalid memory address or nil pointer dereference in the",
+	}, issueErrorStrings(body))
+}
+
+func Test_FindSimilarIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindSimilarIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_similar_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	issue := func(number int, title, state string) *github.Issue {
+		return &github.Issue{
+			Number:  github.Ptr(number),
+			Title:   github.Ptr(title),
+			State:   github.Ptr(state),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/" + title),
+		}
+	}
+	// Each search finds different issues, so the queries sent can be told apart by the results
+	searchHandler := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			assert.True(t, strings.HasPrefix(q, "repo:owner/repo is:issue "), q)
+			var issues []*github.Issue
+			switch {
+			case strings.Contains(q, `in:body "`):
+				issues = []*github.Issue{issue(3, "Database unavailable", "closed"), issue(7, "Crash on startup", "open")}
+			case strings.Contains(q, "in:title,body"):
+				issues = []*github.Issue{issue(5, "Startup crash when database down", "open")}
+			default:
+				issues = []*github.Issue{issue(7, "Crash on startup", "open"), issue(9, "Startup crash with postgres connection", "open")}
+			}
+			mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(len(issues)), Issues: issues})(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedNumbers []int
+		expectedQueries int
+	}{
+		{
+			name:         "ranks candidates found by several searches first",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler(t))),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "Crash on startup with postgres connection",
+				"body":  "Error: connection refused",
+			},
+			expectedNumbers: []int{7, 9, 5, 3},
+			expectedQueries: 3,
+		},
+		{
+			name:         "excludes the issue itself and limits",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler(t))),
+			requestArgs: map[string]any{
+				"owner":                "owner",
+				"repo":                 "repo",
+				"title":                "Crash on startup with postgres connection",
+				"body":                 "Error: connection refused",
+				"exclude_issue_number": float64(7),
+				"limit":                float64(2),
+			},
+			expectedNumbers: []int{9, 5},
+			expectedQueries: 3,
+		},
+		{
+			name:         "nothing to search for",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "It does not work",
+			},
+			expectError:    true,
+			expectedErrMsg: "the title has no keywords to search for",
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "Crash on startup",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := FindSimilarIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				Candidates []SimilarIssue `json:"candidates"`
+				Queries    []string       `json:"queries"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Len(t, got.Queries, tc.expectedQueries)
+			numbers := make([]int, 0, len(got.Candidates))
+			for _, c := range got.Candidates {
+				numbers = append(numbers, c.Number)
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SummaryParam is the parameter list tools accept to return a summary instead of the items.
+const SummaryParam = "summary"
+
+const (
+	// maxSummaryItems bounds how many items a summary reads across all pages.
+	maxSummaryItems = 1000
+	// maxSummarySamples bounds how many representative items a summary includes.
+	maxSummarySamples = 5
+)
+
+// WithSummary adds the summary parameter to a list tool.
+func WithSummary() mcp.ToolOption {
+	return mcp.WithBoolean(SummaryParam,
+		mcp.Description("Return counts and a few representative samples computed over all pages instead of the items themselves. Pagination parameters are ignored."),
+	)
+}
+
+// ListSummary condenses a list result to counts per dimension and a few representative items.
+type ListSummary struct {
+	TotalCount int                       `json:"total_count"`
+	Analyzed   int                       `json:"analyzed"`
+	Truncated  bool                      `json:"truncated"`
+	Counts     map[string]map[string]int `json:"counts"`
+	Totals     map[string]int            `json:"totals,omitempty"`
+	Samples    []any                     `json:"samples"`
+
+	sampled map[string]bool
+}
+
+func newListSummary(dimensions ...string) *ListSummary {
+	s := &ListSummary{
+		Counts:  make(map[string]map[string]int, len(dimensions)),
+		Samples: []any{},
+		sampled: map[string]bool{},
+	}
+	for _, dimension := range dimensions {
+		s.Counts[dimension] = map[string]int{}
+	}
+	return s
+}
+
+// count records one item under value in the given dimension. Empty values are counted as "(none)".
+// Dimensions not passed to newListSummary are added when first counted.
+func (s *ListSummary) count(dimension, value string) {
+	if value == "" {
+		value = "(none)"
+	}
+	if s.Counts[dimension] == nil {
+		s.Counts[dimension] = map[string]int{}
+	}
+	s.Counts[dimension][value]++
+}
+
+// add accumulates n into the named total.
+func (s *ListSummary) add(total string, n int) {
+	if s.Totals == nil {
+		s.Totals = map[string]int{}
+	}
+	s.Totals[total] += n
+}
+
+// sample keeps the first item seen for each distinct key, so the samples show the variety of the
+// list rather than its first few entries.
+func (s *ListSummary) sample(key string, item any) {
+	if s.sampled[key] || len(s.Samples) >= maxSummarySamples {
+		return
+	}
+	s.sampled[key] = true
+	s.Samples = append(s.Samples, item)
+}
@@ -16,7 +16,7 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int, archiveDir string) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Define all available features with their default state (disabled)
@@ -25,26 +25,64 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(FetchResource(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetRepoReadme(getClient, t)),
+			toolsets.NewServerTool(GetRepoFileTreeDocs(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(CompareCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetRef(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
 			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GetReleaseNotesData(getClient, t)),
 			toolsets.NewServerTool(ListStarredRepositories(getClient, t)),
+			toolsets.NewServerTool(GetBlame(getGQLClient, t)),
+			toolsets.NewServerTool(GetCustomPropertyValues(getClient, t)),
+			toolsets.NewServerTool(GetCodeownersForPath(getClient, t)),
+			toolsets.NewServerTool(GetContributorsStats(getClient, t)),
+			toolsets.NewServerTool(GetCommitActivity(getClient, t)),
+			toolsets.NewServerTool(GetRepoLanguages(getClient, t)),
+			toolsets.NewServerTool(GetStargazerTimeline(getGQLClient, t)),
+			toolsets.NewServerTool(ListRepoEvents(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryInvitations(getClient, t)),
+			toolsets.NewServerTool(ListDeployKeys(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
+			toolsets.NewServerTool(CheckPushAllowed(getClient, t)),
+			toolsets.NewServerTool(DownloadRepoArchive(getClient, archiveDir, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(SyncForkWithUpstream(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(RenameBranch(getClient, t)),
+			toolsets.NewServerTool(CreateRef(getClient, t)),
+			toolsets.NewServerTool(UpdateRef(getClient, t)),
+			toolsets.NewServerTool(CreateAnnotatedTag(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateChange(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(ProposeHealthFiles(getClient, t)),
 			toolsets.NewServerTool(StarRepository(getClient, t)),
 			toolsets.NewServerTool(UnstarRepository(getClient, t)),
+			toolsets.NewServerTool(SetRepoTopics(getClient, t)),
+			toolsets.NewServerTool(SetCustomPropertyValues(getClient, t)),
+			toolsets.NewServerTool(AcceptRepositoryInvitation(getClient, t)),
+			toolsets.NewServerTool(DeclineRepositoryInvitation(getClient, t)),
+			toolsets.NewServerTool(DeleteRepositoryInvitation(getClient, t)),
+			toolsets.NewServerTool(AddDeployKey(getClient, t)),
+			toolsets.NewServerTool(DeleteDeployKey(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
+			toolsets.NewServerTool(ArchiveRepository(getClient, t)),
+			toolsets.NewServerTool(UnarchiveRepository(getClient, t)),
+			toolsets.NewServerTool(TransferRepository(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -57,19 +95,39 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
+			toolsets.NewServerTool(FindSimilarIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
+			toolsets.NewServerTool(GetIssueTemplates(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(ListReactions(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(ListAssignableUsers(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getClient, t)),
+			toolsets.NewServerTool(GetLinkedItems(getGQLClient, t)),
+			toolsets.NewServerTool(ListPinnedIssues(getGQLClient, t)),
+			toolsets.NewServerTool(GetMilestoneProgress(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(BulkUpdateIssues(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(AddReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(DeleteReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(AddAssignees(getClient, t)),
+			toolsets.NewServerTool(RemoveAssignees(getClient, t)),
+			toolsets.NewServerTool(StartWorkOnIssue(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(LockIssue(getClient, t)),
+			toolsets.NewServerTool(UnlockIssue(getClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(CloseIssueAsDuplicate(getGQLClient, t)),
+			toolsets.NewServerTool(PinIssue(getGQLClient, t)),
+			toolsets.NewServerTool(UnpinIssue(getGQLClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
@@ -77,21 +135,47 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(ListPublicSSHKeys(getClient, t)),
+			toolsets.NewServerTool(ListGPGKeys(getClient, t)),
+			toolsets.NewServerTool(GetUserActivity(getClient, getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddSSHKey(getClient, t)),
+			toolsets.NewServerTool(DeleteSSHKey(getClient, t)),
 		)
 	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+			toolsets.NewServerTool(SearchOrgMembers(getClient, t)),
+			toolsets.NewServerTool(GetOrgMembership(getClient, t)),
+			toolsets.NewServerTool(ListOutsideCollaborators(getClient, t)),
+			toolsets.NewServerTool(ListOrgInstallations(getClient, t)),
+			toolsets.NewServerTool(ListOrgHooks(getClient, t)),
+			toolsets.NewServerTool(ListOrgEvents(getClient, t)),
+			toolsets.NewServerTool(AggregateOrgLanguages(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(RemoveOutsideCollaborator(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetPullRequest(getClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestDiffstat(getClient, t)),
+			toolsets.NewServerTool(SuggestReviewers(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(GetReviewRequestsDigest(getGQLClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(WaitForChecks(getClient, t)),
+			toolsets.NewServerTool(CompareChecksToProtection(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviewComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(ListPullRequestReviewThreads(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(GetMergeQueue(getGQLClient, t)),
+			toolsets.NewServerTool(ListMergeQueueEntries(getGQLClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
@@ -99,6 +183,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(ConvertPullRequestToDraft(getGQLClient, t)),
+			toolsets.NewServerTool(MarkPullRequestReadyForReview(getGQLClient, t)),
+			toolsets.NewServerTool(EnablePullRequestAutoMerge(getGQLClient, t)),
+			toolsets.NewServerTool(DisablePullRequestAutoMerge(getGQLClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
@@ -106,16 +194,21 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+			toolsets.NewServerTool(ResolveReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnresolveReviewThread(getGQLClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(GetRepoSecuritySettings(getClient, t)),
+			toolsets.NewServerTool(GetOrgSecurityOverview(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListPushProtectionBypasses(getClient, t)),
 		)
 	dependabot := toolsets.NewToolset("dependabot", "Dependabot tools").
 		AddReadTools(
@@ -133,6 +226,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(MarkAllNotificationsRead(getClient, t)),
 			toolsets.NewServerTool(ManageNotificationSubscription(getClient, t)),
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
+			toolsets.NewServerTool(SubscribeToThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnsubscribeFromThread(getGQLClient, t)),
 		)
 
 	discussions := toolsets.NewToolset("discussions", "GitHub Discussions related tools").
@@ -148,19 +243,30 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(WaitForWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t, contentWindowSize)),
+			toolsets.NewServerTool(GetCheckAnnotations(getClient, t)),
+			toolsets.NewServerTool(GetDORAMetrics(getClient, t)),
+			toolsets.NewServerTool(ValidateWorkflowFile(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(GetDeploymentDiff(getClient, t)),
+			toolsets.NewServerTool(GetActionsPermissions(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryDispatchEvent(getClient, t)),
 			toolsets.NewServerTool(RerunWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployment(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(CreateWorkflow(getClient, t)),
+			toolsets.NewServerTool(SetActionsPermissions(getClient, t)),
 		)
 
 	securityAdvisories := toolsets.NewToolset("security_advisories", "Security advisories related tools").
@@ -171,6 +277,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListOrgRepositorySecurityAdvisories(getClient, t)),
 		)
 
+	dependencyGraph := toolsets.NewToolset("dependency_graph", "Dependency graph and software bill of materials (SBOM) tools").
+		AddReadTools(
+			toolsets.NewServerTool(GetDependencyGraph(getGQLClient, t)),
+			toolsets.NewServerTool(ExportSBOM(getClient, t)),
+		)
+
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
@@ -179,6 +291,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetMe(getClient, t)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
+			toolsets.NewServerTool(CheckAuth(getClient, tsg, t)),
+			toolsets.NewServerTool(PlanTokenPermissions(tsg, t)),
 		)
 
 	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
@@ -190,6 +304,28 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(UpdateGist(getClient, t)),
 		)
 
+	projects := toolsets.NewToolset("projects", "GitHub Projects related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListProjectIterations(getGQLClient, t)),
+			toolsets.NewServerTool(GetProjectInsights(getGQLClient, t)),
+			toolsets.NewServerTool(ExportProjectItems(getGQLClient, archiveDir, t)),
+			toolsets.NewServerTool(FindItemInProjects(getGQLClient, t)),
+			toolsets.NewServerTool(ResolveProject(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectRepos(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectWorkflows(getGQLClient, t)),
+			toolsets.NewServerTool(SearchProjectIssues(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectTemplates(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ProvisionProject(getGQLClient, t)),
+			toolsets.NewServerTool(SetItemIteration(getGQLClient, t)),
+			toolsets.NewServerTool(UpdateDraftIssue(getGQLClient, t)),
+			toolsets.NewServerTool(CommentOnProjectItem(getGQLClient, t)),
+			toolsets.NewServerTool(ClearProjectItemField(getGQLClient, t)),
+			toolsets.NewServerTool(MarkProjectAsTemplate(getGQLClient, t)),
+			toolsets.NewServerTool(UnmarkProjectAsTemplate(getGQLClient, t)),
+		)
+
 	// Add toolsets to the group
 	tsg.AddToolset(contextTools)
 	tsg.AddToolset(repos)
@@ -201,11 +337,17 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(dependabot)
+	tsg.AddToolset(dependencyGraph)
 	tsg.AddToolset(notifications)
 	tsg.AddToolset(experiments)
 	tsg.AddToolset(discussions)
 	tsg.AddToolset(gists)
 	tsg.AddToolset(securityAdvisories)
+	tsg.AddToolset(projects)
+
+	for _, toolset := range tsg.Toolsets {
+		toolset.WrapTools(WithFieldProjection)
+	}
 
 	return tsg
 }
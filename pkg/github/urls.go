@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GitHubURL is what a github.com URL identifies. Fields the URL does not carry are left empty.
+type GitHubURL struct {
+	// Kind is one of repository, issue, pull, discussion, commit, blob, tree, run or project
+	Kind      string
+	Owner     string
+	OwnerType string
+	Repo      string
+	Number    int
+	Ref       string
+	Path      string
+}
+
+// ParseGitHubURL parses the URL of a repository, issue, pull request, discussion, commit, file,
+// directory, workflow run or project. The host is not checked, so URLs of GitHub Enterprise Server
+// are accepted as well. Refs containing slashes cannot be told apart from paths in file URLs, so
+// the first segment after blob or tree is always taken as the ref.
+func ParseGitHubURL(rawURL string) (GitHubURL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return GitHubURL{}, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return GitHubURL{}, fmt.Errorf("%q is not a GitHub URL", rawURL)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if segments[0] == "orgs" || segments[0] == "users" {
+		owner, ownerType, number, err := parseProjectURL(rawURL)
+		if err != nil {
+			return GitHubURL{}, err
+		}
+		return GitHubURL{Kind: "project", Owner: owner, OwnerType: ownerType, Number: number}, nil
+	}
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return GitHubURL{}, fmt.Errorf("%q is not a GitHub URL", rawURL)
+	}
+
+	parsed := GitHubURL{Kind: "repository", Owner: segments[0], Repo: strings.TrimSuffix(segments[1], ".git")}
+	rest := segments[2:]
+	if len(rest) == 0 {
+		return parsed, nil
+	}
+
+	switch rest[0] {
+	case "issues", "pull", "discussions":
+		if len(rest) == 1 {
+			return parsed, nil
+		}
+		parsed.Kind = map[string]string{"issues": "issue", "pull": "pull", "discussions": "discussion"}[rest[0]]
+		parsed.Number, err = strconv.Atoi(rest[1])
+	case "commit":
+		if len(rest) < 2 {
+			return GitHubURL{}, fmt.Errorf("%q is missing the commit SHA", rawURL)
+		}
+		parsed.Kind = "commit"
+		parsed.Ref = rest[1]
+	case "blob", "tree":
+		if len(rest) < 2 {
+			return GitHubURL{}, fmt.Errorf("%q is missing the ref", rawURL)
+		}
+		parsed.Kind = rest[0]
+		parsed.Ref = rest[1]
+		parsed.Path = strings.Join(rest[2:], "/")
+	case "actions":
+		if len(rest) < 3 || rest[1] != "runs" {
+			return parsed, nil
+		}
+		parsed.Kind = "run"
+		parsed.Number, err = strconv.Atoi(rest[2])
+	}
+	if err != nil || (parsed.Kind != "repository" && parsed.Ref == "" && parsed.Number <= 0) {
+		return GitHubURL{}, fmt.Errorf("%q has an invalid %s number", rawURL, parsed.Kind)
+	}
+	return parsed, nil
+}
+
+// urlNumberParams lists, for each kind of URL, the parameters its number may be passed as, in the
+// order they are preferred. Pull requests are issues too, so their number can go to issue tools.
+var urlNumberParams = map[string][]string{
+	"issue":      {"issue_number", "issueNumber", "number"},
+	"pull":       {"pullNumber", "pull_number", "issue_number", "issueNumber", "number"},
+	"discussion": {"discussionNumber", "number"},
+	"run":        {"run_id"},
+	"project":    {"project_number"},
+}
+
+// urlRefParams lists, for each kind of URL, the parameters its ref may be passed as.
+var urlRefParams = map[string][]string{
+	"commit": {"sha", "ref"},
+	"blob":   {"ref", "branch", "sha"},
+	"tree":   {"ref", "branch", "sha"},
+}
+
+// arguments returns the parameters of the tool with the given properties that u provides.
+func (u GitHubURL) arguments(properties map[string]any) map[string]any {
+	args := map[string]any{}
+	set := func(candidates []string, value any) {
+		for _, name := range candidates {
+			if _, ok := properties[name]; ok {
+				args[name] = value
+				return
+			}
+		}
+	}
+
+	set([]string{"owner"}, u.Owner)
+	if u.OwnerType != "" {
+		set([]string{"owner_type"}, u.OwnerType)
+	}
+	if u.Repo != "" {
+		set([]string{"repo"}, u.Repo)
+	}
+	if u.Number != 0 {
+		set(urlNumberParams[u.Kind], float64(u.Number))
+	}
+	if u.Ref != "" {
+		set(urlRefParams[u.Kind], u.Ref)
+	}
+	if u.Path != "" {
+		set([]string{"path"}, u.Path)
+	}
+	return args
+}
+
+// AcceptURLs adds a url parameter to tools that take an owner, so that a github.com URL pasted by
+// the user can stand in for the owner, repo, number, ref and path parameters it carries. A URL
+// passed as the owner is understood the same way. Explicit arguments win over those taken from the
+// URL. Tools without an owner parameter, or with a url parameter of their own, are returned unchanged.
+func AcceptURLs(tool server.ServerTool) server.ServerTool {
+	properties := tool.Tool.InputSchema.Properties
+	if _, ok := properties["owner"]; !ok {
+		return tool
+	}
+	if _, ok := properties["url"]; ok {
+		return tool
+	}
+
+	// The parameters a URL can provide are no longer required, as they may come from the URL
+	fromURL := map[string]bool{"owner": true, "repo": true, "path": true}
+	for _, names := range urlNumberParams {
+		for _, name := range names {
+			fromURL[name] = true
+		}
+	}
+
+	// Copy the schema so the original tool definition is left untouched
+	wrapped := tool.Tool
+	wrapped.InputSchema.Properties = maps.Clone(properties)
+	wrapped.InputSchema.Properties["url"] = map[string]any{
+		"type":        "string",
+		"description": "A GitHub URL of a repository, issue, pull request, discussion, commit, file, workflow run or project. The owner, repo, number, ref and path are taken from it when they are omitted",
+	}
+	wrapped.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.Tool.InputSchema.Required), func(name string) bool {
+		return fromURL[name]
+	})
+
+	next := tool.Handler
+	return server.ServerTool{
+		Tool: wrapped,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := maps.Clone(request.GetArguments())
+			if args == nil {
+				args = map[string]any{}
+			}
+
+			rawURL, ok := args["url"].(string)
+			delete(args, "url")
+			if owner, isString := args["owner"].(string); !ok && isString && strings.Contains(owner, "://") {
+				rawURL, ok = owner, true
+				delete(args, "owner")
+			}
+			if ok && rawURL != "" {
+				parsed, err := ParseGitHubURL(rawURL)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				for name, value := range parsed.arguments(properties) {
+					if _, set := args[name]; !set {
+						args[name] = value
+					}
+				}
+			}
+
+			request.Params.Arguments = args
+			return next(ctx, request)
+		},
+	}
+}
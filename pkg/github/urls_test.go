@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGitHubURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expected       GitHubURL
+		expectedErrMsg string
+	}{
+		{
+			name:     "repository",
+			url:      "https://github.com/octo-org/octo-repo",
+			expected: GitHubURL{Kind: "repository", Owner: "octo-org", Repo: "octo-repo"},
+		},
+		{
+			name:     "clone URL",
+			url:      "https://github.com/octo-org/octo-repo.git",
+			expected: GitHubURL{Kind: "repository", Owner: "octo-org", Repo: "octo-repo"},
+		},
+		{
+			name:     "issue with comment anchor",
+			url:      "https://github.com/octo-org/octo-repo/issues/42#issuecomment-1",
+			expected: GitHubURL{Kind: "issue", Owner: "octo-org", Repo: "octo-repo", Number: 42},
+		},
+		{
+			name:     "pull request files tab",
+			url:      "https://github.com/octo-org/octo-repo/pull/7/files",
+			expected: GitHubURL{Kind: "pull", Owner: "octo-org", Repo: "octo-repo", Number: 7},
+		},
+		{
+			name:     "discussion",
+			url:      "https://github.com/octo-org/octo-repo/discussions/3",
+			expected: GitHubURL{Kind: "discussion", Owner: "octo-org", Repo: "octo-repo", Number: 3},
+		},
+		{
+			name:     "file permalink",
+			url:      "https://github.com/octo-org/octo-repo/blob/abc123/docs/README.md#L10-L20",
+			expected: GitHubURL{Kind: "blob", Owner: "octo-org", Repo: "octo-repo", Ref: "abc123", Path: "docs/README.md"},
+		},
+		{
+			name:     "directory",
+			url:      "https://github.com/octo-org/octo-repo/tree/main/pkg",
+			expected: GitHubURL{Kind: "tree", Owner: "octo-org", Repo: "octo-repo", Ref: "main", Path: "pkg"},
+		},
+		{
+			name:     "commit",
+			url:      "https://github.com/octo-org/octo-repo/commit/abc123",
+			expected: GitHubURL{Kind: "commit", Owner: "octo-org", Repo: "octo-repo", Ref: "abc123"},
+		},
+		{
+			name:     "workflow run",
+			url:      "https://github.com/octo-org/octo-repo/actions/runs/12345/job/6",
+			expected: GitHubURL{Kind: "run", Owner: "octo-org", Repo: "octo-repo", Number: 12345},
+		},
+		{
+			name:     "enterprise server project",
+			url:      "https://ghes.example.com/orgs/octo-org/projects/7/views/1",
+			expected: GitHubURL{Kind: "project", Owner: "octo-org", OwnerType: "org", Number: 7},
+		},
+		{
+			name:           "invalid issue number",
+			url:            "https://github.com/octo-org/octo-repo/issues/new",
+			expectedErrMsg: "has an invalid issue number",
+		},
+		{
+			name:           "not a URL",
+			url:            "octo-org",
+			expectedErrMsg: "is not a GitHub URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseGitHubURL(tc.url)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func Test_AcceptURLs(t *testing.T) {
+	var received map[string]any
+	tool, _ := GetPullRequestFiles(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	original := server.ServerTool{
+		Tool: tool,
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			received = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+
+	wrapped := AcceptURLs(original)
+
+	assert.Empty(t, wrapped.Tool.InputSchema.Required)
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties, "url")
+	// The original definition is left untouched
+	assert.ElementsMatch(t, []string{"owner", "repo", "pullNumber"}, original.Tool.InputSchema.Required)
+	assert.NotContains(t, original.Tool.InputSchema.Properties, "url")
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expected       map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:     "pull request URL",
+			args:     map[string]any{"url": "https://github.com/octo-org/octo-repo/pull/7"},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "pullNumber": float64(7)},
+		},
+		{
+			name:     "URL passed as the owner",
+			args:     map[string]any{"owner": "https://github.com/octo-org/octo-repo/pull/7"},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "pullNumber": float64(7)},
+		},
+		{
+			name:     "explicit values win",
+			args:     map[string]any{"url": "https://github.com/octo-org/octo-repo/pull/7", "pullNumber": float64(8)},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "pullNumber": float64(8)},
+		},
+		{
+			name:     "without URL",
+			args:     map[string]any{"owner": "octo-org", "repo": "octo-repo", "pullNumber": float64(7)},
+			expected: map[string]any{"owner": "octo-org", "repo": "octo-repo", "pullNumber": float64(7)},
+		},
+		{
+			name:           "invalid URL",
+			args:           map[string]any{"url": "https://github.com/octo-org/octo-repo/pull/latest"},
+			expectedErrMsg: "has an invalid pull number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = nil
+			result, err := wrapped.Handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, received)
+				return
+			}
+			assert.Equal(t, tc.expected, received)
+		})
+	}
+
+	t.Run("file URL fills ref and path", func(t *testing.T) {
+		fileTool, _ := GetFileContents(stubGetClientFn(github.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		wrappedFile := AcceptURLs(server.ServerTool{Tool: fileTool, Handler: original.Handler})
+		_, err := wrappedFile.Handler(context.Background(), createMCPRequest(map[string]any{
+			"url": "https://github.com/octo-org/octo-repo/blob/main/docs/README.md",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"owner": "octo-org", "repo": "octo-repo", "ref": "main", "path": "docs/README.md"}, received)
+	})
+
+	t.Run("tools without an owner are unchanged", func(t *testing.T) {
+		searchTool, _ := SearchRepositories(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		unchanged := AcceptURLs(server.ServerTool{Tool: searchTool})
+		assert.Equal(t, searchTool, unchanged.Tool)
+	})
+}
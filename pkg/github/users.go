@@ -0,0 +1,573 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// MinimalSSHKey is the trimmed output type for a public SSH key.
+type MinimalSSHKey struct {
+	ID        int64      `json:"id"`
+	Key       string     `json:"key"`
+	Title     string     `json:"title,omitempty"`
+	Verified  bool       `json:"verified,omitempty"`
+	ReadOnly  bool       `json:"read_only,omitempty"`
+	AddedBy   string     `json:"added_by,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+}
+
+// MinimalGPGKey is the trimmed output type for a GPG key.
+type MinimalGPGKey struct {
+	ID        int64      `json:"id"`
+	KeyID     string     `json:"key_id"`
+	Emails    []string   `json:"emails,omitempty"`
+	CanSign   bool       `json:"can_sign"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func convertToMinimalSSHKey(key *github.Key) MinimalSSHKey {
+	minimalKey := MinimalSSHKey{
+		ID:       key.GetID(),
+		Key:      key.GetKey(),
+		Title:    key.GetTitle(),
+		Verified: key.GetVerified(),
+		ReadOnly: key.GetReadOnly(),
+		AddedBy:  key.GetAddedBy(),
+	}
+	if key.CreatedAt != nil {
+		minimalKey.CreatedAt = &key.CreatedAt.Time
+	}
+	if key.LastUsed != nil {
+		minimalKey.LastUsed = &key.LastUsed.Time
+	}
+	return minimalKey
+}
+
+// ListPublicSSHKeys creates a tool to list the public SSH keys of a user.
+func ListPublicSSHKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_public_ssh_keys",
+			mcp.WithDescription(t("TOOL_LIST_PUBLIC_SSH_KEYS_DESCRIPTION", "List the public SSH keys of a GitHub user. Omit username to list the keys of the authenticated user, including their titles and when they were last used.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PUBLIC_SSH_KEYS_USER_TITLE", "List public SSH keys"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username to list the keys of. Defaults to the authenticated user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListKeys(ctx, username, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list public SSH keys",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalKeys := make([]MinimalSSHKey, 0, len(keys))
+			for _, key := range keys {
+				minimalKeys = append(minimalKeys, convertToMinimalSSHKey(key))
+			}
+			return MarshalledTextResult(minimalKeys), nil
+		}
+}
+
+// AddSSHKey creates a tool to add a public SSH key to the authenticated user's account.
+func AddSSHKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_ssh_key",
+			mcp.WithDescription(t("TOOL_ADD_SSH_KEY_DESCRIPTION", "Add a public SSH key to the authenticated user's GitHub account, granting whoever holds the private key access to everything the user can access over SSH. Requires the write:public_key scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SSH_KEY_USER_TITLE", "Add SSH key"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Descriptive name for the key, for example the machine it belongs to"),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("Public SSH key, for example 'ssh-ed25519 AAAA... user@host'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := RequiredParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key = strings.TrimSpace(key)
+			if strings.Contains(key, "PRIVATE KEY") {
+				return mcp.NewToolResultError("key looks like a private key, only the public key must be added"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Users.CreateKey(ctx, &github.Key{
+				Title: github.Ptr(title),
+				Key:   github.Ptr(key),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to add SSH key",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalSSHKey(created)), nil
+		}
+}
+
+// DeleteSSHKey creates a tool to delete a public SSH key from the authenticated user's account.
+func DeleteSSHKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_ssh_key",
+			mcp.WithDescription(t("TOOL_DELETE_SSH_KEY_DESCRIPTION", "Delete a public SSH key from the authenticated user's GitHub account, revoking its access. Requires the admin:public_key scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_SSH_KEY_USER_TITLE", "Delete SSH key"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("key_id",
+				mcp.Required(),
+				mcp.Description("ID of the SSH key, as returned by list_public_ssh_keys"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyID, err := RequiredInt(request, "key_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Users.DeleteKey(ctx, int64(keyID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete SSH key %d", keyID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted SSH key %d", keyID)), nil
+		}
+}
+
+// ListGPGKeys creates a tool to list the GPG keys of a user.
+func ListGPGKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_gpg_keys",
+			mcp.WithDescription(t("TOOL_LIST_GPG_KEYS_DESCRIPTION", "List the GPG keys a GitHub user signs commits with, including the email addresses each key is valid for. Omit username to list the keys of the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_GPG_KEYS_USER_TITLE", "List GPG keys"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username to list the keys of. Defaults to the authenticated user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListGPGKeys(ctx, username, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list GPG keys",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalKeys := make([]MinimalGPGKey, 0, len(keys))
+			for _, key := range keys {
+				minimalKey := MinimalGPGKey{
+					ID:      key.GetID(),
+					KeyID:   key.GetKeyID(),
+					CanSign: key.GetCanSign(),
+				}
+				for _, email := range key.Emails {
+					minimalKey.Emails = append(minimalKey.Emails, email.GetEmail())
+				}
+				if key.CreatedAt != nil {
+					minimalKey.CreatedAt = &key.CreatedAt.Time
+				}
+				if key.ExpiresAt != nil {
+					minimalKey.ExpiresAt = &key.ExpiresAt.Time
+				}
+				minimalKeys = append(minimalKeys, minimalKey)
+			}
+			return MarshalledTextResult(minimalKeys), nil
+		}
+}
+
+// maxUserActivityEvents is the number of recent events the events API returns at most.
+const maxUserActivityEvents = 300
+
+// maxUserActivityEventAge is how long the events API keeps events.
+const maxUserActivityEventAge = 90 * 24 * time.Hour
+
+// ActivityItem is an issue, pull request or review in a user activity summary.
+type ActivityItem struct {
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	State      string    `json:"state,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ActivityTotals holds the number of contributions of a user activity summary.
+type ActivityTotals struct {
+	Commits              int `json:"commits"`
+	PullRequestsOpened   int `json:"pull_requests_opened"`
+	ReviewsGiven         int `json:"reviews_given"`
+	IssuesOpened         int `json:"issues_opened"`
+	IssuesClosed         int `json:"issues_closed"`
+	PrivateContributions int `json:"private_contributions"`
+}
+
+// RepositoryCommits is the number of commits a user made to a repository.
+type RepositoryCommits struct {
+	Repository string `json:"repository"`
+	Commits    int    `json:"commits"`
+}
+
+// UserActivity summarizes the contributions of a user over a date range.
+type UserActivity struct {
+	Login              string              `json:"login"`
+	From               time.Time           `json:"from"`
+	To                 time.Time           `json:"to"`
+	Totals             ActivityTotals      `json:"totals"`
+	CommitsByRepo      []RepositoryCommits `json:"commits_by_repository"`
+	PullRequestsOpened []ActivityItem      `json:"pull_requests_opened"`
+	ReviewsGiven       []ActivityItem      `json:"reviews_given"`
+	IssuesOpened       []ActivityItem      `json:"issues_opened"`
+	IssuesClosed       []ActivityItem      `json:"issues_closed"`
+	// EventsComplete is false if the events API, which only returns the most recent events,
+	// did not reach back to the start of the range, so closed issues may be missing.
+	EventsComplete bool `json:"events_complete"`
+}
+
+type activityRepository struct {
+	NameWithOwner githubv4.String
+}
+
+type userActivityQuery struct {
+	User struct {
+		Login                   githubv4.String
+		ContributionsCollection struct {
+			TotalCommitContributions            githubv4.Int
+			TotalIssueContributions             githubv4.Int
+			TotalPullRequestContributions       githubv4.Int
+			TotalPullRequestReviewContributions githubv4.Int
+			RestrictedContributionsCount        githubv4.Int
+			CommitContributionsByRepository     []struct {
+				Repository    activityRepository
+				Contributions struct {
+					TotalCount githubv4.Int
+				}
+			} `graphql:"commitContributionsByRepository(maxRepositories: 25)"`
+			PullRequestContributions struct {
+				Nodes []struct {
+					PullRequest struct {
+						Number     githubv4.Int
+						Title      githubv4.String
+						URL        githubv4.String
+						State      githubv4.String
+						CreatedAt  githubv4.DateTime
+						Repository activityRepository
+					}
+				}
+			} `graphql:"pullRequestContributions(first: $first)"`
+			PullRequestReviewContributions struct {
+				Nodes []struct {
+					OccurredAt        githubv4.DateTime
+					PullRequestReview struct {
+						State githubv4.String
+						URL   githubv4.String
+					}
+					PullRequest struct {
+						Number     githubv4.Int
+						Title      githubv4.String
+						Repository activityRepository
+					}
+				}
+			} `graphql:"pullRequestReviewContributions(first: $first)"`
+			IssueContributions struct {
+				Nodes []struct {
+					Issue struct {
+						Number     githubv4.Int
+						Title      githubv4.String
+						URL        githubv4.String
+						State      githubv4.String
+						CreatedAt  githubv4.DateTime
+						Repository activityRepository
+					}
+				}
+			} `graphql:"issueContributions(first: $first)"`
+		} `graphql:"contributionsCollection(from: $from, to: $to)"`
+	} `graphql:"user(login: $login)"`
+}
+
+// GetUserActivity creates a tool that summarizes what a user contributed over a date range.
+func GetUserActivity(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_user_activity",
+			mcp.WithDescription(t("TOOL_GET_USER_ACTIVITY_DESCRIPTION", "Summarize the activity of a GitHub user over a date range: commits per repository, pull requests opened, reviews given, and issues opened and closed. Useful for standup and retrospective summaries. Closed issues come from the events API, which only covers the last 300 events of the past 90 days.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_USER_ACTIVITY_USER_TITLE", "Get user activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username to summarize the activity of. Defaults to the authenticated user"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the range as an ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to 7 days ago"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the range as an ISO 8601 timestamp (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD). Defaults to now. The range may span at most one year"),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description("Maximum number of pull requests, reviews and issues to list per category (default 25, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", 25)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			to := time.Now()
+			if until != "" {
+				if to, err = parseISOTimestamp(until); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse until: %s", err)), nil
+				}
+			}
+			from := to.AddDate(0, 0, -7)
+			if since != "" {
+				if from, err = parseISOTimestamp(since); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err)), nil
+				}
+			}
+			if !from.Before(to) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+			if to.After(from.AddDate(1, 0, 0)) {
+				return mcp.NewToolResultError("the range between since and until may span at most one year"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			if username == "" {
+				user, resp, err := client.Users.Get(ctx, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get authenticated user",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				username = user.GetLogin()
+			}
+
+			var query userActivityQuery
+			vars := map[string]any{
+				"login": githubv4.String(username),
+				"from":  githubv4.DateTime{Time: from},
+				"to":    githubv4.DateTime{Time: to},
+				"first": githubv4.Int(int32(maxItems)), // #nosec G115 - max_items is capped at 100
+			}
+			if err := gqlClient.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get user contributions", err), nil
+			}
+
+			contributions := query.User.ContributionsCollection
+			activity := UserActivity{
+				Login: string(query.User.Login),
+				From:  from,
+				To:    to,
+				Totals: ActivityTotals{
+					Commits:              int(contributions.TotalCommitContributions),
+					PullRequestsOpened:   int(contributions.TotalPullRequestContributions),
+					ReviewsGiven:         int(contributions.TotalPullRequestReviewContributions),
+					IssuesOpened:         int(contributions.TotalIssueContributions),
+					PrivateContributions: int(contributions.RestrictedContributionsCount),
+				},
+				CommitsByRepo:      []RepositoryCommits{},
+				PullRequestsOpened: []ActivityItem{},
+				ReviewsGiven:       []ActivityItem{},
+				IssuesOpened:       []ActivityItem{},
+				IssuesClosed:       []ActivityItem{},
+			}
+			for _, c := range contributions.CommitContributionsByRepository {
+				activity.CommitsByRepo = append(activity.CommitsByRepo, RepositoryCommits{
+					Repository: string(c.Repository.NameWithOwner),
+					Commits:    int(c.Contributions.TotalCount),
+				})
+			}
+			for _, n := range contributions.PullRequestContributions.Nodes {
+				pr := n.PullRequest
+				activity.PullRequestsOpened = append(activity.PullRequestsOpened, ActivityItem{
+					Repository: string(pr.Repository.NameWithOwner),
+					Number:     int(pr.Number),
+					Title:      string(pr.Title),
+					URL:        string(pr.URL),
+					State:      string(pr.State),
+					OccurredAt: pr.CreatedAt.Time,
+				})
+			}
+			for _, n := range contributions.PullRequestReviewContributions.Nodes {
+				activity.ReviewsGiven = append(activity.ReviewsGiven, ActivityItem{
+					Repository: string(n.PullRequest.Repository.NameWithOwner),
+					Number:     int(n.PullRequest.Number),
+					Title:      string(n.PullRequest.Title),
+					URL:        string(n.PullRequestReview.URL),
+					State:      string(n.PullRequestReview.State),
+					OccurredAt: n.OccurredAt.Time,
+				})
+			}
+			for _, n := range contributions.IssueContributions.Nodes {
+				issue := n.Issue
+				activity.IssuesOpened = append(activity.IssuesOpened, ActivityItem{
+					Repository: string(issue.Repository.NameWithOwner),
+					Number:     int(issue.Number),
+					Title:      string(issue.Title),
+					URL:        string(issue.URL),
+					State:      string(issue.State),
+					OccurredAt: issue.CreatedAt.Time,
+				})
+			}
+
+			// Closing issues is not a contribution, so it is only found in the events of the user.
+			opts := &github.ListOptions{PerPage: 100}
+			for seen := 0; seen < maxUserActivityEvents && !activity.EventsComplete; {
+				events, resp, err := client.Activity.ListEventsPerformedByUser(ctx, username, false, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list user events",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, event := range events {
+					seen++
+					createdAt := event.GetCreatedAt().Time
+					if createdAt.Before(from) {
+						activity.EventsComplete = true
+						break
+					}
+					if createdAt.After(to) || event.GetType() != "IssuesEvent" {
+						continue
+					}
+					payload, err := event.ParsePayload()
+					if err != nil {
+						continue
+					}
+					issuesEvent, ok := payload.(*github.IssuesEvent)
+					if !ok || issuesEvent.GetAction() != "closed" {
+						continue
+					}
+					activity.Totals.IssuesClosed++
+					if len(activity.IssuesClosed) < maxItems {
+						activity.IssuesClosed = append(activity.IssuesClosed, ActivityItem{
+							Repository: event.GetRepo().GetName(),
+							Number:     issuesEvent.GetIssue().GetNumber(),
+							Title:      issuesEvent.GetIssue().GetTitle(),
+							URL:        issuesEvent.GetIssue().GetHTMLURL(),
+							State:      "closed",
+							OccurredAt: createdAt,
+						})
+					}
+				}
+				if resp.NextPage == 0 {
+					// All events the API keeps have been seen, but it drops events older than 90 days.
+					activity.EventsComplete = activity.EventsComplete || time.Since(from) < maxUserActivityEventAge
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			return MarshalledTextResult(activity), nil
+		}
+}
@@ -0,0 +1,413 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxWaitTimeout is the longest a tool may wait for something to finish.
+const maxWaitTimeout = 30 * time.Minute
+
+// pagingToolTimeout is the timeout of tools that page through many API responses, which can take
+// minutes on large repositories and organizations.
+const pagingToolTimeout = 5 * time.Minute
+
+// LongRunningToolTimeouts are the timeouts of tools that would be cut short by the default tool
+// timeout: tools that wait for something to finish, which bound their waiting themselves, and
+// tools that page through many API responses.
+var LongRunningToolTimeouts = map[string]time.Duration{
+	"wait_for_checks":       maxWaitTimeout + time.Minute,
+	"wait_for_workflow_run": maxWaitTimeout + time.Minute,
+	"get_dora_metrics":      pagingToolTimeout,
+	"get_user_activity":     pagingToolTimeout,
+	"export_project_items":  pagingToolTimeout,
+	"bulk_update_issues":    pagingToolTimeout,
+	"get_deployment_diff":   pagingToolTimeout,
+	"download_repo_archive": pagingToolTimeout,
+}
+
+var (
+	// waitPollInterval is the time to wait between the first polls, it grows up to waitMaxPollInterval.
+	waitPollInterval = 10 * time.Second
+	// waitMaxPollInterval is the longest time to wait between polls.
+	waitMaxPollInterval = time.Minute
+	// waitStartGrace is how long the wait tools wait for the first check to be reported, or for a
+	// dispatched workflow run to be created.
+	waitStartGrace = 2 * time.Minute
+)
+
+// pollUntil calls poll until it reports being done or the timeout passes, waiting longer between
+// every poll. It returns whether the timeout passed and how often poll was called.
+func pollUntil(ctx context.Context, timeout time.Duration, poll func(elapsed time.Duration) (done bool)) (timedOut bool, polls int, err error) {
+	start := time.Now()
+	interval := waitPollInterval
+	for {
+		polls++
+		if poll(time.Since(start)) {
+			return false, polls, nil
+		}
+		remaining := timeout - time.Since(start)
+		if remaining <= 0 {
+			return true, polls, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, polls, ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
+		interval = min(interval*3/2, waitMaxPollInterval)
+	}
+}
+
+// waitTimeoutParam returns the timeout_seconds parameter of the wait tools as a duration.
+func waitTimeoutParam(request mcp.CallToolRequest, defaultSeconds int) (time.Duration, error) {
+	seconds, err := OptionalIntParamWithDefault(request, "timeout_seconds", defaultSeconds)
+	if err != nil {
+		return 0, err
+	}
+	timeout := time.Duration(seconds) * time.Second
+	if timeout <= 0 || timeout > maxWaitTimeout {
+		return 0, fmt.Errorf("timeout_seconds must be between 1 and %d", int(maxWaitTimeout.Seconds()))
+	}
+	return timeout, nil
+}
+
+// WaitForChecks creates a tool that waits for the checks of a commit to finish and returns their verdict.
+func WaitForChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_checks",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_CHECKS_DESCRIPTION", "Wait for the check runs and commit statuses of a commit, branch, tag or pull request head to finish, polling with growing intervals, and return the final verdict: 'passing', 'failing', 'pending' if the timeout passed first, or 'none' if no check was reported. Use it instead of polling get_pull_request_status, for example before merging when the checks are green.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WAIT_FOR_CHECKS_USER_TITLE", "Wait for checks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Commit SHA, branch or tag whose checks to wait for. Branches and tags are resolved to their commit once, at the start. Required unless pullNumber is given"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Description("Pull request whose head commit's checks to wait for"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description("How long to wait for the checks to finish, in seconds"),
+				mcp.Min(1),
+				mcp.Max(maxWaitTimeout.Seconds()),
+				mcp.DefaultNumber(600),
+			),
+			mcp.WithBoolean("fail_fast",
+				mcp.Description("Return as soon as a check fails, without waiting for the others"),
+				mcp.DefaultBool(true),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeout, err := waitTimeoutParam(request, 600)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			failFast, err := OptionalBoolParamWithDefault(request, "fail_fast", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (ref == "") == (pullNumber == 0) {
+				return mcp.NewToolResultError("exactly one of ref or pullNumber must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var sha string
+			if pullNumber != 0 {
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				sha = pr.GetHead().GetSHA()
+			} else {
+				var resp *github.Response
+				sha, resp, err = client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to resolve ref %s", ref), resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			var summary PullRequestChecksSummary
+			var errResult *mcp.CallToolResult
+			timedOut, polls, err := pollUntil(ctx, timeout, func(elapsed time.Duration) bool {
+				summary, errResult = getChecksSummary(ctx, client, owner, repo, sha)
+				if errResult != nil {
+					return true
+				}
+				switch summary.State {
+				case "passing":
+					return true
+				case "failing":
+					return failFast || len(summary.Pending) == 0
+				case "none":
+					// Checks may not have been created yet for a commit that was just pushed
+					return elapsed >= waitStartGrace
+				}
+				return false
+			})
+			if err != nil {
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
+			}
+			summary.PullNumber = pullNumber
+
+			return MarshalledTextResult(map[string]any{
+				"state":     summary.State,
+				"timed_out": timedOut,
+				"polls":     polls,
+				"checks":    summary,
+			}), nil
+		}
+}
+
+// FailedJob summarizes a job of a workflow run that did not succeed.
+type FailedJob struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Conclusion  string   `json:"conclusion"`
+	HTMLURL     string   `json:"html_url"`
+	FailedSteps []string `json:"failed_steps"`
+}
+
+// WorkflowRunOutcome is the state of a workflow run a wait_for_workflow_run call ended with.
+type WorkflowRunOutcome struct {
+	RunID      int64       `json:"run_id"`
+	RunNumber  int         `json:"run_number"`
+	Name       string      `json:"name"`
+	HTMLURL    string      `json:"html_url"`
+	HeadBranch string      `json:"head_branch"`
+	HeadSHA    string      `json:"head_sha"`
+	Status     string      `json:"status"`
+	Conclusion string      `json:"conclusion,omitempty"`
+	FailedJobs []FailedJob `json:"failed_jobs,omitempty"`
+}
+
+// successfulJobConclusions are the conclusions of jobs that did not fail.
+var successfulJobConclusions = map[string]bool{"success": true, "neutral": true, "skipped": true}
+
+// listFailedJobs lists the jobs of the latest attempt of a workflow run that did not succeed.
+func listFailedJobs(ctx context.Context, client *github.Client, owner, repo string, runID int64) ([]FailedJob, *github.Response, error) {
+	failed := []FailedJob{}
+	opts := &github.ListWorkflowJobsOptions{Filter: "latest", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, job := range jobs.Jobs {
+			if successfulJobConclusions[job.GetConclusion()] {
+				continue
+			}
+			steps := []string{}
+			for _, step := range job.Steps {
+				if !successfulJobConclusions[step.GetConclusion()] && step.GetConclusion() != "" {
+					steps = append(steps, step.GetName())
+				}
+			}
+			failed = append(failed, FailedJob{
+				ID:          job.GetID(),
+				Name:        job.GetName(),
+				Conclusion:  job.GetConclusion(),
+				HTMLURL:     job.GetHTMLURL(),
+				FailedSteps: steps,
+			})
+		}
+		if resp.NextPage == 0 {
+			return failed, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// WaitForWorkflowRun creates a tool that waits for a workflow run to complete and returns its conclusion.
+func WaitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_workflow_run",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_WORKFLOW_RUN_DESCRIPTION", "Wait for a workflow run to complete, polling with growing intervals, and return its conclusion along with the jobs that failed and their failed steps. Give run_id, or workflow_id to wait for the latest run dispatched with run_workflow; pass dispatched_after to make sure an earlier run is not picked up. Returns the current status if the timeout passes first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WAIT_FOR_WORKFLOW_RUN_USER_TITLE", "Wait for workflow run"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("The unique identifier of the workflow run to wait for. Either run_id or workflow_id is required"),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml) whose latest dispatched run to wait for"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch the workflow was dispatched on, when waiting for a dispatched run"),
+			),
+			mcp.WithString("dispatched_after",
+				mcp.Description("Only wait for a dispatched run created at or after this time, in ISO 8601 format. Use the time just before calling run_workflow"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description("How long to wait for the run to complete, in seconds"),
+				mcp.Min(1),
+				mcp.Max(maxWaitTimeout.Seconds()),
+				mcp.DefaultNumber(900),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := OptionalIntParam(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := OptionalParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dispatchedAfter, err := OptionalParam[string](request, "dispatched_after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeout, err := waitTimeoutParam(request, 900)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (runIDInt == 0) == (workflowID == "") {
+				return mcp.NewToolResultError("exactly one of run_id or workflow_id must be provided"), nil
+			}
+			var created string
+			if dispatchedAfter != "" {
+				since, err := time.Parse(time.RFC3339, dispatchedAfter)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid dispatched_after %q, expected ISO 8601 format: %v", dispatchedAfter, err)), nil
+				}
+				created = ">=" + since.UTC().Format(time.RFC3339)
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var run *github.WorkflowRun
+			var errResult *mcp.CallToolResult
+			timedOut, polls, err := pollUntil(ctx, timeout, func(elapsed time.Duration) bool {
+				if runID == 0 {
+					runs, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, &github.ListWorkflowRunsOptions{
+						Branch:      ref,
+						Event:       "workflow_dispatch",
+						Created:     created,
+						ListOptions: github.ListOptions{PerPage: 1},
+					})
+					if err != nil {
+						errResult = ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err)
+						return true
+					}
+					_ = resp.Body.Close()
+					if len(runs.WorkflowRuns) == 0 {
+						// A dispatched run takes a few seconds to be created
+						if elapsed >= waitStartGrace {
+							errResult = mcp.NewToolResultError(fmt.Sprintf("no dispatched run of workflow %s was found", workflowID))
+							return true
+						}
+						return false
+					}
+					run = runs.WorkflowRuns[0]
+					runID = run.GetID()
+				} else {
+					r, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+					if err != nil {
+						errResult = ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err)
+						return true
+					}
+					_ = resp.Body.Close()
+					run = r
+				}
+				return run.GetStatus() == "completed"
+			})
+			if err != nil {
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
+			}
+			if run == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("no dispatched run of workflow %s was found before the timeout", workflowID)), nil
+			}
+
+			outcome := WorkflowRunOutcome{
+				RunID:      run.GetID(),
+				RunNumber:  run.GetRunNumber(),
+				Name:       run.GetName(),
+				HTMLURL:    run.GetHTMLURL(),
+				HeadBranch: run.GetHeadBranch(),
+				HeadSHA:    run.GetHeadSHA(),
+				Status:     run.GetStatus(),
+				Conclusion: run.GetConclusion(),
+			}
+			if outcome.Status == "completed" && !successfulJobConclusions[outcome.Conclusion] {
+				failedJobs, resp, err := listFailedJobs(ctx, client, owner, repo, outcome.RunID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow jobs", resp, err), nil
+				}
+				outcome.FailedJobs = failedJobs
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"run":       outcome,
+				"timed_out": timedOut,
+				"polls":     polls,
+			}), nil
+		}
+}
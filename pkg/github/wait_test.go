@@ -0,0 +1,405 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastPolling makes the wait tools poll without waiting for the rest of the test.
+func fastPolling(t *testing.T) {
+	interval, maxInterval, grace := waitPollInterval, waitMaxPollInterval, waitStartGrace
+	t.Cleanup(func() {
+		waitPollInterval, waitMaxPollInterval, waitStartGrace = interval, maxInterval, grace
+	})
+	waitPollInterval, waitMaxPollInterval = time.Millisecond, 10*time.Millisecond
+}
+
+func Test_LongRunningToolTimeouts(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), nil, nil, translations.NullTranslationHelper, 5000, "")
+
+	tools := map[string]bool{}
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = true
+		}
+	}
+	for name, d := range LongRunningToolTimeouts {
+		assert.True(t, tools[name], "timeout is listed for unknown tool %s", name)
+		assert.Greater(t, d, 30*time.Second, "tool %s", name)
+	}
+}
+
+func Test_WaitForChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "wait_for_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.Contains(t, LongRunningToolTimeouts, tool.Name)
+
+	fastPolling(t)
+
+	pr := &github.PullRequest{Number: github.Ptr(42), Head: &github.PullRequestBranch{SHA: github.Ptr("abc123")}}
+	noStatuses := &github.CombinedStatus{State: github.Ptr("pending")}
+	checkRuns := func(runs ...*github.CheckRun) *github.ListCheckRunsResults {
+		return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}
+	}
+	pendingRun := &github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("in_progress")}
+	passingRun := &github.CheckRun{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}
+	failingRun := &github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")}
+	resolveRef := mock.WithRequestMatchHandler(
+		mock.GetReposCommitsByOwnerByRepoByRef,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("abc123"))
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedState  string
+		expectedPolls  int
+		timedOut       bool
+	}{
+		{
+			name: "waits for pending checks to pass",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, noStatuses, noStatuses),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(passingRun, pendingRun),
+					checkRuns(passingRun, &github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedState: "passing",
+			expectedPolls: 2,
+		},
+		{
+			name: "returns on the first failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, noStatuses),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(failingRun, pendingRun)),
+				// Registered last, as it would also match the status and check runs of the commit
+				resolveRef,
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedState: "failing",
+			expectedPolls: 1,
+		},
+		{
+			name: "times out while checks are pending",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsStatusByOwnerByRepoByRef, mockResponse(t, http.StatusOK, noStatuses)),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, mockResponse(t, http.StatusOK, checkRuns(pendingRun))),
+				resolveRef,
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"ref":             "main",
+				"timeout_seconds": float64(1),
+			},
+			expectedState: "pending",
+			timedOut:      true,
+		},
+		{
+			name: "exactly one of ref and pullNumber",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "main",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of ref or pullNumber must be provided",
+		},
+		{
+			name: "timeout too long",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"ref":             "main",
+				"timeout_seconds": float64(3600),
+			},
+			expectError:    true,
+			expectedErrMsg: "timeout_seconds must be between 1 and 1800",
+		},
+		{
+			name: "check runs cannot be listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, noStatuses),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+				resolveRef,
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WaitForChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				State    string                   `json:"state"`
+				TimedOut bool                     `json:"timed_out"`
+				Polls    int                      `json:"polls"`
+				Checks   PullRequestChecksSummary `json:"checks"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, tc.expectedState, got.State)
+			assert.Equal(t, tc.timedOut, got.TimedOut)
+			assert.Equal(t, "abc123", got.Checks.HeadSHA)
+			if tc.expectedPolls > 0 {
+				assert.Equal(t, tc.expectedPolls, got.Polls)
+			}
+		})
+	}
+
+	t.Run("no checks after the grace period", func(t *testing.T) {
+		waitStartGrace = 0
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+			mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns()),
+			resolveRef,
+		))
+		_, handler := WaitForChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "ref": "abc123"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var got map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+		assert.Equal(t, "none", got["state"])
+		assert.Equal(t, float64(1), got["polls"])
+	})
+}
+
+func Test_WaitForWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "wait_for_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.Contains(t, LongRunningToolTimeouts, tool.Name)
+
+	fastPolling(t)
+
+	run := func(status, conclusion string) *github.WorkflowRun {
+		return &github.WorkflowRun{
+			ID:         github.Ptr(int64(99)),
+			RunNumber:  github.Ptr(7),
+			Name:       github.Ptr("CI"),
+			HeadBranch: github.Ptr("main"),
+			Status:     github.Ptr(status),
+			Conclusion: github.Ptr(conclusion),
+		}
+	}
+	jobs := &github.Jobs{
+		TotalCount: github.Ptr(2),
+		Jobs: []*github.WorkflowJob{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("success")},
+			{
+				ID:         github.Ptr(int64(2)),
+				Name:       github.Ptr("test"),
+				Conclusion: github.Ptr("failure"),
+				Steps: []*github.TaskStep{
+					{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+					{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+					{Name: github.Ptr("Upload"), Conclusion: github.Ptr("skipped")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedConclusion string
+		expectedFailedJobs []FailedJob
+		expectedPolls      int
+	}{
+		{
+			name: "waits for a run to succeed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					run("queued", ""),
+					run("in_progress", ""),
+					run("completed", "success"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(99),
+			},
+			expectedConclusion: "success",
+			expectedPolls:      3,
+		},
+		{
+			name: "finds the dispatched run and summarizes failed jobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					expectQueryParams(t, map[string]string{
+						"branch":   "main",
+						"event":    "workflow_dispatch",
+						"created":  ">=2024-05-01T10:00:00Z",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+							TotalCount:   github.Ptr(1),
+							WorkflowRuns: []*github.WorkflowRun{run("in_progress", "")},
+						}),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, run("completed", "failure")),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, jobs),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"workflow_id":      "ci.yml",
+				"ref":              "main",
+				"dispatched_after": "2024-05-01T12:00:00+02:00",
+			},
+			expectedConclusion: "failure",
+			expectedFailedJobs: []FailedJob{{ID: 2, Name: "test", Conclusion: "failure", FailedSteps: []string{"Run tests"}}},
+			expectedPolls:      2,
+		},
+		{
+			name: "no dispatched run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId, &github.WorkflowRuns{TotalCount: github.Ptr(0)}),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "no dispatched run of workflow ci.yml was found",
+		},
+		{
+			name: "run_id or workflow_id",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of run_id or workflow_id must be provided",
+		},
+		{
+			name: "invalid dispatched_after",
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"workflow_id":      "ci.yml",
+				"dispatched_after": "yesterday",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid dispatched_after",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			waitStartGrace = 0
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				Run      WorkflowRunOutcome `json:"run"`
+				TimedOut bool               `json:"timed_out"`
+				Polls    int                `json:"polls"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.False(t, got.TimedOut)
+			assert.Equal(t, int64(99), got.Run.RunID)
+			assert.Equal(t, "completed", got.Run.Status)
+			assert.Equal(t, tc.expectedConclusion, got.Run.Conclusion)
+			assert.Equal(t, tc.expectedFailedJobs, got.Run.FailedJobs)
+			assert.Equal(t, tc.expectedPolls, got.Polls)
+		})
+	}
+}
@@ -0,0 +1,522 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowDir is where GitHub Actions looks for workflow files.
+const workflowDir = ".github/workflows/"
+
+var (
+	workflowKeys = keySet("name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs")
+	// workflowEvents are the events that can trigger a workflow.
+	workflowEvents = keySet(
+		"branch_protection_rule", "check_run", "check_suite", "create", "delete", "deployment", "deployment_status",
+		"discussion", "discussion_comment", "fork", "gollum", "issue_comment", "issues", "label", "merge_group",
+		"milestone", "page_build", "project", "project_card", "project_column", "public", "pull_request",
+		"pull_request_review", "pull_request_review_comment", "pull_request_target", "push", "registry_package",
+		"release", "repository_dispatch", "schedule", "status", "watch", "workflow_call", "workflow_dispatch",
+		"workflow_run",
+	)
+	jobKeys = keySet(
+		"name", "permissions", "needs", "if", "runs-on", "environment", "concurrency", "outputs", "env", "defaults",
+		"steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services", "uses", "with", "secrets",
+	)
+	// reusableJobKeys are the keys allowed in a job that calls a reusable workflow.
+	reusableJobKeys = keySet("name", "uses", "with", "secrets", "needs", "if", "permissions", "strategy", "concurrency")
+	stepKeys        = keySet("id", "if", "name", "uses", "run", "shell", "with", "env", "continue-on-error", "timeout-minutes", "working-directory")
+	// githubHostedRunners are the labels of GitHub-hosted runners, and the default labels of self-hosted runners.
+	githubHostedRunners = keySet(
+		"ubuntu-latest", "ubuntu-24.04", "ubuntu-22.04", "ubuntu-20.04", "ubuntu-24.04-arm", "ubuntu-22.04-arm",
+		"windows-latest", "windows-2025", "windows-2022", "windows-2019", "windows-11-arm",
+		"macos-latest", "macos-15", "macos-14", "macos-13",
+		"macos-latest-large", "macos-15-large", "macos-14-large", "macos-13-large",
+		"macos-latest-xlarge", "macos-15-xlarge", "macos-14-xlarge", "macos-13-xlarge",
+		"self-hosted", "linux", "windows", "macos", "x64", "arm", "arm64",
+	)
+	workflowDispatchInputTypes = keySet("string", "boolean", "choice", "number", "environment")
+
+	jobIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	// actionRefPattern matches owner/repo[/path]@ref.
+	actionRefPattern = regexp.MustCompile(`^[^/@\s]+/[^/@\s]+(/[^@\s]+)?@[^@\s]+$`)
+)
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// WorkflowIssue is a problem found in a workflow file.
+type WorkflowIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// WorkflowValidation is the result of validating a workflow file. Errors make the workflow fail, warnings
+// point at things that are likely mistakes.
+type WorkflowValidation struct {
+	Source   string          `json:"source"`
+	Valid    bool            `json:"valid"`
+	Errors   []WorkflowIssue `json:"errors"`
+	Warnings []WorkflowIssue `json:"warnings"`
+}
+
+// workflowValidator collects the issues of a workflow file.
+type workflowValidator struct {
+	errors   []WorkflowIssue
+	warnings []WorkflowIssue
+}
+
+func (v *workflowValidator) errorf(node *yaml.Node, path, format string, args ...any) {
+	v.errors = append(v.errors, WorkflowIssue{Line: node.Line, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *workflowValidator) warnf(node *yaml.Node, path, format string, args ...any) {
+	v.warnings = append(v.warnings, WorkflowIssue{Line: node.Line, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// mappingEntries returns the keys and values of a mapping node, in order.
+func mappingEntries(node *yaml.Node) (keys, values []*yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i])
+		values = append(values, node.Content[i+1])
+	}
+	return keys, values
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	keys, values := mappingEntries(node)
+	for i, k := range keys {
+		if k.Value == key {
+			return values[i]
+		}
+	}
+	return nil
+}
+
+// isExpression reports whether a scalar is a GitHub Actions expression, which can only be checked at run time.
+func isExpression(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "${{")
+}
+
+// checkKeys reports the keys of a mapping that are not allowed.
+func (v *workflowValidator) checkKeys(node *yaml.Node, path string, allowed map[string]bool, what string) {
+	keys, _ := mappingEntries(node)
+	for _, key := range keys {
+		if !allowed[key.Value] {
+			v.errorf(key, path, "unknown %s key %q", what, key.Value)
+		}
+	}
+}
+
+// validateWorkflow checks a workflow file against the workflow syntax of GitHub Actions.
+func validateWorkflow(content string) (errors, warnings []WorkflowIssue) {
+	v := &workflowValidator{}
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		v.errors = append(v.errors, WorkflowIssue{Message: fmt.Sprintf("invalid YAML: %s", err)})
+		return v.errors, v.warnings
+	}
+	if len(document.Content) == 0 {
+		v.errors = append(v.errors, WorkflowIssue{Message: "the workflow file is empty"})
+		return v.errors, v.warnings
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.errorf(root, "", "a workflow must be a mapping")
+		return v.errors, v.warnings
+	}
+	v.checkKeys(root, "", workflowKeys, "workflow")
+
+	if on := mappingValue(root, "on"); on == nil {
+		v.errorf(root, "", `missing required key "on", the events that trigger the workflow`)
+	} else {
+		v.validateTriggers(on)
+	}
+
+	jobs := mappingValue(root, "jobs")
+	switch {
+	case jobs == nil:
+		v.errorf(root, "", `missing required key "jobs"`)
+	case jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0:
+		v.errorf(jobs, "jobs", "jobs must be a mapping of at least one job")
+	default:
+		v.validateJobs(jobs)
+	}
+
+	byLine := func(issues []WorkflowIssue) {
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	}
+	byLine(v.errors)
+	byLine(v.warnings)
+	return v.errors, v.warnings
+}
+
+func (v *workflowValidator) validateTriggers(on *yaml.Node) {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		v.validateEvent(on, on.Value, nil)
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event.Kind != yaml.ScalarNode {
+				v.errorf(event, "on", "events must be names")
+				continue
+			}
+			v.validateEvent(event, event.Value, nil)
+		}
+	case yaml.MappingNode:
+		keys, values := mappingEntries(on)
+		for i, event := range keys {
+			v.validateEvent(event, event.Value, values[i])
+		}
+	default:
+		v.errorf(on, "on", "on must be an event, a list of events or a mapping of events")
+	}
+}
+
+func (v *workflowValidator) validateEvent(node *yaml.Node, event string, config *yaml.Node) {
+	path := "on." + event
+	if !workflowEvents[event] {
+		v.errorf(node, "on", "unknown event %q", event)
+		return
+	}
+	if event == "schedule" {
+		if config == nil || config.Kind != yaml.SequenceNode || len(config.Content) == 0 {
+			v.errorf(node, path, "schedule must be a list of cron entries")
+			return
+		}
+		for _, entry := range config.Content {
+			cron := mappingValue(entry, "cron")
+			if entry.Kind != yaml.MappingNode || cron == nil {
+				v.errorf(entry, path, `each schedule entry must have a "cron" key`)
+				continue
+			}
+			if fields := strings.Fields(cron.Value); len(fields) != 5 {
+				v.errorf(cron, path, "cron %q must have 5 fields (minute hour day month weekday), got %d", cron.Value, len(fields))
+			}
+		}
+		return
+	}
+	if config == nil || config.Kind != yaml.MappingNode {
+		return
+	}
+	for _, pair := range [][2]string{{"branches", "branches-ignore"}, {"tags", "tags-ignore"}, {"paths", "paths-ignore"}} {
+		if mappingValue(config, pair[0]) != nil && mappingValue(config, pair[1]) != nil {
+			v.errorf(config, path, "%s and %s cannot be used together for the same event", pair[0], pair[1])
+		}
+	}
+	if event == "workflow_dispatch" {
+		if inputs := mappingValue(config, "inputs"); inputs != nil && inputs.Kind == yaml.MappingNode {
+			names, definitions := mappingEntries(inputs)
+			for i, name := range names {
+				inputType := mappingValue(definitions[i], "type")
+				if inputType == nil {
+					continue
+				}
+				if !workflowDispatchInputTypes[inputType.Value] {
+					v.errorf(inputType, path+".inputs."+name.Value, "unknown input type %q", inputType.Value)
+				}
+				if inputType.Value == "choice" && mappingValue(definitions[i], "options") == nil {
+					v.errorf(inputType, path+".inputs."+name.Value, "choice inputs must list their options")
+				}
+			}
+		}
+	}
+}
+
+func (v *workflowValidator) validateJobs(jobs *yaml.Node) {
+	ids, definitions := mappingEntries(jobs)
+	jobNodes := make(map[string]*yaml.Node, len(ids))
+	for _, id := range ids {
+		jobNodes[id.Value] = id
+	}
+
+	needs := map[string][]string{}
+	for i, id := range ids {
+		path := "jobs." + id.Value
+		job := definitions[i]
+		if !jobIDPattern.MatchString(id.Value) {
+			v.errorf(id, path, "job id %q must start with a letter or _ and contain only alphanumeric characters, - or _", id.Value)
+		}
+		if job.Kind != yaml.MappingNode {
+			v.errorf(job, path, "a job must be a mapping")
+			continue
+		}
+
+		if mappingValue(job, "uses") != nil {
+			v.checkKeys(job, path, reusableJobKeys, "reusable workflow job")
+		} else {
+			v.checkKeys(job, path, jobKeys, "job")
+			if runsOn := mappingValue(job, "runs-on"); runsOn == nil {
+				v.errorf(id, path, `missing required key "runs-on"`)
+			} else {
+				v.validateRunsOn(runsOn, path+".runs-on")
+			}
+			if steps := mappingValue(job, "steps"); steps == nil {
+				v.errorf(id, path, `missing required key "steps"`)
+			} else {
+				v.validateSteps(steps, path+".steps")
+			}
+		}
+
+		if need := mappingValue(job, "needs"); need != nil {
+			var names []*yaml.Node
+			switch need.Kind {
+			case yaml.ScalarNode:
+				names = []*yaml.Node{need}
+			case yaml.SequenceNode:
+				names = need.Content
+			default:
+				v.errorf(need, path+".needs", "needs must be a job id or a list of job ids")
+			}
+			for _, name := range names {
+				if _, ok := jobNodes[name.Value]; !ok {
+					v.errorf(name, path+".needs", "needs unknown job %q", name.Value)
+					continue
+				}
+				needs[id.Value] = append(needs[id.Value], name.Value)
+			}
+		}
+	}
+
+	if cycle := findNeedsCycle(ids, needs); cycle != nil {
+		v.errorf(jobNodes[cycle[0]], "jobs."+cycle[0]+".needs", "jobs depend on each other in a cycle: %s", strings.Join(cycle, " -> "))
+	}
+}
+
+func (v *workflowValidator) validateRunsOn(runsOn *yaml.Node, path string) {
+	var labels []*yaml.Node
+	switch runsOn.Kind {
+	case yaml.ScalarNode:
+		labels = []*yaml.Node{runsOn}
+	case yaml.SequenceNode:
+		labels = runsOn.Content
+	case yaml.MappingNode:
+		// A runner group, optionally with labels
+		if group := mappingValue(runsOn, "labels"); group != nil {
+			v.validateRunsOn(group, path+".labels")
+		}
+		return
+	}
+	selfHosted := false
+	for _, label := range labels {
+		if label.Value == "self-hosted" || isExpression(label) {
+			selfHosted = true
+		}
+	}
+	if selfHosted {
+		return
+	}
+	for _, label := range labels {
+		if !githubHostedRunners[label.Value] {
+			v.warnf(label, path, "unknown runner label %q, this is only valid for a larger runner or a self-hosted runner with this label", label.Value)
+		}
+	}
+}
+
+func (v *workflowValidator) validateSteps(steps *yaml.Node, path string) {
+	if steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		v.errorf(steps, path, "steps must be a list of at least one step")
+		return
+	}
+	stepIDs := map[string]bool{}
+	for i, step := range steps.Content {
+		stepPath := fmt.Sprintf("%s[%d]", path, i)
+		if step.Kind != yaml.MappingNode {
+			v.errorf(step, stepPath, "a step must be a mapping")
+			continue
+		}
+		v.checkKeys(step, stepPath, stepKeys, "step")
+		uses, run := mappingValue(step, "uses"), mappingValue(step, "run")
+		switch {
+		case uses == nil && run == nil:
+			v.errorf(step, stepPath, `a step must have either "uses" or "run"`)
+		case uses != nil && run != nil:
+			v.errorf(step, stepPath, `a step cannot have both "uses" and "run"`)
+		case uses != nil:
+			v.validateUses(uses, stepPath+".uses")
+		}
+		if id := mappingValue(step, "id"); id != nil {
+			if stepIDs[id.Value] {
+				v.errorf(id, stepPath+".id", "step id %q is used more than once in the job", id.Value)
+			}
+			stepIDs[id.Value] = true
+		}
+	}
+}
+
+func (v *workflowValidator) validateUses(uses *yaml.Node, path string) {
+	action := uses.Value
+	if strings.HasPrefix(action, "./") || strings.HasPrefix(action, "docker://") || isExpression(uses) {
+		return
+	}
+	if !strings.Contains(action, "@") {
+		v.errorf(uses, path, "action %q must be pinned to a ref, for example %s@v4", action, action)
+		return
+	}
+	if !actionRefPattern.MatchString(action) {
+		v.errorf(uses, path, "action %q must be owner/repo@ref, owner/repo/path@ref, ./path or docker://image", action)
+	}
+}
+
+// findNeedsCycle returns the job ids of a dependency cycle, starting and ending with the same job, or nil.
+func findNeedsCycle(ids []*yaml.Node, needs map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var stack []string
+	var visit func(job string) []string
+	visit = func(job string) []string {
+		state[job] = visiting
+		stack = append(stack, job)
+		for _, need := range needs[job] {
+			switch state[need] {
+			case visiting:
+				for i, j := range stack {
+					if j == need {
+						return append(append([]string{}, stack[i:]...), need)
+					}
+				}
+			case unvisited:
+				if cycle := visit(need); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[job] = visited
+		return nil
+	}
+	for _, id := range ids {
+		if state[id.Value] == unvisited {
+			if cycle := visit(id.Value); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateWorkflowFile creates a tool that checks a GitHub Actions workflow file for errors.
+func ValidateWorkflowFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_workflow_file",
+			mcp.WithDescription(t("TOOL_VALIDATE_WORKFLOW_FILE_DESCRIPTION", "Validate a GitHub Actions workflow file before pushing it. Checks the YAML, triggers, jobs, steps, job dependencies including needs cycles, and runner labels, and returns errors and warnings with line numbers. Pass the workflow as content, or owner, repo and path to validate a file in a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VALIDATE_WORKFLOW_FILE_USER_TITLE", "Validate workflow file"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("content",
+				mcp.Description("The workflow YAML to validate. Takes precedence over owner, repo and path."),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner, to validate a workflow file in a repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name, to validate a workflow file in a repository"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path of the workflow file, or its file name in .github/workflows (e.g., ci.yml)"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Git ref to read the workflow file from. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			source := "content"
+			if content == "" {
+				if owner == "" || repo == "" || path == "" {
+					return mcp.NewToolResultError("either content, or owner, repo and path are required"), nil
+				}
+				if !strings.Contains(path, "/") {
+					path = workflowDir + path
+				}
+
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("workflow file %s not found in %s/%s", path, owner, repo)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get workflow file",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if fileContent == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a workflow file", path)), nil
+				}
+				if content, err = fileContent.GetContent(); err != nil {
+					return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+				}
+				source = fmt.Sprintf("%s/%s/%s", owner, repo, path)
+				if ref != "" {
+					source += "@" + ref
+				}
+			}
+
+			errors, warnings := validateWorkflow(content)
+			if source != "content" && !strings.HasPrefix(path, workflowDir) {
+				warnings = append(warnings, WorkflowIssue{Message: fmt.Sprintf("workflow files must be in %s to run", workflowDir)})
+			}
+			validation := WorkflowValidation{
+				Source:   source,
+				Valid:    len(errors) == 0,
+				Errors:   errors,
+				Warnings: warnings,
+			}
+			if validation.Errors == nil {
+				validation.Errors = []WorkflowIssue{}
+			}
+			if validation.Warnings == nil {
+				validation.Warnings = []WorkflowIssue{}
+			}
+
+			return MarshalledTextResult(validation), nil
+		}
+}
@@ -0,0 +1,237 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateWorkflow(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectedErrors   []WorkflowIssue
+		expectedWarnings []WorkflowIssue
+	}{
+		{
+			name: "valid workflow",
+			content: `name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+  schedule:
+    - cron: "0 3 * * 1"
+  workflow_dispatch:
+    inputs:
+      level:
+        type: choice
+        options: [info, debug]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: go test ./...
+  release:
+    needs: build
+    runs-on: [self-hosted, gpu]
+    steps:
+      - uses: ./.github/actions/release
+  reuse:
+    needs: [build, release]
+    uses: org/workflows/.github/workflows/deploy.yml@main
+    secrets: inherit
+`,
+		},
+		{
+			name: "structural errors",
+			content: `on:
+  pushh:
+  push:
+    branches: [main]
+    branches-ignore: [dev]
+  schedule:
+    - cron: "0 3 * *"
+jobs:
+  build:
+    runs_on: ubuntu-latest
+    steps:
+      - uses: actions/checkout
+      - name: nothing
+      - uses: actions/setup-go@v5
+        run: echo
+  test:
+    needs: [lint]
+    runs-on: ubuntu-latst
+    steps:
+      - run: go test ./...
+`,
+			expectedErrors: []WorkflowIssue{
+				{Line: 2, Path: "on", Message: `unknown event "pushh"`},
+				{Line: 4, Path: "on.push", Message: "branches and branches-ignore cannot be used together for the same event"},
+				{Line: 7, Path: "on.schedule", Message: `cron "0 3 * *" must have 5 fields (minute hour day month weekday), got 4`},
+				{Line: 9, Path: "jobs.build", Message: `missing required key "runs-on"`},
+				{Line: 10, Path: "jobs.build", Message: `unknown job key "runs_on"`},
+				{Line: 12, Path: "jobs.build.steps[0].uses", Message: `action "actions/checkout" must be pinned to a ref, for example actions/checkout@v4`},
+				{Line: 13, Path: "jobs.build.steps[1]", Message: `a step must have either "uses" or "run"`},
+				{Line: 14, Path: "jobs.build.steps[2]", Message: `a step cannot have both "uses" and "run"`},
+				{Line: 17, Path: "jobs.test.needs", Message: `needs unknown job "lint"`},
+			},
+			expectedWarnings: []WorkflowIssue{
+				{Line: 18, Path: "jobs.test.runs-on", Message: `unknown runner label "ubuntu-latst", this is only valid for a larger runner or a self-hosted runner with this label`},
+			},
+		},
+		{
+			name: "needs cycle",
+			content: `on: push
+jobs:
+  a:
+    needs: c
+    runs-on: ubuntu-latest
+    steps: [{run: echo a}]
+  b:
+    needs: a
+    runs-on: ubuntu-latest
+    steps: [{run: echo b}]
+  c:
+    needs: b
+    runs-on: ubuntu-latest
+    steps: [{run: echo c}]
+`,
+			expectedErrors: []WorkflowIssue{
+				{Line: 3, Path: "jobs.a.needs", Message: "jobs depend on each other in a cycle: a -> c -> b -> a"},
+			},
+		},
+		{
+			name:    "missing triggers and jobs",
+			content: "name: nothing\n",
+			expectedErrors: []WorkflowIssue{
+				{Line: 1, Message: `missing required key "on", the events that trigger the workflow`},
+				{Line: 1, Message: `missing required key "jobs"`},
+			},
+		},
+		{
+			name:    "invalid YAML",
+			content: "on: [push\njobs:",
+			expectedErrors: []WorkflowIssue{
+				{Message: "invalid YAML: yaml: line 1: did not find expected ',' or ']'"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errors, warnings := validateWorkflow(tc.content)
+			assert.Equal(t, tc.expectedErrors, errors)
+			assert.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
+
+func Test_ValidateWorkflowFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ValidateWorkflowFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_workflow_file", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	workflow := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make\n"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       WorkflowValidation
+	}{
+		{
+			name:         "validate content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs:  map[string]any{"content": "on: push\njobs: {}\n"},
+			expected: WorkflowValidation{
+				Source:   "content",
+				Errors:   []WorkflowIssue{{Line: 2, Path: "jobs", Message: "jobs must be a mapping of at least one job"}},
+				Warnings: []WorkflowIssue{},
+			},
+		},
+		{
+			name: "validate a file in a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/contents/.github/workflows/ci.yml", r.URL.Path)
+						assert.Equal(t, "feature", r.URL.Query().Get("ref"))
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(github.RepositoryContent{
+							Type:     github.Ptr("file"),
+							Encoding: github.Ptr("base64"),
+							Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(workflow))),
+						})
+					}),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "path": "ci.yml", "ref": "feature"},
+			expected: WorkflowValidation{
+				Source:   "owner/repo/.github/workflows/ci.yml@feature",
+				Valid:    true,
+				Errors:   []WorkflowIssue{},
+				Warnings: []WorkflowIssue{},
+			},
+		},
+		{
+			name: "workflow file not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "missing.yml"},
+			expectError:    true,
+			expectedErrMsg: "workflow file .github/workflows/missing.yml not found in owner/repo",
+		},
+		{
+			name:           "nothing to validate",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner"},
+			expectError:    true,
+			expectedErrMsg: "either content, or owner, repo and path are required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ValidateWorkflowFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var validation WorkflowValidation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &validation))
+			assert.Equal(t, tc.expected, validation)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package httpcache provides an http.RoundTripper that makes conditional requests
+// to the GitHub API and replays cached responses when GitHub answers 304 Not Modified.
+// Conditional requests answered with 304 do not count against the primary rate limit,
+// so repeated reads within a session become effectively free.
+// See: https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#use-conditional-requests-if-appropriate
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxEntryBytes is the largest response body that will be cached. Larger bodies are
+// passed through untouched so a handful of big files can't balloon memory usage.
+const maxEntryBytes = 1 << 20
+
+// FromCacheHeader is set on responses that were replayed from the cache.
+const FromCacheHeader = "X-From-Cache"
+
+type entry struct {
+	key          string
+	etag         string
+	lastModified string
+	statusCode   int
+	header       http.Header
+	body         []byte
+}
+
+// Transport is an http.RoundTripper that caches GET responses carrying an ETag or
+// Last-Modified header, keyed by URL and the credentials used to fetch them.
+type Transport struct {
+	transport  http.RoundTripper
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// NewTransport wraps the provided transport with an in-memory cache holding at most
+// maxEntries responses. If maxEntries is zero or negative, caching is disabled.
+// If transport is nil, http.DefaultTransport is used.
+func NewTransport(transport http.RoundTripper, maxEntries int) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{
+		transport:  transport,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.cacheable(req) {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached := t.get(key)
+
+	outReq := req
+	if cached != nil {
+		outReq = req.Clone(req.Context())
+		if cached.etag != "" && outReq.Header.Get("If-None-Match") == "" {
+			outReq.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" && outReq.Header.Get("If-Modified-Since") == "" {
+			outReq.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cached.response(req, resp), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp, nil
+	}
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+	if resp.ContentLength > maxEntryBytes {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEntryBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxEntryBytes {
+		// Too large to cache, stitch the bytes we already consumed back in front of the rest of the stream.
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.put(&entry{
+		key:          key,
+		etag:         etag,
+		lastModified: lastModified,
+		statusCode:   resp.StatusCode,
+		header:       resp.Header.Clone(),
+		body:         body,
+	})
+
+	return resp, nil
+}
+
+func (t *Transport) cacheable(req *http.Request) bool {
+	if t.maxEntries <= 0 {
+		return false
+	}
+	if req.Method != http.MethodGet {
+		return false
+	}
+	// Partial content can't be safely replayed for a different range.
+	return req.Header.Get("Range") == ""
+}
+
+func (t *Transport) get(key string) *entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	el, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	t.lru.MoveToFront(el)
+	return el.Value.(*entry)
+}
+
+func (t *Transport) put(e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if el, ok := t.entries[e.key]; ok {
+		el.Value = e
+		t.lru.MoveToFront(el)
+		return
+	}
+
+	t.entries[e.key] = t.lru.PushFront(e)
+	for t.lru.Len() > t.maxEntries {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (t *Transport) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lru.Len()
+}
+
+// response builds a fresh response from the cache entry. Rate limit headers are
+// taken from the 304 response so callers see the current quota.
+func (e *entry) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := e.header.Clone()
+	for k, v := range notModified.Header {
+		if strings.HasPrefix(k, "X-Ratelimit-") {
+			header[k] = v
+		}
+	}
+	header.Set(FromCacheHeader, "1")
+
+	return &http.Response{
+		Status:        http.StatusText(e.statusCode),
+		StatusCode:    e.statusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies a response by URL, the representation requested and the
+// credentials used. The Authorization header is hashed so tokens are not kept in memory as keys.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return strings.Join([]string{
+		req.URL.String(),
+		req.Header.Get("Accept"),
+		hex.EncodeToString(auth[:]),
+	}, "\x00")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,180 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, url, token string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransport_ReplaysNotModified(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-RateLimit-Remaining", "4998")
+		_, _ = w.Write([]byte(`{"name":"repo"}`))
+	}))
+	defer srv.Close()
+
+	transport := NewTransport(http.DefaultTransport, 10)
+	client := &http.Client{Transport: transport}
+
+	resp, body := get(t, client, srv.URL+"/repos/owner/repo", "token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name":"repo"}`, body)
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, 1, transport.Len())
+
+	resp, body = get(t, client, srv.URL+"/repos/owner/repo", "token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name":"repo"}`, body)
+	assert.Equal(t, "1", resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestTransport_LastModified(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte("contents"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, 10)}
+
+	_, _ = get(t, client, srv.URL, "token")
+	resp, body := get(t, client, srv.URL, "token")
+	assert.Equal(t, "1", resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, "contents", body)
+}
+
+func TestTransport_KeyedByToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")))
+	}))
+	defer srv.Close()
+
+	transport := NewTransport(http.DefaultTransport, 10)
+	client := &http.Client{Transport: transport}
+
+	_, body := get(t, client, srv.URL, "alice")
+	assert.Equal(t, "alice", body)
+
+	resp, body := get(t, client, srv.URL, "bob")
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, "bob", body)
+	assert.Equal(t, 2, transport.Len())
+}
+
+func TestTransport_Bypass(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		method     string
+		header     http.Header
+	}{
+		{name: "disabled", maxEntries: 0, method: http.MethodGet},
+		{name: "non GET", maxEntries: 10, method: http.MethodPost},
+		{name: "range request", maxEntries: 10, method: http.MethodGet, header: http.Header{"Range": []string{"bytes=0-10"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Empty(t, r.Header.Get("If-None-Match"))
+				w.Header().Set("ETag", `"v1"`)
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer srv.Close()
+
+			transport := NewTransport(http.DefaultTransport, tc.maxEntries)
+			client := &http.Client{Transport: transport}
+
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest(tc.method, srv.URL, nil)
+				require.NoError(t, err)
+				for k, v := range tc.header {
+					req.Header[k] = v
+				}
+				resp, err := client.Do(req)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+			}
+			assert.Equal(t, 0, transport.Len())
+		})
+	}
+}
+
+func TestTransport_EvictsLeastRecentlyUsed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	transport := NewTransport(http.DefaultTransport, 2)
+	client := &http.Client{Transport: transport}
+
+	_, _ = get(t, client, srv.URL+"/a", "token")
+	_, _ = get(t, client, srv.URL+"/b", "token")
+	_, _ = get(t, client, srv.URL+"/c", "token")
+
+	assert.Equal(t, 2, transport.Len())
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/a", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	assert.Nil(t, transport.get(cacheKey(req)))
+}
+
+func TestTransport_LargeBodiesNotCached(t *testing.T) {
+	large := strings.Repeat("x", maxEntryBytes+10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ETag", `"big"`)
+		// Force a chunked response so the size is only discovered while reading.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(large))
+	}))
+	defer srv.Close()
+
+	transport := NewTransport(http.DefaultTransport, 10)
+	client := &http.Client{Transport: transport}
+
+	_, body := get(t, client, srv.URL, "token")
+	assert.Equal(t, large, body)
+	assert.Equal(t, 0, transport.Len())
+}
@@ -0,0 +1,230 @@
+// Package metrics collects operational metrics of the server, such as tool call
+// counts and latencies, GitHub API response codes and the remaining rate limit,
+// and exposes them in the Prometheus text exposition format.
+// See: https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the tool call latency histogram.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type toolCallKey struct {
+	tool    string
+	outcome string
+}
+
+type apiRequestKey struct {
+	api    string
+	status string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// Metrics holds the collected metrics. It is safe for concurrent use.
+type Metrics struct {
+	mu                 sync.Mutex
+	toolCalls          map[toolCallKey]uint64
+	toolDurations      map[string]*histogram
+	apiRequests        map[apiRequestKey]uint64
+	rateLimitRemaining map[string]int64
+
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// New creates an empty set of metrics.
+func New() *Metrics {
+	return &Metrics{
+		toolCalls:          make(map[toolCallKey]uint64),
+		toolDurations:      make(map[string]*histogram),
+		apiRequests:        make(map[apiRequestKey]uint64),
+		rateLimitRemaining: make(map[string]int64),
+		now:                time.Now,
+	}
+}
+
+// ToolHandlerMiddleware records the outcome and latency of every tool call.
+func (m *Metrics) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := m.now()
+		result, err := next(ctx, request)
+		outcome := "success"
+		if err != nil || (result != nil && result.IsError) {
+			outcome = "error"
+		}
+		m.observeToolCall(request.Params.Name, outcome, m.now().Sub(start))
+		return result, err
+	}
+}
+
+func (m *Metrics) observeToolCall(tool, outcome string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.toolCalls[toolCallKey{tool: tool, outcome: outcome}]++
+
+	h, ok := m.toolDurations[tool]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.toolDurations[tool] = h
+	}
+	seconds := d.Seconds()
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Transport wraps transport so that every GitHub API response is counted by status code, and the
+// remaining rate limit GitHub reports is tracked. If transport is nil, http.DefaultTransport is used.
+func (m *Metrics) Transport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		api := "rest"
+		if strings.HasSuffix(req.URL.Path, "/graphql") {
+			api = "graphql"
+		}
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			m.observeAPIRequest(api, "error", nil)
+			return nil, err
+		}
+		m.observeAPIRequest(api, strconv.Itoa(resp.StatusCode), resp.Header)
+		return resp, nil
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (m *Metrics) observeAPIRequest(api, status string, header http.Header) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.apiRequests[apiRequestKey{api: api, status: status}]++
+
+	if header == nil {
+		return
+	}
+	remaining, err := strconv.ParseInt(header.Get("X-RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return
+	}
+	resource := header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	m.rateLimitRemaining[resource] = remaining
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text exposition format. Series are sorted so the
+// output is stable.
+func (m *Metrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	writeHeader(&b, "github_mcp_tool_calls_total", "counter", "Number of tool calls, by tool and outcome.")
+	toolCallKeys := make([]toolCallKey, 0, len(m.toolCalls))
+	for k := range m.toolCalls {
+		toolCallKeys = append(toolCallKeys, k)
+	}
+	sort.Slice(toolCallKeys, func(i, j int) bool {
+		if toolCallKeys[i].tool != toolCallKeys[j].tool {
+			return toolCallKeys[i].tool < toolCallKeys[j].tool
+		}
+		return toolCallKeys[i].outcome < toolCallKeys[j].outcome
+	})
+	for _, k := range toolCallKeys {
+		fmt.Fprintf(&b, "github_mcp_tool_calls_total{tool=%s,outcome=%s} %d\n", quote(k.tool), quote(k.outcome), m.toolCalls[k])
+	}
+
+	writeHeader(&b, "github_mcp_tool_call_duration_seconds", "histogram", "Latency of tool calls in seconds, by tool.")
+	for _, tool := range sortedKeys(m.toolDurations) {
+		h := m.toolDurations[tool]
+		var cumulative uint64
+		for i, bound := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "github_mcp_tool_call_duration_seconds_bucket{tool=%s,le=%s} %d\n", quote(tool), quote(strconv.FormatFloat(bound, 'g', -1, 64)), cumulative)
+		}
+		fmt.Fprintf(&b, "github_mcp_tool_call_duration_seconds_bucket{tool=%s,le=\"+Inf\"} %d\n", quote(tool), h.count)
+		fmt.Fprintf(&b, "github_mcp_tool_call_duration_seconds_sum{tool=%s} %s\n", quote(tool), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "github_mcp_tool_call_duration_seconds_count{tool=%s} %d\n", quote(tool), h.count)
+	}
+
+	writeHeader(&b, "github_mcp_github_api_requests_total", "counter", "Number of GitHub API requests, by API and response status code.")
+	apiRequestKeys := make([]apiRequestKey, 0, len(m.apiRequests))
+	for k := range m.apiRequests {
+		apiRequestKeys = append(apiRequestKeys, k)
+	}
+	sort.Slice(apiRequestKeys, func(i, j int) bool {
+		if apiRequestKeys[i].api != apiRequestKeys[j].api {
+			return apiRequestKeys[i].api < apiRequestKeys[j].api
+		}
+		return apiRequestKeys[i].status < apiRequestKeys[j].status
+	})
+	for _, k := range apiRequestKeys {
+		fmt.Fprintf(&b, "github_mcp_github_api_requests_total{api=%s,status=%s} %d\n", quote(k.api), quote(k.status), m.apiRequests[k])
+	}
+
+	writeHeader(&b, "github_mcp_github_rate_limit_remaining", "gauge", "Requests remaining in the current GitHub rate limit window, by resource.")
+	for _, resource := range sortedKeys(m.rateLimitRemaining) {
+		fmt.Fprintf(&b, "github_mcp_github_rate_limit_remaining{resource=%s} %d\n", quote(resource), m.rateLimitRemaining[resource])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// quote quotes a label value, escaping backslashes, double quotes and line feeds.
+func quote(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func callTool(t *testing.T, m *Metrics, name string, result *mcp.CallToolResult, err error) {
+	t.Helper()
+	handler := m.ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return result, err
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	_, _ = handler(context.Background(), request)
+}
+
+func TestMetrics_ToolCalls(t *testing.T) {
+	m := New()
+	elapsed := []time.Duration{0, 300 * time.Millisecond, 0, 2 * time.Second, 0, 20 * time.Millisecond}
+	start := time.Unix(0, 0)
+	calls := 0
+	m.now = func() time.Time {
+		start = start.Add(elapsed[calls])
+		calls++
+		return start
+	}
+
+	callTool(t, m, "get_me", mcp.NewToolResultText("ok"), nil)
+	callTool(t, m, "get_me", mcp.NewToolResultError("failed"), nil)
+	callTool(t, m, "list_issues", nil, errors.New("boom"))
+
+	var b strings.Builder
+	require.NoError(t, m.Write(&b))
+	out := b.String()
+
+	assert.Contains(t, out, "# TYPE github_mcp_tool_calls_total counter\n")
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="get_me",outcome="error"} 1`)
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="get_me",outcome="success"} 1`)
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="list_issues",outcome="error"} 1`)
+
+	assert.Contains(t, out, "# TYPE github_mcp_tool_call_duration_seconds histogram\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_me",le="0.25"} 0`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_me",le="0.5"} 1`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_me",le="2.5"} 2`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_me",le="+Inf"} 2`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_sum{tool="get_me"} 2.3`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_count{tool="get_me"} 2`)
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="list_issues",le="0.05"} 1`)
+}
+
+func TestMetrics_Transport(t *testing.T) {
+	m := New()
+	responses := []*http.Response{
+		{StatusCode: http.StatusOK, Header: http.Header{"X-Ratelimit-Remaining": {"4999"}, "X-Ratelimit-Resource": {"core"}}},
+		{StatusCode: http.StatusNotFound, Header: http.Header{"X-Ratelimit-Remaining": {"4998"}}},
+		{StatusCode: http.StatusOK, Header: http.Header{"X-Ratelimit-Remaining": {"4321"}, "X-Ratelimit-Resource": {"graphql"}}},
+	}
+	calls := 0
+	transport := m.Transport(roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		if calls == len(responses) {
+			return nil, errors.New("connection reset")
+		}
+		resp := responses[calls]
+		calls++
+		return resp, nil
+	}))
+
+	for _, url := range []string{
+		"https://api.github.com/user",
+		"https://api.github.com/repos/owner/repo",
+		"https://api.github.com/graphql",
+		"https://api.github.com/user",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		_, _ = transport.RoundTrip(req)
+	}
+
+	var b strings.Builder
+	require.NoError(t, m.Write(&b))
+	out := b.String()
+
+	assert.Contains(t, out, `github_mcp_github_api_requests_total{api="graphql",status="200"} 1`)
+	assert.Contains(t, out, `github_mcp_github_api_requests_total{api="rest",status="200"} 1`)
+	assert.Contains(t, out, `github_mcp_github_api_requests_total{api="rest",status="404"} 1`)
+	assert.Contains(t, out, `github_mcp_github_api_requests_total{api="rest",status="error"} 1`)
+	assert.Contains(t, out, `github_mcp_github_rate_limit_remaining{resource="core"} 4998`)
+	assert.Contains(t, out, `github_mcp_github_rate_limit_remaining{resource="graphql"} 4321`)
+}
+
+func TestMetrics_ServeHTTP(t *testing.T) {
+	m := New()
+	callTool(t, m, `weird"tool`, mcp.NewToolResultText("ok"), nil)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `github_mcp_tool_calls_total{tool="weird\"tool",outcome="success"} 1`)
+}
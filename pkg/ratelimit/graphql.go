@@ -0,0 +1,320 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitAlias is the alias the rateLimit field is added to queries under, so that it cannot
+// clash with the fields the query selects itself.
+const rateLimitAlias = "githubMCPRateLimit"
+
+// GraphQLBudgetConfig controls the cost guard of a GraphQLBudget. The zero value only tracks costs.
+type GraphQLBudgetConfig struct {
+	// MaxQueryCost is the highest cost in rate limit points a single GraphQL query may have.
+	// Queries predicted to cost more are refused before they run. Zero or a negative value
+	// disables the check, which otherwise costs a dry run per query.
+	MaxQueryCost int
+
+	// MaxCallCost is the highest number of points the GraphQL queries of a single tool call may
+	// cost together. Once it is spent, further queries of the call are refused. Zero or a
+	// negative value means unlimited.
+	MaxCallCost int
+}
+
+// GraphQLBudget tracks the GraphQL rate limit points spent by a token and guards against
+// expensive queries. GitHub charges GraphQL queries by the number of nodes they may return
+// rather than per request, out of an hourly budget of points, so a single dump of a large
+// project board can spend as much as hundreds of REST calls.
+type GraphQLBudget struct {
+	config GraphQLBudgetConfig
+
+	mu    sync.Mutex
+	state GraphQLBudgetState
+}
+
+// GraphQLBudgetState is a snapshot of the points spent and left, as last reported by GitHub.
+type GraphQLBudgetState struct {
+	Limit        int        `json:"limit,omitempty"`
+	Remaining    int        `json:"remaining,omitempty"`
+	ResetAt      *time.Time `json:"reset_at,omitempty"`
+	Queries      int        `json:"queries"`
+	TotalCost    int        `json:"total_cost"`
+	LastCost     int        `json:"last_cost"`
+	HighestCost  int        `json:"highest_cost"`
+	Refused      int        `json:"refused"`
+	MaxQueryCost int        `json:"max_query_cost,omitempty"`
+	MaxCallCost  int        `json:"max_call_cost,omitempty"`
+}
+
+// NewGraphQLBudget returns a budget guarding queries as described by cfg.
+func NewGraphQLBudget(cfg GraphQLBudgetConfig) *GraphQLBudget {
+	return &GraphQLBudget{config: cfg}
+}
+
+// State returns a snapshot of the budget.
+func (b *GraphQLBudget) State() GraphQLBudgetState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.state
+	state.MaxQueryCost = max(b.config.MaxQueryCost, 0)
+	state.MaxCallCost = max(b.config.MaxCallCost, 0)
+	return state
+}
+
+// GraphQLCostError is returned for GraphQL queries the budget refused to run.
+type GraphQLCostError struct {
+	// Cost is the predicted cost of the query, or the points the tool call already spent.
+	Cost int
+	// Max is the limit that would have been exceeded.
+	Max int
+	// PerCall is set when the limit of the tool call, rather than of the query, was exceeded.
+	PerCall bool
+}
+
+func (e *GraphQLCostError) Error() string {
+	if e.PerCall {
+		return fmt.Sprintf("GraphQL queries of this call would cost more than the maximum of %d points per call, %d were spent already", e.Max, e.Cost)
+	}
+	return fmt.Sprintf("GraphQL query would cost %d points, more than the maximum of %d points per query, request fewer items at a time", e.Cost, e.Max)
+}
+
+// graphQLCall records the points spent by the GraphQL queries made with a context.
+type graphQLCall struct {
+	mu    sync.Mutex
+	spent int
+}
+
+type graphQLCallKey struct{}
+
+// ContextWithGraphQLCall returns a context whose GraphQL queries count towards a single call's budget.
+func ContextWithGraphQLCall(ctx context.Context) context.Context {
+	return context.WithValue(ctx, graphQLCallKey{}, &graphQLCall{})
+}
+
+func graphQLCallFromContext(ctx context.Context) *graphQLCall {
+	call, _ := ctx.Value(graphQLCallKey{}).(*graphQLCall)
+	return call
+}
+
+func (c *graphQLCall) spend(cost int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spent += cost
+}
+
+func (c *graphQLCall) spentPoints() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spent
+}
+
+// Transport wraps the transport of a GraphQL client so that its queries are tracked and guarded
+// by the budget. If transport is nil, http.DefaultTransport is used.
+func (b *GraphQLBudget) Transport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &graphQLBudgetTransport{budget: b, transport: transport}
+}
+
+type graphQLBudgetTransport struct {
+	budget    *GraphQLBudget
+	transport http.RoundTripper
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string          `json:"query"`
+	Variables json.RawMessage `json:"variables,omitempty"`
+}
+
+// rateLimitData is the rateLimit field GitHub reports the cost of a query and the budget left in.
+type rateLimitData struct {
+	Cost      int       `json:"cost"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// RoundTrip implements http.RoundTripper. Queries get the rateLimit field added, which GitHub
+// answers with their actual cost and is removed from the response again. Mutations cannot
+// report their cost and are passed through unchanged.
+func (t *graphQLBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return t.transport.RoundTrip(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var payload graphQLRequest
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return t.transport.RoundTrip(withBody(req, body))
+	}
+	tracked, ok := addRateLimitField(payload.Query, rateLimitAlias+": rateLimit { cost limit remaining resetAt }")
+	if !ok {
+		return t.transport.RoundTrip(withBody(req, body))
+	}
+
+	ctx := req.Context()
+	call := graphQLCallFromContext(ctx)
+	maxCall := t.budget.config.MaxCallCost
+	if maxCall > 0 && call != nil && call.spentPoints() >= maxCall {
+		return nil, t.budget.refuse(&GraphQLCostError{Cost: call.spentPoints(), Max: maxCall, PerCall: true})
+	}
+
+	if maxQuery := t.budget.config.MaxQueryCost; maxQuery > 0 {
+		cost, ok, err := t.predictCost(req, payload)
+		if err != nil {
+			return nil, err
+		}
+		if ok && cost > maxQuery {
+			return nil, t.budget.refuse(&GraphQLCostError{Cost: cost, Max: maxQuery})
+		}
+		if ok && maxCall > 0 && call != nil && call.spentPoints()+cost > maxCall {
+			return nil, t.budget.refuse(&GraphQLCostError{Cost: call.spentPoints(), Max: maxCall, PerCall: true})
+		}
+	}
+
+	payload.Query = tracked
+	trackedBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.transport.RoundTrip(withBody(req, trackedBody))
+	if err != nil {
+		return nil, err
+	}
+	rateLimit, ok := takeRateLimitField(resp)
+	if ok {
+		t.budget.record(rateLimit)
+		if call != nil {
+			call.spend(rateLimit.Cost)
+		}
+	}
+	return resp, nil
+}
+
+// predictCost runs the query as a dry run, which GitHub answers with its cost without evaluating it.
+// It reports false if the cost could not be determined, in which case the query runs unguarded
+// and reports its own errors.
+func (t *graphQLBudgetTransport) predictCost(req *http.Request, payload graphQLRequest) (int, bool, error) {
+	query, _ := addRateLimitField(payload.Query, rateLimitAlias+": rateLimit(dryRun: true) { cost }")
+	payload.Query = query
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, false, err
+	}
+	resp, err := t.transport.RoundTrip(withBody(req, body))
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	rateLimit, ok := takeRateLimitField(resp)
+	return rateLimit.Cost, ok, nil
+}
+
+func (b *GraphQLBudget) refuse(err *GraphQLCostError) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state.Refused++
+	return err
+}
+
+func (b *GraphQLBudget) record(rateLimit rateLimitData) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state.Queries++
+	b.state.TotalCost += rateLimit.Cost
+	b.state.LastCost = rateLimit.Cost
+	b.state.HighestCost = max(b.state.HighestCost, rateLimit.Cost)
+	b.state.Limit = rateLimit.Limit
+	b.state.Remaining = rateLimit.Remaining
+	if !rateLimit.ResetAt.IsZero() {
+		resetAt := rateLimit.ResetAt
+		b.state.ResetAt = &resetAt
+	}
+}
+
+// withBody returns a copy of req sending body.
+func withBody(req *http.Request, body []byte) *http.Request {
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return req
+}
+
+// addRateLimitField adds field to the top-level selection of a query. It reports false for
+// mutations and subscriptions, whose types have no rateLimit field.
+func addRateLimitField(query, field string) (string, bool) {
+	trimmed := strings.TrimSpace(query)
+	if !strings.HasPrefix(trimmed, "query") && !strings.HasPrefix(trimmed, "{") {
+		return "", false
+	}
+	// The selection starts at the first brace outside the variable definitions.
+	depth := 0
+	for i, r := range query {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				return query[:i+1] + field + " " + query[i+1:], true
+			}
+		}
+	}
+	return "", false
+}
+
+// takeRateLimitField removes the added rateLimit field from a successful response and returns it.
+// The response body is replaced, so it can be read as if the field had never been asked for.
+func takeRateLimitField(resp *http.Response) (rateLimitData, bool) {
+	if resp.StatusCode != http.StatusOK {
+		return rateLimitData{}, false
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return rateLimitData{}, false
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return rateLimitData{}, false
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(payload["data"], &data); err != nil || data[rateLimitAlias] == nil {
+		return rateLimitData{}, false
+	}
+	var rateLimit rateLimitData
+	if err := json.Unmarshal(data[rateLimitAlias], &rateLimit); err != nil {
+		return rateLimitData{}, false
+	}
+
+	delete(data, rateLimitAlias)
+	if payload["data"], err = json.Marshal(data); err != nil {
+		return rateLimitData{}, false
+	}
+	if body, err = json.Marshal(payload); err != nil {
+		return rateLimitData{}, false
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return rateLimit, true
+}
@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLServer answers viewer queries, reporting cost as the cost of every query.
+func graphQLServer(t *testing.T, cost int, queries *[]string) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		var payload graphQLRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		*queries = append(*queries, payload.Query)
+
+		rateLimit := fmt.Sprintf(`{"cost":%d,"limit":5000,"remaining":4990,"resetAt":"2024-05-01T13:00:00Z"}`, cost)
+		if strings.Contains(payload.Query, "dryRun: true") {
+			rateLimit = fmt.Sprintf(`{"cost":%d}`, cost)
+		}
+		return newResponse(http.StatusOK, nil, `{"data":{"viewer":{"login":"octocat"},"`+rateLimitAlias+`":`+rateLimit+`}}`), nil
+	}
+}
+
+type viewerQuery struct {
+	Viewer struct {
+		Login githubv4.String
+	}
+}
+
+func TestGraphQLBudget_TracksCost(t *testing.T) {
+	var queries []string
+	budget := NewGraphQLBudget(GraphQLBudgetConfig{})
+	client := githubv4.NewClient(&http.Client{Transport: budget.Transport(graphQLServer(t, 2, &queries))})
+
+	var query viewerQuery
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+
+	// The added field is removed again, so the query decodes as if it had not been asked for
+	assert.Equal(t, githubv4.String("octocat"), query.Viewer.Login)
+	require.Len(t, queries, 2)
+	assert.Contains(t, queries[0], rateLimitAlias+": rateLimit { cost limit remaining resetAt }")
+
+	resetAt := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	assert.Equal(t, GraphQLBudgetState{
+		Limit:       5000,
+		Remaining:   4990,
+		ResetAt:     &resetAt,
+		Queries:     2,
+		TotalCost:   4,
+		LastCost:    2,
+		HighestCost: 2,
+	}, budget.State())
+}
+
+func TestGraphQLBudget_MutationsPassThrough(t *testing.T) {
+	body := `{"query":"mutation($input:AddStarInput!){addStar(input: $input){clientMutationId}}"}`
+	var forwarded string
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		forwarded = string(b)
+		return newResponse(http.StatusOK, nil, `{"data":{}}`), nil
+	})
+	budget := NewGraphQLBudget(GraphQLBudgetConfig{MaxQueryCost: 1})
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader(body))
+	resp, err := budget.Transport(next).RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, body, forwarded)
+	assert.Equal(t, 0, budget.State().Queries)
+}
+
+func TestGraphQLBudget_MaxQueryCost(t *testing.T) {
+	var queries []string
+	budget := NewGraphQLBudget(GraphQLBudgetConfig{MaxQueryCost: 10})
+	client := githubv4.NewClient(&http.Client{Transport: budget.Transport(graphQLServer(t, 50, &queries))})
+
+	var query viewerQuery
+	err := client.Query(context.Background(), &query, nil)
+	var costErr *GraphQLCostError
+	require.ErrorAs(t, err, &costErr)
+	assert.Equal(t, &GraphQLCostError{Cost: 50, Max: 10}, costErr)
+
+	// Only the dry run reached GitHub
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "dryRun: true")
+	state := budget.State()
+	assert.Equal(t, 1, state.Refused)
+	assert.Equal(t, 0, state.Queries)
+	assert.Equal(t, 10, state.MaxQueryCost)
+}
+
+func TestGraphQLBudget_MaxCallCost(t *testing.T) {
+	var queries []string
+	budget := NewGraphQLBudget(GraphQLBudgetConfig{MaxCallCost: 3})
+	client := githubv4.NewClient(&http.Client{Transport: budget.Transport(graphQLServer(t, 2, &queries))})
+	ctx := ContextWithGraphQLCall(context.Background())
+
+	var query viewerQuery
+	require.NoError(t, client.Query(ctx, &query, nil))
+	require.NoError(t, client.Query(ctx, &query, nil))
+	err := client.Query(ctx, &query, nil)
+	var costErr *GraphQLCostError
+	require.True(t, errors.As(err, &costErr))
+	assert.Equal(t, &GraphQLCostError{Cost: 4, Max: 3, PerCall: true}, costErr)
+	assert.Len(t, queries, 2)
+
+	// Another call starts with a budget of its own
+	require.NoError(t, client.Query(ContextWithGraphQLCall(context.Background()), &query, nil))
+	assert.Equal(t, 1, budget.State().Refused)
+	assert.Equal(t, 3, budget.State().Queries)
+}
+
+func TestAddRateLimitField(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		ok       bool
+	}{
+		{
+			query:    "{viewer{login}}",
+			expected: "{f viewer{login}}",
+			ok:       true,
+		},
+		{
+			query:    "query($owner:String!$first:Int!){organization(login: $owner){login}}",
+			expected: "query($owner:String!$first:Int!){f organization(login: $owner){login}}",
+			ok:       true,
+		},
+		{
+			query: "mutation($input:AddStarInput!){addStar(input: $input){clientMutationId}}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			query, ok := addRateLimitField(tc.query, "f")
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
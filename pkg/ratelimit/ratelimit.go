@@ -0,0 +1,319 @@
+// Package ratelimit provides an http.RoundTripper that bounds how hard the server
+// can hit the GitHub API, so that a runaway agent loop cannot exhaust an
+// organization's API quota, and that retries calls which failed transiently.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBaseBackoff is the initial wait before retrying a request that hit a
+	// secondary rate limit and did not tell us how long to wait.
+	defaultBaseBackoff = time.Second
+
+	// maxBackoff caps the time we will wait between two attempts of the same request.
+	maxBackoff = time.Minute
+)
+
+// Config controls the behaviour of the Transport. The zero value disables all limits.
+type Config struct {
+	// MaxConcurrent is the maximum number of GitHub API calls in flight at any one time.
+	// Zero or a negative value means unlimited.
+	MaxConcurrent int
+
+	// RequestsPerMinute is the maximum number of GitHub API calls started per minute.
+	// Zero or a negative value means unlimited.
+	RequestsPerMinute int
+
+	// MaxRetries is the number of times a request is retried after hitting a
+	// secondary rate limit, or after a 502 or 503 response to an idempotent
+	// request. Zero disables retries.
+	MaxRetries int
+}
+
+// Transport is an http.RoundTripper that limits concurrency and request rate, and
+// retries requests that hit a secondary rate limit or a temporarily unavailable
+// server with exponential backoff.
+type Transport struct {
+	transport  http.RoundTripper
+	sem        chan struct{}
+	interval   time.Duration
+	maxRetries int
+
+	mu   sync.Mutex
+	next time.Time
+
+	// baseBackoff and sleep are overridable for tests.
+	baseBackoff time.Duration
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport wraps the provided transport with the limits described by cfg.
+// If transport is nil, http.DefaultTransport is used.
+func NewTransport(transport http.RoundTripper, cfg Config) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	t := &Transport{
+		transport:   transport,
+		maxRetries:  cfg.MaxRetries,
+		baseBackoff: defaultBaseBackoff,
+		sleep:       sleepContext,
+	}
+	if cfg.MaxConcurrent > 0 {
+		t.sem = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	if cfg.RequestsPerMinute > 0 {
+		t.interval = time.Minute / time.Duration(cfg.RequestsPerMinute)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTripOnce(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt >= t.maxRetries || !isRetryable(req, resp) {
+			return resp, nil
+		}
+
+		// We can only replay the request if we are able to rewind its body.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := t.backoff(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if retries := RetriesFromContext(ctx); retries != nil {
+			retries.record(resp.StatusCode, wait)
+		}
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// roundTripOnce performs a single attempt, honouring the concurrency and rate limits.
+func (t *Transport) roundTripOnce(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-t.sem }()
+	}
+
+	if err := t.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// wait blocks until the next request slot allowed by RequestsPerMinute is available.
+// Slots are handed out at a fixed interval, so bursts are smoothed out rather than
+// allowed up front.
+func (t *Transport) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	slot := t.next
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next = slot.Add(t.interval)
+	t.mu.Unlock()
+
+	return t.sleep(ctx, time.Until(slot))
+}
+
+// backoff returns how long to wait before the next attempt. GitHub's Retry-After
+// header takes precedence, then X-RateLimit-Reset when no requests remain, otherwise
+// the wait doubles with every attempt. It returns false when GitHub asks us to wait
+// longer than maxBackoff, as there is no point in retrying before then.
+func (t *Transport) backoff(resp *http.Response, attempt int) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			return wait, wait <= maxBackoff
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+			if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+				wait := max(time.Until(time.Unix(reset, 0)), 0)
+				return wait, wait <= maxBackoff
+			}
+		}
+	}
+
+	wait := t.baseBackoff << attempt
+	if wait <= 0 || wait > maxBackoff {
+		return maxBackoff, true
+	}
+	return wait, true
+}
+
+// isRetryable reports whether the request failed in a way that is worth retrying:
+// a secondary rate limit, or a server that was temporarily unavailable while
+// handling a request that is safe to repeat.
+func isRetryable(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable {
+		return isIdempotent(req)
+	}
+	return isSecondaryRateLimit(resp)
+}
+
+// isIdempotent reports whether repeating the request cannot apply a change twice.
+// A 502 or 503 doesn't tell whether the change was made, so only such requests are
+// retried. GraphQL queries are POSTed but idempotent, unlike mutations.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		if !strings.HasSuffix(req.URL.Path, "/graphql") || req.GetBody == nil {
+			return false
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return false
+		}
+		defer func() { _ = body.Close() }()
+		var payload struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(body).Decode(&payload); err != nil {
+			return false
+		}
+		return !strings.HasPrefix(strings.TrimSpace(payload.Query), "mutation")
+	default:
+		return false
+	}
+}
+
+// isSecondaryRateLimit reports whether the response indicates that a secondary
+// rate limit was hit. The response body is restored so callers can still read it.
+// A primary rate limit is reported without Retry-After and without mentioning a
+// secondary rate limit, and is not retried as it lasts until the hourly reset.
+// See: https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api#about-secondary-rate-limits
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+// Retries records the retries the Transport performed for the requests made with a
+// context, so that a call which still fails can report how hard it was tried.
+type Retries struct {
+	mu         sync.Mutex
+	count      int
+	waited     time.Duration
+	lastStatus int
+}
+
+type retriesKey struct{}
+
+// ContextWithRetries returns a context whose requests record their retries in the returned Retries.
+func ContextWithRetries(ctx context.Context) (context.Context, *Retries) {
+	retries := &Retries{}
+	return context.WithValue(ctx, retriesKey{}, retries), retries
+}
+
+// RetriesFromContext returns the Retries recorded for ctx, or nil if ctx does not record retries.
+func RetriesFromContext(ctx context.Context) *Retries {
+	retries, _ := ctx.Value(retriesKey{}).(*Retries)
+	return retries
+}
+
+func (r *Retries) record(status int, wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count++
+	r.waited += wait
+	r.lastStatus = status
+}
+
+// Count returns the number of retries performed.
+func (r *Retries) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// String describes the retries performed, or returns an empty string if there were none.
+func (r *Retries) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("retried %d times over %s, most recently after a %d %s response", r.count, r.waited.Round(time.Second), r.lastStatus, http.StatusText(r.lastStatus))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
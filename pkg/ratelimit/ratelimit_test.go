@@ -0,0 +1,351 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newResponse(status int, headers map[string]string, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestTransport_MaxConcurrent(t *testing.T) {
+	var inFlight, maxInFlight int32
+	release := make(chan struct{})
+
+	next := roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&inFlight, -1)
+		return newResponse(http.StatusOK, nil, "ok"), nil
+	})
+
+	transport := NewTransport(next, Config{MaxConcurrent: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+			resp, err := transport.RoundTrip(req)
+			assert.NoError(t, err)
+			_ = resp.Body.Close()
+		}()
+	}
+
+	// Give the goroutines a chance to pile up against the semaphore before releasing them.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestTransport_RequestsPerMinute(t *testing.T) {
+	next := roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, nil, "ok"), nil
+	})
+
+	transport := NewTransport(next, Config{RequestsPerMinute: 60})
+	var waits []time.Duration
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	require.Len(t, waits, 3)
+	assert.LessOrEqual(t, waits[0], time.Duration(0))
+	assert.InDelta(t, time.Second, waits[1], float64(100*time.Millisecond))
+	assert.InDelta(t, 2*time.Second, waits[2], float64(100*time.Millisecond))
+}
+
+func TestTransport_SecondaryRateLimitRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []*http.Response
+		maxRetries    int
+		expectStatus  int
+		expectCalls   int
+		expectBackoff []time.Duration
+	}{
+		{
+			name: "retries after secondary rate limit message",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, nil, `{"message":"You have exceeded a secondary rate limit"}`),
+				newResponse(http.StatusOK, nil, "ok"),
+			},
+			maxRetries:    3,
+			expectStatus:  http.StatusOK,
+			expectCalls:   2,
+			expectBackoff: []time.Duration{time.Second},
+		},
+		{
+			name: "honours Retry-After header",
+			responses: []*http.Response{
+				newResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}, ""),
+				newResponse(http.StatusOK, nil, "ok"),
+			},
+			maxRetries:    3,
+			expectStatus:  http.StatusOK,
+			expectCalls:   2,
+			expectBackoff: []time.Duration{7 * time.Second},
+		},
+		{
+			name: "backs off exponentially and gives up after max retries",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, nil, "secondary rate limit"),
+				newResponse(http.StatusForbidden, nil, "secondary rate limit"),
+				newResponse(http.StatusForbidden, nil, "secondary rate limit"),
+			},
+			maxRetries:    2,
+			expectStatus:  http.StatusForbidden,
+			expectCalls:   3,
+			expectBackoff: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name: "does not retry primary rate limit",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0"}, "API rate limit exceeded"),
+			},
+			maxRetries:   3,
+			expectStatus: http.StatusForbidden,
+			expectCalls:  1,
+		},
+		{
+			name: "does not retry unrelated forbidden",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, nil, "Resource not accessible by integration"),
+			},
+			maxRetries:   3,
+			expectStatus: http.StatusForbidden,
+			expectCalls:  1,
+		},
+		{
+			name: "retries disabled",
+			responses: []*http.Response{
+				newResponse(http.StatusForbidden, nil, "secondary rate limit"),
+			},
+			maxRetries:   0,
+			expectStatus: http.StatusForbidden,
+			expectCalls:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			var bodies []string
+			next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Body != nil {
+					b, _ := io.ReadAll(req.Body)
+					bodies = append(bodies, string(b))
+				}
+				resp := tc.responses[calls]
+				calls++
+				return resp, nil
+			})
+
+			transport := NewTransport(next, Config{MaxRetries: tc.maxRetries})
+			var backoffs []time.Duration
+			transport.sleep = func(_ context.Context, d time.Duration) error {
+				backoffs = append(backoffs, d)
+				return nil
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader(`{"query":"{viewer{login}}"}`))
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectCalls, calls)
+			assert.Equal(t, tc.expectBackoff, backoffs)
+
+			// The request body must be replayed in full on every attempt.
+			for _, b := range bodies {
+				assert.Equal(t, `{"query":"{viewer{login}}"}`, b)
+			}
+
+			// The final response body must still be readable by the caller.
+			_, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTransport_ContextCancelledWhileWaiting(t *testing.T) {
+	next := roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, nil, "ok"), nil
+	})
+
+	transport := NewTransport(next, Config{MaxConcurrent: 1})
+	transport.sem <- struct{}{} // occupy the only slot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	_, err := transport.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTransport_ServerErrorRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		body        string
+		status      int
+		expectCalls int
+	}{
+		{
+			name:        "retries GET after 503",
+			method:      http.MethodGet,
+			url:         "https://api.github.com/repos/owner/repo",
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 2,
+		},
+		{
+			name:        "retries GraphQL query after 502",
+			method:      http.MethodPost,
+			url:         "https://api.github.com/graphql",
+			body:        `{"query":"query($owner:String!){repository(owner:$owner){id}}"}`,
+			status:      http.StatusBadGateway,
+			expectCalls: 2,
+		},
+		{
+			name:        "does not retry GraphQL mutation after 502",
+			method:      http.MethodPost,
+			url:         "https://api.github.com/graphql",
+			body:        `{"query":"mutation($input:AddStarInput!){addStar(input:$input){clientMutationId}}"}`,
+			status:      http.StatusBadGateway,
+			expectCalls: 1,
+		},
+		{
+			name:        "does not retry REST POST after 503",
+			method:      http.MethodPost,
+			url:         "https://api.github.com/repos/owner/repo/issues",
+			body:        `{"title":"bug"}`,
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 1,
+		},
+		{
+			name:        "does not retry 500",
+			method:      http.MethodGet,
+			url:         "https://api.github.com/repos/owner/repo",
+			status:      http.StatusInternalServerError,
+			expectCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			next := roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+				calls++
+				if calls == 1 {
+					return newResponse(tc.status, nil, "unavailable"), nil
+				}
+				return newResponse(http.StatusOK, nil, "ok"), nil
+			})
+
+			transport := NewTransport(next, Config{MaxRetries: 3})
+			transport.sleep = func(_ context.Context, _ time.Duration) error { return nil }
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req, err := http.NewRequest(tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectCalls, calls)
+		})
+	}
+}
+
+func TestTransport_RateLimitResetBackoff(t *testing.T) {
+	t.Run("waits until the reset time of an exhausted secondary rate limit", func(t *testing.T) {
+		reset := time.Now().Add(10 * time.Second)
+		transport := NewTransport(nil, Config{MaxRetries: 1})
+		wait, ok := transport.backoff(newResponse(http.StatusForbidden, map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		}, "secondary rate limit"), 0)
+		assert.True(t, ok)
+		assert.InDelta(t, 10*time.Second, wait, float64(time.Second))
+	})
+
+	t.Run("gives up when asked to wait too long", func(t *testing.T) {
+		transport := NewTransport(nil, Config{MaxRetries: 1})
+		_, ok := transport.backoff(newResponse(http.StatusForbidden, map[string]string{"Retry-After": "3600"}, ""), 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestTransport_RecordsRetries(t *testing.T) {
+	next := roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusServiceUnavailable, map[string]string{"Retry-After": "2"}, ""), nil
+	})
+	transport := NewTransport(next, Config{MaxRetries: 2})
+	transport.sleep = func(_ context.Context, _ time.Duration) error { return nil }
+
+	ctx, retries := ContextWithRetries(context.Background())
+	assert.Same(t, retries, RetriesFromContext(ctx))
+	assert.Empty(t, retries.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 2, retries.Count())
+	assert.Equal(t, "retried 2 times over 4s, most recently after a 503 Service Unavailable response", retries.String())
+	assert.Nil(t, RetriesFromContext(context.Background()))
+}
@@ -3,6 +3,7 @@ package raw
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -71,3 +72,17 @@ func (c *Client) GetRawContent(ctx context.Context, owner, repo, path string, op
 
 	return c.client.Client().Do(req)
 }
+
+// GetRawContentRange fetches length bytes of a file from a GitHub repository, starting at offset,
+// with an HTTP range request. The response is 206 Partial Content with the Content-Range header
+// set, or 416 Range Not Satisfiable if offset is past the end of the file.
+func (c *Client) GetRawContentRange(ctx context.Context, owner, repo, path string, opts *ContentOpts, offset, length int64) (*http.Response, error) {
+	url := c.URLFromOpts(opts, owner, repo, path)
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	return c.client.Client().Do(req)
+}
@@ -100,6 +100,30 @@ func TestGetRawContent(t *testing.T) {
 	}
 }
 
+func TestGetRawContentRange(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoBySHAByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "bytes=10-19", r.Header.Get("Range"))
+				w.Header().Set("Content-Range", "bytes 10-19/100")
+				w.WriteHeader(http.StatusPartialContent)
+				_, err := w.Write([]byte("0123456789"))
+				require.NoError(t, err)
+			}),
+		),
+	)
+	client := NewClient(github.NewClient(mockedClient), base)
+	resp, err := client.GetRawContentRange(context.Background(), "octocat", "hello", "logo.png", &ContentOpts{SHA: "abc123"}, 10, 10)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, "bytes 10-19/100", resp.Header.Get("Content-Range"))
+}
+
 func TestUrlFromOpts(t *testing.T) {
 	base, _ := url.Parse("https://raw.example.com/")
 	ghClient := github.NewClient(nil)
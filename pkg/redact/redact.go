@@ -0,0 +1,147 @@
+// Package redact removes sensitive content, such as email addresses and secrets, from tool
+// results before they are returned to the model.
+package redact
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// filters are the built-in redaction filters, by name.
+var filters = map[string][]string{
+	"emails": {
+		`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	},
+	"tokens": {
+		// GitHub personal access, OAuth, user-to-server, server-to-server and refresh tokens
+		`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,}\b`,
+		`\bgithub_pat_[A-Za-z0-9_]{22,}\b`,
+		// AWS access key IDs
+		`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+		// Slack tokens
+		`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`,
+		// Google API keys
+		`\bAIza[0-9A-Za-z_-]{35}\b`,
+		// Private keys
+		`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	},
+}
+
+// Filters returns the names of the built-in filters.
+func Filters() []string {
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor replaces the content matching its rules with a placeholder naming the rule, unless
+// the content is explicitly allowed.
+type Redactor struct {
+	rules []rule
+	allow []*regexp.Regexp
+}
+
+// New creates a Redactor from the names of built-in filters and additional regular expressions.
+// Matches that are entirely matched by one of the allow expressions are kept, for example to keep
+// noreply email addresses while redacting all others.
+func New(filterNames, patterns, allow []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, name := range filterNames {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		exprs, ok := filters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction filter %q, available filters are: %s", name, strings.Join(Filters(), ", "))
+		}
+		for _, expr := range exprs {
+			r.rules = append(r.rules, rule{
+				pattern:     regexp.MustCompile(expr),
+				replacement: fmt.Sprintf("[REDACTED:%s]", name),
+			})
+		}
+	}
+	for _, expr := range patterns {
+		if expr == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		r.rules = append(r.rules, rule{pattern: pattern, replacement: "[REDACTED]"})
+	}
+	for _, expr := range allow {
+		if expr == "" {
+			continue
+		}
+		pattern, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction allow pattern %q: %w", expr, err)
+		}
+		r.allow = append(r.allow, pattern)
+	}
+	return r, nil
+}
+
+// Enabled reports whether the Redactor has any rules.
+func (r *Redactor) Enabled() bool {
+	return len(r.rules) > 0
+}
+
+// Redact returns s with all content matching a rule replaced.
+func (r *Redactor) Redact(s string) string {
+	for _, rule := range r.rules {
+		s = rule.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			for _, allow := range r.allow {
+				if allow.MatchString(match) {
+					return match
+				}
+			}
+			return rule.replacement
+		})
+	}
+	return s
+}
+
+// ToolHandlerMiddleware redacts the text content of every tool result, including the text of
+// embedded resources.
+func (r *Redactor) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if result == nil {
+			return result, err
+		}
+		for i, content := range result.Content {
+			switch c := content.(type) {
+			case mcp.TextContent:
+				c.Text = r.Redact(c.Text)
+				result.Content[i] = c
+			case *mcp.TextContent:
+				c.Text = r.Redact(c.Text)
+			case mcp.EmbeddedResource:
+				if text, ok := c.Resource.(mcp.TextResourceContents); ok {
+					text.Text = r.Redact(text.Text)
+					c.Resource = text
+					result.Content[i] = c
+				}
+			}
+		}
+		return result, err
+	}
+}
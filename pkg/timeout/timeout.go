@@ -0,0 +1,162 @@
+// Package timeout bounds how long tool calls may run, and cancels tool calls the client has given
+// up on, so that a hung GitHub API call cannot keep a tool call running forever.
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MethodNotificationCancelled is the notification a client sends when it no longer waits for the
+// response to a request.
+const MethodNotificationCancelled = "notifications/cancelled"
+
+// ParseToolTimeouts parses per-tool timeouts given as "tool=duration", such as "download_repo_archive=5m".
+// Entries may also be separated by commas, as they are when read from an environment variable.
+func ParseToolTimeouts(entries []string) (map[string]time.Duration, error) {
+	var specs []string
+	for _, entry := range entries {
+		specs = append(specs, strings.Split(entry, ",")...)
+	}
+	timeouts := make(map[string]time.Duration, len(specs))
+	for _, spec := range specs {
+		tool, value, found := strings.Cut(spec, "=")
+		if !found || tool == "" {
+			return nil, fmt.Errorf("invalid tool timeout %q, expected tool=duration", spec)
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool timeout %q: %w", spec, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid tool timeout %q: must not be negative", spec)
+		}
+		timeouts[tool] = d
+	}
+	return timeouts, nil
+}
+
+// Timeouts applies a deadline to the context of every tool call and cancels the context of calls
+// the client cancels.
+type Timeouts struct {
+	defaultTimeout time.Duration
+	toolTimeouts   map[string]time.Duration
+
+	mu sync.Mutex
+	// requestIDs holds the IDs of calls that have not reached the middleware yet. mcp-go only passes
+	// the ID to hooks, so they are matched up by the Meta of the request, which the handler's copy of
+	// the request shares.
+	requestIDs map[*mcp.Meta]string
+	// running holds the cancel functions of the calls in progress, by request ID.
+	running map[string]context.CancelFunc
+}
+
+// New creates Timeouts that stop tool calls after defaultTimeout, or the timeout in toolTimeouts
+// for the tool. A timeout of 0 lets calls run until they finish or are cancelled.
+func New(defaultTimeout time.Duration, toolTimeouts map[string]time.Duration) *Timeouts {
+	return &Timeouts{
+		defaultTimeout: defaultTimeout,
+		toolTimeouts:   toolTimeouts,
+		requestIDs:     map[*mcp.Meta]string{},
+		running:        map[string]context.CancelFunc{},
+	}
+}
+
+// Timeout returns the timeout of a tool.
+func (t *Timeouts) Timeout(tool string) time.Duration {
+	if d, ok := t.toolTimeouts[tool]; ok {
+		return d
+	}
+	return t.defaultTimeout
+}
+
+// OnBeforeCallTool is a hook that records the request ID of a tool call for ToolHandlerMiddleware.
+func (t *Timeouts) OnBeforeCallTool(_ context.Context, id any, request *mcp.CallToolRequest) {
+	if id == nil {
+		return
+	}
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requestIDs[request.Params.Meta] = fmt.Sprint(id)
+}
+
+// OnError is a hook that forgets the request ID of a tool call that failed before reaching
+// ToolHandlerMiddleware, such as a call of an unknown tool.
+func (t *Timeouts) OnError(_ context.Context, _ any, _ mcp.MCPMethod, message any, _ error) {
+	request, ok := message.(*mcp.CallToolRequest)
+	if !ok || request.Params.Meta == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.requestIDs, request.Params.Meta)
+}
+
+// ToolHandlerMiddleware runs every tool call with a context that is cancelled when the call times
+// out or the client cancels it, and reports either as an error result.
+func (t *Timeouts) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		timeout := t.Timeout(request.Params.Name)
+		if timeout > 0 {
+			var cancelTimeout context.CancelFunc
+			ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+			defer cancelTimeout()
+		}
+
+		t.mu.Lock()
+		id, ok := t.requestIDs[request.Params.Meta]
+		if ok {
+			delete(t.requestIDs, request.Params.Meta)
+			t.running[id] = cancel
+		}
+		t.mu.Unlock()
+		if ok {
+			defer func() {
+				t.mu.Lock()
+				defer t.mu.Unlock()
+				delete(t.running, id)
+			}()
+		}
+
+		result, err := next(ctx, request)
+		// A call that succeeded keeps its result even if the context ended right after it returned,
+		// as whatever it changed was changed and reporting it as stopped would invite a retry
+		if err == nil && (result == nil || !result.IsError) {
+			return result, err
+		}
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return mcp.NewToolResultError(fmt.Sprintf("%s did not finish within %s and was stopped, retry with narrower parameters", request.Params.Name, timeout)), nil
+		case errors.Is(ctx.Err(), context.Canceled):
+			return mcp.NewToolResultError(fmt.Sprintf("%s was cancelled", request.Params.Name)), nil
+		}
+		return result, err
+	}
+}
+
+// HandleCancelled is a notification handler for MethodNotificationCancelled that cancels the
+// context of the tool call the client cancelled.
+func (t *Timeouts) HandleCancelled(_ context.Context, notification mcp.JSONRPCNotification) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok || id == nil {
+		return
+	}
+	t.mu.Lock()
+	cancel, ok := t.running[fmt.Sprint(id)]
+	t.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
@@ -0,0 +1,126 @@
+package timeout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolTimeouts(t *testing.T) {
+	timeouts, err := ParseToolTimeouts([]string{"download_repo_archive=5m", "get_me=0s,list_issues=1m"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"download_repo_archive": 5 * time.Minute, "get_me": 0, "list_issues": time.Minute}, timeouts)
+
+	for _, spec := range []string{"get_me", "=1s", "get_me=soon", "get_me=-1s"} {
+		_, err := ParseToolTimeouts([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+// blockingHandler waits for its context to be done, like a tool stuck on a hung API call.
+func blockingHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func toolRequest(name string) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Name = name
+	return request
+}
+
+func TestTimeouts_ToolHandlerMiddleware(t *testing.T) {
+	timeouts := New(10*time.Millisecond, map[string]time.Duration{"slow_tool": time.Hour})
+	assert.Equal(t, 10*time.Millisecond, timeouts.Timeout("get_me"))
+	assert.Equal(t, time.Hour, timeouts.Timeout("slow_tool"))
+
+	t.Run("times out", func(t *testing.T) {
+		result, err := timeouts.ToolHandlerMiddleware(blockingHandler)(context.Background(), toolRequest("get_me"))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "get_me did not finish within 10ms")
+	})
+
+	t.Run("finishes in time", func(t *testing.T) {
+		handler := timeouts.ToolHandlerMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Minute)
+			return mcp.NewToolResultText("done"), nil
+		})
+		result, err := handler(context.Background(), toolRequest("slow_tool"))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("cancelled after succeeding", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		handler := timeouts.ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// The client gives up just as the write completes
+			cancel()
+			return mcp.NewToolResultText("comment created"), nil
+		})
+		result, err := handler(ctx, toolRequest("add_issue_comment"))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Equal(t, "comment created", result.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestTimeouts_HandleCancelled(t *testing.T) {
+	timeouts := New(0, nil)
+	request := toolRequest("get_me")
+	// Request IDs are decoded from JSON, so numbers arrive as float64
+	timeouts.OnBeforeCallTool(context.Background(), float64(7), &request)
+
+	type callResult struct {
+		result *mcp.CallToolResult
+		err    error
+	}
+	done := make(chan callResult)
+	started := make(chan struct{})
+	go func() {
+		result, err := timeouts.ToolHandlerMiddleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			return blockingHandler(ctx, request)
+		})(context.Background(), request)
+		done <- callResult{result, err}
+	}()
+	<-started
+
+	// Cancelling another request does nothing
+	timeouts.HandleCancelled(context.Background(), cancelledNotification(float64(8)))
+	timeouts.HandleCancelled(context.Background(), cancelledNotification(float64(7)))
+
+	select {
+	case res := <-done:
+		require.NoError(t, res.err)
+		require.True(t, res.result.IsError)
+		assert.Equal(t, "get_me was cancelled", res.result.Content[0].(mcp.TextContent).Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the tool call was not cancelled")
+	}
+	assert.Empty(t, timeouts.running)
+	assert.Empty(t, timeouts.requestIDs)
+}
+
+func TestTimeouts_OnError(t *testing.T) {
+	timeouts := New(0, nil)
+	request := toolRequest("unknown_tool")
+	timeouts.OnBeforeCallTool(context.Background(), "abc", &request)
+	require.Len(t, timeouts.requestIDs, 1)
+
+	timeouts.OnError(context.Background(), "abc", mcp.MethodToolsCall, &request, assert.AnError)
+	assert.Empty(t, timeouts.requestIDs)
+}
+
+func cancelledNotification(id any) mcp.JSONRPCNotification {
+	var notification mcp.JSONRPCNotification
+	notification.Method = MethodNotificationCancelled
+	notification.Params.AdditionalFields = map[string]any{"requestId": id, "reason": "user aborted"}
+	return notification
+}
@@ -160,6 +160,37 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// WrapTools replaces every tool of the toolset with the result of wrap, for example to change
+// the schema or handler of some of the tools depending on server configuration.
+func (t *Toolset) WrapTools(wrap func(server.ServerTool) server.ServerTool) {
+	for i, tool := range t.readTools {
+		t.readTools[i] = wrap(tool)
+	}
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = wrap(tool)
+	}
+}
+
+// RemoveTools drops the tools with the given names from the toolset, for example because the
+// server they would call does not support them.
+func (t *Toolset) RemoveTools(names ...string) {
+	remove := make(map[string]bool, len(names))
+	for _, name := range names {
+		remove[name] = true
+	}
+	keep := func(tools []server.ServerTool) []server.ServerTool {
+		kept := tools[:0]
+		for _, tool := range tools {
+			if !remove[tool.Tool.Name] {
+				kept = append(kept, tool)
+			}
+		}
+		return kept
+	}
+	t.readTools = keep(t.readTools)
+	t.writeTools = keep(t.writeTools)
+}
+
 type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
 	everythingOn bool
@@ -3,6 +3,9 @@ package toolsets
 import (
 	"errors"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -250,3 +253,38 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestToolset_WrapTools(t *testing.T) {
+	toolset := NewToolset("test-toolset", "A test toolset")
+	readOnly, writable := true, false
+	toolset.AddReadTools(server.ServerTool{Tool: mcp.NewTool("read", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))})
+	toolset.AddWriteTools(server.ServerTool{Tool: mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &writable}))})
+
+	toolset.WrapTools(func(tool server.ServerTool) server.ServerTool {
+		tool.Tool.Description = "wrapped " + tool.Tool.Name
+		return tool
+	})
+
+	for _, tool := range toolset.GetAvailableTools() {
+		if tool.Tool.Description != "wrapped "+tool.Tool.Name {
+			t.Errorf("Expected tool %s to be wrapped, got description %q", tool.Tool.Name, tool.Tool.Description)
+		}
+	}
+}
+
+func TestToolset_RemoveTools(t *testing.T) {
+	toolset := NewToolset("test-toolset", "A test toolset")
+	readOnly, writable := true, false
+	toolset.AddReadTools(
+		server.ServerTool{Tool: mcp.NewTool("read1", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))},
+		server.ServerTool{Tool: mcp.NewTool("read2", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))},
+	)
+	toolset.AddWriteTools(server.ServerTool{Tool: mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &writable}))})
+
+	toolset.RemoveTools("read1", "write", "unknown")
+
+	tools := toolset.GetAvailableTools()
+	if len(tools) != 1 || tools[0].Tool.Name != "read2" {
+		t.Errorf("Expected only read2 to remain, got %v", tools)
+	}
+}